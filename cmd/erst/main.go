@@ -16,9 +16,12 @@ func main() {
 	// Set version in cmd package
 	cmd.Version = Version
 
-	// Start update checker in background (non-blocking)
-	checker := updater.NewChecker(Version)
-	go checker.CheckForUpdates()
+	// Start update checker in background (non-blocking), unless strict
+	// mode or --no-update-check rules out implicit network access. Execute
+	// never waits for it.
+	if cmd.UpdateCheckRequested(os.Args[1:]) {
+		updater.NewChecker(Version).Start()
+	}
 
 	if err := cmd.Execute(); err != nil {
 		os.Exit(1)
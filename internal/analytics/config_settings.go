@@ -0,0 +1,202 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package analytics
+
+import (
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// FeeModel holds the network's Soroban resource fee rates, in stroops, as
+// published in its ConfigSetting ledger entries.
+type FeeModel struct {
+	// FeePerInstructionsIncrement is charged per 10,000 CPU instructions.
+	FeePerInstructionsIncrement int64
+	FeePerDiskReadEntry         int64
+	FeePerWriteEntry            int64
+	FeePerDiskRead1KB           int64
+	FeePerWrite1KB              int64
+	FeePerHistorical1KB         int64
+	FeePerContractEvents1KB     int64
+	FeePerTxSize1KB             int64
+	// RentFee1KBLow and RentFee1KBHigh bound the rent write fee, which
+	// scales with the live state size around SorobanStateTargetSizeBytes.
+	RentFee1KBLow                 int64
+	RentFee1KBHigh                int64
+	SorobanStateTargetSizeBytes   int64
+	PersistentRentRateDenominator int64
+	TempRentRateDenominator       int64
+}
+
+// DefaultFeeModel approximates the mainnet fee settings, for estimates made
+// without the network's config settings. Only the rates erst estimates
+// with are filled in.
+var DefaultFeeModel = FeeModel{
+	FeePerInstructionsIncrement:   25,
+	FeePerDiskReadEntry:           6250,
+	FeePerWriteEntry:              10000,
+	FeePerDiskRead1KB:             1786,
+	FeePerWrite1KB:                11800,
+	RentFee1KBLow:                 -17000,
+	RentFee1KBHigh:                10000,
+	PersistentRentRateDenominator: 2103,
+}
+
+// DefaultMinPersistentTTL approximates the mainnet minimum TTL, in ledgers,
+// of a new or restored persistent entry.
+const DefaultMinPersistentTTL uint32 = 120960
+
+// StorageModel returns the per-byte write rate as a StorageFeeModel,
+// rounding up so the estimate never undercharges.
+func (m FeeModel) StorageModel() StorageFeeModel {
+	if m.FeePerWrite1KB <= 0 {
+		return StorageFeeModel{}
+	}
+	return StorageFeeModel{FeePerByte: uint64((m.FeePerWrite1KB + 1023) / 1024)}
+}
+
+// LimitModel holds the network's Soroban resource limits and the cost
+// parameters the host meters CPU and memory with.
+type LimitModel struct {
+	LedgerMaxInstructions        int64
+	TxMaxInstructions            int64
+	TxMemoryLimit                uint32
+	TxMaxDiskReadEntries         uint32
+	TxMaxDiskReadBytes           uint32
+	TxMaxWriteEntries            uint32
+	TxMaxWriteBytes              uint32
+	TxMaxFootprintEntries        uint32
+	TxMaxContractEventsSizeBytes uint32
+	TxMaxSizeBytes               uint32
+	ContractMaxSizeBytes         uint32
+	ContractDataKeySizeBytes     uint32
+	ContractDataEntrySizeBytes   uint32
+	MaxEntryTTL                  uint32
+	MinTemporaryTTL              uint32
+	MinPersistentTTL             uint32
+	CPUCostParams                xdr.ContractCostParams
+	MemCostParams                xdr.ContractCostParams
+}
+
+// ModelFromConfigSettings builds the fee and limit models from the
+// network's ConfigSetting ledger entries, as returned by getLedgerEntries
+// for CONFIG_SETTING keys. Settings absent from entries leave their fields
+// zero; settings that carry no fees or limits (the eviction iterator, the
+// state size window, SCP timing) are ignored. Any entry that is not a
+// config setting is an error.
+func ModelFromConfigSettings(entries []*xdr.LedgerEntry) (FeeModel, LimitModel, error) {
+	var fees FeeModel
+	var limits LimitModel
+	if len(entries) == 0 {
+		return fees, limits, fmt.Errorf("no config setting entries")
+	}
+
+	for i, entry := range entries {
+		if entry == nil {
+			return FeeModel{}, LimitModel{}, fmt.Errorf("entry %d is nil", i)
+		}
+		cs := entry.Data.ConfigSetting
+		if entry.Data.Type != xdr.LedgerEntryTypeConfigSetting || cs == nil {
+			return FeeModel{}, LimitModel{}, fmt.Errorf("entry %d is a %s entry, not a config setting", i, entry.Data.Type)
+		}
+
+		// ok turns false when the setting's value arm is unset.
+		ok := true
+		switch cs.ConfigSettingId {
+		case xdr.ConfigSettingIdConfigSettingContractMaxSizeBytes:
+			v := cs.ContractMaxSizeBytes
+			if ok = v != nil; ok {
+				limits.ContractMaxSizeBytes = uint32(*v)
+			}
+
+		case xdr.ConfigSettingIdConfigSettingContractComputeV0:
+			c := cs.ContractCompute
+			if ok = c != nil; ok {
+				fees.FeePerInstructionsIncrement = int64(c.FeeRatePerInstructionsIncrement)
+				limits.LedgerMaxInstructions = int64(c.LedgerMaxInstructions)
+				limits.TxMaxInstructions = int64(c.TxMaxInstructions)
+				limits.TxMemoryLimit = uint32(c.TxMemoryLimit)
+			}
+
+		case xdr.ConfigSettingIdConfigSettingContractLedgerCostV0:
+			c := cs.ContractLedgerCost
+			if ok = c != nil; ok {
+				fees.FeePerDiskReadEntry = int64(c.FeeDiskReadLedgerEntry)
+				fees.FeePerWriteEntry = int64(c.FeeWriteLedgerEntry)
+				fees.FeePerDiskRead1KB = int64(c.FeeDiskRead1Kb)
+				fees.RentFee1KBLow = int64(c.RentFee1KbSorobanStateSizeLow)
+				fees.RentFee1KBHigh = int64(c.RentFee1KbSorobanStateSizeHigh)
+				fees.SorobanStateTargetSizeBytes = int64(c.SorobanStateTargetSizeBytes)
+				limits.TxMaxDiskReadEntries = uint32(c.TxMaxDiskReadEntries)
+				limits.TxMaxDiskReadBytes = uint32(c.TxMaxDiskReadBytes)
+				limits.TxMaxWriteEntries = uint32(c.TxMaxWriteLedgerEntries)
+				limits.TxMaxWriteBytes = uint32(c.TxMaxWriteBytes)
+			}
+
+		case xdr.ConfigSettingIdConfigSettingContractLedgerCostExtV0:
+			c := cs.ContractLedgerCostExt
+			if ok = c != nil; ok {
+				fees.FeePerWrite1KB = int64(c.FeeWrite1Kb)
+				limits.TxMaxFootprintEntries = uint32(c.TxMaxFootprintEntries)
+			}
+
+		case xdr.ConfigSettingIdConfigSettingContractHistoricalDataV0:
+			c := cs.ContractHistoricalData
+			if ok = c != nil; ok {
+				fees.FeePerHistorical1KB = int64(c.FeeHistorical1Kb)
+			}
+
+		case xdr.ConfigSettingIdConfigSettingContractEventsV0:
+			c := cs.ContractEvents
+			if ok = c != nil; ok {
+				fees.FeePerContractEvents1KB = int64(c.FeeContractEvents1Kb)
+				limits.TxMaxContractEventsSizeBytes = uint32(c.TxMaxContractEventsSizeBytes)
+			}
+
+		case xdr.ConfigSettingIdConfigSettingContractBandwidthV0:
+			c := cs.ContractBandwidth
+			if ok = c != nil; ok {
+				fees.FeePerTxSize1KB = int64(c.FeeTxSize1Kb)
+				limits.TxMaxSizeBytes = uint32(c.TxMaxSizeBytes)
+			}
+
+		case xdr.ConfigSettingIdConfigSettingContractCostParamsCpuInstructions:
+			if ok = cs.ContractCostParamsCpuInsns != nil; ok {
+				limits.CPUCostParams = *cs.ContractCostParamsCpuInsns
+			}
+
+		case xdr.ConfigSettingIdConfigSettingContractCostParamsMemoryBytes:
+			if ok = cs.ContractCostParamsMemBytes != nil; ok {
+				limits.MemCostParams = *cs.ContractCostParamsMemBytes
+			}
+
+		case xdr.ConfigSettingIdConfigSettingContractDataKeySizeBytes:
+			v := cs.ContractDataKeySizeBytes
+			if ok = v != nil; ok {
+				limits.ContractDataKeySizeBytes = uint32(*v)
+			}
+
+		case xdr.ConfigSettingIdConfigSettingContractDataEntrySizeBytes:
+			v := cs.ContractDataEntrySizeBytes
+			if ok = v != nil; ok {
+				limits.ContractDataEntrySizeBytes = uint32(*v)
+			}
+
+		case xdr.ConfigSettingIdConfigSettingStateArchival:
+			c := cs.StateArchivalSettings
+			if ok = c != nil; ok {
+				fees.PersistentRentRateDenominator = int64(c.PersistentRentRateDenominator)
+				fees.TempRentRateDenominator = int64(c.TempRentRateDenominator)
+				limits.MaxEntryTTL = uint32(c.MaxEntryTtl)
+				limits.MinTemporaryTTL = uint32(c.MinTemporaryTtl)
+				limits.MinPersistentTTL = uint32(c.MinPersistentTtl)
+			}
+		}
+		if !ok {
+			return FeeModel{}, LimitModel{}, fmt.Errorf("entry %d: config setting %d has no value", i, int32(cs.ConfigSettingId))
+		}
+	}
+	return fees, limits, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func configEntry(cs xdr.ConfigSettingEntry) *xdr.LedgerEntry {
+	return &xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 100,
+		Data: xdr.LedgerEntryData{
+			Type:          xdr.LedgerEntryTypeConfigSetting,
+			ConfigSetting: &cs,
+		},
+	}
+}
+
+func sampleConfigEntries(t *testing.T) []*xdr.LedgerEntry {
+	t.Helper()
+	maxSize := xdr.Uint32(65536)
+	entries := []*xdr.LedgerEntry{
+		configEntry(xdr.ConfigSettingEntry{
+			ConfigSettingId:      xdr.ConfigSettingIdConfigSettingContractMaxSizeBytes,
+			ContractMaxSizeBytes: &maxSize,
+		}),
+		configEntry(xdr.ConfigSettingEntry{
+			ConfigSettingId: xdr.ConfigSettingIdConfigSettingContractComputeV0,
+			ContractCompute: &xdr.ConfigSettingContractComputeV0{
+				LedgerMaxInstructions:           500000000,
+				TxMaxInstructions:               100000000,
+				FeeRatePerInstructionsIncrement: 25,
+				TxMemoryLimit:                   41943040,
+			},
+		}),
+		configEntry(xdr.ConfigSettingEntry{
+			ConfigSettingId: xdr.ConfigSettingIdConfigSettingContractLedgerCostV0,
+			ContractLedgerCost: &xdr.ConfigSettingContractLedgerCostV0{
+				TxMaxDiskReadEntries:    100,
+				TxMaxDiskReadBytes:      200000,
+				TxMaxWriteLedgerEntries: 50,
+				TxMaxWriteBytes:         132096,
+				FeeDiskReadLedgerEntry:  6250,
+				FeeWriteLedgerEntry:     10000,
+				FeeDiskRead1Kb:          1786,
+			},
+		}),
+		configEntry(xdr.ConfigSettingEntry{
+			ConfigSettingId: xdr.ConfigSettingIdConfigSettingContractLedgerCostExtV0,
+			ContractLedgerCostExt: &xdr.ConfigSettingContractLedgerCostExtV0{
+				TxMaxFootprintEntries: 400,
+				FeeWrite1Kb:           3500,
+			},
+		}),
+		configEntry(xdr.ConfigSettingEntry{
+			ConfigSettingId: xdr.ConfigSettingIdConfigSettingContractCostParamsCpuInstructions,
+			ContractCostParamsCpuInsns: &xdr.ContractCostParams{
+				{ConstTerm: 4, LinearTerm: 0},
+				{ConstTerm: 434, LinearTerm: 16},
+			},
+		}),
+		configEntry(xdr.ConfigSettingEntry{
+			ConfigSettingId: xdr.ConfigSettingIdConfigSettingStateArchival,
+			StateArchivalSettings: &xdr.StateArchivalSettings{
+				MaxEntryTtl:                   3110400,
+				MinTemporaryTtl:               17280,
+				MinPersistentTtl:              2073600,
+				PersistentRentRateDenominator: 1215,
+				TempRentRateDenominator:       2430,
+			},
+		}),
+		// Carries no fees or limits and is skipped.
+		configEntry(xdr.ConfigSettingEntry{
+			ConfigSettingId:  xdr.ConfigSettingIdConfigSettingEvictionIterator,
+			EvictionIterator: &xdr.EvictionIterator{BucketListLevel: 6},
+		}),
+	}
+
+	// Round-trip through XDR as entries fetched from RPC would be.
+	for i, entry := range entries {
+		b64, err := xdr.MarshalBase64(entry)
+		require.NoError(t, err)
+		var decoded xdr.LedgerEntry
+		require.NoError(t, xdr.SafeUnmarshalBase64(b64, &decoded))
+		entries[i] = &decoded
+	}
+	return entries
+}
+
+func TestModelFromConfigSettings(t *testing.T) {
+	fees, limits, err := ModelFromConfigSettings(sampleConfigEntries(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, FeeModel{
+		FeePerInstructionsIncrement:   25,
+		FeePerDiskReadEntry:           6250,
+		FeePerWriteEntry:              10000,
+		FeePerDiskRead1KB:             1786,
+		FeePerWrite1KB:                3500,
+		PersistentRentRateDenominator: 1215,
+		TempRentRateDenominator:       2430,
+	}, fees)
+
+	assert.Equal(t, uint32(65536), limits.ContractMaxSizeBytes)
+	assert.Equal(t, int64(100000000), limits.TxMaxInstructions)
+	assert.Equal(t, int64(500000000), limits.LedgerMaxInstructions)
+	assert.Equal(t, uint32(41943040), limits.TxMemoryLimit)
+	assert.Equal(t, uint32(100), limits.TxMaxDiskReadEntries)
+	assert.Equal(t, uint32(132096), limits.TxMaxWriteBytes)
+	assert.Equal(t, uint32(400), limits.TxMaxFootprintEntries)
+	assert.Equal(t, uint32(2073600), limits.MinPersistentTTL)
+	assert.Len(t, limits.CPUCostParams, 2)
+	assert.Nil(t, limits.MemCostParams, "settings absent from the input stay zero")
+}
+
+func TestFeeModel_StorageModel(t *testing.T) {
+	fees, _, err := ModelFromConfigSettings(sampleConfigEntries(t))
+	require.NoError(t, err)
+
+	// 3500 stroops per KB rounds up to 4 stroops per byte.
+	model := fees.StorageModel()
+	assert.Equal(t, uint64(4), model.FeePerByte)
+	assert.Equal(t, int64(400), CalculateStorageFee(100, model))
+
+	assert.Equal(t, StorageFeeModel{}, FeeModel{}.StorageModel())
+}
+
+func TestModelFromConfigSettings_Errors(t *testing.T) {
+	_, _, err := ModelFromConfigSettings(nil)
+	assert.Error(t, err)
+
+	_, _, err = ModelFromConfigSettings([]*xdr.LedgerEntry{nil})
+	assert.Error(t, err)
+
+	account := &xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.AccountEntry{AccountId: xdr.MustAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")},
+	}}
+	_, _, err = ModelFromConfigSettings([]*xdr.LedgerEntry{account})
+	assert.ErrorContains(t, err, "not a config setting")
+
+	missing := configEntry(xdr.ConfigSettingEntry{ConfigSettingId: xdr.ConfigSettingIdConfigSettingContractComputeV0})
+	_, _, err = ModelFromConfigSettings([]*xdr.LedgerEntry{missing})
+	assert.ErrorContains(t, err, "has no value")
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package analytics
+
+// MinInclusionFee is the network base fee per operation, in stroops.
+const MinInclusionFee int64 = 100
+
+// InclusionFeePercentiles are recent inclusion fees (in stroops) paid by
+// Soroban transactions, as reported by the RPC getFeeStats method.
+type InclusionFeePercentiles struct {
+	P50 int64
+	P90 int64
+	P99 int64
+}
+
+// FeeSuggestion is a suggested total fee for one inclusion tier.
+type FeeSuggestion struct {
+	Tier         string
+	InclusionFee int64
+	ResourceFee  int64
+	Total        int64
+}
+
+// SuggestFees combines a simulated resource fee with recent inclusion fee
+// percentiles into economy (p50), standard (p90) and priority (p99)
+// suggestions. Inclusion fees never go below MinInclusionFee.
+func SuggestFees(resourceFee int64, p InclusionFeePercentiles) []FeeSuggestion {
+	tiers := []struct {
+		name string
+		fee  int64
+	}{
+		{"economy", p.P50},
+		{"standard", p.P90},
+		{"priority", p.P99},
+	}
+
+	out := make([]FeeSuggestion, 0, len(tiers))
+	for _, t := range tiers {
+		inclusion := t.fee
+		if inclusion < MinInclusionFee {
+			inclusion = MinInclusionFee
+		}
+		out = append(out, FeeSuggestion{
+			Tier:         t.name,
+			InclusionFee: inclusion,
+			ResourceFee:  resourceFee,
+			Total:        inclusion + resourceFee,
+		})
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package analytics
+
+import (
+	"math"
+	"math/big"
+)
+
+// RentFeeModel holds the rates, in stroops, charged for extending the TTL of
+// a ledger entry. Storing 1 KiB for RentRateDenominator ledgers costs
+// RentFee1KB, so the per-byte-per-ledger rate is
+// RentFee1KB / (1024 * RentRateDenominator).
+type RentFeeModel struct {
+	// RentFee1KB is the network's current rent write fee per KiB, which
+	// scales with the live Soroban state size (see FeeModel.RentFee1KBLow
+	// and RentFee1KBHigh).
+	RentFee1KB int64
+	// RentRateDenominator is FeeModel.PersistentRentRateDenominator for
+	// persistent entries and TempRentRateDenominator for temporary ones.
+	RentRateDenominator int64
+}
+
+// CalculateRentFee returns the rent for extending an entry of
+// entrySizeBytes from live-until ledger currentTTL to targetTTL. Like the
+// network, it rounds up to the next stroop. Extensions that do not move
+// the live-until ledger forward, empty entries and an incomplete model
+// cost 0; fees too large for an int64 are clamped.
+func CalculateRentFee(entrySizeBytes int64, currentTTL, targetTTL uint32, model RentFeeModel) int64 {
+	if entrySizeBytes <= 0 || targetTTL <= currentTTL || model.RentFee1KB <= 0 || model.RentRateDenominator <= 0 {
+		return 0
+	}
+	ledgers := int64(targetTTL - currentTTL)
+
+	fee := big.NewInt(entrySizeBytes)
+	fee.Mul(fee, big.NewInt(model.RentFee1KB))
+	fee.Mul(fee, big.NewInt(ledgers))
+	denom := new(big.Int).Mul(big.NewInt(1024), big.NewInt(model.RentRateDenominator))
+	fee.Add(fee, new(big.Int).Sub(denom, big.NewInt(1)))
+	fee.Quo(fee, denom)
+	if !fee.IsInt64() {
+		return math.MaxInt64
+	}
+	return fee.Int64()
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package analytics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateRentFee(t *testing.T) {
+	model := RentFeeModel{RentFee1KB: 1000, RentRateDenominator: 100}
+
+	tests := []struct {
+		name    string
+		size    int64
+		current uint32
+		target  uint32
+		want    int64
+	}{
+		{"one KiB for one period", 1024, 1000, 1100, 1000},
+		{"half a KiB for two periods", 512, 1000, 1200, 1000},
+		{"rounds up", 1, 1000, 1001, 1},
+		{"no extension", 1024, 1000, 1000, 0},
+		{"target before current", 1024, 1000, 900, 0},
+		{"empty entry", 0, 1000, 1100, 0},
+		{"negative size", -1, 1000, 1100, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CalculateRentFee(tt.size, tt.current, tt.target, model))
+		})
+	}
+
+	assert.Zero(t, CalculateRentFee(1024, 0, 100, RentFeeModel{RentFee1KB: 1000}), "missing denominator")
+	assert.Equal(t, int64(math.MaxInt64), CalculateRentFee(math.MaxInt64, 0, math.MaxUint32, RentFeeModel{RentFee1KB: math.MaxInt64, RentRateDenominator: 1}))
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package analytics
+
+import (
+	"math"
+	"math/big"
+)
+
+const (
+	// InstructionsIncrement is the number of CPU instructions
+	// ResourceFeeModel.FeePerInstructionsIncrement is charged for.
+	InstructionsIncrement = 10_000
+	// memoryIncrement is the number of bytes FeePerMemory1KB is charged for.
+	memoryIncrement = 1024
+)
+
+// ResourceFeeModel holds the rates, in stroops, that CalculateResourceFee
+// charges for the CPU and memory a simulation used.
+type ResourceFeeModel struct {
+	// FeePerInstructionsIncrement is charged per 10,000 CPU instructions.
+	FeePerInstructionsIncrement int64
+	// FeePerMemory1KB is charged per KiB of memory. The protocol meters
+	// memory against a limit but does not bill it, so networks leave it 0.
+	FeePerMemory1KB int64
+}
+
+// DefaultResourceFeeModel holds the compute rates of DefaultFeeModel.
+var DefaultResourceFeeModel = DefaultFeeModel.ResourceModel()
+
+// ResourceModel returns the compute rates of the fee model.
+func (m FeeModel) ResourceModel() ResourceFeeModel {
+	return ResourceFeeModel{FeePerInstructionsIncrement: m.FeePerInstructionsIncrement}
+}
+
+// ResourceUsage is the CPU and memory a simulation used, as reported in
+// its budget.
+type ResourceUsage struct {
+	CPUInstructions uint64
+	MemoryBytes     uint64
+}
+
+// ResourceFeeBreakdown is the fee, in stroops, charged for each resource a
+// simulation used.
+type ResourceFeeBreakdown struct {
+	CPUFee    int64
+	MemoryFee int64
+	Total     int64
+}
+
+// CalculateResourceFee converts the CPU instructions and memory bytes of
+// usage into fees at the model's rates. Like the network, it rounds each
+// component up to the next stroop. Fees too large for an int64 are clamped.
+func CalculateResourceFee(usage ResourceUsage, model ResourceFeeModel) ResourceFeeBreakdown {
+	b := ResourceFeeBreakdown{
+		CPUFee:    feePerIncrement(usage.CPUInstructions, model.FeePerInstructionsIncrement, InstructionsIncrement),
+		MemoryFee: feePerIncrement(usage.MemoryBytes, model.FeePerMemory1KB, memoryIncrement),
+	}
+	b.Total = b.CPUFee + b.MemoryFee
+	if b.Total < b.CPUFee {
+		b.Total = math.MaxInt64
+	}
+	return b
+}
+
+// feePerIncrement returns ceil(amount * rate / increment), or 0 for a
+// non-positive rate.
+func feePerIncrement(amount uint64, rate, increment int64) int64 {
+	if amount == 0 || rate <= 0 {
+		return 0
+	}
+	fee := new(big.Int).SetUint64(amount)
+	fee.Mul(fee, big.NewInt(rate))
+	fee.Add(fee, big.NewInt(increment-1))
+	fee.Quo(fee, big.NewInt(increment))
+	if !fee.IsInt64() {
+		return math.MaxInt64
+	}
+	return fee.Int64()
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package analytics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateResourceFee(t *testing.T) {
+	model := ResourceFeeModel{FeePerInstructionsIncrement: 25, FeePerMemory1KB: 3}
+
+	tests := []struct {
+		name  string
+		usage ResourceUsage
+		want  ResourceFeeBreakdown
+	}{
+		{"zero", ResourceUsage{}, ResourceFeeBreakdown{}},
+		{"exact increments", ResourceUsage{CPUInstructions: 1_000_000, MemoryBytes: 2048}, ResourceFeeBreakdown{CPUFee: 2500, MemoryFee: 6, Total: 2506}},
+		{"rounds up", ResourceUsage{CPUInstructions: 10_001, MemoryBytes: 1}, ResourceFeeBreakdown{CPUFee: 26, MemoryFee: 1, Total: 27}},
+		{"large counts", ResourceUsage{CPUInstructions: math.MaxUint64}, ResourceFeeBreakdown{CPUFee: 46116860184273880, Total: 46116860184273880}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CalculateResourceFee(tt.usage, model))
+		})
+	}
+
+	huge := ResourceFeeModel{FeePerInstructionsIncrement: math.MaxInt64}
+	got := CalculateResourceFee(ResourceUsage{CPUInstructions: math.MaxUint64}, huge)
+	assert.Equal(t, int64(math.MaxInt64), got.CPUFee)
+	assert.Equal(t, int64(math.MaxInt64), got.Total)
+}
+
+func TestCalculateResourceFee_DefaultModel(t *testing.T) {
+	got := CalculateResourceFee(ResourceUsage{CPUInstructions: 5_000_000, MemoryBytes: 1 << 20}, DefaultResourceFeeModel)
+	assert.Equal(t, ResourceFeeBreakdown{CPUFee: 12500, Total: 12500}, got)
+}
+
+func TestFeeModel_ResourceModel(t *testing.T) {
+	m := FeeModel{FeePerInstructionsIncrement: 40}
+	assert.Equal(t, ResourceFeeModel{FeePerInstructionsIncrement: 40}, m.ResourceModel())
+}
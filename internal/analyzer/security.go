@@ -30,7 +30,7 @@ func NewSecurityAnalyzer() *SecurityAnalyzer {
 func (sa *SecurityAnalyzer) Analyze(resp *simulator.SimulationResponse) []SecurityViolation {
 	sa.violations = make([]SecurityViolation, 0)
 
-	if resp.Status != "success" {
+	if resp.Outcome() != simulator.OutcomeSuccess {
 		return sa.violations
 	}
 
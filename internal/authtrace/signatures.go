@@ -0,0 +1,225 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package authtrace
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// ThresholdLevel names which of an account's thresholds an operation needs.
+type ThresholdLevel string
+
+const (
+	ThresholdLow    ThresholdLevel = "low"
+	ThresholdMedium ThresholdLevel = "medium"
+	ThresholdHigh   ThresholdLevel = "high"
+)
+
+// SignatureCheck compares the signatures attached to an envelope with the
+// signers and thresholds of the transaction's source account. Signers are
+// matched by signature hint only; the signatures themselves are not verified.
+type SignatureCheck struct {
+	AccountID       string          `json:"account_id"`
+	Signatures      int             `json:"signatures"`
+	MatchedSigners  []KeyWeight     `json:"matched_signers"`
+	CollectedWeight uint32          `json:"collected_weight"`
+	RequiredWeight  uint32          `json:"required_weight"`
+	Level           ThresholdLevel  `json:"threshold_level"`
+	Thresholds      ThresholdConfig `json:"thresholds"`
+	Sufficient      bool            `json:"sufficient"`
+}
+
+// MissingWeight returns how much more signer weight the transaction needs.
+// A zero threshold still needs one signer with non-zero weight.
+func (c *SignatureCheck) MissingWeight() uint32 {
+	if c.CollectedWeight == 0 && c.RequiredWeight == 0 {
+		return 1
+	}
+	if c.CollectedWeight >= c.RequiredWeight {
+		return 0
+	}
+	return c.RequiredWeight - c.CollectedWeight
+}
+
+// EnvelopeSignatureCount returns the number of signatures on a base64
+// TransactionEnvelope. For fee-bump envelopes only the outer signatures are
+// counted.
+func EnvelopeSignatureCount(envelopeXdr string) (int, error) {
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXdr, &env); err != nil {
+		return 0, fmt.Errorf("failed to decode envelope: %w", err)
+	}
+	return len(env.Signatures()), nil
+}
+
+// CheckSignatures checks whether the envelope's signatures carry enough weight
+// for its source account. entries maps base64 LedgerKeys to base64
+// LedgerEntries, as returned by rpc.ExtractLedgerEntriesFromMeta. It returns
+// nil without an error when the source account's entry is not in entries.
+func CheckSignatures(envelopeXdr string, entries map[string]string) (*SignatureCheck, error) {
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXdr, &env); err != nil {
+		return nil, fmt.Errorf("failed to decode envelope: %w", err)
+	}
+
+	// For fee bumps, the inner signatures authorize the inner source.
+	var (
+		source     xdr.MuxedAccount
+		ops        []xdr.Operation
+		signatures []xdr.DecoratedSignature
+	)
+	switch env.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
+		source = xdr.MuxedAccount{
+			Type:    xdr.CryptoKeyTypeKeyTypeEd25519,
+			Ed25519: &env.V0.Tx.SourceAccountEd25519,
+		}
+		ops, signatures = env.V0.Tx.Operations, env.V0.Signatures
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		source, ops, signatures = env.V1.Tx.SourceAccount, env.V1.Tx.Operations, env.V1.Signatures
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		inner := env.FeeBump.Tx.InnerTx.V1
+		source, ops, signatures = inner.Tx.SourceAccount, inner.Tx.Operations, inner.Signatures
+	default:
+		return nil, fmt.Errorf("unsupported envelope type: %v", env.Type)
+	}
+
+	accountID := source.ToAccountId()
+	account, err := findAccountEntry(accountID, entries)
+	if err != nil || account == nil {
+		return nil, err
+	}
+
+	level := requiredLevel(accountID, ops)
+	thresholds := ThresholdConfig{
+		LowThreshold:    uint32(account.ThresholdLow()),
+		MediumThreshold: uint32(account.ThresholdMedium()),
+		HighThreshold:   uint32(account.ThresholdHigh()),
+	}
+
+	check := &SignatureCheck{
+		AccountID:  accountID.Address(),
+		Signatures: len(signatures),
+		Level:      level,
+		Thresholds: thresholds,
+	}
+	switch level {
+	case ThresholdLow:
+		check.RequiredWeight = thresholds.LowThreshold
+	case ThresholdMedium:
+		check.RequiredWeight = thresholds.MediumThreshold
+	case ThresholdHigh:
+		check.RequiredWeight = thresholds.HighThreshold
+	}
+
+	for _, s := range accountSigners(account) {
+		if s.Weight == 0 || !hasHint(signatures, s.Key) {
+			continue
+		}
+		check.MatchedSigners = append(check.MatchedSigners, KeyWeight{
+			PublicKey: s.Key.Address(),
+			Weight:    uint32(s.Weight),
+			Type:      Ed25519,
+		})
+		check.CollectedWeight += uint32(s.Weight)
+	}
+	check.Sufficient = check.CollectedWeight > 0 && check.CollectedWeight >= check.RequiredWeight
+	return check, nil
+}
+
+func findAccountEntry(accountID xdr.AccountId, entries map[string]string) (*xdr.AccountEntry, error) {
+	key := xdr.LedgerKey{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.LedgerKeyAccount{AccountId: accountID},
+	}
+	keyXdr, err := xdr.MarshalBase64(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode account key: %w", err)
+	}
+	entryXdr, ok := entries[keyXdr]
+	if !ok {
+		return nil, nil
+	}
+	var entry xdr.LedgerEntry
+	if err := xdr.SafeUnmarshalBase64(entryXdr, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode account entry: %w", err)
+	}
+	if entry.Data.Account == nil {
+		return nil, fmt.Errorf("ledger entry for %s is not an account", accountID.Address())
+	}
+	return entry.Data.Account, nil
+}
+
+// accountSigners returns the master key and the ed25519 signers of an
+// account. Pre-auth and hash(x) signers do not sign with a key and are skipped.
+func accountSigners(account *xdr.AccountEntry) []xdr.Signer {
+	signers := []xdr.Signer{{
+		Key: xdr.SignerKey{
+			Type:    xdr.SignerKeyTypeSignerKeyTypeEd25519,
+			Ed25519: account.AccountId.Ed25519,
+		},
+		Weight: xdr.Uint32(account.MasterKeyWeight()),
+	}}
+	for _, s := range account.Signers {
+		if s.Key.Type == xdr.SignerKeyTypeSignerKeyTypeEd25519 {
+			signers = append(signers, s)
+		}
+	}
+	return signers
+}
+
+func hasHint(signatures []xdr.DecoratedSignature, key xdr.SignerKey) bool {
+	hint := key.Ed25519[28:]
+	for _, s := range signatures {
+		if bytes.Equal(s.Hint[:], hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredLevel returns the highest threshold needed by the transaction and
+// the operations that use the transaction's source account.
+func requiredLevel(source xdr.AccountId, ops []xdr.Operation) ThresholdLevel {
+	level := ThresholdLow
+	for _, op := range ops {
+		if op.SourceAccount != nil && op.SourceAccount.ToAccountId().Address() != source.Address() {
+			continue
+		}
+		switch opLevel := operationLevel(op.Body); {
+		case opLevel == ThresholdHigh:
+			return ThresholdHigh
+		case opLevel == ThresholdMedium:
+			level = ThresholdMedium
+		}
+	}
+	return level
+}
+
+func operationLevel(body xdr.OperationBody) ThresholdLevel {
+	switch body.Type {
+	case xdr.OperationTypeAllowTrust,
+		xdr.OperationTypeSetTrustLineFlags,
+		xdr.OperationTypeBumpSequence,
+		xdr.OperationTypeInflation,
+		xdr.OperationTypeInvokeHostFunction,
+		xdr.OperationTypeExtendFootprintTtl,
+		xdr.OperationTypeRestoreFootprint:
+		return ThresholdLow
+	case xdr.OperationTypeAccountMerge:
+		return ThresholdHigh
+	case xdr.OperationTypeSetOptions:
+		o := body.SetOptionsOp
+		if o != nil && (o.MasterWeight != nil || o.LowThreshold != nil ||
+			o.MedThreshold != nil || o.HighThreshold != nil || o.Signer != nil) {
+			return ThresholdHigh
+		}
+		return ThresholdMedium
+	default:
+		return ThresholdMedium
+	}
+}
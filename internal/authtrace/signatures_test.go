@@ -0,0 +1,168 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package authtrace
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+func testKey(fill byte) xdr.Uint256 {
+	var k xdr.Uint256
+	for i := range k {
+		k[i] = fill
+	}
+	return k
+}
+
+func decorated(key xdr.Uint256) xdr.DecoratedSignature {
+	var hint xdr.SignatureHint
+	copy(hint[:], key[28:])
+	return xdr.DecoratedSignature{Hint: hint, Signature: make([]byte, 64)}
+}
+
+func testEnvelope(t *testing.T, source xdr.Uint256, body xdr.OperationBody, sigs ...xdr.DecoratedSignature) string {
+	t.Helper()
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx: xdr.Transaction{
+				SourceAccount: xdr.MuxedAccount{Type: xdr.CryptoKeyTypeKeyTypeEd25519, Ed25519: &source},
+				Fee:           100,
+				SeqNum:        1,
+				Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+				Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+				Operations:    []xdr.Operation{{Body: body}},
+			},
+			Signatures: sigs,
+		},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	if err != nil {
+		t.Fatalf("marshal envelope: %v", err)
+	}
+	return b64
+}
+
+// accountEntries returns a ledger entry map holding a single account with the
+// given master weight, thresholds (low, medium, high) and extra signers.
+func accountEntries(t *testing.T, id xdr.Uint256, master byte, low, med, high byte, signers ...xdr.Signer) map[string]string {
+	t.Helper()
+	accountID := xdr.AccountId{Type: xdr.PublicKeyTypePublicKeyTypeEd25519, Ed25519: &id}
+	entry := xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId:  accountID,
+				Balance:    100,
+				Thresholds: xdr.Thresholds{master, low, med, high},
+				Signers:    signers,
+			},
+		},
+	}
+	key := xdr.LedgerKey{Type: xdr.LedgerEntryTypeAccount, Account: &xdr.LedgerKeyAccount{AccountId: accountID}}
+	keyB64, err := xdr.MarshalBase64(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	entryB64, err := xdr.MarshalBase64(entry)
+	if err != nil {
+		t.Fatalf("marshal entry: %v", err)
+	}
+	return map[string]string{keyB64: entryB64}
+}
+
+func paymentBody() xdr.OperationBody {
+	dst := testKey(0x09)
+	return xdr.OperationBody{
+		Type: xdr.OperationTypePayment,
+		PaymentOp: &xdr.PaymentOp{
+			Destination: xdr.MuxedAccount{Type: xdr.CryptoKeyTypeKeyTypeEd25519, Ed25519: &dst},
+			Asset:       xdr.Asset{Type: xdr.AssetTypeAssetTypeNative},
+			Amount:      10,
+		},
+	}
+}
+
+func TestCheckSignatures_InsufficientWeight(t *testing.T) {
+	source := testKey(0x01)
+	cosigner := testKey(0x02)
+	entries := accountEntries(t, source, 1, 1, 3, 3, xdr.Signer{
+		Key:    xdr.SignerKey{Type: xdr.SignerKeyTypeSignerKeyTypeEd25519, Ed25519: &cosigner},
+		Weight: 2,
+	})
+
+	// Only the master key signed: weight 1 against a medium threshold of 3.
+	env := testEnvelope(t, source, paymentBody(), decorated(source))
+	check, err := CheckSignatures(env, entries)
+	if err != nil {
+		t.Fatalf("CheckSignatures: %v", err)
+	}
+	if check == nil {
+		t.Fatal("expected a check result")
+	}
+	if check.Sufficient {
+		t.Error("expected insufficient weight")
+	}
+	if check.Level != ThresholdMedium || check.RequiredWeight != 3 {
+		t.Errorf("expected medium threshold 3, got %s %d", check.Level, check.RequiredWeight)
+	}
+	if check.CollectedWeight != 1 || check.MissingWeight() != 2 {
+		t.Errorf("expected weight 1 missing 2, got %d missing %d", check.CollectedWeight, check.MissingWeight())
+	}
+	if check.Signatures != 1 {
+		t.Errorf("expected 1 signature, got %d", check.Signatures)
+	}
+
+	// Adding the cosigner meets the threshold.
+	env = testEnvelope(t, source, paymentBody(), decorated(source), decorated(cosigner))
+	check, err = CheckSignatures(env, entries)
+	if err != nil {
+		t.Fatalf("CheckSignatures: %v", err)
+	}
+	if !check.Sufficient || check.CollectedWeight != 3 || len(check.MatchedSigners) != 2 {
+		t.Errorf("expected sufficient weight 3 from 2 signers, got %+v", check)
+	}
+}
+
+func TestCheckSignatures_HighThresholdForMerge(t *testing.T) {
+	source := testKey(0x01)
+	dst := testKey(0x05)
+	entries := accountEntries(t, source, 2, 0, 2, 5)
+
+	body := xdr.OperationBody{
+		Type:        xdr.OperationTypeAccountMerge,
+		Destination: &xdr.MuxedAccount{Type: xdr.CryptoKeyTypeKeyTypeEd25519, Ed25519: &dst},
+	}
+	check, err := CheckSignatures(testEnvelope(t, source, body, decorated(source)), entries)
+	if err != nil {
+		t.Fatalf("CheckSignatures: %v", err)
+	}
+	if check.Level != ThresholdHigh || check.Sufficient {
+		t.Errorf("expected insufficient high threshold, got %s sufficient=%v", check.Level, check.Sufficient)
+	}
+}
+
+func TestCheckSignatures_NoAccountEntry(t *testing.T) {
+	source := testKey(0x01)
+	check, err := CheckSignatures(testEnvelope(t, source, paymentBody(), decorated(source)), map[string]string{})
+	if err != nil {
+		t.Fatalf("CheckSignatures: %v", err)
+	}
+	if check != nil {
+		t.Errorf("expected nil check without account entry, got %+v", check)
+	}
+}
+
+func TestEnvelopeSignatureCount(t *testing.T) {
+	source := testKey(0x01)
+	n, err := EnvelopeSignatureCount(testEnvelope(t, source, paymentBody(), decorated(source), decorated(testKey(0x02))))
+	if err != nil {
+		t.Fatalf("EnvelopeSignatureCount: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 signatures, got %d", n)
+	}
+}
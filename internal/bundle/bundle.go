@@ -0,0 +1,194 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bundle writes diagnostics bundles: a single zip archive holding
+// everything about a debug run that is useful for a support ticket.
+package bundle
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/redact"
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// Redacted replaces values removed by redaction.
+const Redacted = redact.Redacted
+
+// File names inside the archive.
+const (
+	FileEnvironment = "environment.json"
+	FileEnvelope    = "envelope.xdr"
+	FileResult      = "result.xdr"
+	FileResultMeta  = "result_meta.xdr"
+	FileRequest     = "simulation_request.json"
+	FileResponse    = "simulation_response.json"
+	FileLogs        = "logs.txt"
+)
+
+// Environment describes the tool and network a bundle was produced with.
+type Environment struct {
+	ErstVersion string    `json:"erst_version"`
+	CommitSHA   string    `json:"commit_sha"`
+	GoVersion   string    `json:"go_version"`
+	OS          string    `json:"os"`
+	Arch        string    `json:"arch"`
+	Network     string    `json:"network"`
+	RPCURL      string    `json:"rpc_url,omitempty"`
+	TxHash      string    `json:"tx_hash"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Contents is the data captured from a debug run.
+type Contents struct {
+	Environment   Environment
+	EnvelopeXdr   string
+	ResultXdr     string
+	ResultMetaXdr string
+	Request       *simulator.SimulationRequest
+	Response      *simulator.SimulationResponse
+	Logs          []string
+}
+
+// Options controls how a bundle is written.
+type Options struct {
+	// Redact strips transaction XDR, ledger entry values and RPC URL
+	// credentials from the bundle.
+	Redact bool
+
+	// Policy redacts additional fields by name, or values matching its
+	// pattern, in every file of the bundle. The plain-text files are
+	// matched by the field names envelope_xdr, result_xdr, result_meta_xdr
+	// and logs; JSON files by their keys.
+	Policy *redact.Policy
+}
+
+// Write creates the bundle archive at path.
+func Write(path string, c *Contents, opts Options) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	if err := WriteTo(f, c, opts); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
+
+// WriteTo writes the bundle archive to w.
+func WriteTo(w io.Writer, c *Contents, opts Options) error {
+	if opts.Redact {
+		c = redactContents(c)
+	}
+
+	zw := zip.NewWriter(w)
+	p := opts.Policy
+
+	if err := writeJSON(zw, FileEnvironment, c.Environment, p); err != nil {
+		return err
+	}
+	if err := writeText(zw, FileEnvelope, p.Value("envelope_xdr", c.EnvelopeXdr)); err != nil {
+		return err
+	}
+	if err := writeText(zw, FileResult, p.Value("result_xdr", c.ResultXdr)); err != nil {
+		return err
+	}
+	if err := writeText(zw, FileResultMeta, p.Value("result_meta_xdr", c.ResultMetaXdr)); err != nil {
+		return err
+	}
+	if c.Request != nil {
+		if err := writeJSON(zw, FileRequest, c.Request, p); err != nil {
+			return err
+		}
+	}
+	if c.Response != nil {
+		if err := writeJSON(zw, FileResponse, c.Response, p); err != nil {
+			return err
+		}
+	}
+	if err := writeText(zw, FileLogs, p.Value("logs", strings.Join(c.Logs, "\n"))); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize bundle: %w", err)
+	}
+	return nil
+}
+
+func writeText(zw *zip.Writer, name, content string) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to bundle: %w", name, err)
+	}
+	if _, err := io.WriteString(fw, content); err != nil {
+		return fmt.Errorf("failed to write %s to bundle: %w", name, err)
+	}
+	return nil
+}
+
+func writeJSON(zw *zip.Writer, name string, v interface{}, p *redact.Policy) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	if data, err = p.JSON(data); err != nil {
+		return fmt.Errorf("failed to redact %s: %w", name, err)
+	}
+	return writeText(zw, name, string(data))
+}
+
+// redactContents returns a copy of c with sensitive values replaced. Ledger keys are
+// kept so the set of touched entries is still visible.
+func redactContents(c *Contents) *Contents {
+	out := *c
+	out.Environment.RPCURL = redactURL(c.Environment.RPCURL)
+	out.EnvelopeXdr = redactValue(c.EnvelopeXdr)
+	out.ResultXdr = redactValue(c.ResultXdr)
+	out.ResultMetaXdr = redactValue(c.ResultMetaXdr)
+
+	if c.Request != nil {
+		req := *c.Request
+		req.EnvelopeXdr = redactValue(req.EnvelopeXdr)
+		req.ResultMetaXdr = redactValue(req.ResultMetaXdr)
+		if req.LedgerEntries != nil {
+			req.LedgerEntries = make(map[string]string, len(c.Request.LedgerEntries))
+			for k := range c.Request.LedgerEntries {
+				req.LedgerEntries[k] = Redacted
+			}
+		}
+		out.Request = &req
+	}
+	return &out
+}
+
+func redactValue(s string) string {
+	if s == "" {
+		return ""
+	}
+	return Redacted
+}
+
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Redacted
+	}
+	u.User = nil
+	u.RawQuery = ""
+	return u.String()
+}
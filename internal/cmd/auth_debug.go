@@ -47,7 +47,7 @@ Examples:
 			opts = append(opts, rpc.WithHorizonURL(authRPCURLFlag))
 		}
 
-		client, err := rpc.NewClient(opts...)
+		client, err := rpcClients.Client(opts...)
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
@@ -109,5 +109,7 @@ func init() {
 	authDebugCmd.Flags().StringVar(&authRPCURLFlag, "rpc-url", "", "Custom Horizon RPC URL")
 	authDebugCmd.Flags().BoolVar(&authDetailedFlag, "detailed", false, "Show detailed analysis and missing signatures")
 	authDebugCmd.Flags().BoolVar(&authJSONOutputFlag, "json", false, "Output as JSON")
+
+	requireInStrict(authDebugCmd, "network")
 	rootCmd.AddCommand(authDebugCmd)
 }
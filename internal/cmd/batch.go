@@ -0,0 +1,166 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+// batchCheckpointSuffix names the state file kept next to a batch file. It
+// lists the hashes that finished, one per line.
+const batchCheckpointSuffix = ".done"
+
+// readBatchHashes reads one transaction hash or URL per line, skipping blank
+// lines and # comments. Every entry is resolved and validated up front so a
+// typo fails the run before any work is done.
+func readBatchHashes(r io.Reader) ([]string, error) {
+	var hashes []string
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		hash, err := rpc.ResolveTransactionRef(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		hashes = append(hashes, hash)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read batch file: %w", err)
+	}
+	return hashes, nil
+}
+
+// batchCheckpoint records which hashes of a batch completed, so an
+// interrupted run can pick up where it stopped.
+type batchCheckpoint struct {
+	path string
+	done map[string]bool
+}
+
+// openBatchCheckpoint loads the state file at path. With resume unset the
+// file is truncated and the run starts from scratch.
+func openBatchCheckpoint(path string, resume bool) (*batchCheckpoint, error) {
+	cp := &batchCheckpoint{path: path, done: make(map[string]bool)}
+	if !resume {
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			return nil, fmt.Errorf("failed to reset batch checkpoint: %w", err)
+		}
+		return cp, nil
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open batch checkpoint: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if hash := strings.TrimSpace(scanner.Text()); hash != "" {
+			cp.done[hash] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read batch checkpoint: %w", err)
+	}
+	return cp, nil
+}
+
+// Done reports whether hash completed in an earlier run.
+func (c *batchCheckpoint) Done(hash string) bool {
+	return c.done[hash]
+}
+
+// Record appends hash to the state file. The file is reopened for every
+// entry so a crash loses at most the hash being processed.
+func (c *batchCheckpoint) Record(hash string) error {
+	f, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to record batch progress: %w", err)
+	}
+	if _, err := fmt.Fprintln(f, hash); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to record batch progress: %w", err)
+	}
+	c.done[hash] = true
+	return f.Close()
+}
+
+// batchResult counts the outcome of a batch debug run.
+type batchResult struct {
+	Processed int
+	Skipped   int
+	Failed    int
+}
+
+// runDebugBatch runs debugOne for each hash not yet completed, recording
+// successes in cp. A failing hash is reported and left out of the
+// checkpoint so a resumed run retries it; only a checkpoint write error
+// stops the batch.
+func runDebugBatch(w io.Writer, hashes []string, cp *batchCheckpoint, debugOne func(hash string) error) (batchResult, error) {
+	var res batchResult
+	for i, hash := range hashes {
+		if cp.Done(hash) {
+			res.Skipped++
+			_, _ = fmt.Fprintf(w, "[%d/%d] %s: already completed, skipping\n", i+1, len(hashes), hash)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "[%d/%d] %s\n", i+1, len(hashes), hash)
+		if err := debugOne(hash); err != nil {
+			res.Failed++
+			_, _ = fmt.Fprintf(w, "[%d/%d] %s: error: %v\n", i+1, len(hashes), hash, err)
+			continue
+		}
+		if err := cp.Record(hash); err != nil {
+			return res, err
+		}
+		res.Processed++
+	}
+
+	_, _ = fmt.Fprintf(w, "\nBatch: %d processed, %d skipped, %d failed\n", res.Processed, res.Skipped, res.Failed)
+	if res.Failed > 0 {
+		return res, fmt.Errorf("%d of %d transactions failed; rerun with --resume to retry them", res.Failed, len(hashes))
+	}
+	return res, nil
+}
+
+// runBatchFile debugs every hash listed in path by running cmd once per
+// hash. Progress is checkpointed to path+".done".
+func runBatchFile(cmd *cobra.Command, path string, resume bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open batch file: %w", err)
+	}
+	hashes, err := readBatchHashes(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	cp, err := openBatchCheckpoint(path+batchCheckpointSuffix, resume)
+	if err != nil {
+		return err
+	}
+
+	_, err = runDebugBatch(cmd.OutOrStdout(), hashes, cp, func(hash string) error {
+		return cmd.RunE(cmd, []string{hash})
+	})
+	return err
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchHash(c byte) string {
+	return strings.Repeat(string(c), 64)
+}
+
+func TestRunDebugBatch_ResumeSkipsCompletedHashes(t *testing.T) {
+	hashes := []string{batchHash('a'), batchHash('b'), batchHash('c'), batchHash('d')}
+	path := filepath.Join(t.TempDir(), "hashes.txt.done")
+
+	// First run crashes on the third hash.
+	cp, err := openBatchCheckpoint(path, false)
+	require.NoError(t, err)
+	var first []string
+	_, err = runDebugBatch(&bytes.Buffer{}, hashes, cp, func(hash string) error {
+		first = append(first, hash)
+		if hash == batchHash('c') {
+			return errors.New("simulator crashed")
+		}
+		return nil
+	})
+	require.Error(t, err)
+	assert.Equal(t, hashes, first)
+
+	// The resumed run only processes what did not complete.
+	cp, err = openBatchCheckpoint(path, true)
+	require.NoError(t, err)
+	var second []string
+	out := &bytes.Buffer{}
+	res, err := runDebugBatch(out, hashes, cp, func(hash string) error {
+		second = append(second, hash)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{batchHash('c')}, second)
+	assert.Equal(t, batchResult{Processed: 1, Skipped: 3}, res)
+	assert.Contains(t, out.String(), "already completed, skipping")
+
+	// A third resumed run has nothing left to do.
+	cp, err = openBatchCheckpoint(path, true)
+	require.NoError(t, err)
+	res, err = runDebugBatch(&bytes.Buffer{}, hashes, cp, func(hash string) error {
+		t.Errorf("unexpected run for %s", hash)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, res.Skipped)
+}
+
+func TestRunDebugBatch_WithoutResumeStartsOver(t *testing.T) {
+	hashes := []string{batchHash('a'), batchHash('b')}
+	path := filepath.Join(t.TempDir(), "hashes.txt.done")
+
+	cp, err := openBatchCheckpoint(path, false)
+	require.NoError(t, err)
+	_, err = runDebugBatch(&bytes.Buffer{}, hashes, cp, func(string) error { return nil })
+	require.NoError(t, err)
+
+	cp, err = openBatchCheckpoint(path, false)
+	require.NoError(t, err)
+	var ran []string
+	_, err = runDebugBatch(&bytes.Buffer{}, hashes, cp, func(hash string) error {
+		ran = append(ran, hash)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hashes, ran)
+}
+
+func TestOpenBatchCheckpoint_ResumeWithoutStateFile(t *testing.T) {
+	cp, err := openBatchCheckpoint(filepath.Join(t.TempDir(), "missing.done"), true)
+	require.NoError(t, err)
+	assert.False(t, cp.Done(batchHash('a')))
+}
+
+func TestReadBatchHashes(t *testing.T) {
+	input := "# nightly failures\n" + batchHash('a') + "\n\n  " + batchHash('b') + "  \n"
+	hashes, err := readBatchHashes(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, []string{batchHash('a'), batchHash('b')}, hashes)
+
+	_, err = readBatchHashes(strings.NewReader(batchHash('a') + "\nnot-a-hash\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
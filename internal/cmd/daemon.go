@@ -33,6 +33,7 @@ var daemonCmd = &cobra.Command{
 Endpoints:
   - debug_transaction: Debug a failed transaction
   - get_trace: Get execution traces for a transaction
+  - GET /version: CLI, simulator and Go versions as JSON
 
 Example:
   erst daemon --port 8080 --network testnet
@@ -68,6 +69,10 @@ Example:
 			Network:   daemonNetwork,
 			RPCURL:    daemonRPCURL,
 			AuthToken: daemonAuthToken,
+			VersionInfo: func() interface{} {
+				return getVersionInfo()
+			},
+			Clients: rpcClients,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create server: %w", err)
@@ -108,5 +113,6 @@ func init() {
 	daemonCmd.Flags().BoolVar(&daemonTracing, "tracing", false, "Enable OpenTelemetry tracing")
 	daemonCmd.Flags().StringVar(&daemonOTLPURL, "otlp-url", "http://localhost:4318", "OTLP exporter URL")
 
+	requireInStrict(daemonCmd, "network")
 	rootCmd.AddCommand(daemonCmd)
 }
@@ -8,16 +8,25 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/dotandev/hintents/internal/analytics"
+	"github.com/dotandev/hintents/internal/authtrace"
+	"github.com/dotandev/hintents/internal/bundle"
 	"github.com/dotandev/hintents/internal/config"
+	"github.com/dotandev/hintents/internal/db"
+	"github.com/dotandev/hintents/internal/decoder"
 	"github.com/dotandev/hintents/internal/errors"
 	"github.com/dotandev/hintents/internal/localization"
 	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/redact"
 	"github.com/dotandev/hintents/internal/rpc"
 	"github.com/dotandev/hintents/internal/security"
 	"github.com/dotandev/hintents/internal/session"
@@ -29,27 +38,66 @@ import (
 	"github.com/dotandev/hintents/internal/watch"
 
 	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/strkey"
 	"github.com/stellar/go-stellar-sdk/xdr"
 	"go.opentelemetry.io/otel/attribute"
 )
 
 var (
-	networkFlag        string
-	rpcURLFlag         string
-	rpcTokenFlag       string
-	tracingEnabled     bool
-	otlpExporterURL    string
-	generateTrace      bool
-	traceOutputFile    string
-	snapshotFlag       string
-	compareNetworkFlag string
-	verbose            bool
-	wasmPath           string
-	args               []string
-	noCacheFlag        bool
-	demoMode           bool
-	watchFlag          bool
-	watchTimeoutFlag   int
+	networkFlag         string
+	rpcURLFlag          string
+	rpcTokenFlag        string
+	proxyFlag           string
+	tracingEnabled      bool
+	otlpExporterURL     string
+	generateTrace       bool
+	traceOutputFile     string
+	snapshotFlag        string
+	compareNetworkFlag  string
+	verbose             bool
+	wasmPath            string
+	args                []string
+	noCacheFlag         bool
+	demoMode            bool
+	watchFlag           bool
+	watchTimeoutFlag    int
+	restoreArchived     bool
+	waitForTxFlag       time.Duration
+	bundleFlag          string
+	redactFlag          bool
+	listOperationsFlag  bool
+	assetLabelsFlag     string
+	assetFilterFlag     []string
+	tokenFlowFormatFlag string
+	outputFlag          string
+	saveSessionFlag     bool
+	envelopeFileFlag    string
+	metaFileFlag        string
+	userAgentFlag       string
+	passphraseFlag      string
+	networkIDFlag       string
+	progressFlag        string
+	redactFieldsFlag    []string
+	redactPatternFlag   string
+	rawBudgetFlag       bool
+	eventsFormatFlag    string
+	accountFlag         string
+	latestFlag          bool
+	simPathFlag         string
+	balancesFlag        bool
+	balancesCSVFlag     string
+	overrideSeqFlag     int64
+	timingFlag          bool
+	eventSourceFlag     string
+	explainBudgetFlag   bool
+	batchFileFlag       string
+	resumeFlag          bool
+	ledgerSequenceFlag  uint32
+	protocolFlag        uint32
+	limitEntriesFlag    int
+	limitBytesFlag      int
+	truncateEntriesFlag bool
+	repeatFlag          int
 )
 
 // DebugCommand holds dependencies for the debug command
@@ -115,15 +163,16 @@ func (d *DebugCommand) runDebug(cmd *cobra.Command, args []string) error {
 		opts = append(opts, rpc.WithHorizonURL(rpcURLFlag))
 	}
 
-	client, err := rpc.NewClient(opts...)
+	client, err := rpcClients.Client(opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
-	fmt.Printf("Debugging transaction: %s\n", txHash)
-	fmt.Printf("Network: %s\n", networkFlag)
+	w := cmd.OutOrStdout()
+	fmt.Fprintf(w, "Debugging transaction: %s\n", txHash)
+	fmt.Fprintf(w, "Network: %s\n", networkFlag)
 	if rpcURLFlag != "" {
-		fmt.Printf("RPC URL: %s\n", rpcURLFlag)
+		fmt.Fprintf(w, "RPC URL: %s\n", rpcURLFlag)
 	}
 
 	// Fetch transaction details
@@ -132,20 +181,188 @@ func (d *DebugCommand) runDebug(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to fetch transaction: %w", err)
 	}
 
-	fmt.Printf("Transaction fetched successfully. Envelope size: %d bytes\n", len(resp.EnvelopeXdr))
+	fmt.Fprintf(w, "Transaction fetched successfully. Envelope size: %d bytes\n", len(resp.EnvelopeXdr))
 
-	// TODO: Use d.Runner for simulation when ready
-	// simReq := &simulator.SimulationRequest{
-	//     EnvelopeXdr: resp.EnvelopeXdr,
-	//     ResultMetaXdr: resp.ResultMetaXdr,
-	// }
-	// simResp, err := d.Runner.Run(simReq)
+	if d.Runner == nil {
+		return nil
+	}
+
+	entries, err := fetchFootprintEntries(cmd.Context(), client, resp.EnvelopeXdr, nil)
+	if err != nil {
+		return err
+	}
+	if entries, err = limitLedgerEntries(nil, entries); err != nil {
+		return err
+	}
 
+	simReq := &simulator.SimulationRequest{
+		EnvelopeXdr:     resp.EnvelopeXdr,
+		ResultMetaXdr:   resp.ResultMetaXdr,
+		LedgerEntries:   entries,
+		LedgerSequence:  pinLedgerSequence(cmd.Context(), client, entries),
+		ProtocolVersion: resolveProtocolVersion(cmd.Context(), client, nil),
+	}
+	simResp, err := d.Runner.Run(simReq)
+	if err != nil {
+		return fmt.Errorf("simulation failed: %w", err)
+	}
+	printSimulationResult(w, networkFlag, simResp)
 	return nil
 }
 
+// recordHistory adds the simulation result to the history searched by
+// "erst search", with the topic signatures of its diagnostic events.
+func recordHistory(txHash, network string, res *simulator.SimulationResponse) error {
+	store, err := db.InitDB()
+	if err != nil {
+		return err
+	}
+	return store.SaveSession(&db.Session{
+		TxHash:          txHash,
+		Network:         network,
+		Status:          res.Status,
+		ErrorMsg:        res.Error,
+		Events:          res.Events,
+		Logs:            res.Logs,
+		EventSignatures: simulator.EventSignatures(res.DiagnosticEvents),
+	})
+}
+
+// pinLedgerSequence picks the ledger a simulation runs against: the
+// --ledger-sequence override, else the latest ledger of client's network,
+// else the newest of entries. A nil client skips the network lookup.
+func pinLedgerSequence(ctx context.Context, client *rpc.Client, entries map[string]string) uint32 {
+	seq, source := ledgerSequenceFlag, "flag"
+	if seq == 0 && client != nil {
+		latest, err := client.GetLatestLedger(ctx)
+		if err != nil {
+			logger.Logger.Warn("Failed to fetch latest ledger, pinning to ledger entries", "error", err)
+		} else {
+			seq, source = latest, "network"
+		}
+	}
+	if seq == 0 {
+		seq, source = simulator.EntriesLedgerSequence(entries), "ledger entries"
+	}
+	if seq != 0 {
+		logger.Logger.Info("Simulation pinned to ledger", "sequence", seq, "source", source)
+	}
+	return seq
+}
+
+// resolveProtocolVersion picks the protocol a simulation runs under: the
+// --protocol override, else the current protocol of client's network. It
+// returns nil, leaving the simulator's latest protocol, when the network
+// cannot be asked or runs a protocol the simulator does not support. A nil
+// client skips the network lookup.
+func resolveProtocolVersion(ctx context.Context, client *rpc.Client, warnings *WarningCollector) *uint32 {
+	if protocolFlag != 0 {
+		v := protocolFlag
+		return &v
+	}
+	if client == nil {
+		return nil
+	}
+	v, err := client.GetProtocolVersion(ctx)
+	if err != nil {
+		logger.Logger.Warn("Failed to fetch network protocol version, using the simulator default", "error", err)
+		return nil
+	}
+	if err := simulator.Validate(v); err != nil {
+		warnings.Add("protocol", "network runs protocol %d, which the simulator does not support; simulating under protocol %d instead", v, simulator.LatestVersion())
+		return nil
+	}
+	logger.Logger.Info("Simulation protocol from network", "version", v)
+	return &v
+}
+
+// validateProtocolFlag checks --protocol against the versions the simulator
+// supports. Zero means unset.
+func validateProtocolFlag() error {
+	if protocolFlag == 0 {
+		return nil
+	}
+	if err := simulator.Validate(protocolFlag); err != nil {
+		return fmt.Errorf("invalid protocol: %d. Must be one of: %s", protocolFlag, joinVersions(simulator.Supported()))
+	}
+	return nil
+}
+
+func joinVersions(versions []uint32) string {
+	parts := make([]string, len(versions))
+	for i, v := range versions {
+		parts[i] = strconv.FormatUint(uint64(v), 10)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// limitLedgerEntries applies the --limit-ledger-entries and
+// --limit-ledger-bytes caps to entries. Over the cap it fails, or with
+// --truncate-ledger-entries drops the excess and records a warning.
+func limitLedgerEntries(warnings *WarningCollector, entries map[string]string) (map[string]string, error) {
+	limit := simulator.LedgerEntryLimit{MaxEntries: limitEntriesFlag, MaxBytes: limitBytesFlag}
+	kept, dropped, err := limit.Apply(entries, truncateEntriesFlag)
+	if err != nil {
+		return nil, fmt.Errorf("%w; raise --limit-ledger-entries/--limit-ledger-bytes or pass --truncate-ledger-entries", err)
+	}
+	if dropped > 0 {
+		logger.Logger.Warn("Truncated ledger entries to the configured limit", "dropped", dropped, "kept", len(kept), "limit", limit.String())
+		warnings.Add("ledger", "dropped %d of %d ledger entries to stay within %s; the simulation may miss state", dropped, len(entries), limit)
+	}
+	return kept, nil
+}
+
+// fetchFootprintEntries fetches the ledger entries named in the footprint of
+// the envelope's Soroban transaction data, skipping the keys already in
+// known. Classic transactions have no footprint and get a nil map. An RPC
+// failure or a key the RPC does not know is logged and the simulation goes
+// ahead with whatever was found.
+func fetchFootprintEntries(ctx context.Context, client *rpc.Client, envelopeXdr string, known map[string]string) (map[string]string, error) {
+	footprint, err := simulator.FootprintKeys(envelopeXdr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction footprint: %w", err)
+	}
+	var keys []string
+	for _, key := range footprint {
+		if _, ok := known[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	entries, err := client.GetLedgerEntries(ctx, keys)
+	if err != nil {
+		logger.Logger.Warn("Failed to fetch footprint ledger entries", "count", len(keys), "error", err)
+		return nil, nil
+	}
+	for _, key := range keys {
+		if _, ok := entries[key]; !ok {
+			logger.Logger.Warn("Footprint ledger entry not found", "key", key)
+		}
+	}
+	return entries, nil
+}
+
+// addFootprintEntries adds the footprint entries missing from entries, so
+// contract state that the result meta does not carry is still injected.
+func addFootprintEntries(ctx context.Context, client *rpc.Client, envelopeXdr string, entries map[string]string) (map[string]string, error) {
+	fetched, err := fetchFootprintEntries(ctx, client, envelopeXdr, entries)
+	if err != nil || len(fetched) == 0 {
+		return entries, err
+	}
+	if entries == nil {
+		entries = make(map[string]string, len(fetched))
+	}
+	for key, entry := range fetched {
+		entries[key] = entry
+	}
+	return entries, nil
+}
+
 var debugCmd = &cobra.Command{
-	Use:   "debug <transaction-hash>",
+	Use:   "debug <transaction-hash|url>",
 	Short: "Debug a failed Soroban transaction",
 	Long: `Fetch and simulate a Soroban transaction to debug failures and analyze execution.
 
@@ -158,6 +375,11 @@ through the local simulator, and displays detailed execution traces including:
 
 The simulation results are stored in a session that can be saved for later analysis.
 
+Offline Transaction Mode:
+  Use --envelope-file and --meta-file to replay XDR captured locally, such as
+  from a failed submission, without fetching the transaction. The hash
+  argument is then optional.
+
 Local WASM Replay Mode:
   Use --wasm flag to test contracts locally without network data.`,
 	Example: `  # Debug a transaction on mainnet
@@ -166,6 +388,9 @@ Local WASM Replay Mode:
   # Debug on testnet
   erst debug --network testnet abc123...def789
 
+  # Debug from a stellar.expert or Horizon URL
+  erst debug --network testnet https://stellar.expert/explorer/testnet/tx/abc123...def789
+
   # Debug and compare results between networks
   erst debug --network mainnet --compare-network testnet abc123...def789
 
@@ -175,6 +400,22 @@ Local WASM Replay Mode:
   # Compare execution across networks
   erst debug --network testnet --compare-network mainnet <tx-hash>
 
+  # Debug the most recent transaction of an account
+  erst debug --network testnet --account GABC...XYZ --latest
+
+  # Debug every hash in a file, then resume after an interruption
+  erst debug --network testnet --batch hashes.txt
+  erst debug --network testnet --batch hashes.txt --resume
+
+  # Replay an envelope and result meta captured locally
+  erst debug --network testnet --envelope-file tx.xdr --meta-file meta.xdr
+
+  # Reproduce a failure under the protocol that was live at the time
+  erst debug --network mainnet --protocol 21 <tx-hash>
+
+  # Compare the budget of a transaction across protocol upgrades
+  erst debug --network testnet --compare-protocols 20,21,22 <tx-hash>
+
   # Local WASM replay (no network required)
   erst debug --wasm ./contract.wasm --args "arg1" --args "arg2"
 
@@ -183,16 +424,38 @@ Local WASM Replay Mode:
 	Args: cobra.MaximumNArgs(1),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		// Demo mode or local WASM replay don't need transaction hash
-		if demoMode || wasmPath != "" {
+		if demoMode {
+			return nil
+		}
+		if wasmPath != "" {
+			if strictFlag {
+				return checkStrict(cmd.Flags(), []string{"sim-path"})
+			}
 			return nil
 		}
 
-		if len(args) == 0 {
-			return fmt.Errorf("transaction hash is required when not using --wasm or --demo flag")
+		if resumeFlag && batchFileFlag == "" {
+			return fmt.Errorf("--resume requires --batch")
+		}
+		switch {
+		case envelopeFileFlag != "" || metaFileFlag != "":
+			if err := validateFileTarget(args); err != nil {
+				return err
+			}
+		case batchFileFlag != "":
+			if len(args) > 0 || accountFlag != "" || latestFlag {
+				return fmt.Errorf("--batch cannot be combined with a transaction hash or --account --latest")
+			}
+		default:
+			if err := validateTxTarget(args, accountFlag, latestFlag); err != nil {
+				return err
+			}
 		}
 
-		if err := rpc.ValidateTransactionHash(args[0]); err != nil {
-			return fmt.Errorf("error: invalid transaction hash format: %w", err)
+		if strictFlag {
+			if err := checkStrict(cmd.Flags(), debugStrictFlags); err != nil {
+				return err
+			}
 		}
 
 		// Validate network flag
@@ -203,6 +466,46 @@ Local WASM Replay Mode:
 			return errors.WrapInvalidNetwork(networkFlag)
 		}
 
+		switch eventsFormatFlag {
+		case "list", "table":
+		default:
+			return fmt.Errorf("invalid events-format: %s. Must be one of: list, table", eventsFormatFlag)
+		}
+
+		switch outputFlag {
+		case "text", "json":
+		default:
+			return fmt.Errorf("invalid output: %s. Must be one of: text, json", outputFlag)
+		}
+
+		switch tokenFlowFormatFlag {
+		case "mermaid", "dot", "csv":
+		default:
+			return fmt.Errorf("invalid token-flow-format: %s. Must be one of: mermaid, dot, csv", tokenFlowFormatFlag)
+		}
+
+		switch eventSourceFlag {
+		case "sim", "meta":
+		default:
+			return fmt.Errorf("invalid source: %s. Must be one of: sim, meta", eventSourceFlag)
+		}
+
+		if overrideSeqFlag < 0 {
+			return fmt.Errorf("invalid override-seq: %d. Must not be negative", overrideSeqFlag)
+		}
+		if repeatFlag < 1 {
+			return fmt.Errorf("invalid repeat: %d. Must be at least 1", repeatFlag)
+		}
+		if err := validateProtocolFlag(); err != nil {
+			return err
+		}
+		if err := validateCompareProtocols(); err != nil {
+			return err
+		}
+		if limitEntriesFlag < 0 || limitBytesFlag < 0 {
+			return fmt.Errorf("invalid ledger entry limit: must not be negative")
+		}
+
 		// Validate compare network flag if present
 		if compareNetworkFlag != "" {
 			switch rpc.Network(compareNetworkFlag) {
@@ -221,19 +524,66 @@ Local WASM Replay Mode:
 			logger.SetLevel(slog.LevelWarn)
 		}
 
+		redaction, err := resolveRedactionPolicy()
+		if err != nil {
+			return err
+		}
+		logger.SetRedaction(redaction)
+
 		// Demo mode: print sample output for testing color detection (no network)
 		if demoMode {
-			return runDemoMode(cmdArgs)
+			return runDemoMode(cmd.OutOrStdout(), cmdArgs)
 		}
 
 		// Local WASM replay mode
 		if wasmPath != "" {
-			return runLocalWasmReplay()
+			return runLocalWasmReplay(cmd.OutOrStdout())
+		}
+
+		// Batch mode: run each hash of the file through this same command
+		if batchFileFlag != "" && len(cmdArgs) == 0 {
+			return runBatchFile(cmd, batchFileFlag, resumeFlag)
 		}
 
 		// Network transaction replay mode
 		ctx := cmd.Context()
-		txHash := cmdArgs[0]
+		var txHash string
+		if len(cmdArgs) > 0 {
+			txHash, err = rpc.ResolveTransactionRef(cmdArgs[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		// With --output json the step-by-step text goes to stderr and only
+		// the JSON document is written to stdout.
+		out := cmd.OutOrStdout()
+		w := out
+		jsonOutput := outputFlag == "json"
+		if jsonOutput {
+			w = cmd.ErrOrStderr()
+		}
+
+		warnings := NewWarningCollector()
+		defer warnings.Render(w)
+
+		// With --envelope-file the transaction is read from disk and never
+		// fetched; check the files before doing any network work.
+		var fileResp *rpc.TransactionResponse
+		if envelopeFileFlag != "" {
+			fileResp, err = loadTransactionFiles(envelopeFileFlag, metaFileFlag)
+			if err != nil {
+				return err
+			}
+		}
+
+		stopInterrupts := handleInterrupts(os.Stderr)
+		defer stopInterrupts()
+
+		progress, err := NewProgressReporter(progressFlag, os.Stderr)
+		if err != nil {
+			return err
+		}
 
 		// Initialize OpenTelemetry if enabled
 		if tracingEnabled {
@@ -261,18 +611,24 @@ Local WASM Replay Mode:
 		opts := []rpc.ClientOption{
 			rpc.WithNetwork(rpc.Network(networkFlag)),
 			rpc.WithToken(rpcTokenFlag),
+			rpc.WithProxy(proxyFlag),
+			rpc.WithUserAgent(resolveUserAgent()),
+			rpc.WithCacheEnabled(!noCacheFlag),
+		}
+		if batchFileFlag != "" {
+			// A dead endpoint should fail the rest of the batch fast
+			// instead of costing a full backoff sequence per item.
+			opts = append(opts, rpc.WithCircuitBreaker(rpc.DefaultCircuitBreakerConfig()))
 		}
 
-		if rpcURLFlag != "" {
-			urls := strings.Split(rpcURLFlag, ",")
-			for i := range urls {
-				urls[i] = strings.TrimSpace(urls[i])
-			}
-			opts = append(opts, rpc.WithAltURLs(urls))
-			horizonURL = urls[0]
+		urlOpts, primaryURL, err := rpcURLOptions(rpcURLFlag, passphraseFlag, networkIDFlag)
+		if err != nil {
+			return err
 		}
+		opts = append(opts, urlOpts...)
+		horizonURL = primaryURL
 
-		client, err := rpc.NewClient(opts...)
+		client, err := rpcClients.Client(opts...)
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
@@ -283,14 +639,30 @@ Local WASM Replay Mode:
 		}
 
 		if noCacheFlag {
-			client.CacheEnabled = false
-			fmt.Println("🚫 Cache disabled by --no-cache flag")
+			fmt.Fprintln(w, "🚫 Cache disabled by --no-cache flag")
+		}
+
+		if accountFlag != "" {
+			txHash, err = latestTransactionHash(ctx, client, accountFlag)
+			if err != nil {
+				return err
+			}
+			span.SetAttributes(attribute.String("transaction.hash", txHash))
+			fmt.Fprintf(w, "Latest transaction of %s: %s\n", accountFlag, txHash)
 		}
 
-		fmt.Printf("Debugging transaction: %s\n", txHash)
-		fmt.Printf("Primary Network: %s\n", networkFlag)
+		if fileResp != nil && txHash == "" {
+			if txHash, err = envelopeHash(fileResp.EnvelopeXdr, client.Config.ID()); err != nil {
+				warnings.Add("decoder", "could not compute the transaction hash: %v", err)
+				txHash = "local"
+			}
+			span.SetAttributes(attribute.String("transaction.hash", txHash))
+		}
+
+		fmt.Fprintf(w, "Debugging transaction: %s\n", txHash)
+		fmt.Fprintf(w, "Primary Network: %s\n", networkFlag)
 		if compareNetworkFlag != "" {
-			fmt.Printf("Comparing against Network: %s\n", compareNetworkFlag)
+			fmt.Fprintf(w, "Comparing against Network: %s\n", compareNetworkFlag)
 		}
 
 		// Fetch transaction details
@@ -325,13 +697,72 @@ Local WASM Replay Mode:
 			spinner.StopWithMessage("Transaction found! Starting debug...")
 		}
 
-		fmt.Printf("Fetching transaction: %s\n", txHash)
-		resp, err := client.GetTransaction(ctx, txHash)
-		if err != nil {
-			return fmt.Errorf(localization.Get("error.fetch_transaction"), err)
+		progress.Phase(PhaseFetching)
+		var timings debugTimings
+		resp := fileResp
+		if resp != nil {
+			fmt.Fprintf(w, "Transaction loaded from %s. Envelope size: %d bytes\n", envelopeFileFlag, len(resp.EnvelopeXdr))
+		} else {
+			fmt.Fprintf(w, "Fetching transaction: %s\n", txHash)
+			resp, err = fetchTransaction(ctx, client, txHash, waitForTxFlag, &timings)
+			if err != nil {
+				return fmt.Errorf(localization.Get("error.fetch_transaction"), err)
+			}
+
+			fmt.Fprintf(w, "Transaction fetched successfully. Envelope size: %d bytes\n", len(resp.EnvelopeXdr))
+		}
+
+		if cmd.Flags().Changed("override-seq") {
+			original, err := simulator.EnvelopeSequence(resp.EnvelopeXdr)
+			if err != nil {
+				return fmt.Errorf("failed to read sequence number: %w", err)
+			}
+			resp.EnvelopeXdr, err = simulator.OverrideSequence(resp.EnvelopeXdr, overrideSeqFlag)
+			if err != nil {
+				return fmt.Errorf("failed to override sequence number: %w", err)
+			}
+			fmt.Fprintf(w, "Sequence number overridden: %d -> %d\n", original, overrideSeqFlag)
+			warnings.Add("sequence", "simulating with sequence %d instead of %d; signatures no longer match the envelope", overrideSeqFlag, original)
+		}
+
+		if err := simulator.PreflightXDR(resp.EnvelopeXdr, resp.ResultMetaXdr); err != nil {
+			return err
+		}
+
+		var fees *decoder.FeeSummary
+		if resp.ResultXdr != "" {
+			if fees, err = decoder.SummarizeFees(resp.EnvelopeXdr, resp.ResultXdr); err == nil {
+				printFeeSummary(w, fees)
+			} else {
+				warnings.Add("decoder", "could not decode fees: %v", err)
+			}
 		}
 
-		fmt.Printf("Transaction fetched successfully. Envelope size: %d bytes\n", len(resp.EnvelopeXdr))
+		if deployed, err := decoder.DeployedContracts(resp.EnvelopeXdr, resp.ResultMetaXdr, client.Config.ID()); err != nil {
+			warnings.Add("decoder", "could not determine the deployed contract: %v", err)
+		} else {
+			for _, id := range deployed {
+				fmt.Fprintf(w, "Deployed contract: %s\n", id)
+			}
+		}
+
+		if entries, err := rpc.ExtractLedgerEntriesFromMeta(resp.ResultMetaXdr); err == nil {
+			check, err := authtrace.CheckSignatures(resp.EnvelopeXdr, entries)
+			if err != nil {
+				warnings.Add("signatures", "could not check signature weights: %v", err)
+			} else if check != nil {
+				printSignatureCheck(w, check)
+				if !check.Sufficient {
+					warnings.Add("signatures", "signer weight %d is below the %s threshold %d of %s", check.CollectedWeight, check.Level, check.RequiredWeight, check.AccountID)
+				}
+			}
+		}
+
+		if listOperationsFlag {
+			if err := printOperations(w, resp.EnvelopeXdr); err != nil {
+				warnings.Add("decoder", "could not list operations: %v", err)
+			}
+		}
 
 		// Extract ledger keys for replay
 		keys, err := extractLedgerKeys(resp.ResultMetaXdr)
@@ -339,8 +770,22 @@ Local WASM Replay Mode:
 			return fmt.Errorf("failed to extract ledger keys: %w", err)
 		}
 
+		// Detect archived footprint entries that would need a restore first.
+		// This costs an extra RPC round trip, so it only runs on request.
+		var archived []simulator.ArchivedEntry
+		var archivedEntries map[string]string
+		if restoreArchived {
+			archived, archivedEntries, err = detectArchivedFootprint(ctx, client, resp.EnvelopeXdr)
+			if err != nil {
+				warnings.Add("restore", "could not check footprint for archived entries: %v", err)
+			} else if len(archived) > 0 {
+				cost := simulator.EstimateRestoreCost(archived, simulator.DefaultRestoreFeeModel)
+				fmt.Fprintf(w, "Archived footprint entries: %d (estimated restore fee: %d stroops)\n", len(archived), cost.TotalFee)
+			}
+		}
+
 		// Initialize Simulator Runner
-		runner, err := simulator.NewRunner("", tracingEnabled)
+		runner, err := simulator.NewRunner(simPathFlag, tracingEnabled, simulator.WithMinVersion(simulator.MinSimulatorVersion))
 		if err != nil {
 			return fmt.Errorf("failed to initialize simulator: %w", err)
 		}
@@ -356,10 +801,23 @@ Local WASM Replay Mode:
 		}
 
 		var lastSimResp *simulator.SimulationResponse
+		var lastSimReq *simulator.SimulationRequest
 
+		// Snapshot replays have no network protocol to default to.
+		protocolClient := client
+		if snapshotFlag != "" {
+			protocolClient = nil
+		}
+		protocolVersion := resolveProtocolVersion(ctx, protocolClient, warnings)
+		if protocolVersion != nil {
+			fmt.Fprintf(w, "Simulating under protocol %d\n", *protocolVersion)
+		}
+
+		progress.Phase(PhaseSimulating)
+		simStart := time.Now()
 		for _, ts := range timestamps {
 			if len(timestamps) > 1 {
-				fmt.Printf("\n--- Simulating at Timestamp: %d ---\n", ts)
+				fmt.Fprintf(w, "\n--- Simulating at Timestamp: %d ---\n", ts)
 			}
 
 			var simResp *simulator.SimulationResponse
@@ -373,12 +831,13 @@ Local WASM Replay Mode:
 						return fmt.Errorf("failed to load snapshot: %w", err)
 					}
 					ledgerEntries = snap.ToMap()
-					fmt.Printf("Loaded %d ledger entries from snapshot\n", len(ledgerEntries))
+					fmt.Fprintf(w, "Loaded %d ledger entries from snapshot\n", len(ledgerEntries))
 				} else {
 					// Try to extract from metadata first, fall back to fetching
 					ledgerEntries, err = rpc.ExtractLedgerEntriesFromMeta(resp.ResultMetaXdr)
 					if err != nil {
 						logger.Logger.Warn("Failed to extract ledger entries from metadata, fetching from network", "error", err)
+						warnings.Add("ledger", "could not extract ledger entries from metadata, fetched from network instead: %v", err)
 						ledgerEntries, err = client.GetLedgerEntries(ctx, keys)
 						if err != nil {
 							return fmt.Errorf("failed to fetch ledger entries: %w", err)
@@ -386,25 +845,61 @@ Local WASM Replay Mode:
 					} else {
 						logger.Logger.Info("Extracted ledger entries for simulation", "count", len(ledgerEntries))
 					}
+					if ledgerEntries, err = addFootprintEntries(ctx, client, resp.EnvelopeXdr, ledgerEntries); err != nil {
+						return err
+					}
+				}
+				if ledgerEntries, err = limitLedgerEntries(warnings, ledgerEntries); err != nil {
+					return err
+				}
+
+				// Snapshot replays stay pinned to the snapshot's own ledger.
+				ledgerClient := client
+				if snapshotFlag != "" {
+					ledgerClient = nil
 				}
 
-				fmt.Printf("Running simulation on %s...\n", networkFlag)
+				fmt.Fprintf(w, "Running simulation on %s...\n", networkFlag)
 				simReq := &simulator.SimulationRequest{
-					EnvelopeXdr:   resp.EnvelopeXdr,
-					ResultMetaXdr: resp.ResultMetaXdr,
-					LedgerEntries: ledgerEntries,
-					Timestamp:     ts,
+					EnvelopeXdr:     resp.EnvelopeXdr,
+					ResultMetaXdr:   resp.ResultMetaXdr,
+					LedgerEntries:   ledgerEntries,
+					LedgerSequence:  pinLedgerSequence(ctx, ledgerClient, ledgerEntries),
+					Timestamp:       ts,
+					ProtocolVersion: protocolVersion,
+				}
+
+				if len(archived) > 0 {
+					if err := simulateRestore(w, runner, simReq, archived, archivedEntries); err != nil {
+						return err
+					}
 				}
 
-				simResp, err = runner.Run(simReq)
+				if repeatFlag > 1 {
+					var stats repeatStats
+					simResp, stats, err = runRepeated(runner, simReq, repeatFlag)
+					if err == nil {
+						printRepeatStats(w, stats)
+						if !stats.Deterministic() {
+							warnings.Add("simulator", "nondeterministic simulation: %d of %d repeated runs differ from the first", len(stats.Mismatched), stats.Runs)
+						}
+					}
+				} else {
+					simResp, err = runner.Run(simReq)
+				}
 				if err != nil {
 					return fmt.Errorf("simulation failed: %w", err)
 				}
-				printSimulationResult(networkFlag, simResp)
+				lastSimReq = simReq
+				printSimulationResult(w, networkFlag, withEventSource(w, warnings, resp.ResultMetaXdr, simResp))
+				checkEventsAgainstChain(warnings, resp.ResultMetaXdr, simResp)
+				collectBudgetWarnings(warnings, networkFlag, simResp)
+				collectStatusWarnings(warnings, networkFlag, simResp)
 			} else {
 				// Comparison Run
 				var wg sync.WaitGroup
 				var primaryResult, compareResult *simulator.SimulationResponse
+				var primaryReq *simulator.SimulationRequest
 				var primaryErr, compareErr error
 
 				wg.Add(2)
@@ -420,12 +915,21 @@ Local WASM Replay Mode:
 							return
 						}
 					}
-					primaryResult, primaryErr = runner.Run(&simulator.SimulationRequest{
-						EnvelopeXdr:   resp.EnvelopeXdr,
-						ResultMetaXdr: resp.ResultMetaXdr,
-						LedgerEntries: entries,
-						Timestamp:     ts,
-					})
+					if entries, primaryErr = addFootprintEntries(ctx, client, resp.EnvelopeXdr, entries); primaryErr != nil {
+						return
+					}
+					if entries, primaryErr = limitLedgerEntries(warnings, entries); primaryErr != nil {
+						return
+					}
+					primaryReq = &simulator.SimulationRequest{
+						EnvelopeXdr:     resp.EnvelopeXdr,
+						ResultMetaXdr:   resp.ResultMetaXdr,
+						LedgerEntries:   entries,
+						LedgerSequence:  pinLedgerSequence(ctx, client, entries),
+						Timestamp:       ts,
+						ProtocolVersion: protocolVersion,
+					}
+					primaryResult, primaryErr = runner.Run(primaryReq)
 				}()
 
 				go func() {
@@ -433,15 +937,15 @@ Local WASM Replay Mode:
 					compareOpts := []rpc.ClientOption{
 						rpc.WithNetwork(rpc.Network(compareNetworkFlag)),
 						rpc.WithToken(rpcTokenFlag),
+						rpc.WithProxy(proxyFlag),
+						rpc.WithUserAgent(resolveUserAgent()),
+						rpc.WithCacheEnabled(!noCacheFlag),
 					}
-					compareClient, clientErr := rpc.NewClient(compareOpts...)
+					compareClient, clientErr := rpcClients.Client(compareOpts...)
 					if clientErr != nil {
 						compareErr = fmt.Errorf("failed to create compare client: %w", clientErr)
 						return
 					}
-					if noCacheFlag {
-						compareClient.CacheEnabled = false
-					}
 
 					compareResp, txErr := compareClient.GetTransaction(ctx, txHash)
 					if txErr != nil {
@@ -457,12 +961,20 @@ Local WASM Replay Mode:
 							return
 						}
 					}
+					if entries, compareErr = addFootprintEntries(ctx, compareClient, resp.EnvelopeXdr, entries); compareErr != nil {
+						return
+					}
+					if entries, compareErr = limitLedgerEntries(warnings, entries); compareErr != nil {
+						return
+					}
 
 					compareResult, compareErr = runner.Run(&simulator.SimulationRequest{
-						EnvelopeXdr:   resp.EnvelopeXdr,
-						ResultMetaXdr: compareResp.ResultMetaXdr,
-						LedgerEntries: entries,
-						Timestamp:     ts,
+						EnvelopeXdr:     resp.EnvelopeXdr,
+						ResultMetaXdr:   compareResp.ResultMetaXdr,
+						LedgerEntries:   entries,
+						LedgerSequence:  pinLedgerSequence(ctx, compareClient, entries),
+						Timestamp:       ts,
+						ProtocolVersion: resolveProtocolVersion(ctx, compareClient, warnings),
 					})
 				}()
 
@@ -475,9 +987,18 @@ Local WASM Replay Mode:
 				}
 
 				simResp = primaryResult // Use primary for further analysis
-				printSimulationResult(networkFlag, primaryResult)
-				printSimulationResult(compareNetworkFlag, compareResult)
-				diffResults(primaryResult, compareResult, networkFlag, compareNetworkFlag)
+				lastSimReq = primaryReq
+				printSimulationResult(w, networkFlag, primaryResult)
+				printSimulationResult(w, compareNetworkFlag, compareResult)
+				diffResults(w, primaryResult, compareResult, networkFlag, compareNetworkFlag)
+				collectBudgetWarnings(warnings, networkFlag, primaryResult)
+				collectBudgetWarnings(warnings, compareNetworkFlag, compareResult)
+				collectStatusWarnings(warnings, networkFlag, primaryResult)
+				collectStatusWarnings(warnings, compareNetworkFlag, compareResult)
+				if primaryResult.Status != compareResult.Status {
+					warnings.Add("compare", "status mismatch: %s on %s vs %s on %s",
+						primaryResult.Status, networkFlag, compareResult.Status, compareNetworkFlag)
+				}
 			}
 			lastSimResp = simResp
 		}
@@ -485,13 +1006,91 @@ Local WASM Replay Mode:
 		if lastSimResp == nil {
 			return fmt.Errorf("no simulation results generated")
 		}
+		timings.Simulate = time.Since(simStart)
+		logger.Logger.Info("Debug timings", "fetch_ms", timings.Fetch.Milliseconds(), "retries", timings.Retries, "simulate_ms", timings.Simulate.Milliseconds())
+		if verbose || timingFlag {
+			fmt.Fprintln(w, timings.String())
+		}
+
+		if len(compareProtocolsFlag) > 0 && lastSimReq != nil {
+			budgets, err := simulator.CompareProtocols(runner, lastSimReq, compareProtocolVersions())
+			if err != nil {
+				warnings.Add("protocol", "protocol comparison failed: %v", err)
+			} else {
+				printProtocolBudgets(w, budgets)
+				for _, b := range budgets {
+					if b.Error != "" {
+						warnings.Add("protocol", "protocol %d: %s", b.Version, b.Error)
+					}
+				}
+			}
+		}
+
+		// Session Management: record the session before the analysis so an
+		// interrupt from here on can still save it.
+		simReq := &simulator.SimulationRequest{
+			EnvelopeXdr:   resp.EnvelopeXdr,
+			ResultMetaXdr: resp.ResultMetaXdr,
+		}
+		simReqJSON, err := json.Marshal(simReq)
+		if err != nil {
+			warnings.Add("session", "failed to serialize simulation data: %v", err)
+		}
+		simRespJSON, err := json.Marshal(lastSimResp)
+		if err != nil {
+			warnings.Add("session", "failed to serialize simulation results: %v", err)
+		}
+
+		sessionData := &session.SessionData{
+			ID:              session.GenerateID(txHash),
+			CreatedAt:       time.Now(),
+			LastAccessAt:    time.Now(),
+			Status:          "active",
+			Network:         networkFlag,
+			HorizonURL:      horizonURL,
+			TxHash:          txHash,
+			EnvelopeXdr:     resp.EnvelopeXdr,
+			ResultXdr:       resp.ResultXdr,
+			ResultMetaXdr:   resp.ResultMetaXdr,
+			SimRequestJSON:  string(simReqJSON),
+			SimResponseJSON: string(simRespJSON),
+			ErstVersion:     Version,
+			SchemaVersion:   session.SchemaVersion,
+		}
+		SetCurrentSession(sessionData)
+		if err := recordHistory(txHash, networkFlag, lastSimResp); err != nil {
+			warnings.Add("history", "could not record the session for 'erst search': %v", err)
+		}
+		sessionSaved := false
+		if saveSessionFlag {
+			if err := persistSession(ctx, sessionData); err != nil {
+				warnings.Add("session", "%v", err)
+			} else {
+				sessionSaved = true
+			}
+		}
+
+		progress.Phase(PhaseParsing)
+
+		// Analysis: Footprint
+		if len(lastSimResp.AccessedKeys) > 0 {
+			diff, err := simulator.DiffFootprint(resp.EnvelopeXdr, lastSimResp.AccessedKeys)
+			if err != nil {
+				warnings.Add("footprint", "failed to compare footprint: %v", err)
+			} else {
+				printFootprintDiff(w, diff)
+				for _, key := range diff.Undeclared {
+					warnings.Add("footprint", "ledger key accessed but not declared in footprint: %s", key)
+				}
+			}
+		}
 
 		// Analysis: Security
-		fmt.Printf("\n=== Security Analysis ===\n")
+		fmt.Fprintf(w, "\n=== Security Analysis ===\n")
 		secDetector := security.NewDetector()
 		findings := secDetector.Analyze(resp.EnvelopeXdr, resp.ResultMetaXdr, lastSimResp.Events, lastSimResp.Logs)
 		if len(findings) == 0 {
-			fmt.Printf("%s No security issues detected\n", visualizer.Success())
+			fmt.Fprintf(w, "%s No security issues detected\n", visualizer.Success())
 		} else {
 			verifiedCount := 0
 			heuristicCount := 0
@@ -505,113 +1104,148 @@ Local WASM Replay Mode:
 			}
 
 			if verifiedCount > 0 {
-				fmt.Printf("\n[!]  VERIFIED SECURITY RISKS: %d\n", verifiedCount)
+				fmt.Fprintf(w, "\n[!]  VERIFIED SECURITY RISKS: %d\n", verifiedCount)
 			}
 			if heuristicCount > 0 {
-				fmt.Printf("* HEURISTIC WARNINGS: %d\n", heuristicCount)
+				fmt.Fprintf(w, "* HEURISTIC WARNINGS: %d\n", heuristicCount)
+			}
+			for _, finding := range findings {
+				warnings.Add("security", "[%s] %s", finding.Severity, finding.Title)
 			}
 
-			fmt.Printf("\nFindings:\n")
+			fmt.Fprintf(w, "\nFindings:\n")
 			for i, finding := range findings {
 				icon := "*"
 				if finding.Type == security.FindingVerifiedRisk {
 					icon = "[!]"
 				}
-				fmt.Printf("%d. %s [%s] %s - %s\n", i+1, icon, finding.Type, finding.Severity, finding.Title)
-				fmt.Printf("   %s\n", finding.Description)
+				fmt.Fprintf(w, "%d. %s [%s] %s - %s\n", i+1, icon, finding.Type, finding.Severity, finding.Title)
+				fmt.Fprintf(w, "   %s\n", finding.Description)
 				if finding.Evidence != "" {
-					fmt.Printf("   Evidence: %s\n", finding.Evidence)
+					fmt.Fprintf(w, "   Evidence: %s\n", finding.Evidence)
 				}
 			}
 		}
 
 		// Analysis: Token Flows
-		if report, err := tokenflow.BuildReport(resp.EnvelopeXdr, resp.ResultMetaXdr); err == nil && len(report.Agg) > 0 {
-			fmt.Printf("\nToken Flow Summary:\n")
+		var flowReport *tokenflow.Report
+		if report, err := tokenflow.BuildReport(resp.EnvelopeXdr, resp.ResultMetaXdr, tokenflow.WithAssetFilter(assetFilterFlag...)); err == nil && len(report.Agg) > 0 {
+			flowReport = report
+			report.Labels = tokenflow.NewLabelRegistry()
+			if assetLabelsFlag != "" {
+				if err := report.Labels.LoadFile(assetLabelsFlag); err != nil {
+					warnings.Add("tokenflow", "%v", err)
+				}
+			}
+			fmt.Fprintf(w, "\nToken Flow Summary:\n")
+			highlight := visualizer.NewHighlighter()
 			for _, line := range report.SummaryLines() {
-				fmt.Printf("  %s\n", line)
+				fmt.Fprintf(w, "  %s\n", highlight.Values(line))
+			}
+			printTokenFlowChart(w, report, tokenFlowFormatFlag)
+			if balancesFlag {
+				fmt.Fprintf(w, "\nBalance Changes:\n")
+				fmt.Fprint(w, report.BalancesTable())
+			}
+			if balancesCSVFlag != "" {
+				if err := writeBalancesCSV(report, balancesCSVFlag); err != nil {
+					warnings.Add("tokenflow", "%v", err)
+				} else {
+					fmt.Fprintf(w, "Balance changes written to %s\n", balancesCSVFlag)
+				}
 			}
-			fmt.Printf("\nToken Flow Chart (Mermaid):\n")
-			fmt.Println(report.MermaidFlowchart())
 		}
 
-		// Session Management
-		simReq := &simulator.SimulationRequest{
-			EnvelopeXdr:   resp.EnvelopeXdr,
-			ResultMetaXdr: resp.ResultMetaXdr,
-		}
-		simReqJSON, err := json.Marshal(simReq)
-		if err != nil {
-			fmt.Printf("Warning: failed to serialize simulation data: %v\n", err)
+		if bundleFlag != "" {
+			contents := &bundle.Contents{
+				Environment: bundle.Environment{
+					ErstVersion: Version,
+					CommitSHA:   CommitSHA,
+					GoVersion:   runtime.Version(),
+					OS:          runtime.GOOS,
+					Arch:        runtime.GOARCH,
+					Network:     networkFlag,
+					RPCURL:      horizonURL,
+					TxHash:      txHash,
+					CreatedAt:   time.Now().UTC(),
+				},
+				EnvelopeXdr:   resp.EnvelopeXdr,
+				ResultXdr:     resp.ResultXdr,
+				ResultMetaXdr: resp.ResultMetaXdr,
+				Request:       lastSimReq,
+				Response:      lastSimResp,
+				Logs:          lastSimResp.Logs,
+			}
+			if err := bundle.Write(bundleFlag, contents, bundle.Options{Redact: redactFlag, Policy: redaction}); err != nil {
+				return fmt.Errorf("failed to write diagnostics bundle: %w", err)
+			}
+			fmt.Fprintf(w, "\nDiagnostics bundle written to %s\n", bundleFlag)
 		}
-		simRespJSON, err := json.Marshal(lastSimResp)
-		if err != nil {
-			fmt.Printf("Warning: failed to serialize simulation results: %v\n", err)
+
+		if sessionSaved {
+			fmt.Fprintf(w, "\nSession saved: %s\n", sessionData.ID)
+			fmt.Fprintf(w, "Run 'erst session resume %s' to reopen it.\n", sessionData.ID)
+		} else {
+			fmt.Fprintf(w, "\nSession created: %s\n", sessionData.ID)
+			fmt.Fprintf(w, "Run 'erst session save' to persist this session.\n")
 		}
 
-		sessionData := &session.SessionData{
-			ID:              session.GenerateID(txHash),
-			CreatedAt:       time.Now(),
-			LastAccessAt:    time.Now(),
-			Status:          "active",
-			Network:         networkFlag,
-			HorizonURL:      horizonURL,
-			TxHash:          txHash,
-			EnvelopeXdr:     resp.EnvelopeXdr,
-			ResultXdr:       resp.ResultXdr,
-			ResultMetaXdr:   resp.ResultMetaXdr,
-			SimRequestJSON:  string(simReqJSON),
-			SimResponseJSON: string(simRespJSON),
-			ErstVersion:     Version,
-			SchemaVersion:   session.SchemaVersion,
+		if jsonOutput {
+			tx := debugJSONTransaction{
+				EnvelopeXdr:   resp.EnvelopeXdr,
+				ResultXdr:     resp.ResultXdr,
+				ResultMetaXdr: resp.ResultMetaXdr,
+			}
+			doc := newDebugJSONOutput(txHash, networkFlag, sessionData.ID, tx, fees, lastSimResp, flowReport, warnings)
+			if err := writeDebugJSON(out, doc); err != nil {
+				return err
+			}
 		}
-		SetCurrentSession(sessionData)
-		fmt.Printf("\nSession created: %s\n", sessionData.ID)
-		fmt.Printf("Run 'erst session save' to persist this session.\n")
+		progress.Phase(PhaseDone)
 		return nil
 	},
 }
 
 // runDemoMode prints sample output without network/WASM - for testing color detection.
-func runDemoMode(cmdArgs []string) error {
+func runDemoMode(w io.Writer, cmdArgs []string) error {
 	txHash := "5c0a1234567890abcdef1234567890abcdef1234567890abcdef1234567890ab"
 	if len(cmdArgs) > 0 && len(cmdArgs[0]) == 64 {
 		txHash = cmdArgs[0]
 	}
 
-	fmt.Printf("Fetching transaction: %s\n", txHash)
-	fmt.Printf("Transaction fetched successfully. Envelope size: 256 bytes\n")
-	fmt.Printf("\n--- Result for %s ---\n", networkFlag)
-	fmt.Printf("Status: success\n")
-	fmt.Printf("\nResource Usage:\n")
-	fmt.Printf("  CPU Instructions: 12345\n")
-	fmt.Printf("  Memory Bytes: 1024\n")
-	fmt.Printf("  Operations: 5\n")
-	fmt.Printf("\nEvents: 2, Logs: 3\n")
-	fmt.Printf("\n=== Security Analysis ===\n")
-	fmt.Printf("%s No security issues detected\n", visualizer.Success())
-	fmt.Printf("\nToken Flow Summary:\n")
-	fmt.Printf("  %s XLM transferred\n", visualizer.Symbol("arrow_r"))
-	fmt.Printf("\nSession ready. Use 'erst session save' to persist.\n")
+	fmt.Fprintf(w, "Fetching transaction: %s\n", txHash)
+	fmt.Fprintf(w, "Transaction fetched successfully. Envelope size: 256 bytes\n")
+	fmt.Fprintf(w, "\n--- Result for %s ---\n", networkFlag)
+	fmt.Fprintf(w, "Status: success\n")
+	fmt.Fprintf(w, "\nResource Usage:\n")
+	fmt.Fprintf(w, "  CPU Instructions: 12345\n")
+	fmt.Fprintf(w, "  Memory Bytes: 1024\n")
+	fmt.Fprintf(w, "  Operations: 5\n")
+	fmt.Fprintf(w, "\nEvents: 2, Logs: 3\n")
+	fmt.Fprintf(w, "\n=== Security Analysis ===\n")
+	fmt.Fprintf(w, "%s No security issues detected\n", visualizer.Success())
+	fmt.Fprintf(w, "\nToken Flow Summary:\n")
+	fmt.Fprintf(w, "  %s XLM transferred\n", visualizer.Symbol("arrow_r"))
+	fmt.Fprintf(w, "\nSession ready. Use 'erst session save' to persist.\n")
 	return nil
 }
 
-func runLocalWasmReplay() error {
-	fmt.Printf("%s  WARNING: Using Mock State (not mainnet data)\n", visualizer.Warning())
-	fmt.Println()
+func runLocalWasmReplay(w io.Writer) error {
+	fmt.Fprintf(w, "%s  WARNING: Using Mock State (not mainnet data)\n", visualizer.Warning())
+	fmt.Fprintln(w)
 
 	// Verify WASM file exists
 	if _, err := os.Stat(wasmPath); os.IsNotExist(err) {
 		return fmt.Errorf("WASM file not found: %s", wasmPath)
 	}
 
-	fmt.Printf("%s Local WASM Replay Mode\n", visualizer.Symbol("wrench"))
-	fmt.Printf("WASM File: %s\n", wasmPath)
-	fmt.Printf("Arguments: %v\n", args)
-	fmt.Println()
+	fmt.Fprintf(w, "%s Local WASM Replay Mode\n", visualizer.Symbol("wrench"))
+	fmt.Fprintf(w, "WASM File: %s\n", wasmPath)
+	fmt.Fprintf(w, "Arguments: %v\n", args)
+	fmt.Fprintln(w)
 
 	// Create simulator runner
-	runner, err := simulator.NewRunner("", tracingEnabled)
+	runner, err := simulator.NewRunner(simPathFlag, tracingEnabled, simulator.WithMinVersion(simulator.MinSimulatorVersion))
 	if err != nil {
 		return fmt.Errorf("failed to initialize simulator: %w", err)
 	}
@@ -626,38 +1260,38 @@ func runLocalWasmReplay() error {
 	}
 
 	// Run simulation
-	fmt.Printf("%s Executing contract locally...\n", visualizer.Symbol("play"))
+	fmt.Fprintf(w, "%s Executing contract locally...\n", visualizer.Symbol("play"))
 	resp, err := runner.Run(req)
 	if err != nil {
-		fmt.Printf("%s Execution failed: %v\n", visualizer.Error(), err)
+		fmt.Fprintf(w, "%s Execution failed: %v\n", visualizer.Error(), err)
 		return err
 	}
 
 	// Display results
-	fmt.Println()
-	fmt.Printf("%s Execution completed successfully\n", visualizer.Success())
-	fmt.Println()
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s Execution completed successfully\n", visualizer.Success())
+	fmt.Fprintln(w)
 
 	if len(resp.Logs) > 0 {
-		fmt.Printf("%s Logs:\n", visualizer.Symbol("logs"))
+		fmt.Fprintf(w, "%s Logs:\n", visualizer.Symbol("logs"))
 		for _, log := range resp.Logs {
-			fmt.Printf("  %s\n", log)
+			fmt.Fprintf(w, "  %s\n", log)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
 	if len(resp.Events) > 0 {
-		fmt.Printf("%s Events:\n", visualizer.Symbol("events"))
+		fmt.Fprintf(w, "%s Events:\n", visualizer.Symbol("events"))
 		for _, event := range resp.Events {
-			fmt.Printf("  %s\n", event)
+			fmt.Fprintf(w, "  %s\n", event)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
 	if verbose {
-		fmt.Printf("%s Full Response:\n", visualizer.Symbol("magnify"))
+		fmt.Fprintf(w, "%s Full Response:\n", visualizer.Symbol("magnify"))
 		jsonBytes, _ := json.MarshalIndent(resp, "", "  ")
-		fmt.Println(string(jsonBytes))
+		fmt.Fprintln(w, string(jsonBytes))
 	}
 
 	return nil
@@ -749,16 +1383,430 @@ func extractLedgerKeys(metaXdr string) ([]string, error) {
 	return res, nil
 }
 
-func printSimulationResult(network string, res *simulator.SimulationResponse) {
-	fmt.Printf("\n--- Result for %s ---\n", network)
-	fmt.Printf("Status: %s\n", res.Status)
+// detectArchivedFootprint looks up the TTL of every footprint entry of the
+// envelope and returns the archived ones together with their entry XDR.
+func detectArchivedFootprint(ctx context.Context, client *rpc.Client, envelopeXdr string) ([]simulator.ArchivedEntry, map[string]string, error) {
+	keys, err := simulator.FootprintKeys(envelopeXdr)
+	if err != nil || len(keys) == 0 {
+		return nil, nil, err
+	}
+
+	states, err := client.GetLedgerEntryStates(ctx, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	footprint := make(map[string]simulator.FootprintEntryState, len(states.Entries))
+	for key, st := range states.Entries {
+		footprint[key] = simulator.FootprintEntryState{EntryXdr: st.Xdr, LiveUntilLedger: st.LiveUntilLedger}
+	}
+
+	archived := simulator.DetectArchivedEntries(keys, footprint, states.LatestLedger)
+	entries := make(map[string]string, len(archived))
+	for _, e := range archived {
+		entries[e.Key] = footprint[e.Key].EntryXdr
+	}
+	return archived, entries, nil
+}
+
+// simulateRestore runs a RestoreFootprint simulation for the archived entries
+// ahead of the main simulation and reports its cost.
+func simulateRestore(w io.Writer, runner simulator.RunnerInterface, simReq *simulator.SimulationRequest, archived []simulator.ArchivedEntry, entries map[string]string) error {
+	if simReq.LedgerEntries == nil {
+		simReq.LedgerEntries = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		if _, ok := simReq.LedgerEntries[k]; !ok {
+			simReq.LedgerEntries[k] = v
+		}
+	}
+
+	restoreReq, err := simulator.BuildRestoreRequest(simReq, archived, simulator.DefaultRestoreFeeModel)
+	if err != nil {
+		return fmt.Errorf("failed to build restore simulation: %w", err)
+	}
+
+	cost := simulator.EstimateRestoreCost(archived, simulator.DefaultRestoreFeeModel)
+	fmt.Fprintf(w, "Simulating RestoreFootprint for %d archived entries...\n", len(archived))
+	restoreResp, err := runner.Run(restoreReq)
+	if err != nil {
+		return fmt.Errorf("restore simulation failed: %w", err)
+	}
+
+	fmt.Fprintf(w, "Restore status: %s\n", restoreResp.Status)
+	fmt.Fprintf(w, "Restore cost: %d stroops (entries %d, rent %d, read %d B, write %d B)\n",
+		cost.TotalFee, cost.EntryFee, cost.RentFee, cost.ReadBytes, cost.WriteBytes)
+	return nil
+}
+
+// rpcURLOptions turns --rpc-url (a comma-separated failover list) and
+// --network-passphrase or --network-id into client options, returning the
+// primary URL. A passphrase or network id describes a custom network and so
+// requires --rpc-url.
+func rpcURLOptions(rpcURL, passphrase, networkID string) ([]rpc.ClientOption, string, error) {
+	if passphrase != "" && networkID != "" {
+		return nil, "", fmt.Errorf("--network-passphrase and --network-id are mutually exclusive")
+	}
+	if rpcURL == "" {
+		if passphrase != "" {
+			return nil, "", fmt.Errorf("--network-passphrase requires --rpc-url")
+		}
+		if networkID != "" {
+			return nil, "", fmt.Errorf("--network-id requires --rpc-url")
+		}
+		return nil, "", nil
+	}
+
+	urls := strings.Split(rpcURL, ",")
+	for i := range urls {
+		urls[i] = strings.TrimSpace(urls[i])
+	}
+
+	var opts []rpc.ClientOption
+	if passphrase != "" || networkID != "" {
+		cfg := rpc.NetworkConfig{
+			Name:              "custom",
+			HorizonURL:        urls[0],
+			SorobanRPCURL:     urls[0],
+			NetworkPassphrase: passphrase,
+		}
+		if networkID != "" {
+			id, err := rpc.ParseNetworkID(networkID)
+			if err != nil {
+				return nil, "", err
+			}
+			cfg.NetworkID = id
+		}
+		opts = append(opts, rpc.WithNetworkConfig(cfg))
+	}
+	opts = append(opts, rpc.WithAltURLs(urls))
+	return opts, urls[0], nil
+}
+
+// validateTxTarget checks that the debug target is either a transaction
+// hash or --account together with --latest, but not both.
+func validateTxTarget(args []string, account string, latest bool) error {
+	if account != "" || latest {
+		if account == "" || !latest {
+			return fmt.Errorf("--account and --latest must be used together")
+		}
+		if len(args) > 0 {
+			return fmt.Errorf("cannot combine a transaction hash with --account --latest")
+		}
+		if !strkey.IsValidEd25519PublicKey(account) {
+			return fmt.Errorf("error: invalid account address: %s", account)
+		}
+		return nil
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("transaction hash is required when not using --wasm, --demo or --account --latest")
+	}
+	if strings.Contains(args[0], "://") {
+		_, err := rpc.TransactionHashFromURL(args[0])
+		return err
+	}
+	if err := rpc.ValidateTransactionHash(args[0]); err != nil {
+		return fmt.Errorf("error: invalid transaction hash format: %w", err)
+	}
+	return nil
+}
+
+// latestTransactionHash returns the hash of the most recent transaction
+// involving account.
+func latestTransactionHash(ctx context.Context, client *rpc.Client, account string) (string, error) {
+	txs, err := client.GetAccountTransactions(ctx, account, 1)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch latest transaction: %w", err)
+	}
+	if len(txs) == 0 {
+		return "", fmt.Errorf("no transactions found for account %s", account)
+	}
+	return txs[0].Hash, nil
+}
+
+// resolveUserAgent returns the User-Agent override from --user-agent,
+// ERST_USER_AGENT or the config file, in that order. Empty means the
+// client default. Strict mode only honours the flag.
+func resolveUserAgent() string {
+	if userAgentFlag != "" || strictFlag {
+		return userAgentFlag
+	}
+	if ua := os.Getenv("ERST_USER_AGENT"); ua != "" {
+		return ua
+	}
+	if cfg, err := config.LoadConfig(); err == nil {
+		return cfg.UserAgent
+	}
+	return ""
+}
+
+// resolveRedactionPolicy builds the redaction policy from --redact-fields
+// and --redact-pattern, falling back to ERST_REDACT_FIELDS (comma-separated)
+// and ERST_REDACT_PATTERN unless in strict mode. With neither set nothing
+// is redacted.
+func resolveRedactionPolicy() (*redact.Policy, error) {
+	fields := redactFieldsFlag
+	if len(fields) == 0 && !strictFlag {
+		if env := os.Getenv("ERST_REDACT_FIELDS"); env != "" {
+			fields = strings.Split(env, ",")
+		}
+	}
+	pattern := redactPatternFlag
+	if pattern == "" && !strictFlag {
+		pattern = os.Getenv("ERST_REDACT_PATTERN")
+	}
+	return redact.NewPolicy(fields, pattern)
+}
+
+// printTokenFlowChart writes the token flow movements in the format chosen
+// with --token-flow-format: a Mermaid chart, a Graphviz graph or CSV rows.
+func printTokenFlowChart(w io.Writer, report *tokenflow.Report, format string) {
+	switch format {
+	case "dot":
+		fmt.Fprintf(w, "\nToken Flow Chart (Graphviz):\n")
+		fmt.Fprint(w, report.GraphvizDOT())
+	case "csv":
+		fmt.Fprintf(w, "\nToken Flows (CSV):\n")
+		_ = report.WriteCSV(w)
+	default:
+		fmt.Fprintf(w, "\nToken Flow Chart (Mermaid):\n")
+		fmt.Fprintln(w, report.MermaidFlowchart())
+	}
+}
+
+// writeBalancesCSV exports the report's net balance changes to path.
+func writeBalancesCSV(report *tokenflow.Report, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create balances CSV: %w", err)
+	}
+	if err := report.WriteBalancesCSV(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write balances CSV: %w", err)
+	}
+	return f.Close()
+}
+
+func printFootprintDiff(w io.Writer, diff *simulator.FootprintDiff) {
+	fmt.Fprintf(w, "\n=== Footprint ===\n")
+	if !diff.HasUndeclared() {
+		fmt.Fprintf(w, "%s All accessed ledger keys are declared\n", visualizer.Success())
+	} else {
+		fmt.Fprintf(w, "%s %d ledger key(s) accessed but not declared:\n", visualizer.Error(), len(diff.Undeclared))
+		for _, key := range diff.Undeclared {
+			fmt.Fprintf(w, "  - %s\n", key)
+		}
+	}
+	if len(diff.Unused) > 0 {
+		fmt.Fprintf(w, "%d declared key(s) were never accessed\n", len(diff.Unused))
+	}
+}
+
+// debugTimings records where the debug command spent its time.
+type debugTimings struct {
+	Fetch    time.Duration
+	Retries  int64
+	Simulate time.Duration
+}
+
+func (t debugTimings) String() string {
+	retries := "retries"
+	if t.Retries == 1 {
+		retries = "retry"
+	}
+	return fmt.Sprintf("Fetched in %dms (%d %s), simulated in %dms",
+		t.Fetch.Milliseconds(), t.Retries, retries, t.Simulate.Milliseconds())
+}
+
+// fetchTransaction fetches hash, waiting up to wait for it to appear, and
+// records the fetch duration and the RPC retries it took in timings.
+func fetchTransaction(ctx context.Context, client *rpc.Client, hash string, wait time.Duration, timings *debugTimings) (*rpc.TransactionResponse, error) {
+	before := client.RetryStats()
+	start := time.Now()
+
+	var resp *rpc.TransactionResponse
+	var err error
+	if wait > 0 {
+		resp, err = client.WaitForTransaction(ctx, hash, wait)
+	} else {
+		resp, err = client.GetTransaction(ctx, hash)
+	}
+
+	timings.Fetch = time.Since(start)
+	timings.Retries = client.RetryStats().Retries - before.Retries
+	return resp, err
+}
+
+func printFeeSummary(w io.Writer, fees *decoder.FeeSummary) {
+	fmt.Fprintf(w, "Fee bid: %d stroops", fees.Bid)
+	if fees.ResourceFee > 0 {
+		fmt.Fprintf(w, " (resource fee: %d)", fees.ResourceFee)
+	}
+	fmt.Fprintf(w, ", charged: %d stroops", fees.Charged)
+	if fees.Refund > 0 {
+		fmt.Fprintf(w, ", refunded: %d stroops", fees.Refund)
+	}
+	fmt.Fprintln(w)
+}
+
+func printSignatureCheck(w io.Writer, check *authtrace.SignatureCheck) {
+	fmt.Fprintf(w, "Signatures: %d, signer weight %d of %d required (%s threshold)\n",
+		check.Signatures, check.CollectedWeight, check.RequiredWeight, check.Level)
+	if !check.Sufficient {
+		fmt.Fprintf(w, "  Insufficient signer weight for %s: %d more needed\n", check.AccountID, check.MissingWeight())
+	}
+}
+
+func printOperations(w io.Writer, envelopeXdr string) error {
+	ops, err := decoder.ListOperations(envelopeXdr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\nOperations (%d):\n", len(ops))
+	for _, op := range ops {
+		source := op.Source
+		if op.InheritedSource {
+			source += " (tx source)"
+		}
+		fmt.Fprintf(w, "  [%d] %-24s source: %s\n", op.Index, op.Type, source)
+	}
+	return nil
+}
+
+// resultText joins the error, events and logs of a result for pattern
+// matching.
+func resultText(res *simulator.SimulationResponse) string {
+	parts := []string{res.Error}
+	parts = append(parts, res.Events...)
+	for _, e := range res.DiagnosticEvents {
+		parts = append(parts, strings.Join(e.Topics, " "), e.Data)
+	}
+	parts = append(parts, res.Logs...)
+	return strings.Join(parts, "\n")
+}
+
+// printHostErrors explains the Soroban host errors mentioned in the error,
+// events and logs of a simulation.
+func printHostErrors(w io.Writer, res *simulator.SimulationResponse) {
+	hostErrors := decoder.FindHostErrors(resultText(res))
+	if len(hostErrors) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nHost errors:\n")
+	for _, he := range hostErrors {
+		fmt.Fprintf(w, "  %s (%s, %s)\n", he.Message, he.Type, he.Code)
+		for _, cause := range he.Causes {
+			fmt.Fprintf(w, "    - %s\n", cause)
+		}
+	}
+}
+
+// printErrorHints prints advice for recognized Soroban errors. Successful
+// runs are skipped so incidental matches in their logs stay quiet.
+func printErrorHints(w io.Writer, res *simulator.SimulationResponse) {
+	if res.Outcome() == simulator.OutcomeSuccess {
+		return
+	}
+	hints := simulator.FindHints(resultText(res))
+	if len(hints) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nHints:\n")
+	for _, hint := range hints {
+		fmt.Fprintf(w, "  - %s\n", hint)
+	}
+}
+
+// budgetInstructions and budgetBytes render budget values for humans
+// unless --raw asks for exact counts.
+func budgetInstructions(n uint64) string {
+	if rawBudgetFlag {
+		return strconv.FormatUint(n, 10)
+	}
+	return simulator.FormatInstructions(n)
+}
+
+func budgetBytes(n uint64) string {
+	if rawBudgetFlag {
+		return strconv.FormatUint(n, 10)
+	}
+	return simulator.FormatBytes(n)
+}
+
+// withEventSource returns the response to print for the selected --source.
+// With "meta" the simulated diagnostic events are swapped for the ones
+// recorded in the on-chain result meta; the simulation result is untouched.
+func withEventSource(w io.Writer, warnings *WarningCollector, resultMetaXdr string, res *simulator.SimulationResponse) *simulator.SimulationResponse {
+	if eventSourceFlag != "meta" || res == nil {
+		return res
+	}
+	events, err := simulator.MetaDiagnosticEvents(resultMetaXdr)
+	if err != nil {
+		warnings.Add("events", "could not read on-chain events, showing simulated events: %v", err)
+		return res
+	}
+	fmt.Fprintln(w, "Showing on-chain events from the transaction meta")
+	shown := *res
+	shown.DiagnosticEvents = events
+	return &shown
+}
+
+// checkEventsAgainstChain reports every ledger event the simulation and the
+// transaction meta disagree on. It is skipped with --source meta, where the
+// simulated events are not shown, and for simulators that only report raw
+// event strings.
+func checkEventsAgainstChain(warnings *WarningCollector, resultMetaXdr string, res *simulator.SimulationResponse) {
+	if eventSourceFlag == "meta" || res == nil {
+		return
+	}
+	if len(res.DiagnosticEvents) == 0 && len(res.Events) > 0 {
+		return
+	}
+	onChain, err := simulator.MetaDiagnosticEvents(resultMetaXdr)
+	if err != nil {
+		warnings.Add("events", "could not read on-chain events to check the simulated ones: %v", err)
+		return
+	}
+	diff := simulator.CompareEventSets(res.DiagnosticEvents, onChain)
+	for _, ev := range diff.OnlySimulated {
+		warnings.Add("events", "simulated but not on chain: %s", simulator.DescribeEvent(ev))
+	}
+	for _, ev := range diff.OnlyOnChain {
+		warnings.Add("events", "on chain but not simulated: %s", simulator.DescribeEvent(ev))
+	}
+}
+
+// printBudgetBreakdown prints the per-contract budget attribution and the
+// call tree it was derived from.
+func printBudgetBreakdown(w io.Writer, usage *simulator.BudgetUsage) {
+	breakdown := simulator.ExplainBudget(usage)
+	if len(breakdown) == 0 {
+		fmt.Fprintf(w, "\n  No per-call budget attribution was reported by the simulator\n")
+		return
+	}
+	fmt.Fprintf(w, "\nBudget by Contract:\n")
+	for _, line := range strings.Split(strings.TrimRight(simulator.FormatBudgetBreakdown(breakdown, rawBudgetFlag), "\n"), "\n") {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+	fmt.Fprintf(w, "\nCall Tree:\n")
+	for _, line := range strings.Split(strings.TrimRight(simulator.FormatCallTree(usage.Calls, rawBudgetFlag), "\n"), "\n") {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+}
+
+func printSimulationResult(w io.Writer, network string, res *simulator.SimulationResponse) {
+	fmt.Fprintf(w, "\n--- Result for %s ---\n", network)
+	fmt.Fprintf(w, "Status: %s\n", simulator.DescribeStatus(res.Status))
 	if res.Error != "" {
-		fmt.Printf("Error: %s\n", res.Error)
+		fmt.Fprintf(w, "Error: %s\n", res.Error)
 	}
+	printHostErrors(w, res)
+	printErrorHints(w, res)
 
 	// Display budget usage if available
 	if res.BudgetUsage != nil {
-		fmt.Printf("\nResource Usage:\n")
+		fmt.Fprintf(w, "\nResource Usage:\n")
 
 		// CPU usage with percentage and warning indicator
 		cpuIndicator := ""
@@ -767,9 +1815,9 @@ func printSimulationResult(network string, res *simulator.SimulationResponse) {
 		} else if res.BudgetUsage.CPUUsagePercent >= 80.0 {
 			cpuIndicator = " [!]  WARNING"
 		}
-		fmt.Printf("  CPU Instructions: %d / %d (%.2f%%)%s\n",
-			res.BudgetUsage.CPUInstructions,
-			res.BudgetUsage.CPULimit,
+		fmt.Fprintf(w, "  CPU Instructions: %s / %s (%.2f%%)%s\n",
+			budgetInstructions(res.BudgetUsage.CPUInstructions),
+			budgetInstructions(res.BudgetUsage.CPULimit),
 			res.BudgetUsage.CPUUsagePercent,
 			cpuIndicator)
 
@@ -780,88 +1828,113 @@ func printSimulationResult(network string, res *simulator.SimulationResponse) {
 		} else if res.BudgetUsage.MemoryUsagePercent >= 80.0 {
 			memIndicator = " [!]  WARNING"
 		}
-		fmt.Printf("  Memory Bytes: %d / %d (%.2f%%)%s\n",
-			res.BudgetUsage.MemoryBytes,
-			res.BudgetUsage.MemoryLimit,
+		fmt.Fprintf(w, "  Memory: %s / %s (%.2f%%)%s\n",
+			budgetBytes(res.BudgetUsage.MemoryBytes),
+			budgetBytes(res.BudgetUsage.MemoryLimit),
 			res.BudgetUsage.MemoryUsagePercent,
 			memIndicator)
 
-		fmt.Printf("  Operations: %d\n", res.BudgetUsage.OperationsCount)
+		fmt.Fprintf(w, "  Operations: %d\n", res.BudgetUsage.OperationsCount)
+
+		// The default rates are mainnet's; other networks configure their
+		// own, so the estimate would be misleading there.
+		if network == string(rpc.Mainnet) {
+			fee := analytics.CalculateResourceFee(analytics.ResourceUsage{
+				CPUInstructions: res.BudgetUsage.CPUInstructions,
+				MemoryBytes:     res.BudgetUsage.MemoryBytes,
+			}, analytics.DefaultResourceFeeModel)
+			fmt.Fprintf(w, "  Est. Resource Fee: %d stroops (CPU %d, memory %d)\n", fee.Total, fee.CPUFee, fee.MemoryFee)
+		}
+
+		if explainBudgetFlag {
+			printBudgetBreakdown(w, res.BudgetUsage)
+		}
+	}
+
+	if res.Timings != nil {
+		fmt.Fprintf(w, "\nTimings:\n")
+		for _, line := range strings.Split(strings.TrimRight(simulator.FormatTimingsTable(res.Timings), "\n"), "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
 	}
 
 	// Display diagnostic events with details
-	if len(res.DiagnosticEvents) > 0 {
-		fmt.Printf("\nDiagnostic Events: %d\n", len(res.DiagnosticEvents))
+	if len(res.DiagnosticEvents) > 0 && eventsFormatFlag == "table" {
+		fmt.Fprintf(w, "\nDiagnostic Events: %d\n", len(res.DiagnosticEvents))
+		fmt.Fprint(w, simulator.FormatDiagnosticEventsTable(res.DiagnosticEvents, 0))
+	} else if len(res.DiagnosticEvents) > 0 {
+		fmt.Fprintf(w, "\nDiagnostic Events: %d\n", len(res.DiagnosticEvents))
 		for i, event := range res.DiagnosticEvents {
 			if i < 10 { // Show first 10 events
-				fmt.Printf("  [%d] Type: %s", i+1, event.EventType)
+				fmt.Fprintf(w, "  [%d] Type: %s", i+1, event.EventType)
 				if event.ContractID != nil {
-					fmt.Printf(", Contract: %s", *event.ContractID)
+					fmt.Fprintf(w, ", Contract: %s", *event.ContractID)
 				}
-				fmt.Printf("\n")
+				fmt.Fprintf(w, "\n")
 				if len(event.Topics) > 0 {
-					fmt.Printf("      Topics: %v\n", event.Topics)
+					fmt.Fprintf(w, "      Topics: %v\n", event.Topics)
+					fmt.Fprintf(w, "      Signature: %s\n", event.Signature())
 				}
 				if event.Data != "" && len(event.Data) < 100 {
-					fmt.Printf("      Data: %s\n", event.Data)
+					fmt.Fprintf(w, "      Data: %s\n", event.Data)
 				}
 			}
 		}
 		if len(res.DiagnosticEvents) > 10 {
-			fmt.Printf("  ... and %d more events\n", len(res.DiagnosticEvents)-10)
+			fmt.Fprintf(w, "  ... and %d more events\n", len(res.DiagnosticEvents)-10)
 		}
 	} else {
-		fmt.Printf("\nEvents: %d\n", len(res.Events))
+		fmt.Fprintf(w, "\nEvents: %d\n", len(res.Events))
 	}
 
 	// Display logs
 	if len(res.Logs) > 0 {
-		fmt.Printf("\nLogs: %d\n", len(res.Logs))
+		fmt.Fprintf(w, "\nLogs: %d\n", len(res.Logs))
 		for i, log := range res.Logs {
 			if i < 5 { // Show first 5 logs
-				fmt.Printf("  - %s\n", log)
+				fmt.Fprintf(w, "  - %s\n", log)
 			}
 		}
 		if len(res.Logs) > 5 {
-			fmt.Printf("  ... and %d more logs\n", len(res.Logs)-5)
+			fmt.Fprintf(w, "  ... and %d more logs\n", len(res.Logs)-5)
 		}
 	}
-	fmt.Printf("Events: %d, Logs: %d\n", len(res.Events), len(res.Logs))
+	fmt.Fprintf(w, "Events: %d, Logs: %d\n", len(res.Events), len(res.Logs))
 }
 
-func diffResults(res1, res2 *simulator.SimulationResponse, net1, net2 string) {
-	fmt.Printf("\n=== Comparison: %s vs %s ===\n", net1, net2)
+func diffResults(w io.Writer, res1, res2 *simulator.SimulationResponse, net1, net2 string) {
+	fmt.Fprintf(w, "\n=== Comparison: %s vs %s ===\n", net1, net2)
 
 	if res1.Status != res2.Status {
-		fmt.Printf("Status Mismatch: %s (%s) vs %s (%s)\n", res1.Status, net1, res2.Status, net2)
+		fmt.Fprintf(w, "Status Mismatch: %s (%s) vs %s (%s)\n", res1.Status, net1, res2.Status, net2)
 	} else {
-		fmt.Printf("Status Match: %s\n", res1.Status)
+		fmt.Fprintf(w, "Status Match: %s\n", res1.Status)
 	}
 
 	// Compare diagnostic events if available
 	if len(res1.DiagnosticEvents) > 0 && len(res2.DiagnosticEvents) > 0 {
 		if len(res1.DiagnosticEvents) != len(res2.DiagnosticEvents) {
-			fmt.Printf("[DIFF] Diagnostic events count mismatch: %d vs %d\n",
+			fmt.Fprintf(w, "[DIFF] Diagnostic events count mismatch: %d vs %d\n",
 				len(res1.DiagnosticEvents), len(res2.DiagnosticEvents))
 		}
 	} else if len(res1.Events) != len(res2.Events) {
-		fmt.Printf("[DIFF] Events count mismatch: %d vs %d\n", len(res1.Events), len(res2.Events))
+		fmt.Fprintf(w, "[DIFF] Events count mismatch: %d vs %d\n", len(res1.Events), len(res2.Events))
 	}
 
 	// Compare budget usage if available
 	if res1.BudgetUsage != nil && res2.BudgetUsage != nil {
 		if res1.BudgetUsage.CPUInstructions != res2.BudgetUsage.CPUInstructions {
-			fmt.Printf("[DIFF] CPU instructions: %d vs %d\n",
-				res1.BudgetUsage.CPUInstructions, res2.BudgetUsage.CPUInstructions)
+			fmt.Fprintf(w, "[DIFF] CPU instructions: %s vs %s\n",
+				budgetInstructions(res1.BudgetUsage.CPUInstructions), budgetInstructions(res2.BudgetUsage.CPUInstructions))
 		}
 		if res1.BudgetUsage.MemoryBytes != res2.BudgetUsage.MemoryBytes {
-			fmt.Printf("[DIFF] Memory bytes: %d vs %d\n",
-				res1.BudgetUsage.MemoryBytes, res2.BudgetUsage.MemoryBytes)
+			fmt.Fprintf(w, "[DIFF] Memory: %s vs %s\n",
+				budgetBytes(res1.BudgetUsage.MemoryBytes), budgetBytes(res2.BudgetUsage.MemoryBytes))
 		}
 	}
 
 	// Compare Events
-	fmt.Println("\nEvent Diff:")
+	fmt.Fprintln(w, "\nEvent Diff:")
 	maxEvents := len(res1.Events)
 	if len(res2.Events) > maxEvents {
 		maxEvents = len(res2.Events)
@@ -882,9 +1955,9 @@ func diffResults(res1, res2 *simulator.SimulationResponse, net1, net2 string) {
 		}
 
 		if ev1 != ev2 {
-			fmt.Printf("  [%d] MISMATCH:\n", i)
-			fmt.Printf("    %s: %s\n", net1, ev1)
-			fmt.Printf("    %s: %s\n", net2, ev2)
+			fmt.Fprintf(w, "  [%d] MISMATCH:\n", i)
+			fmt.Fprintf(w, "    %s: %s\n", net1, ev1)
+			fmt.Fprintf(w, "    %s: %s\n", net2, ev2)
 		}
 	}
 }
@@ -892,20 +1965,59 @@ func diffResults(res1, res2 *simulator.SimulationResponse, net1, net2 string) {
 func init() {
 	debugCmd.Flags().StringVarP(&networkFlag, "network", "n", "mainnet", "Stellar network")
 	debugCmd.Flags().StringVar(&rpcURLFlag, "rpc-url", "", "Custom RPC URL")
+	debugCmd.Flags().StringVar(&passphraseFlag, "network-passphrase", "", "Network passphrase for a custom/private network (requires --rpc-url)")
+	debugCmd.Flags().StringVar(&networkIDFlag, "network-id", "", "Network id as 64 hex characters, for private networks identified without a passphrase (requires --rpc-url)")
 	debugCmd.Flags().StringVar(&rpcTokenFlag, "rpc-token", "", "RPC authentication token (can also use ERST_RPC_TOKEN env var)")
+	debugCmd.Flags().StringVar(&userAgentFlag, "user-agent", "", "User-Agent sent with RPC requests (default erst/<version>)")
+	debugCmd.Flags().StringVar(&proxyFlag, "proxy", "", "HTTP(S) proxy URL for RPC requests (overrides HTTP_PROXY/HTTPS_PROXY)")
 	debugCmd.Flags().BoolVar(&tracingEnabled, "tracing", false, "Enable tracing")
 	debugCmd.Flags().StringVar(&otlpExporterURL, "otlp-url", "http://localhost:4318", "OTLP URL")
 	debugCmd.Flags().BoolVar(&generateTrace, "generate-trace", false, "Generate trace file")
 	debugCmd.Flags().StringVar(&traceOutputFile, "trace-output", "", "Trace output file")
 	debugCmd.Flags().StringVar(&snapshotFlag, "snapshot", "", "Load state from JSON snapshot file")
 	debugCmd.Flags().StringVar(&compareNetworkFlag, "compare-network", "", "Network to compare against (testnet, mainnet, futurenet)")
+	debugCmd.Flags().StringVar(&progressFlag, "progress", "", "Write structured progress events to stderr (ndjson)")
 	debugCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	debugCmd.Flags().BoolVar(&timingFlag, "timing", false, "Print how long fetching and simulation took and how many RPC retries occurred")
+	debugCmd.Flags().StringVar(&batchFileFlag, "batch", "", "File with one transaction hash or URL per line to debug in turn")
+	debugCmd.Flags().BoolVar(&resumeFlag, "resume", false, "Skip hashes a previous --batch run already completed")
+	debugCmd.Flags().BoolVar(&explainBudgetFlag, "explain-budget", false, "Break down CPU and memory by contract in the call tree, most expensive first")
+	debugCmd.Flags().StringVar(&eventSourceFlag, "source", "sim", "Which events to show: sim (simulated) or meta (recorded on chain)")
 	debugCmd.Flags().StringVar(&wasmPath, "wasm", "", "Path to local WASM file for local replay (no network required)")
 	debugCmd.Flags().StringSliceVar(&args, "args", []string{}, "Mock arguments for local replay (JSON array of strings)")
 	debugCmd.Flags().BoolVar(&noCacheFlag, "no-cache", false, "Disable local ledger state caching")
 	debugCmd.Flags().BoolVar(&demoMode, "demo", false, "Print sample output (no network) - for testing color detection")
 	debugCmd.Flags().BoolVar(&watchFlag, "watch", false, "Poll for transaction on-chain before debugging")
 	debugCmd.Flags().IntVar(&watchTimeoutFlag, "watch-timeout", 30, "Timeout in seconds for watch mode")
+	debugCmd.Flags().DurationVar(&waitForTxFlag, "wait-for-tx", 0, "Keep retrying for this long while the transaction is not found yet (e.g. 30s)")
+	debugCmd.Flags().StringVar(&bundleFlag, "bundle", "", "Write a diagnostics bundle (zip) with XDR, simulation I/O, logs and environment info")
+	debugCmd.Flags().BoolVar(&redactFlag, "redact", false, "Redact XDR payloads, ledger entry values and RPC credentials from the bundle")
+	debugCmd.Flags().StringSliceVar(&redactFieldsFlag, "redact-fields", nil, "Field names whose values are redacted in logs and the bundle (e.g. envelope_xdr,ledger_entries)")
+	debugCmd.Flags().StringVar(&redactPatternFlag, "redact-pattern", "", "Regular expression whose matches are redacted in logs and the bundle")
+	debugCmd.Flags().BoolVar(&rawBudgetFlag, "raw", false, "Print exact instruction and byte counts instead of human-friendly units")
+	debugCmd.Flags().StringVar(&eventsFormatFlag, "events-format", "list", "How to print diagnostic events: list or table")
+	debugCmd.Flags().StringVar(&simPathFlag, "sim-path", "", "Path to the erst-sim binary (default: auto-discovered)")
+	debugCmd.Flags().StringVar(&accountFlag, "account", "", "Account (G...) whose latest transaction to debug; use with --latest")
+	debugCmd.Flags().BoolVar(&latestFlag, "latest", false, "Debug the most recent transaction of --account instead of a hash")
+	debugCmd.Flags().BoolVar(&listOperationsFlag, "list-operations", false, "Print an indexed list of the transaction's operations with their source accounts")
+	debugCmd.Flags().StringVar(&envelopeFileFlag, "envelope-file", "", "Read the base64 transaction envelope from this file instead of fetching the transaction")
+	debugCmd.Flags().StringVar(&metaFileFlag, "meta-file", "", "Read the base64 result meta from this file; required with --envelope-file")
+	debugCmd.Flags().BoolVar(&saveSessionFlag, "save", false, "Save the session to the session store as soon as it is created")
+	debugCmd.Flags().StringVarP(&outputFlag, "output", "o", "text", "Output format: text, or json for a single JSON document on stdout")
+	debugCmd.Flags().StringVar(&tokenFlowFormatFlag, "token-flow-format", "mermaid", "How to print token flows: mermaid, dot or csv")
+	debugCmd.Flags().StringSliceVar(&assetFilterFlag, "asset", nil, "Only show token flows of these assets (symbol, native, CODE:ISSUER or contract ID); repeatable")
+	debugCmd.Flags().StringVar(&assetLabelsFlag, "asset-labels", "", "JSON file mapping CODE:ISSUER to issuer labels for token flow output")
+	debugCmd.Flags().Int64Var(&overrideSeqFlag, "override-seq", 0, "Rewrite the envelope's sequence number to this value before simulating")
+	debugCmd.Flags().BoolVar(&balancesFlag, "balances", false, "Print the net balance change per account and asset")
+	debugCmd.Flags().StringVar(&balancesCSVFlag, "balances-csv", "", "Write the net balance changes to this CSV file")
+	debugCmd.Flags().Uint32Var(&ledgerSequenceFlag, "ledger-sequence", 0, "Pin the simulation to this ledger sequence (default: the network's latest ledger)")
+	debugCmd.Flags().Uint32Var(&protocolFlag, "protocol", 0, "Simulate under this protocol version (default: the network's current protocol)")
+	debugCmd.Flags().UintSliceVar(&compareProtocolsFlag, "compare-protocols", nil, "Also simulate under these protocol versions (e.g. 20,21,22) and print a budget table")
+	debugCmd.Flags().IntVar(&limitEntriesFlag, "limit-ledger-entries", 0, "Fail when more than this many ledger entries would be injected into the simulation (0 = no limit)")
+	debugCmd.Flags().IntVar(&limitBytesFlag, "limit-ledger-bytes", 0, "Fail when the injected ledger entries exceed this many bytes of XDR (0 = no limit)")
+	debugCmd.Flags().BoolVar(&truncateEntriesFlag, "truncate-ledger-entries", false, "Drop ledger entries over the limit with a warning instead of failing")
+	debugCmd.Flags().IntVar(&repeatFlag, "repeat", 1, "Run the simulation this many times, report min/median/max durations and flag responses that differ")
+	debugCmd.Flags().BoolVar(&restoreArchived, "restore-archived", false, "Check the footprint for archived entries and simulate a RestoreFootprint for them before the transaction")
 
 	rootCmd.AddCommand(debugCmd)
 }
@@ -0,0 +1,103 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// validateFileTarget checks the flags of a debug run that reads its
+// transaction from --envelope-file and --meta-file instead of the network.
+// A transaction hash is optional and only used to label the session.
+func validateFileTarget(args []string) error {
+	if envelopeFileFlag == "" || metaFileFlag == "" {
+		return fmt.Errorf("--envelope-file and --meta-file must be used together")
+	}
+	switch {
+	case batchFileFlag != "":
+		return fmt.Errorf("--envelope-file cannot be combined with --batch")
+	case accountFlag != "" || latestFlag:
+		return fmt.Errorf("--envelope-file cannot be combined with --account --latest")
+	case compareNetworkFlag != "":
+		return fmt.Errorf("--envelope-file cannot be combined with --compare-network")
+	case watchFlag || waitForTxFlag > 0:
+		return fmt.Errorf("--envelope-file cannot be combined with --watch or --wait-for-tx")
+	}
+	if len(args) > 0 {
+		if err := rpc.ValidateTransactionHash(args[0]); err != nil {
+			return fmt.Errorf("error: invalid transaction hash format: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadTransactionFiles reads a base64 TransactionEnvelope and
+// TransactionResultMeta from local files and checks that both decode.
+func loadTransactionFiles(envelopePath, metaPath string) (*rpc.TransactionResponse, error) {
+	envelope, err := os.ReadFile(envelopePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read envelope file: %w", err)
+	}
+	meta, err := os.ReadFile(metaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read meta file: %w", err)
+	}
+
+	resp := &rpc.TransactionResponse{
+		EnvelopeXdr:   strings.Join(strings.Fields(string(envelope)), ""),
+		ResultMetaXdr: strings.Join(strings.Fields(string(meta)), ""),
+	}
+	if resp.ResultMetaXdr == "" {
+		return nil, fmt.Errorf("meta file %s is empty", metaPath)
+	}
+	if err := simulator.PreflightXDR(resp.EnvelopeXdr, resp.ResultMetaXdr); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// envelopeHash computes the hash of the transaction in a base64 envelope
+// on the network identified by networkID. V0 envelopes are not supported.
+func envelopeHash(envelopeB64 string, networkID [32]byte) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(envelopeB64)
+	if err != nil {
+		return "", err
+	}
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshal(raw, &env); err != nil {
+		return "", err
+	}
+
+	payload := xdr.TransactionSignaturePayload{NetworkId: xdr.Hash(networkID)}
+	switch env.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		payload.TaggedTransaction = xdr.TransactionSignaturePayloadTaggedTransaction{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			Tx:   &env.V1.Tx,
+		}
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		payload.TaggedTransaction = xdr.TransactionSignaturePayloadTaggedTransaction{
+			Type:    xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+			FeeBump: &env.FeeBump.Tx,
+		}
+	default:
+		return "", fmt.Errorf("cannot hash %s envelopes", env.Type)
+	}
+
+	b, err := payload.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEnvelope(t *testing.T) (xdr.TransactionEnvelope, string) {
+	t.Helper()
+	source := xdr.MustMuxedAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx: xdr.Transaction{
+				SourceAccount: source,
+				Fee:           100,
+				SeqNum:        1,
+				Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+				Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+				Operations: []xdr.Operation{{Body: xdr.OperationBody{
+					Type:           xdr.OperationTypeBumpSequence,
+					BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 2},
+				}}},
+			},
+		},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	return env, b64
+}
+
+func testResultMeta(t *testing.T) string {
+	t.Helper()
+	results := []xdr.OperationResult{}
+	meta := xdr.TransactionResultMeta{
+		Result: xdr.TransactionResultPair{Result: xdr.TransactionResult{
+			Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &results},
+		}},
+		TxApplyProcessing: xdr.TransactionMeta{V: 3, V3: &xdr.TransactionMetaV3{}},
+	}
+	b64, err := xdr.MarshalBase64(meta)
+	require.NoError(t, err)
+	return b64
+}
+
+func TestLoadTransactionFiles(t *testing.T) {
+	dir := t.TempDir()
+	_, envB64 := testEnvelope(t)
+	metaB64 := testResultMeta(t)
+
+	envPath := filepath.Join(dir, "tx.xdr")
+	metaPath := filepath.Join(dir, "meta.xdr")
+	// Captured XDR often ends with a newline or is wrapped.
+	require.NoError(t, os.WriteFile(envPath, []byte(envB64[:10]+"\n"+envB64[10:]+"\n"), 0644))
+	require.NoError(t, os.WriteFile(metaPath, []byte(metaB64+"\n"), 0644))
+
+	resp, err := loadTransactionFiles(envPath, metaPath)
+	require.NoError(t, err)
+	assert.Equal(t, envB64, resp.EnvelopeXdr)
+	assert.Equal(t, metaB64, resp.ResultMetaXdr)
+	assert.Empty(t, resp.ResultXdr)
+}
+
+func TestLoadTransactionFiles_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	_, envB64 := testEnvelope(t)
+	good := filepath.Join(dir, "tx.xdr")
+	require.NoError(t, os.WriteFile(good, []byte(envB64), 0644))
+	bad := filepath.Join(dir, "bad.xdr")
+	require.NoError(t, os.WriteFile(bad, []byte("not base64!"), 0644))
+	empty := filepath.Join(dir, "empty.xdr")
+	require.NoError(t, os.WriteFile(empty, nil, 0644))
+
+	_, err := loadTransactionFiles(bad, good)
+	assert.Error(t, err)
+	_, err = loadTransactionFiles(good, bad)
+	assert.Error(t, err)
+	_, err = loadTransactionFiles(good, empty)
+	assert.ErrorContains(t, err, "empty")
+	_, err = loadTransactionFiles(filepath.Join(dir, "missing"), good)
+	assert.ErrorContains(t, err, "failed to read envelope file")
+}
+
+func TestEnvelopeHash_MatchesNetworkHash(t *testing.T) {
+	env, b64 := testEnvelope(t)
+	want, err := network.HashTransactionInEnvelope(env, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+
+	got, err := envelopeHash(b64, network.ID(network.TestNetworkPassphrase))
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(want[:]), got)
+}
+
+func TestValidateFileTarget(t *testing.T) {
+	defer func() {
+		envelopeFileFlag, metaFileFlag, compareNetworkFlag = "", "", ""
+	}()
+
+	envelopeFileFlag, metaFileFlag = "tx.xdr", ""
+	assert.ErrorContains(t, validateFileTarget(nil), "must be used together")
+
+	metaFileFlag = "meta.xdr"
+	assert.NoError(t, validateFileTarget(nil))
+	assert.Error(t, validateFileTarget([]string{"not-a-hash"}))
+
+	compareNetworkFlag = "testnet"
+	assert.ErrorContains(t, validateFileTarget(nil), "--compare-network")
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/tokenflow"
+)
+
+// debugJSONOutput is the document printed by "erst debug --output json".
+type debugJSONOutput struct {
+	TxHash      string               `json:"tx_hash"`
+	Network     string               `json:"network"`
+	SessionID   string               `json:"session_id"`
+	Transaction debugJSONTransaction `json:"transaction"`
+	Simulation  debugJSONSimulation  `json:"simulation"`
+	TokenFlow   *debugJSONTokenFlow  `json:"token_flow,omitempty"`
+	Warnings    *WarningCollector    `json:"warnings"`
+}
+
+type debugJSONTransaction struct {
+	EnvelopeXdr   string `json:"envelope_xdr"`
+	ResultXdr     string `json:"result_xdr,omitempty"`
+	ResultMetaXdr string `json:"result_meta_xdr"`
+	FeeBid        *int64 `json:"fee_bid,omitempty"`
+	FeeCharged    *int64 `json:"fee_charged,omitempty"`
+	ResourceFee   *int64 `json:"resource_fee,omitempty"`
+}
+
+type debugJSONSimulation struct {
+	Status           string                      `json:"status"`
+	Error            string                      `json:"error,omitempty"`
+	Events           []string                    `json:"events"`
+	DiagnosticEvents []simulator.DiagnosticEvent `json:"diagnostic_events"`
+	Logs             []string                    `json:"logs"`
+	BudgetUsage      *simulator.BudgetUsage      `json:"budget_usage,omitempty"`
+}
+
+type debugJSONTokenFlow struct {
+	Summary   []string        `json:"summary"`
+	Transfers []debugJSONFlow `json:"transfers"`
+}
+
+type debugJSONFlow struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Asset  string `json:"asset"`
+	Amount string `json:"amount"`
+	Type   string `json:"type"`
+}
+
+// newDebugJSONOutput assembles the JSON document from the results of a
+// debug run. fees and report may be nil when they could not be computed.
+func newDebugJSONOutput(txHash, network, sessionID string, tx debugJSONTransaction, fees *decoder.FeeSummary, sim *simulator.SimulationResponse, report *tokenflow.Report, warnings *WarningCollector) *debugJSONOutput {
+	if fees != nil {
+		tx.FeeBid = &fees.Bid
+		tx.FeeCharged = &fees.Charged
+		tx.ResourceFee = &fees.ResourceFee
+	}
+
+	out := &debugJSONOutput{
+		TxHash:      txHash,
+		Network:     network,
+		SessionID:   sessionID,
+		Transaction: tx,
+		Simulation: debugJSONSimulation{
+			Status:           sim.Status,
+			Error:            sim.Error,
+			Events:           nonNil(sim.Events),
+			DiagnosticEvents: nonNil(sim.DiagnosticEvents),
+			Logs:             nonNil(sim.Logs),
+			BudgetUsage:      sim.BudgetUsage,
+		},
+		Warnings: warnings,
+	}
+
+	if report != nil {
+		flow := &debugJSONTokenFlow{Summary: nonNil(report.SummaryLines()), Transfers: []debugJSONFlow{}}
+		for _, t := range report.Agg {
+			amount := "0"
+			if t.Amount != nil {
+				amount = t.Amount.String()
+			}
+			flow.Transfers = append(flow.Transfers, debugJSONFlow{
+				From:   t.From,
+				To:     t.To,
+				Asset:  tokenflow.AssetKey(t.Token),
+				Amount: amount,
+				Type:   string(t.Kind),
+			})
+		}
+		out.TokenFlow = flow
+	}
+	return out
+}
+
+// nonNil returns s, or an empty slice when s is nil, so the JSON document
+// always has arrays where a consumer expects them.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+func writeDebugJSON(w io.Writer, out *debugJSONOutput) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	return nil
+}
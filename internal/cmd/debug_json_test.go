@@ -0,0 +1,82 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/tokenflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDebugJSON(t *testing.T) {
+	sim := &simulator.SimulationResponse{
+		Status: "error",
+		Error:  "HostError: Error(Contract, #3)",
+		Logs:   []string{"log line"},
+		BudgetUsage: &simulator.BudgetUsage{
+			CPUInstructions: 1000,
+			MemoryBytes:     2048,
+		},
+	}
+	report := &tokenflow.Report{Agg: []tokenflow.Transfer{{
+		From:   "GA",
+		To:     "GB",
+		Token:  tokenflow.Token{Symbol: "XLM"},
+		Amount: big.NewInt(10_000_000),
+		Kind:   tokenflow.KindTransfer,
+	}}}
+	warnings := NewWarningCollector()
+	warnings.Add("simulator", "something odd")
+
+	doc := newDebugJSONOutput("abc123", "testnet", "sess-1",
+		debugJSONTransaction{EnvelopeXdr: "ENV", ResultMetaXdr: "META"},
+		&decoder.FeeSummary{Bid: 200, Charged: 150, ResourceFee: 100},
+		sim, report, warnings)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeDebugJSON(&buf, doc))
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+	assert.Equal(t, "abc123", got["tx_hash"])
+	assert.Equal(t, "sess-1", got["session_id"])
+
+	tx := got["transaction"].(map[string]interface{})
+	assert.Equal(t, "ENV", tx["envelope_xdr"])
+	assert.EqualValues(t, 150, tx["fee_charged"])
+
+	simOut := got["simulation"].(map[string]interface{})
+	assert.Equal(t, "error", simOut["status"])
+	assert.Equal(t, "HostError: Error(Contract, #3)", simOut["error"])
+	assert.Equal(t, []interface{}{}, simOut["events"])
+	assert.Equal(t, []interface{}{"log line"}, simOut["logs"])
+	assert.EqualValues(t, 1000, simOut["budget_usage"].(map[string]interface{})["cpu_instructions"])
+
+	flow := got["token_flow"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"GA -> 1 XLM -> GB"}, flow["summary"])
+	transfer := flow["transfers"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "XLM", transfer["asset"])
+	assert.Equal(t, "10000000", transfer["amount"])
+
+	warn := got["warnings"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "something odd", warn["message"])
+}
+
+func TestWriteDebugJSON_NoTokenFlow(t *testing.T) {
+	doc := newDebugJSONOutput("abc", "mainnet", "s", debugJSONTransaction{}, nil, &simulator.SimulationResponse{Status: "success"}, nil, NewWarningCollector())
+
+	var buf bytes.Buffer
+	require.NoError(t, writeDebugJSON(&buf, doc))
+	assert.NotContains(t, buf.String(), "token_flow")
+	assert.NotContains(t, buf.String(), "fee_charged")
+	assert.Contains(t, buf.String(), `"warnings": []`)
+}
@@ -0,0 +1,311 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/spf13/pflag"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+// debugRPC is a JSON-RPC server standing in for Soroban RPC in tests that
+// run the registered debug command. It counts the calls per method and
+// answers the ones debug makes from entries, a ledger at sequence 100.
+type debugRPC struct {
+	*httptest.Server
+	mu      sync.Mutex
+	calls   map[string]int
+	entries map[string]string
+}
+
+// newDebugRPC starts a debugRPC serving entries, keyed by base64 ledger
+// key, from getLedgerEntries.
+func newDebugRPC(t *testing.T, entries map[string]string) *debugRPC {
+	t.Helper()
+	d := &debugRPC{calls: map[string]int{}, entries: entries}
+	d.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     interface{}       `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		d.mu.Lock()
+		d.calls[req.Method]++
+		d.mu.Unlock()
+
+		var result interface{}
+		switch req.Method {
+		case "getLedgerEntries":
+			var keys []string
+			if len(req.Params) > 0 {
+				_ = json.Unmarshal(req.Params[0], &keys)
+			}
+			found := []interface{}{}
+			for _, key := range keys {
+				if entry, ok := d.entries[key]; ok {
+					found = append(found, map[string]interface{}{"key": key, "xdr": entry, "lastModifiedLedgerSeq": 90, "liveUntilLedgerSeq": 1000})
+				}
+			}
+			result = map[string]interface{}{"entries": found, "latestLedger": 100}
+		case "getLatestLedger":
+			result = map[string]interface{}{"id": "00", "protocolVersion": 22, "sequence": 100}
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"jsonrpc": "2.0", "id": req.ID,
+				"error": map[string]interface{}{"code": -32601, "message": "method not found"},
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(d.Close)
+	return d
+}
+
+func (d *debugRPC) Calls(method string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.calls[method]
+}
+
+// fakeSimulatorBinary writes an erst-sim stand-in that answers every
+// simulation with response. The last request it read is kept in
+// request.json next to the binary.
+func fakeSimulatorBinary(t *testing.T, response string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake simulator script requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "erst-sim")
+	script := "#!/bin/sh\nif [ \"$1\" = \"--version\" ]; then echo 'erst-sim 99.0.0'; exit 0; fi\ncat > \"$(dirname \"$0\")/request.json\"\nprintf '%s' '" + response + "'\n"
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	return path
+}
+
+// testContractDataKey is the persistent contract data entry read by the
+// footprint of sorobanTestEnvelope.
+func testContractDataKey() xdr.LedgerKey {
+	sym := xdr.ScSymbol("balance")
+	return xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &xdr.ContractId{0xAA}},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+			Durability: xdr.ContractDataDurabilityPersistent,
+		},
+	}
+}
+
+// sorobanTestEnvelope returns testEnvelope with Soroban transaction data
+// whose footprint reads testContractDataKey.
+func sorobanTestEnvelope(t *testing.T) string {
+	t.Helper()
+	env, _ := testEnvelope(t)
+	key := testContractDataKey()
+	env.V1.Tx.Ext = xdr.TransactionExt{
+		V: 1,
+		SorobanData: &xdr.SorobanTransactionData{
+			Resources: xdr.SorobanResources{
+				Footprint: xdr.LedgerFootprint{ReadOnly: []xdr.LedgerKey{key}, ReadWrite: []xdr.LedgerKey{}},
+			},
+		},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	return b64
+}
+
+// debugFileArgs writes envB64 and a test result meta and returns the debug
+// arguments that replay them against rpcURL with the simulator at simPath.
+// The network passphrase points Soroban RPC calls at rpcURL as well.
+func debugFileArgs(t *testing.T, rpcURL, simPath, envB64 string) []string {
+	t.Helper()
+	dir := t.TempDir()
+	envPath := filepath.Join(dir, "tx.xdr")
+	metaPath := filepath.Join(dir, "meta.xdr")
+	require.NoError(t, os.WriteFile(envPath, []byte(envB64), 0644))
+	require.NoError(t, os.WriteFile(metaPath, []byte(testResultMeta(t)), 0644))
+	return []string{
+		"debug",
+		"--envelope-file", envPath,
+		"--meta-file", metaPath,
+		"--network", "testnet",
+		"--rpc-url", rpcURL,
+		"--network-passphrase", network.TestNetworkPassphrase,
+		"--sim-path", simPath,
+	}
+}
+
+// runDebugCommand runs the registered debug command through the root
+// command with a fresh HOME and RPC client pool, restoring every debug flag
+// to its default afterwards.
+func runDebugCommand(t *testing.T, args ...string) error {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	prev := rpcClients
+	rpcClients = rpc.NewClientProvider()
+	t.Cleanup(func() {
+		rpcClients = prev
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		resetCommandFlags(t, debugCmd.Flags())
+		resetCommandFlags(t, rootCmd.PersistentFlags())
+	})
+
+	rootCmd.SetArgs(args)
+	return Execute()
+}
+
+// resetCommandFlags restores every flag of fs to its default and clears
+// its changed state, so the next command run does not inherit it.
+func resetCommandFlags(t *testing.T, fs *pflag.FlagSet) {
+	t.Helper()
+	fs.VisitAll(func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			require.NoError(t, sv.Replace(nil))
+		} else {
+			require.NoError(t, f.Value.Set(f.DefValue))
+		}
+		f.Changed = false
+	})
+}
+
+func TestDebugCommand_ArchivedFootprintCheckOnlyWithRestoreArchived(t *testing.T) {
+	server := newDebugRPC(t, nil)
+	sim := fakeSimulatorBinary(t, `{"status":"success"}`)
+
+	args := debugFileArgs(t, server.URL, sim, sorobanTestEnvelope(t))
+
+	// The footprint entries missing from the meta are fetched once per run;
+	// only --restore-archived adds the archived-entry lookup.
+	require.NoError(t, runDebugCommand(t, args...))
+	require.Equal(t, 1, server.Calls("getLedgerEntries"), "debug without --restore-archived must not check for archived entries")
+
+	require.NoError(t, runDebugCommand(t, append(args, "--restore-archived")...))
+	require.Equal(t, 3, server.Calls("getLedgerEntries"))
+}
+
+func TestDebugCommand_InjectsFootprintEntries(t *testing.T) {
+	key, err := xdr.MarshalBase64(testContractDataKey())
+	require.NoError(t, err)
+	sym := xdr.ScSymbol("balance")
+	entry, err := xdr.MarshalBase64(xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 90,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeContractData,
+			ContractData: &xdr.ContractDataEntry{
+				Contract:   testContractDataKey().ContractData.Contract,
+				Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+				Durability: xdr.ContractDataDurabilityPersistent,
+				Val:        xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	server := newDebugRPC(t, map[string]string{key: entry})
+	sim := fakeSimulatorBinary(t, `{"status":"success"}`)
+
+	require.NoError(t, runDebugCommand(t, debugFileArgs(t, server.URL, sim, sorobanTestEnvelope(t))...))
+	require.Equal(t, 1, server.Calls("getLedgerEntries"))
+
+	raw, err := os.ReadFile(filepath.Join(filepath.Dir(sim), "request.json"))
+	require.NoError(t, err)
+	var req simulator.SimulationRequest
+	require.NoError(t, json.Unmarshal(raw, &req))
+	require.Equal(t, entry, req.LedgerEntries[key])
+}
+
+func TestDebugCommand_JSONOutputKeepsTextOffStdout(t *testing.T) {
+	server := newDebugRPC(t, nil)
+	sim := fakeSimulatorBinary(t, `{"status":"success"}`)
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	args := append(debugFileArgs(t, server.URL, sim, sorobanTestEnvelope(t)), "--output", "json")
+	require.NoError(t, runDebugCommand(t, args...))
+
+	var doc struct {
+		Network    string `json:"network"`
+		Simulation struct {
+			Status string `json:"status"`
+		} `json:"simulation"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &doc), "stdout must hold only the JSON document")
+	require.Equal(t, "testnet", doc.Network)
+	require.Equal(t, "success", doc.Simulation.Status)
+	require.Contains(t, stderr.String(), "--- Result for testnet ---")
+}
+
+func TestDebugCommand_TeeCapturesSimulationSummary(t *testing.T) {
+	server := newDebugRPC(t, nil)
+	sim := fakeSimulatorBinary(t, `{"status":"success"}`)
+	path := filepath.Join(t.TempDir(), "debug.log")
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	args := append(debugFileArgs(t, server.URL, sim, sorobanTestEnvelope(t)), "--tee", path)
+	require.NoError(t, runDebugCommand(t, args...))
+
+	teed, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(teed), "--- Result for testnet ---")
+	require.Contains(t, string(teed), "Status: success")
+	require.Contains(t, string(teed), "=== Security Analysis ===")
+	require.Equal(t, stdout.String(), string(teed))
+}
+
+func TestDebugCommand_SessionFoundBySignature(t *testing.T) {
+	server := newDebugRPC(t, nil)
+	sim := fakeSimulatorBinary(t, `{"status":"success","diagnostic_events":[{"event_type":"contract","topics":["transfer","GABC"],"data":"100"}]}`)
+
+	require.NoError(t, runDebugCommand(t, debugFileArgs(t, server.URL, sim, sorobanTestEnvelope(t))...))
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"search", "--signature", simulator.EventSignature([]string{"transfer", "GABC"})})
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+		resetCommandFlags(t, searchCmd.Flags())
+	})
+	require.NoError(t, Execute())
+
+	require.Contains(t, out.String(), "Found 1 matching sessions")
+	require.Contains(t, out.String(), "Network: testnet")
+	require.Contains(t, out.String(), "Status: success")
+}
+
+func TestDebugCommand_SaveReportsSessionOnce(t *testing.T) {
+	server := newDebugRPC(t, nil)
+	sim := fakeSimulatorBinary(t, `{"status":"success"}`)
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	args := append(debugFileArgs(t, server.URL, sim, sorobanTestEnvelope(t)), "--save")
+	require.NoError(t, runDebugCommand(t, args...))
+
+	require.Equal(t, 1, strings.Count(stdout.String(), "Session saved:"))
+	require.Contains(t, stdout.String(), "Run 'erst session resume ")
+}
@@ -4,13 +4,26 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/dotandev/hintents/internal/rpc"
 	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/tokenflow"
 	"github.com/stellar/go-stellar-sdk/xdr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -168,6 +181,10 @@ func (m *MockRunner) Run(req *simulator.SimulationRequest) (*simulator.Simulatio
 	return args.Get(0).(*simulator.SimulationResponse), args.Error(1)
 }
 
+func (m *MockRunner) RunBatch(reqs []*simulator.SimulationRequest) ([]*simulator.SimulationResponse, error) {
+	return simulator.RunEach(m.Run, reqs), nil
+}
+
 func TestDebugCommand_Setup(t *testing.T) {
 	// Test that the debugCmd is properly initialized
 	assert.NotNil(t, debugCmd)
@@ -290,3 +307,571 @@ func TestExtractLedgerKeys(t *testing.T) {
 	}
 	assert.True(t, found, "Key not found in extracted keys")
 }
+
+func TestRPCURLOptions_PassphraseFlowsIntoConfig(t *testing.T) {
+	const passphrase = "Private Network ; 2025"
+
+	opts, primary, err := rpcURLOptions("https://rpc.private.example, https://rpc2.private.example", passphrase, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, "https://rpc.private.example", primary)
+
+	client, err := rpc.NewClient(append([]rpc.ClientOption{rpc.WithNetwork(rpc.Mainnet)}, opts...)...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	assert.Equal(t, passphrase, client.Config.NetworkPassphrase)
+	assert.Equal(t, rpc.Network("custom"), client.Network)
+	assert.Equal(t, "https://rpc.private.example", client.HorizonURL)
+	assert.Equal(t, "https://rpc.private.example", client.SorobanURL)
+	assert.Equal(t, []string{"https://rpc.private.example", "https://rpc2.private.example"}, client.AltURLs)
+}
+
+func TestRPCURLOptions_Validation(t *testing.T) {
+	_, _, err := rpcURLOptions("", "Private Network ; 2025", "")
+	assert.Error(t, err)
+
+	opts, primary, err := rpcURLOptions("", "", "")
+	assert.NoError(t, err)
+	assert.Empty(t, opts)
+	assert.Empty(t, primary)
+
+	// Without a passphrase the --network config is kept.
+	opts, _, err = rpcURLOptions("https://horizon-testnet.stellar.org", "", "")
+	assert.NoError(t, err)
+	client, err := rpc.NewClient(append([]rpc.ClientOption{rpc.WithNetwork(rpc.Testnet)}, opts...)...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	assert.Equal(t, rpc.TestnetConfig.NetworkPassphrase, client.Config.NetworkPassphrase)
+}
+
+func TestRPCURLOptions_NetworkID(t *testing.T) {
+	const idHex = "cee0302d59844d32bdca915c8203dd44b33fbb7edc19051ea37abedf28ecd472"
+
+	opts, _, err := rpcURLOptions("https://rpc.private.example", "", "0x"+idHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client, err := rpc.NewClient(append([]rpc.ClientOption{rpc.WithNetwork(rpc.Mainnet)}, opts...)...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	id := client.Config.ID()
+	assert.Equal(t, idHex, hex.EncodeToString(id[:]))
+	assert.Empty(t, client.Config.NetworkPassphrase)
+	assert.Equal(t, rpc.Network("custom"), client.Network)
+}
+
+func TestRPCURLOptions_NetworkIDValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		rpcURL     string
+		passphrase string
+		networkID  string
+	}{
+		{"requires rpc url", "", "", strings.Repeat("ab", 32)},
+		{"exclusive with passphrase", "https://rpc.private.example", "Private Network ; 2025", strings.Repeat("ab", 32)},
+		{"not hex", "https://rpc.private.example", "", strings.Repeat("zz", 32)},
+		{"too short", "https://rpc.private.example", "", strings.Repeat("ab", 31)},
+		{"too long", "https://rpc.private.example", "", strings.Repeat("ab", 33)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := rpcURLOptions(tt.rpcURL, tt.passphrase, tt.networkID)
+			assert.Error(t, err)
+		})
+	}
+}
+
+const testAccount = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+
+func TestLatestTransactionHash(t *testing.T) {
+	latest := strings.Repeat("ab", 32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/"+testAccount+"/transactions", r.URL.Path)
+		assert.Equal(t, "desc", r.URL.Query().Get("order"))
+		assert.Equal(t, "1", r.URL.Query().Get("limit"))
+
+		w.Header().Set("Content-Type", "application/hal+json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"_embedded": map[string]interface{}{
+				"records": []map[string]interface{}{{
+					"hash":              latest,
+					"successful":        false,
+					"ledger_close_time": "2025-01-02T03:04:05Z",
+				}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL(server.URL+"/"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	hash, err := latestTransactionHash(context.Background(), client, testAccount)
+	assert.NoError(t, err)
+	assert.Equal(t, latest, hash)
+}
+
+func TestLatestTransactionHash_NoTransactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		_, _ = w.Write([]byte(`{"_embedded":{"records":[]}}`))
+	}))
+	defer server.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL(server.URL+"/"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = latestTransactionHash(context.Background(), client, testAccount)
+	assert.ErrorContains(t, err, "no transactions found")
+}
+
+func TestValidateTxTarget(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+
+	assert.NoError(t, validateTxTarget([]string{hash}, "", false))
+	assert.NoError(t, validateTxTarget(nil, testAccount, true))
+
+	assert.Error(t, validateTxTarget(nil, "", false), "hash required")
+	assert.Error(t, validateTxTarget(nil, testAccount, false), "--account needs --latest")
+	assert.Error(t, validateTxTarget(nil, "", true), "--latest needs --account")
+	assert.Error(t, validateTxTarget([]string{hash}, testAccount, true), "hash and account are exclusive")
+	assert.Error(t, validateTxTarget(nil, "GNOTANACCOUNT", true))
+
+	assert.NoError(t, validateTxTarget([]string{"https://stellar.expert/explorer/public/tx/" + hash}, "", false))
+	assert.NoError(t, validateTxTarget([]string{"https://horizon.stellar.org/transactions/" + hash}, "", false))
+	assert.ErrorContains(t, validateTxTarget([]string{"https://example.com/ledger/123"}, "", false), "unrecognized transaction URL")
+}
+
+func TestFetchTransaction_TimingIncludesRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"hash":            "abc",
+			"envelope_xdr":    "AAAA",
+			"result_xdr":      "BBBB",
+			"result_meta_xdr": "CCCC",
+		})
+	}))
+	defer server.Close()
+
+	cfg := rpc.DefaultRetryConfig()
+	cfg.InitialBackoff = 5 * time.Millisecond
+	cfg.MaxBackoff = 10 * time.Millisecond
+	cfg.JitterFraction = 0
+	client, err := rpc.NewClient(
+		rpc.WithNetwork(rpc.Testnet),
+		rpc.WithHorizonURL(server.URL+"/"),
+		rpc.WithHTTPClient(rpc.WithRetry(server.Client(), cfg)),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var timings debugTimings
+	resp, err := fetchTransaction(context.Background(), client, "abc", 0, &timings)
+	assert.NoError(t, err)
+	assert.Equal(t, "AAAA", resp.EnvelopeXdr)
+	assert.Equal(t, int64(2), timings.Retries)
+	assert.Greater(t, timings.Fetch, time.Duration(0))
+
+	timings.Simulate = 42 * time.Millisecond
+	line := timings.String()
+	assert.Contains(t, line, "Fetched in ")
+	assert.Contains(t, line, "(2 retries)")
+	assert.Contains(t, line, "simulated in 42ms")
+}
+
+func TestDebugTimings_SingularRetry(t *testing.T) {
+	line := debugTimings{Fetch: 120 * time.Millisecond, Retries: 1, Simulate: 45 * time.Millisecond}.String()
+	assert.Equal(t, "Fetched in 120ms (1 retry), simulated in 45ms", line)
+}
+
+func TestWithEventSource(t *testing.T) {
+	prev := eventSourceFlag
+	defer func() { eventSourceFlag = prev }()
+
+	res := &simulator.SimulationResponse{
+		Status:           "success",
+		DiagnosticEvents: []simulator.DiagnosticEvent{{EventType: "diagnostic"}},
+	}
+
+	eventSourceFlag = "sim"
+	assert.Same(t, res, withEventSource(io.Discard, NewWarningCollector(), "", res))
+
+	eventSourceFlag = "meta"
+	warnings := NewWarningCollector()
+	got := withEventSource(io.Discard, warnings, "not-xdr", res)
+	assert.Same(t, res, got)
+	assert.Equal(t, 1, warnings.Len())
+}
+
+func footprintEnvelope(t *testing.T, keys ...xdr.LedgerKey) string {
+	t.Helper()
+	src, err := xdr.NewMuxedAccount(xdr.CryptoKeyTypeKeyTypeEd25519, xdr.Uint256{0x01})
+	if err != nil {
+		t.Fatalf("failed to build source account: %v", err)
+	}
+	tx := xdr.Transaction{
+		SourceAccount: src,
+		Fee:           100,
+		SeqNum:        42,
+		Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+		Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+		Operations: []xdr.Operation{{Body: xdr.OperationBody{
+			Type:           xdr.OperationTypeBumpSequence,
+			BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 1},
+		}}},
+	}
+	if len(keys) > 0 {
+		tx.Ext = xdr.TransactionExt{
+			V: 1,
+			SorobanData: &xdr.SorobanTransactionData{
+				Resources: xdr.SorobanResources{Footprint: xdr.LedgerFootprint{ReadOnly: keys}},
+			},
+		}
+	}
+	env, err := xdr.MarshalBase64(xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1:   &xdr.TransactionV1Envelope{Tx: tx},
+	})
+	if err != nil {
+		t.Fatalf("failed to encode envelope: %v", err)
+	}
+	return env
+}
+
+func accountKey(b byte) xdr.LedgerKey {
+	id := xdr.Uint256{b}
+	return xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeAccount,
+		Account: &xdr.LedgerKeyAccount{AccountId: xdr.AccountId{
+			Type:    xdr.PublicKeyTypePublicKeyTypeEd25519,
+			Ed25519: &id,
+		}},
+	}
+}
+
+func TestFetchFootprintEntries(t *testing.T) {
+	found, missing := accountKey(0x02), accountKey(0x03)
+	foundKey, err := xdr.MarshalBase64(found)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string     `json:"method"`
+			Params [][]string `json:"params"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, "getLedgerEntries", req.Method)
+		requested = req.Params[0]
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result": map[string]interface{}{
+				"entries": []map[string]interface{}{{"key": foundKey, "xdr": "AAAA"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL(server.URL), rpc.WithCacheEnabled(false))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	entries, err := fetchFootprintEntries(context.Background(), client, footprintEnvelope(t, found, missing), nil)
+	assert.NoError(t, err)
+	assert.Len(t, requested, 2)
+	assert.Equal(t, map[string]string{foundKey: "AAAA"}, entries)
+}
+
+func TestFetchFootprintEntries_SkipsKnownKeys(t *testing.T) {
+	key, err := xdr.MarshalBase64(accountKey(0x02))
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL("http://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	entries, err := fetchFootprintEntries(context.Background(), client, footprintEnvelope(t, accountKey(0x02)), map[string]string{key: "AAAA"})
+	assert.NoError(t, err)
+	assert.Nil(t, entries)
+}
+
+func TestFetchFootprintEntries_ClassicTransaction(t *testing.T) {
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL("http://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	entries, err := fetchFootprintEntries(context.Background(), client, footprintEnvelope(t), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, entries)
+}
+
+func TestFetchFootprintEntries_RPCFailureIsNotFatal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"boom"}}`))
+	}))
+	defer server.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL(server.URL), rpc.WithCacheEnabled(false))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	entries, err := fetchFootprintEntries(context.Background(), client, footprintEnvelope(t, accountKey(0x02)), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, entries)
+}
+
+func TestPinLedgerSequence(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"id":"abc","protocolVersion":22,"sequence":5000}}`))
+	}))
+	defer server.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithSorobanURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	prev := ledgerSequenceFlag
+	defer func() { ledgerSequenceFlag = prev }()
+	ctx := context.Background()
+
+	ledgerSequenceFlag = 0
+	assert.Equal(t, uint32(5000), pinLedgerSequence(ctx, client, nil), "defaults to the network's latest ledger")
+	assert.Equal(t, uint32(0), pinLedgerSequence(ctx, nil, nil), "no client and no entries leaves it unset")
+
+	ledgerSequenceFlag = 1234
+	assert.Equal(t, uint32(1234), pinLedgerSequence(ctx, client, nil), "--ledger-sequence wins")
+}
+
+func TestResolveProtocolVersion(t *testing.T) {
+	version := 22
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"id":"abc","protocolVersion":%d,"sequence":5000}}`, version)
+	}))
+	defer server.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithSorobanURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	prev := protocolFlag
+	defer func() { protocolFlag = prev }()
+	ctx := context.Background()
+
+	protocolFlag = 0
+	got := resolveProtocolVersion(ctx, client, nil)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, uint32(22), *got, "defaults to the network's protocol")
+	}
+	assert.Nil(t, resolveProtocolVersion(ctx, nil, nil), "no client leaves the simulator default")
+
+	version = 99
+	warnings := NewWarningCollector()
+	assert.Nil(t, resolveProtocolVersion(ctx, client, warnings), "unsupported network protocol falls back")
+	assert.Equal(t, 1, warnings.Len())
+
+	protocolFlag = 20
+	got = resolveProtocolVersion(ctx, client, nil)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, uint32(20), *got, "--protocol wins")
+	}
+}
+
+func TestValidateProtocolFlag(t *testing.T) {
+	prev := protocolFlag
+	defer func() { protocolFlag = prev }()
+
+	protocolFlag = 0
+	assert.NoError(t, validateProtocolFlag())
+	protocolFlag = 21
+	assert.NoError(t, validateProtocolFlag())
+	protocolFlag = 19
+	err := validateProtocolFlag()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Must be one of: 20, 21, 22")
+	}
+}
+
+func TestPinLedgerSequence_FallsBackToEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithSorobanURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	entry := xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 77,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId: xdr.MustAddress(testAccount),
+			},
+		},
+	}
+	entryXdr, err := xdr.MarshalBase64(entry)
+	if err != nil {
+		t.Fatalf("failed to encode entry: %v", err)
+	}
+
+	prev := ledgerSequenceFlag
+	defer func() { ledgerSequenceFlag = prev }()
+	ledgerSequenceFlag = 0
+
+	seq := pinLedgerSequence(context.Background(), client, map[string]string{"key": entryXdr})
+	assert.Equal(t, uint32(77), seq)
+}
+
+func TestCheckEventsAgainstChain(t *testing.T) {
+	cid := xdr.ContractId{0xAA}
+	sym := xdr.ScSymbol("transfer")
+	amount := xdr.Uint32(50)
+	results := []xdr.OperationResult{}
+	meta, err := xdr.MarshalBase64(xdr.TransactionResultMeta{
+		Result: xdr.TransactionResultPair{Result: xdr.TransactionResult{
+			Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &results},
+		}},
+		TxApplyProcessing: xdr.TransactionMeta{V: 3, V3: &xdr.TransactionMetaV3{
+			SorobanMeta: &xdr.SorobanTransactionMeta{
+				ReturnValue: xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+				Events: []xdr.ContractEvent{{
+					ContractId: &cid,
+					Type:       xdr.ContractEventTypeContract,
+					Body: xdr.ContractEventBody{V0: &xdr.ContractEventV0{
+						Topics: []xdr.ScVal{{Type: xdr.ScValTypeScvSymbol, Sym: &sym}},
+						Data:   xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &amount},
+					}},
+				}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to encode meta: %v", err)
+	}
+	onChain, err := simulator.MetaDiagnosticEvents(meta)
+	if err != nil {
+		t.Fatalf("failed to read meta events: %v", err)
+	}
+
+	prev := eventSourceFlag
+	defer func() { eventSourceFlag = prev }()
+	eventSourceFlag = "sim"
+
+	matching := NewWarningCollector()
+	checkEventsAgainstChain(matching, meta, &simulator.SimulationResponse{DiagnosticEvents: onChain})
+	assert.Equal(t, 0, matching.Len())
+
+	extra := onChain[0]
+	extra.ContractID = nil
+	divergent := NewWarningCollector()
+	checkEventsAgainstChain(divergent, meta, &simulator.SimulationResponse{DiagnosticEvents: []simulator.DiagnosticEvent{extra}})
+	if assert.Equal(t, 2, divergent.Len()) {
+		assert.Contains(t, divergent.Warnings()[0].Message, "simulated but not on chain")
+		assert.Contains(t, divergent.Warnings()[1].Message, "on chain but not simulated")
+	}
+
+	eventSourceFlag = "meta"
+	skipped := NewWarningCollector()
+	checkEventsAgainstChain(skipped, meta, &simulator.SimulationResponse{})
+	assert.Equal(t, 0, skipped.Len())
+}
+
+func TestLimitLedgerEntries(t *testing.T) {
+	prevEntries, prevBytes, prevTruncate := limitEntriesFlag, limitBytesFlag, truncateEntriesFlag
+	defer func() { limitEntriesFlag, limitBytesFlag, truncateEntriesFlag = prevEntries, prevBytes, prevTruncate }()
+
+	entries := map[string]string{"k1": "v1", "k2": "v2", "k3": "v3"}
+
+	limitEntriesFlag, limitBytesFlag, truncateEntriesFlag = 3, 0, false
+	warnings := NewWarningCollector()
+	got, err := limitLedgerEntries(warnings, entries)
+	assert.NoError(t, err)
+	assert.Len(t, got, 3)
+	assert.Equal(t, 0, warnings.Len())
+
+	limitEntriesFlag = 2
+	_, err = limitLedgerEntries(warnings, entries)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--truncate-ledger-entries")
+
+	truncateEntriesFlag = true
+	got, err = limitLedgerEntries(warnings, entries)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	if assert.Equal(t, 1, warnings.Len()) {
+		assert.Contains(t, warnings.Warnings()[0].Message, "dropped 1 of 3 ledger entries")
+	}
+}
+
+func TestPrintTokenFlowChart_Formats(t *testing.T) {
+	report := &tokenflow.Report{Agg: []tokenflow.Transfer{{
+		From:   "GA",
+		To:     "GB",
+		Token:  tokenflow.Token{Symbol: "XLM"},
+		Amount: big.NewInt(10_000_000),
+		Kind:   tokenflow.KindTransfer,
+	}}}
+
+	var out bytes.Buffer
+	printTokenFlowChart(&out, report, "csv")
+	assert.Contains(t, out.String(), "from,to,asset,amount,type\nGA,GB,XLM,1,transfer\n")
+
+	out.Reset()
+	printTokenFlowChart(&out, report, "dot")
+	assert.Contains(t, out.String(), "digraph tokenflow {")
+
+	out.Reset()
+	printTokenFlowChart(&out, report, "mermaid")
+	assert.Contains(t, out.String(), "flowchart LR")
+}
+
+func TestPrintSimulationResult_ResourceFeeOnlyOnMainnet(t *testing.T) {
+	res := &simulator.SimulationResponse{
+		Status: "success",
+		BudgetUsage: &simulator.BudgetUsage{
+			CPUInstructions: 1_000_000,
+			MemoryBytes:     4096,
+		},
+	}
+
+	var mainnet bytes.Buffer
+	printSimulationResult(&mainnet, string(rpc.Mainnet), res)
+	assert.Contains(t, mainnet.String(), "Est. Resource Fee:")
+
+	var testnet bytes.Buffer
+	printSimulationResult(&testnet, string(rpc.Testnet), res)
+	assert.NotContains(t, testnet.String(), "Est. Resource Fee")
+	assert.Contains(t, testnet.String(), "Resource Usage:")
+}
@@ -4,10 +4,14 @@
 package cmd
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"os"
+	"strconv"
 
+	"github.com/dotandev/hintents/internal/analytics"
+	"github.com/dotandev/hintents/internal/logger"
 	"github.com/dotandev/hintents/internal/rpc"
 	"github.com/dotandev/hintents/internal/simulator"
 	"github.com/spf13/cobra"
@@ -57,6 +61,7 @@ func init() {
 	dryRunCmd.Flags().StringVar(&dryRunRPCURLFlag, "rpc-url", "", "Custom Horizon RPC URL to use")
 	dryRunCmd.Flags().StringVar(&dryRunRPCTokenFlag, "rpc-token", "", "RPC authentication token (can also use ERST_RPC_TOKEN env var)")
 
+	requireInStrict(dryRunCmd, "network")
 	rootCmd.AddCommand(dryRunCmd)
 }
 
@@ -90,7 +95,7 @@ func runDryRun(cmd *cobra.Command, args []string) error {
 		opts = append(opts, rpc.WithHorizonURL(dryRunRPCURLFlag))
 	}
 
-	client, err := rpc.NewClient(opts...)
+	client, err := rpcClients.Client(opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
@@ -113,6 +118,9 @@ func runDryRun(cmd *cobra.Command, args []string) error {
 		if cpu != 0 || mem != 0 {
 			fmt.Printf("Preflight cost: CPU=%d, MEM=%d\n", cpu, mem)
 		}
+		if resourceFee, err := strconv.ParseInt(fee, 10, 64); err == nil {
+			printFeeSuggestions(ctx, client, resourceFee)
+		}
 		return nil
 	}
 
@@ -126,7 +134,7 @@ func runDryRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to fetch ledger entries: %w", err)
 	}
 
-	runner, err := simulator.NewRunner("", false)
+	runner, err := simulator.NewRunner("", false, simulator.WithMinVersion(simulator.MinSimulatorVersion))
 	if err != nil {
 		return fmt.Errorf("failed to initialize simulator: %w", err)
 	}
@@ -134,9 +142,10 @@ func runDryRun(cmd *cobra.Command, args []string) error {
 	// The current Rust simulator requires a non-empty result_meta_xdr.
 	// For dry-run we don't have it (tx not on-chain), so we use a placeholder.
 	simReq := &simulator.SimulationRequest{
-		EnvelopeXdr:   envXdrB64,
-		ResultMetaXdr: "AAAAAQ==", // placeholder base64
-		LedgerEntries: ledgerEntries,
+		EnvelopeXdr:    envXdrB64,
+		ResultMetaXdr:  placeholderResultMeta,
+		LedgerEntries:  ledgerEntries,
+		LedgerSequence: simulator.EntriesLedgerSequence(ledgerEntries),
 	}
 
 	resp, err := runner.Run(simReq)
@@ -159,6 +168,29 @@ func runDryRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printFeeSuggestions prints suggested total fees based on the network's
+// recent inclusion fees. It is best-effort: without fee stats nothing is
+// printed.
+func printFeeSuggestions(ctx context.Context, client *rpc.Client, resourceFee int64) {
+	stats, err := client.GetFeeStats(ctx)
+	if err != nil {
+		logger.Logger.Debug("Fee stats unavailable", "error", err)
+		return
+	}
+
+	dist := stats.SorobanInclusionFee
+	suggestions := analytics.SuggestFees(resourceFee, analytics.InclusionFeePercentiles{
+		P50: int64(dist.P50),
+		P90: int64(dist.P90),
+		P99: int64(dist.P99),
+	})
+
+	fmt.Printf("\nSuggested fees (last %d ledgers, %d transactions):\n", dist.LedgerCount, dist.TransactionCount)
+	for _, s := range suggestions {
+		fmt.Printf("  %-9s %d stroops (inclusion %d + resource %d)\n", s.Tier+":", s.Total, s.InclusionFee, s.ResourceFee)
+	}
+}
+
 func estimateFeeFromBudget(b simulator.BudgetUsage) (int64, error) {
 	// Conservative heuristic for now.
 	// TODO: Replace with exact network pricing once fee config is exposed by public RPC.
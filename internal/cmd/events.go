@@ -0,0 +1,248 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	eventsNetworkFlag     string
+	eventsRPCURLFlag      string
+	eventsContractFlag    []string
+	eventsTopicFlag       []string
+	eventsStartLedgerFlag uint32
+	eventsFollowFlag      bool
+	eventsIntervalFlag    time.Duration
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Print and decode the events emitted by contracts",
+	Long: `Fetch the events emitted by one or more contracts with getEvents and print
+them with their topics and value decoded to JSON.
+
+Events are read from --start-ledger, or from the latest ledger when it is
+not given. With --follow the command keeps polling for new events, similar
+to 'tail -f', until interrupted with Ctrl-C.
+
+Each --topic is a comma-separated list of topic segments. A segment is a
+symbol name, "*" to match any single segment, "**" to match any remaining
+segments, or "xdr:" followed by a base64 ScVal. An event matches if any
+--topic does.`,
+	Example: `  # Follow every event of a contract
+  erst events --network testnet --contract CA3D... --follow
+
+  # Only transfer events, from a given ledger
+  erst events --contract CA3D... --topic 'transfer,**' --start-ledger 51000000`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := buildEventFilter(eventsContractFlag, eventsTopicFlag)
+		if err != nil {
+			return err
+		}
+
+		opts := []rpc.ClientOption{
+			rpc.WithNetwork(rpc.Network(eventsNetworkFlag)),
+			rpc.WithUserAgent(resolveUserAgent()),
+		}
+		urlOpts, primaryURL, err := rpcURLOptions(eventsRPCURLFlag, "", "")
+		if err != nil {
+			return err
+		}
+		if primaryURL != "" {
+			urlOpts = append(urlOpts, rpc.WithSorobanURL(primaryURL))
+		}
+		client, err := rpcClients.Client(append(opts, urlOpts...)...)
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		start := eventsStartLedgerFlag
+		if start == 0 {
+			start, err = client.GetLatestLedger(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get latest ledger: %w", err)
+			}
+		}
+
+		req := rpc.EventsRequest{
+			StartLedger: start,
+			Filters:     []rpc.EventFilter{filter},
+			Limit:       rpc.DefaultEventsPageLimit,
+		}
+		if eventsFollowFlag {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Following events from ledger %d (Ctrl-C to stop)...\n", start)
+		}
+		return tailEvents(ctx, client, req, eventsFollowFlag, eventsIntervalFlag, cmd.OutOrStdout())
+	},
+}
+
+// eventsPager fetches one page of getEvents results.
+type eventsPager interface {
+	GetEventsPage(ctx context.Context, req rpc.EventsRequest) (*rpc.EventsPage, error)
+}
+
+// tailEvents prints every event from req onwards. Without follow it stops
+// once it has caught up with the latest ledger; with follow it then polls
+// every interval for new events. It returns nil once ctx is cancelled.
+func tailEvents(ctx context.Context, pager eventsPager, req rpc.EventsRequest, follow bool, interval time.Duration, w io.Writer) error {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	if req.Limit <= 0 {
+		req.Limit = rpc.DefaultEventsPageLimit
+	}
+
+	for {
+		page, err := pager.GetEventsPage(ctx, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to fetch events: %w", err)
+		}
+		for _, ev := range page.Events {
+			printContractEvent(w, ev)
+		}
+		advanced := page.Cursor != "" && page.Cursor != req.Cursor
+		if advanced {
+			req.Cursor = page.Cursor
+		}
+		if advanced && len(page.Events) >= req.Limit {
+			// More events are waiting; fetch them without delay. Without a
+			// new cursor the same page would come back again.
+			continue
+		}
+		if !follow {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// printContractEvent writes one event per line with its topics and value
+// decoded to RPC JSON. Values that fail to decode are shown as base64.
+func printContractEvent(w io.Writer, ev rpc.ContractEvent) {
+	topics := make([]string, len(ev.Topic))
+	for i, t := range ev.Topic {
+		topics[i] = decodeEventScVal(t)
+	}
+
+	status := ""
+	if !ev.InSuccessfulContractCall {
+		status = " (failed call)"
+	}
+	fmt.Fprintf(w, "[ledger %d] %s %s%s tx=%s\n", ev.Ledger, ev.Type, ev.ContractID, status, ev.TxHash)
+	fmt.Fprintf(w, "  topics: [%s]\n", strings.Join(topics, ", "))
+	fmt.Fprintf(w, "  value:  %s\n", decodeEventScVal(ev.Value))
+}
+
+func decodeEventScVal(b64 string) string {
+	var val xdr.ScVal
+	if err := xdr.SafeUnmarshalBase64(b64, &val); err != nil {
+		return b64
+	}
+	out, err := decoder.ScValToRPCJSON(val)
+	if err != nil {
+		return b64
+	}
+	return string(out)
+}
+
+// buildEventFilter turns --contract and --topic into a getEvents filter.
+func buildEventFilter(contracts, topics []string) (rpc.EventFilter, error) {
+	var filter rpc.EventFilter
+	if len(contracts) == 0 {
+		return filter, fmt.Errorf("at least one --contract is required")
+	}
+	for _, id := range contracts {
+		if _, err := strkey.Decode(strkey.VersionByteContract, id); err != nil {
+			return filter, fmt.Errorf("invalid contract ID %q: %w", id, err)
+		}
+	}
+	filter.ContractIDs = contracts
+
+	for _, topic := range topics {
+		matcher, err := parseTopicMatcher(topic)
+		if err != nil {
+			return filter, err
+		}
+		filter.Topics = append(filter.Topics, matcher)
+	}
+	return filter, nil
+}
+
+// parseTopicMatcher converts a comma-separated --topic value into the
+// base64 segments getEvents expects.
+func parseTopicMatcher(topic string) ([]string, error) {
+	segments := strings.Split(topic, ",")
+	matcher := make([]string, 0, len(segments))
+	for i, seg := range segments {
+		seg = strings.TrimSpace(seg)
+		switch {
+		case seg == "":
+			return nil, fmt.Errorf("invalid --topic %q: empty segment", topic)
+		case seg == "**":
+			if i != len(segments)-1 {
+				return nil, fmt.Errorf("invalid --topic %q: \"**\" must be the last segment", topic)
+			}
+			matcher = append(matcher, seg)
+		case seg == "*":
+			matcher = append(matcher, seg)
+		case strings.HasPrefix(seg, "xdr:"):
+			raw := strings.TrimPrefix(seg, "xdr:")
+			var val xdr.ScVal
+			if err := xdr.SafeUnmarshalBase64(raw, &val); err != nil {
+				return nil, fmt.Errorf("invalid --topic %q: segment %d is not a base64 ScVal: %w", topic, i+1, err)
+			}
+			matcher = append(matcher, raw)
+		default:
+			sym := xdr.ScSymbol(seg)
+			b64, err := xdr.MarshalBase64(xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym})
+			if err != nil {
+				return nil, fmt.Errorf("invalid --topic %q: %w", topic, err)
+			}
+			matcher = append(matcher, b64)
+		}
+	}
+	return matcher, nil
+}
+
+func init() {
+	eventsCmd.Flags().StringVarP(&eventsNetworkFlag, "network", "n", string(rpc.Mainnet), "Stellar network to use (testnet, mainnet, futurenet)")
+	eventsCmd.Flags().StringVar(&eventsRPCURLFlag, "rpc-url", "", "Custom Soroban RPC URL")
+	eventsCmd.Flags().StringArrayVar(&eventsContractFlag, "contract", nil, "Contract ID (C...) whose events to print; repeat for several contracts")
+	eventsCmd.Flags().StringArrayVar(&eventsTopicFlag, "topic", nil, "Topic filter: comma-separated segments (symbol, \"*\", \"**\" or xdr:<base64 ScVal>); repeatable")
+	eventsCmd.Flags().Uint32Var(&eventsStartLedgerFlag, "start-ledger", 0, "Ledger to read events from (default: the latest ledger)")
+	eventsCmd.Flags().BoolVarP(&eventsFollowFlag, "follow", "f", false, "Keep polling for new events until interrupted")
+	eventsCmd.Flags().DurationVar(&eventsIntervalFlag, "interval", 5*time.Second, "Polling interval with --follow")
+
+	requireInStrict(eventsCmd, "network")
+	rootCmd.AddCommand(eventsCmd)
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContractID = "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE"
+
+// fakeEventsPager returns its pages in order, recording each request, and
+// then empty pages. onEmpty runs whenever it has nothing left to return.
+type fakeEventsPager struct {
+	pages    []*rpc.EventsPage
+	requests []rpc.EventsRequest
+	onEmpty  func()
+}
+
+func (p *fakeEventsPager) GetEventsPage(ctx context.Context, req rpc.EventsRequest) (*rpc.EventsPage, error) {
+	p.requests = append(p.requests, req)
+	if len(p.pages) == 0 {
+		if p.onEmpty != nil {
+			p.onEmpty()
+		}
+		return &rpc.EventsPage{Cursor: req.Cursor}, nil
+	}
+	page := p.pages[0]
+	p.pages = p.pages[1:]
+	return page, nil
+}
+
+func scValB64(t *testing.T, val xdr.ScVal) string {
+	t.Helper()
+	b64, err := xdr.MarshalBase64(val)
+	require.NoError(t, err)
+	return b64
+}
+
+func transferEvent(t *testing.T, ledger uint32, amount int32) rpc.ContractEvent {
+	t.Helper()
+	sym := xdr.ScSymbol("transfer")
+	i := xdr.Int32(amount)
+	return rpc.ContractEvent{
+		Type:                     "contract",
+		Ledger:                   ledger,
+		ContractID:               testContractID,
+		TxHash:                   fmt.Sprintf("tx%d", ledger),
+		InSuccessfulContractCall: true,
+		Topic:                    []string{scValB64(t, xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym})},
+		Value:                    scValB64(t, xdr.ScVal{Type: xdr.ScValTypeScvI32, I32: &i}),
+	}
+}
+
+func TestTailEvents_DecodesAndStopsWhenCaughtUp(t *testing.T) {
+	pager := &fakeEventsPager{pages: []*rpc.EventsPage{
+		{Events: []rpc.ContractEvent{transferEvent(t, 100, 5), transferEvent(t, 101, 7)}, Cursor: "c1"},
+		{Events: []rpc.ContractEvent{transferEvent(t, 102, 9)}, Cursor: "c2"},
+	}}
+
+	var out bytes.Buffer
+	req := rpc.EventsRequest{StartLedger: 100, Limit: 2}
+	err := tailEvents(context.Background(), pager, req, false, time.Millisecond, &out)
+	require.NoError(t, err)
+
+	// A full page is followed immediately by the next one, from its cursor.
+	require.Len(t, pager.requests, 2)
+	assert.Equal(t, uint32(100), pager.requests[0].StartLedger)
+	assert.Equal(t, "c1", pager.requests[1].Cursor)
+
+	text := out.String()
+	assert.Contains(t, text, "[ledger 100] contract "+testContractID+" tx=tx100")
+	assert.Contains(t, text, `topics: [{"symbol":"transfer"}]`)
+	assert.Contains(t, text, `value:  {"i32":5}`)
+	assert.Contains(t, text, `value:  {"i32":9}`)
+	assert.Equal(t, 3, strings.Count(text, "[ledger "))
+}
+
+func TestTailEvents_FullPageWithoutCursorStops(t *testing.T) {
+	page := &rpc.EventsPage{Events: []rpc.ContractEvent{transferEvent(t, 100, 5), transferEvent(t, 101, 7)}}
+	pager := &fakeEventsPager{pages: []*rpc.EventsPage{page, page, page}}
+
+	var out bytes.Buffer
+	req := rpc.EventsRequest{StartLedger: 100, Limit: 2}
+	require.NoError(t, tailEvents(context.Background(), pager, req, false, time.Millisecond, &out))
+
+	require.Len(t, pager.requests, 1, "a full page without a cursor must not be fetched again")
+	assert.Equal(t, 2, strings.Count(out.String(), "[ledger "))
+}
+
+func TestTailEvents_FollowPollsUntilCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+	pager := &fakeEventsPager{pages: []*rpc.EventsPage{
+		{Events: []rpc.ContractEvent{transferEvent(t, 100, 1)}, Cursor: "c1"},
+	}}
+	pager.onEmpty = func() {
+		polls++
+		switch polls {
+		case 1:
+			// A new event arrives between two polls.
+			pager.pages = append(pager.pages, &rpc.EventsPage{
+				Events: []rpc.ContractEvent{transferEvent(t, 105, 2)}, Cursor: "c2",
+			})
+		case 2:
+			cancel()
+		}
+	}
+
+	var out bytes.Buffer
+	err := tailEvents(ctx, pager, rpc.EventsRequest{StartLedger: 100, Limit: 10}, true, time.Millisecond, &out)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "[ledger 100]")
+	assert.Contains(t, out.String(), "[ledger 105]")
+	last := pager.requests[len(pager.requests)-1]
+	assert.Equal(t, "c2", last.Cursor)
+}
+
+func TestTailEvents_ReportsErrors(t *testing.T) {
+	pager := &failingPager{err: fmt.Errorf("rpc error: boom")}
+	err := tailEvents(context.Background(), pager, rpc.EventsRequest{StartLedger: 1}, true, time.Millisecond, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "boom")
+}
+
+type failingPager struct{ err error }
+
+func (p *failingPager) GetEventsPage(context.Context, rpc.EventsRequest) (*rpc.EventsPage, error) {
+	return nil, p.err
+}
+
+func TestPrintContractEvent_UndecodableAndFailed(t *testing.T) {
+	var out bytes.Buffer
+	printContractEvent(&out, rpc.ContractEvent{
+		Type:       "contract",
+		Ledger:     7,
+		ContractID: testContractID,
+		Topic:      []string{"not-xdr"},
+		Value:      "AAAA",
+	})
+	assert.Contains(t, out.String(), "(failed call)")
+	assert.Contains(t, out.String(), "topics: [not-xdr]")
+}
+
+func TestBuildEventFilter(t *testing.T) {
+	filter, err := buildEventFilter([]string{testContractID}, []string{"transfer, *", "mint,**"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{testContractID}, filter.ContractIDs)
+
+	sym := xdr.ScSymbol("transfer")
+	transfer := scValB64(t, xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym})
+	require.Len(t, filter.Topics, 2)
+	assert.Equal(t, []string{transfer, "*"}, filter.Topics[0])
+	assert.Equal(t, "**", filter.Topics[1][1])
+
+	raw, err := buildEventFilter([]string{testContractID}, []string{"xdr:" + transfer})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{transfer}}, raw.Topics)
+}
+
+func TestBuildEventFilter_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		contracts []string
+		topics    []string
+	}{
+		{"no contract", nil, nil},
+		{"account instead of contract", []string{"GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"}, nil},
+		{"empty segment", []string{testContractID}, []string{"transfer,,x"}},
+		{"double star not last", []string{testContractID}, []string{"**,transfer"}},
+		{"bad xdr", []string{testContractID}, []string{"xdr:!!!"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildEventFilter(tt.contracts, tt.topics)
+			assert.Error(t, err)
+		})
+	}
+}
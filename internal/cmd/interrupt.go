@@ -0,0 +1,78 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/dotandev/hintents/internal/session"
+)
+
+// interruptExitCode is the conventional exit status after SIGINT (128+2).
+const interruptExitCode = 130
+
+// exitFunc is replaced in tests.
+var exitFunc = os.Exit
+
+// handleInterrupts saves the current session when the process receives
+// SIGINT or SIGTERM, so an interrupted debug run does not lose a finished
+// simulation, then exits. The returned function stops the handler.
+func handleInterrupts(w io.Writer) func() {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	return watchInterrupts(sigs, w, func() { signal.Stop(sigs) })
+}
+
+// watchInterrupts runs the interrupt handler for signals received on sigs.
+func watchInterrupts(sigs <-chan os.Signal, w io.Writer, stopSignals func()) func() {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigs:
+			fmt.Fprintln(w, "\nInterrupted.")
+			if _, err := saveInterruptedSession(context.Background(), w); err != nil {
+				fmt.Fprintf(w, "Warning: %v\n", err)
+			}
+			exitFunc(interruptExitCode)
+		case <-done:
+		}
+	}()
+	return func() {
+		stopSignals()
+		close(done)
+	}
+}
+
+// saveInterruptedSession persists the current session, if any, and prints
+// its ID. It returns the saved ID, or "" when there was nothing to save.
+func saveInterruptedSession(ctx context.Context, w io.Writer) (string, error) {
+	data := GetCurrentSession()
+	if data == nil {
+		return "", nil
+	}
+	if data.ID == "" {
+		data.ID = session.GenerateID(data.TxHash)
+	}
+	data.Status = "interrupted"
+	data.LastAccessAt = time.Now()
+
+	store, err := session.NewStore()
+	if err != nil {
+		return "", fmt.Errorf("failed to open session store: %w", err)
+	}
+	defer store.Close()
+
+	if err := store.Save(ctx, data); err != nil {
+		return "", fmt.Errorf("failed to save interrupted session: %w", err)
+	}
+	fmt.Fprintf(w, "Session saved: %s\n", data.ID)
+	fmt.Fprintf(w, "Run 'erst session resume %s' to continue.\n", data.ID)
+	return data.ID, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/session"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchInterrupts_SavesCurrentSession(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	SetCurrentSession(&session.SessionData{
+		ID:            "interrupted-run",
+		Network:       "testnet",
+		TxHash:        "abc123",
+		EnvelopeXdr:   "AAAA",
+		SchemaVersion: session.SchemaVersion,
+	})
+	t.Cleanup(func() { SetCurrentSession(nil) })
+
+	exited := make(chan int, 1)
+	exitFunc = func(code int) { exited <- code }
+	t.Cleanup(func() { exitFunc = os.Exit })
+
+	var out syncBuffer
+	sigs := make(chan os.Signal, 1)
+	stop := watchInterrupts(sigs, &out, func() {})
+	defer stop()
+
+	sigs <- os.Interrupt
+	select {
+	case code := <-exited:
+		require.Equal(t, interruptExitCode, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("interrupt handler did not exit")
+	}
+	require.Contains(t, out.String(), "Session saved: interrupted-run")
+
+	store, err := session.NewStore()
+	require.NoError(t, err)
+	defer store.Close()
+
+	saved, err := store.Load(context.Background(), "interrupted-run")
+	require.NoError(t, err)
+	require.Equal(t, "interrupted", saved.Status)
+	require.Equal(t, "AAAA", saved.EnvelopeXdr)
+}
+
+func TestSaveInterruptedSession_NoSession(t *testing.T) {
+	SetCurrentSession(nil)
+
+	var out bytes.Buffer
+	id, err := saveInterruptedSession(context.Background(), &out)
+	require.NoError(t, err)
+	require.Empty(t, id)
+	require.Empty(t, out.String())
+}
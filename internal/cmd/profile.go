@@ -0,0 +1,149 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/visualizer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileNetwork   string
+	profileRPCURL    string
+	profileSimPath   string
+	profileMergedSVG string
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile <transaction-hash|url>...",
+	Short: "Profile the simulation of one or more transactions",
+	Long: `Replay each transaction in the local simulator with profiling enabled and
+print its resource usage.
+
+With --merged-flamegraph the folded-stack profiles of all transactions are
+summed into a single flamegraph, which makes related transactions easier to
+compare than one flamegraph each. Only simulators that report folded stacks
+can be merged; transactions profiled without them are skipped with a note.`,
+	Example: `  erst profile --network testnet abc123... def456...
+  erst profile abc123... def456... --merged-flamegraph merged.svg`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hashes := make([]string, len(args))
+		for i, arg := range args {
+			hash, err := rpc.ResolveTransactionRef(arg)
+			if err != nil {
+				return err
+			}
+			hashes[i] = hash
+		}
+
+		opts := []rpc.ClientOption{
+			rpc.WithNetwork(rpc.Network(profileNetwork)),
+			rpc.WithUserAgent(resolveUserAgent()),
+		}
+		urlOpts, _, err := rpcURLOptions(profileRPCURL, "", "")
+		if err != nil {
+			return err
+		}
+		client, err := rpcClients.Client(append(opts, urlOpts...)...)
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		runner, err := simulator.NewRunner(profileSimPath, false, simulator.WithMinVersion(simulator.MinSimulatorVersion))
+		if err != nil {
+			return fmt.Errorf("failed to initialize simulator: %w", err)
+		}
+
+		load := func(hash string) (*simulator.SimulationRequest, error) {
+			resp, err := client.GetTransaction(cmd.Context(), hash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch transaction: %w", err)
+			}
+			entries, err := rpc.ExtractLedgerEntriesFromMeta(resp.ResultMetaXdr)
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s: could not extract ledger entries from metadata: %v\n", hash, err)
+			}
+			return &simulator.SimulationRequest{
+				EnvelopeXdr:    resp.EnvelopeXdr,
+				ResultMetaXdr:  resp.ResultMetaXdr,
+				LedgerEntries:  entries,
+				LedgerSequence: simulator.EntriesLedgerSequence(entries),
+			}, nil
+		}
+
+		out := cmd.OutOrStdout()
+		merged, profiled, err := profileTransactions(out, hashes, load, runner)
+		if err != nil {
+			return err
+		}
+		if profileMergedSVG == "" {
+			return nil
+		}
+		if profiled == 0 {
+			return fmt.Errorf("no transaction reported folded stacks, so there is nothing to merge; rebuild the simulator with \"erst sim build\"")
+		}
+
+		title := fmt.Sprintf("Merged profile of %d transactions", profiled)
+		svg := visualizer.RenderFlamegraphSVG(merged, title)
+		if err := os.WriteFile(profileMergedSVG, []byte(svg), 0644); err != nil {
+			return fmt.Errorf("failed to write flamegraph: %w", err)
+		}
+		fmt.Fprintf(out, "Merged flamegraph of %d transaction(s) written to %s\n", profiled, profileMergedSVG)
+		return nil
+	},
+}
+
+// profileTransactions simulates each hash with profiling enabled, prints a
+// line of resource usage per transaction and sums their folded stacks. It
+// returns the merged profile and how many transactions contributed to it;
+// a transaction without folded stacks is reported and left out.
+func profileTransactions(w io.Writer, hashes []string, load func(hash string) (*simulator.SimulationRequest, error), runner simulator.RunnerInterface) (visualizer.FoldedStacks, int, error) {
+	var folded []string
+	for _, hash := range hashes {
+		req, err := load(hash)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", hash, err)
+		}
+		req.Profile = true
+		resp, err := runner.Run(req)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: simulation failed: %w", hash, err)
+		}
+
+		fmt.Fprintf(w, "%s: %s", hash, resp.Status)
+		if b := resp.BudgetUsage; b != nil {
+			fmt.Fprintf(w, ", CPU %d instructions, memory %d bytes", b.CPUInstructions, b.MemoryBytes)
+		}
+		fmt.Fprintln(w)
+
+		if resp.FoldedStacks == "" {
+			fmt.Fprintf(w, "  note: the simulator reported no folded stacks for %s; it is left out of the merged flamegraph\n", hash)
+			continue
+		}
+		folded = append(folded, resp.FoldedStacks)
+	}
+
+	merged, err := visualizer.MergeFoldedStacks(folded...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to merge folded stacks: %w", err)
+	}
+	return merged, len(folded), nil
+}
+
+func init() {
+	profileCmd.Flags().StringVarP(&profileNetwork, "network", "n", string(rpc.Mainnet), "Stellar network to use (testnet, mainnet, futurenet)")
+	profileCmd.Flags().StringVar(&profileRPCURL, "rpc-url", "", "Custom RPC URL to fetch the transactions from")
+	profileCmd.Flags().StringVar(&profileSimPath, "sim-path", "", "Path to the erst-sim binary (default: auto-discovered)")
+	profileCmd.Flags().StringVar(&profileMergedSVG, "merged-flamegraph", "", "Write one flamegraph SVG summing the profiles of all transactions to this file")
+
+	requireInStrict(profileCmd, "network", "sim-path")
+	rootCmd.AddCommand(profileCmd)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadByHash(hash string) (*simulator.SimulationRequest, error) {
+	return &simulator.SimulationRequest{EnvelopeXdr: hash}, nil
+}
+
+func TestProfileTransactions_MergesFoldedStacks(t *testing.T) {
+	folded := map[string]string{
+		"aaa": "Total;CPU 1000\nTotal;Memory 200\n",
+		"bbb": "Total;CPU 500\nTotal;Memory 100\n",
+	}
+	runner := simulator.NewMockRunner(func(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+		assert.True(t, req.Profile, "profiling must be enabled")
+		return &simulator.SimulationResponse{
+			Status:       simulator.StatusSuccess,
+			BudgetUsage:  &simulator.BudgetUsage{CPUInstructions: 10, MemoryBytes: 20},
+			FoldedStacks: folded[req.EnvelopeXdr],
+		}, nil
+	})
+
+	var out bytes.Buffer
+	merged, profiled, err := profileTransactions(&out, []string{"aaa", "bbb"}, loadByHash, runner)
+	require.NoError(t, err)
+	assert.Equal(t, 2, profiled)
+	assert.Equal(t, int64(1500), merged["Total;CPU"])
+	assert.Equal(t, int64(300), merged["Total;Memory"])
+	assert.Contains(t, out.String(), "aaa: success, CPU 10 instructions, memory 20 bytes")
+	assert.Contains(t, out.String(), "bbb: success")
+}
+
+func TestProfileTransactions_SkipsResponsesWithoutFoldedStacks(t *testing.T) {
+	runner := simulator.NewMockRunner(func(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+		resp := &simulator.SimulationResponse{Status: simulator.StatusSuccess, Flamegraph: "<svg/>"}
+		if req.EnvelopeXdr == "new" {
+			resp.FoldedStacks = "Total;CPU 7\n"
+		}
+		return resp, nil
+	})
+
+	var out bytes.Buffer
+	merged, profiled, err := profileTransactions(&out, []string{"old", "new"}, loadByHash, runner)
+	require.NoError(t, err)
+	assert.Equal(t, 1, profiled)
+	assert.Equal(t, int64(7), merged.Total())
+	assert.Contains(t, out.String(), "no folded stacks for old")
+}
+
+func TestProfileTransactions_StopsOnFailure(t *testing.T) {
+	runner := simulator.NewMockRunner(func(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+		return nil, fmt.Errorf("simulator crashed")
+	})
+
+	_, _, err := profileTransactions(&bytes.Buffer{}, []string{"aaa"}, loadByHash, runner)
+	assert.ErrorContains(t, err, "aaa: simulation failed: simulator crashed")
+}
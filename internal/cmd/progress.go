@@ -0,0 +1,66 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Phases reported by --progress, in the order a debug run goes through them.
+const (
+	PhaseFetching   = "fetching"
+	PhaseSimulating = "simulating"
+	PhaseParsing    = "parsing"
+	PhaseDone       = "done"
+)
+
+// ProgressEvent is a single line of --progress ndjson output.
+type ProgressEvent struct {
+	Phase     string `json:"phase"`
+	ElapsedMs int64  `json:"elapsed_ms"`
+}
+
+// ProgressReporter writes one JSON object per phase so tools wrapping erst
+// can follow a long run without scraping free-text output. A nil reporter
+// discards events.
+type ProgressReporter struct {
+	mu    sync.Mutex
+	w     io.Writer
+	start time.Time
+}
+
+// NewProgressReporter returns a reporter for the given --progress mode. An
+// empty mode disables progress reporting and returns nil.
+func NewProgressReporter(mode string, w io.Writer) (*ProgressReporter, error) {
+	switch mode {
+	case "":
+		return nil, nil
+	case "ndjson":
+		return &ProgressReporter{w: w, start: time.Now()}, nil
+	default:
+		return nil, fmt.Errorf("invalid --progress %q: must be ndjson", mode)
+	}
+}
+
+// Phase records that the run has entered the named phase.
+func (p *ProgressReporter) Phase(name string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	line, err := json.Marshal(ProgressEvent{
+		Phase:     name,
+		ElapsedMs: time.Since(p.start).Milliseconds(),
+	})
+	if err != nil {
+		return
+	}
+	_, _ = p.w.Write(append(line, '\n'))
+}
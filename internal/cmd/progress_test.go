@@ -0,0 +1,51 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressReporter_EmitsPhasesInOrder(t *testing.T) {
+	var buf bytes.Buffer
+	progress, err := NewProgressReporter("ndjson", &buf)
+	require.NoError(t, err)
+
+	phases := []string{PhaseFetching, PhaseSimulating, PhaseParsing, PhaseDone}
+	for _, phase := range phases {
+		progress.Phase(phase)
+	}
+
+	var got []ProgressEvent
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var ev ProgressEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &ev), "line %q is not JSON", scanner.Text())
+		got = append(got, ev)
+	}
+	require.Len(t, got, len(phases))
+
+	for i, ev := range got {
+		assert.Equal(t, phases[i], ev.Phase)
+		if i > 0 {
+			assert.GreaterOrEqual(t, ev.ElapsedMs, got[i-1].ElapsedMs)
+		}
+	}
+}
+
+func TestNewProgressReporter_Modes(t *testing.T) {
+	progress, err := NewProgressReporter("", &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Nil(t, progress)
+	progress.Phase(PhaseDone) // nil reporter is a no-op
+
+	_, err = NewProgressReporter("xml", &bytes.Buffer{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// compareProtocolsFlag lists the protocol versions --compare-protocols
+// simulates the transaction under.
+var compareProtocolsFlag []uint
+
+// validateCompareProtocols checks --compare-protocols. Versions the
+// simulator does not support are not an error here; they are reported in
+// the comparison table.
+func validateCompareProtocols() error {
+	if len(compareProtocolsFlag) == 0 {
+		return nil
+	}
+	if compareNetworkFlag != "" {
+		return fmt.Errorf("--compare-protocols cannot be combined with --compare-network")
+	}
+	for _, v := range compareProtocolsFlag {
+		if v == 0 || uint64(v) > math.MaxUint32 {
+			return fmt.Errorf("invalid compare-protocols: %d. Must be a protocol version such as 22", v)
+		}
+	}
+	return nil
+}
+
+func compareProtocolVersions() []uint32 {
+	versions := make([]uint32, len(compareProtocolsFlag))
+	for i, v := range compareProtocolsFlag {
+		versions[i] = uint32(v)
+	}
+	return versions
+}
+
+// printProtocolBudgets prints one row of budget usage per protocol version.
+// Versions without a budget show "-" and the reason in the status column.
+func printProtocolBudgets(w io.Writer, budgets []simulator.ProtocolBudget) {
+	fmt.Fprintln(w, "\nBudget by protocol version:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROTOCOL\tCPU INSTRUCTIONS\tMEMORY BYTES\tOPERATIONS\tSTATUS")
+	for _, b := range budgets {
+		cpu, mem, ops := "-", "-", "-"
+		if u := b.BudgetUsage; u != nil {
+			cpu, mem, ops = budgetInstructions(u.CPUInstructions), budgetBytes(u.MemoryBytes), fmt.Sprint(u.OperationsCount)
+		}
+		status := b.Status
+		if b.Error != "" {
+			status += ": " + b.Error
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", b.Version, cpu, mem, ops, status)
+	}
+	_ = tw.Flush()
+}
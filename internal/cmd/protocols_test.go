@@ -0,0 +1,46 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintProtocolBudgets(t *testing.T) {
+	prev := rawBudgetFlag
+	defer func() { rawBudgetFlag = prev }()
+	rawBudgetFlag = true
+
+	var buf bytes.Buffer
+	printProtocolBudgets(&buf, []simulator.ProtocolBudget{
+		{Version: 21, Status: "success", BudgetUsage: &simulator.BudgetUsage{CPUInstructions: 1500, MemoryBytes: 2048, OperationsCount: 3}},
+		{Version: 99, Status: "error", Error: "unsupported protocol version: 99"},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "PROTOCOL  CPU INSTRUCTIONS  MEMORY BYTES  OPERATIONS  STATUS")
+	assert.Contains(t, out, "21        1500              2048          3           success")
+	assert.Contains(t, out, "99        -                 -             -           error: unsupported protocol version: 99")
+}
+
+func TestValidateCompareProtocols(t *testing.T) {
+	prevVersions, prevNetwork := compareProtocolsFlag, compareNetworkFlag
+	defer func() { compareProtocolsFlag, compareNetworkFlag = prevVersions, prevNetwork }()
+
+	compareNetworkFlag = ""
+	compareProtocolsFlag = []uint{20, 21, 22}
+	assert.NoError(t, validateCompareProtocols())
+	assert.Equal(t, []uint32{20, 21, 22}, compareProtocolVersions())
+
+	compareProtocolsFlag = []uint{0}
+	assert.Error(t, validateCompareProtocols())
+
+	compareProtocolsFlag = []uint{22}
+	compareNetworkFlag = "testnet"
+	assert.Error(t, validateCompareProtocols())
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// repeatStats summarizes repeated runs of one simulation request.
+type repeatStats struct {
+	Runs   int
+	Min    time.Duration
+	Median time.Duration
+	Max    time.Duration
+	// Mismatched lists the runs (1-based) whose response differs from
+	// the first one.
+	Mismatched []int
+}
+
+// Deterministic reports whether every run produced the same response.
+func (s repeatStats) Deterministic() bool {
+	return len(s.Mismatched) == 0
+}
+
+// runRepeated runs req n times, timing each run and comparing every
+// response against the first. The first response is returned; an error
+// from any run stops the loop.
+func runRepeated(runner simulator.RunnerInterface, req *simulator.SimulationRequest, n int) (*simulator.SimulationResponse, repeatStats, error) {
+	if n < 1 {
+		n = 1
+	}
+
+	var first *simulator.SimulationResponse
+	var firstPrint string
+	stats := repeatStats{Runs: n}
+	durations := make([]time.Duration, 0, n)
+
+	for i := 0; i < n; i++ {
+		reqCopy := *req
+		start := time.Now()
+		resp, err := runner.Run(&reqCopy)
+		durations = append(durations, time.Since(start))
+		if err != nil {
+			return nil, stats, fmt.Errorf("run %d of %d: %w", i+1, n, err)
+		}
+
+		fp, err := responseFingerprint(resp)
+		if err != nil {
+			return nil, stats, err
+		}
+		if i == 0 {
+			first, firstPrint = resp, fp
+		} else if fp != firstPrint {
+			stats.Mismatched = append(stats.Mismatched, i+1)
+		}
+	}
+
+	stats.Min, stats.Median, stats.Max = summarizeDurations(durations)
+	return first, stats, nil
+}
+
+// responseFingerprint renders resp for comparison. Timings differ on every
+// run by nature and are left out.
+func responseFingerprint(resp *simulator.SimulationResponse) (string, error) {
+	stripped := *resp
+	stripped.Timings = nil
+	b, err := json.Marshal(&stripped)
+	if err != nil {
+		return "", fmt.Errorf("failed to compare simulation responses: %w", err)
+	}
+	return string(b), nil
+}
+
+// summarizeDurations returns the minimum, median and maximum of ds. The
+// median of an even count is the mean of the two middle values.
+func summarizeDurations(ds []time.Duration) (shortest, median, longest time.Duration) {
+	if len(ds) == 0 {
+		return 0, 0, 0
+	}
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	median = sorted[mid]
+	if len(sorted)%2 == 0 {
+		median = (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[0], median, sorted[len(sorted)-1]
+}
+
+// printRepeatStats writes the timing summary and determinism verdict.
+func printRepeatStats(w io.Writer, s repeatStats) {
+	fmt.Fprintf(w, "Simulation repeated %d times: min %s, median %s, max %s\n",
+		s.Runs, s.Min.Round(time.Microsecond), s.Median.Round(time.Microsecond), s.Max.Round(time.Microsecond))
+	if s.Deterministic() {
+		fmt.Fprintln(w, "All runs produced identical responses.")
+		return
+	}
+	runs := make([]string, len(s.Mismatched))
+	for i, r := range s.Mismatched {
+		runs[i] = fmt.Sprint(r)
+	}
+	fmt.Fprintf(w, "Nondeterministic simulation: run(s) %s differ from run 1.\n", strings.Join(runs, ", "))
+}
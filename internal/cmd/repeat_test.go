@@ -0,0 +1,95 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunRepeated_InvokesRunnerNTimes(t *testing.T) {
+	calls := 0
+	runner := simulator.NewMockRunner(func(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+		calls++
+		return &simulator.SimulationResponse{
+			Status:      simulator.StatusSuccess,
+			BudgetUsage: &simulator.BudgetUsage{CPUInstructions: 1000},
+			// Timings vary between runs and must not count as a difference.
+			Timings: &simulator.SimulationTimings{},
+		}, nil
+	})
+
+	resp, stats, err := runRepeated(runner, &simulator.SimulationRequest{EnvelopeXdr: "AAAA"}, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, calls)
+	assert.Equal(t, simulator.StatusSuccess, resp.Status)
+	assert.Equal(t, 5, stats.Runs)
+	assert.True(t, stats.Deterministic())
+	assert.LessOrEqual(t, stats.Min, stats.Median)
+	assert.LessOrEqual(t, stats.Median, stats.Max)
+}
+
+func TestRunRepeated_FlagsNondeterminism(t *testing.T) {
+	calls := 0
+	runner := simulator.NewMockRunner(func(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+		calls++
+		cpu := uint64(1000)
+		if calls == 3 {
+			cpu = 1001
+		}
+		return &simulator.SimulationResponse{
+			Status:      simulator.StatusSuccess,
+			BudgetUsage: &simulator.BudgetUsage{CPUInstructions: cpu},
+		}, nil
+	})
+
+	_, stats, err := runRepeated(runner, &simulator.SimulationRequest{EnvelopeXdr: "AAAA"}, 4)
+	require.NoError(t, err)
+	assert.False(t, stats.Deterministic())
+	assert.Equal(t, []int{3}, stats.Mismatched)
+
+	var out bytes.Buffer
+	printRepeatStats(&out, stats)
+	assert.Contains(t, out.String(), "repeated 4 times")
+	assert.Contains(t, out.String(), "run(s) 3 differ from run 1")
+}
+
+func TestRunRepeated_StopsOnError(t *testing.T) {
+	calls := 0
+	runner := simulator.NewMockRunner(func(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+		calls++
+		if calls == 2 {
+			return nil, fmt.Errorf("simulator crashed")
+		}
+		return &simulator.SimulationResponse{Status: simulator.StatusSuccess}, nil
+	})
+
+	_, _, err := runRepeated(runner, &simulator.SimulationRequest{EnvelopeXdr: "AAAA"}, 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "run 2 of 3")
+	assert.Equal(t, 2, calls)
+}
+
+func TestSummarizeDurations(t *testing.T) {
+	ms := time.Millisecond
+
+	lo, mid, hi := summarizeDurations([]time.Duration{30 * ms, 10 * ms, 20 * ms})
+	assert.Equal(t, 10*ms, lo)
+	assert.Equal(t, 20*ms, mid)
+	assert.Equal(t, 30*ms, hi)
+
+	lo, mid, hi = summarizeDurations([]time.Duration{40 * ms, 10 * ms, 20 * ms, 30 * ms})
+	assert.Equal(t, 10*ms, lo)
+	assert.Equal(t, 25*ms, mid, "even counts average the middle pair")
+	assert.Equal(t, 40*ms, hi)
+
+	lo, mid, hi = summarizeDurations(nil)
+	assert.Zero(t, lo+mid+hi)
+}
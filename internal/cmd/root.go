@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"github.com/dotandev/hintents/internal/localization"
+	"github.com/dotandev/hintents/internal/rpc"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +16,11 @@ var (
 	ProfileFlag   bool
 )
 
+// rpcClients is shared by every command run in this process, so commands
+// that resolve to the same RPC configuration reuse one client and its
+// connection pool, retry and concurrency limits.
+var rpcClients = rpc.NewClientProvider()
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "erst",
@@ -39,7 +45,13 @@ Examples:
 
 Get started with 'erst debug --help' or visit the documentation.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		return localization.LoadTranslations()
+		if err := localization.LoadTranslations(); err != nil {
+			return err
+		}
+		if err := checkStrictCommand(cmd); err != nil {
+			return err
+		}
+		return setupTee(cmd)
 	},
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -48,7 +60,11 @@ Get started with 'erst debug --help' or visit the documentation.`,
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if closeErr := closeTee(); err == nil {
+		err = closeErr
+	}
+	return err
 }
 
 func init() {
@@ -74,5 +90,32 @@ func init() {
 		"Enable CPU/Memory profiling and generate a flamegraph SVG",
 	)
 
+	rootCmd.PersistentFlags().BoolVar(
+		&strictFlag,
+		"strict",
+		false,
+		"Disable implicit discovery (simulator lookup, ERST_* env vars, config file, update check, default network)",
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&strictFlag,
+		"hermetic",
+		false,
+		"Alias for --strict",
+	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&noUpdateCheckFlag,
+		"no-update-check",
+		false,
+		"Skip the background check for a newer erst release",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&teeFlag,
+		"tee",
+		"",
+		"Also write command output to this file",
+	)
+
 	// Register commands
 }
@@ -0,0 +1,56 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPCClientsSharedAcrossCommands(t *testing.T) {
+	var mu sync.Mutex
+	var remotes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		remotes = append(remotes, r.RemoteAddr)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"entries":[]}}`))
+	}))
+	defer server.Close()
+
+	prev := rpcClients
+	rpcClients = rpc.NewClientProvider()
+	t.Cleanup(func() {
+		rpcClients = prev
+		rootCmd.SetArgs(nil)
+		snapshotContractFlag, snapshotOutFlag, snapshotRPCURLFlag = "", "", ""
+		snapshotNetworkFlag = string(rpc.Mainnet)
+	})
+
+	args := []string{
+		"snapshot",
+		"--contract", "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE",
+		"--network", "testnet",
+		"--rpc-url", server.URL,
+		"--out", filepath.Join(t.TempDir(), "state.json"),
+	}
+	for i := 0; i < 2; i++ {
+		rootCmd.SetArgs(args)
+		// The RPC knows no such contract; only the connection matters here.
+		assert.ErrorContains(t, Execute(), "not found")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, remotes, 2)
+	assert.Equal(t, remotes[0], remotes[1], "second command should reuse the first command's connection")
+}
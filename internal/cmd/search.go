@@ -5,31 +5,44 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/dotandev/hintents/internal/db"
 	"github.com/spf13/cobra"
 )
 
 var (
-	searchErrorFlag string
-	searchEventFlag string
-	searchTxFlag    string
-	searchLimitFlag int
+	searchErrorFlag  string
+	searchEventFlag  string
+	searchTxFlag     string
+	searchSigFlag    string
+	searchTextFlag   string
+	searchLimitFlag  int
+	searchOffsetFlag int
+	searchPageFlag   int
+	searchSortFlag   string
+	searchDescFlag   bool
 )
 
 var searchCmd = &cobra.Command{
 	Use:   "search",
 	Short: "Search through saved debugging sessions",
 	Long: `Search through the history of debugging sessions to find past transactions,
-errors, or events. Supports regex patterns for flexible matching.
+errors, or events. Supports regex patterns for flexible matching. Every
+'erst debug' run is added to the history.
 
 You can search by:
   • Transaction hash (exact match)
   • Error message patterns (regex)
   • Event patterns (regex)
+  • Event topic signature (exact match)
+  • A phrase anywhere in the error, events or logs (case-insensitive)
   • Combine multiple filters
 
-Results are ordered by timestamp (most recent first) and limited by --limit flag.`,
+Results are ordered by timestamp (most recent first) unless --sort is given,
+and limited by --limit flag.
+Use --offset or --page to step through larger result sets.`,
 	Example: `  # Search for specific transaction
   erst search --tx abc123...def789
 
@@ -39,51 +52,107 @@ Results are ordered by timestamp (most recent first) and limited by --limit flag
   # Search for contract events
   erst search --event "transfer|mint"
 
+  # Find a phrase that appeared in a log line
+  erst search --text "out of fuel"
+
+  # Find sessions that emitted a specific event type
+  erst search --signature 3f2a9c1b7d4e8a60
+
   # Combine filters and limit results
-  erst search --error "panic" --limit 5`,
+  erst search --error "panic" --limit 5
+
+  # Show the second page of 10 results
+  erst search --error "panic" --page 2
+
+  # List sessions grouped by network, oldest first
+  erst search --sort network
+
+  # Sort by status in descending order
+  erst search --sort status --desc`,
 	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if searchSortFlag != "" && !slices.Contains(db.SortFields(), strings.ToLower(searchSortFlag)) {
+			return fmt.Errorf("invalid sort field: %s. Must be one of: %s", searchSortFlag, strings.Join(db.SortFields(), ", "))
+		}
+		if searchOffsetFlag < 0 {
+			return fmt.Errorf("invalid offset: %d. Must be zero or greater", searchOffsetFlag)
+		}
+		if cmd.Flags().Changed("page") {
+			if cmd.Flags().Changed("offset") {
+				return fmt.Errorf("--page and --offset cannot be used together")
+			}
+			if searchPageFlag < 1 {
+				return fmt.Errorf("invalid page: %d. Must be 1 or greater", searchPageFlag)
+			}
+			if searchLimitFlag <= 0 {
+				return fmt.Errorf("--page requires a positive --limit")
+			}
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		store, err := db.InitDB()
 		if err != nil {
 			return fmt.Errorf("Error: failed to initialize session database: %w", err)
 		}
 
+		offset := searchOffsetFlag
+		if cmd.Flags().Changed("page") {
+			offset = (searchPageFlag - 1) * searchLimitFlag
+		}
+
 		params := db.SearchParams{
-			TxHash:     searchTxFlag,
-			ErrorRegex: searchErrorFlag,
-			EventRegex: searchEventFlag,
-			Limit:      searchLimitFlag,
+			TxHash:         searchTxFlag,
+			ErrorRegex:     searchErrorFlag,
+			EventRegex:     searchEventFlag,
+			EventSignature: searchSigFlag,
+			FullText:       searchTextFlag,
+			Limit:          searchLimitFlag,
+			Offset:         offset,
+			SortBy:         searchSortFlag,
+			SortDesc:       searchDescFlag,
 		}
 
-		sessions, err := store.SearchSessions(params)
+		sessions, total, err := store.SearchSessions(params)
 		if err != nil {
 			return fmt.Errorf("Error: search failed: %w", err)
 		}
 
+		w := cmd.OutOrStdout()
+		if total == 0 {
+			fmt.Fprintln(w, "No matching sessions found.")
+			return nil
+		}
 		if len(sessions) == 0 {
-			fmt.Println("No matching sessions found.")
+			fmt.Fprintf(w, "Found %d matching sessions, none at offset %d.\n", total, offset)
 			return nil
 		}
 
-		fmt.Printf("Found %d matching sessions:\n", len(sessions))
+		fmt.Fprintf(w, "Found %d matching sessions, showing %d-%d:\n", total, offset+1, offset+len(sessions))
 		for _, s := range sessions {
-			fmt.Println("--------------------------------------------------")
-			fmt.Printf("ID: %d\n", s.ID)
-			fmt.Printf("Time: %s\n", s.Timestamp.Format("2006-01-02 15:04:05"))
-			fmt.Printf("Tx Hash: %s\n", s.TxHash)
-			fmt.Printf("Network: %s\n", s.Network)
-			fmt.Printf("Status: %s\n", s.Status)
+			fmt.Fprintln(w, "--------------------------------------------------")
+			fmt.Fprintf(w, "ID: %d\n", s.ID)
+			fmt.Fprintf(w, "Time: %s\n", s.Timestamp.Format("2006-01-02 15:04:05"))
+			fmt.Fprintf(w, "Tx Hash: %s\n", s.TxHash)
+			fmt.Fprintf(w, "Network: %s\n", s.Network)
+			fmt.Fprintf(w, "Status: %s\n", s.Status)
+			if len(s.MatchedFields) > 0 {
+				fmt.Fprintf(w, "Matched In: %s\n", strings.Join(s.MatchedFields, ", "))
+			}
 			if s.ErrorMsg != "" {
-				fmt.Printf("Error: %s\n", s.ErrorMsg)
+				fmt.Fprintf(w, "Error: %s\n", s.ErrorMsg)
 			}
 			if len(s.Events) > 0 {
-				fmt.Println("Events:")
+				fmt.Fprintln(w, "Events:")
 				for _, e := range s.Events {
-					fmt.Printf("  - %s\n", e)
+					fmt.Fprintf(w, "  - %s\n", e)
 				}
 			}
+			if len(s.EventSignatures) > 0 {
+				fmt.Fprintf(w, "Event Signatures: %s\n", strings.Join(s.EventSignatures, ", "))
+			}
 		}
-		fmt.Println("--------------------------------------------------")
+		fmt.Fprintln(w, "--------------------------------------------------")
 
 		return nil
 	},
@@ -92,8 +161,14 @@ Results are ordered by timestamp (most recent first) and limited by --limit flag
 func init() {
 	searchCmd.Flags().StringVar(&searchErrorFlag, "error", "", "Regex pattern to match error messages")
 	searchCmd.Flags().StringVar(&searchEventFlag, "event", "", "Regex pattern to match events")
+	searchCmd.Flags().StringVar(&searchSigFlag, "signature", "", "Event topic signature to match")
+	searchCmd.Flags().StringVar(&searchTextFlag, "text", "", "Phrase to find in error messages, events and logs")
 	searchCmd.Flags().StringVar(&searchTxFlag, "tx", "", "Transaction hash to search for")
 	searchCmd.Flags().IntVar(&searchLimitFlag, "limit", 10, "Maximum number of results to return")
+	searchCmd.Flags().IntVar(&searchOffsetFlag, "offset", 0, "Number of matching sessions to skip")
+	searchCmd.Flags().StringVar(&searchSortFlag, "sort", "", "Sort results by field: timestamp, network, status (ascending unless --desc)")
+	searchCmd.Flags().BoolVar(&searchDescFlag, "desc", false, "Sort in descending order (used with --sort)")
+	searchCmd.Flags().IntVar(&searchPageFlag, "page", 1, "Page of results to show, counting from 1 (uses --limit as the page size)")
 
 	rootCmd.AddCommand(searchCmd)
 }
@@ -4,28 +4,56 @@
 package cmd
 
 import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/dotandev/hintents/internal/db"
 	"github.com/dotandev/hintents/internal/session"
 	"github.com/spf13/cobra"
 )
 
 var (
-	sessionIDFlag string
+	sessionIDFlag           string
+	sessionFollowFlag       bool
+	sessionTailIntervalFlag time.Duration
+	sessionLimitFlag        int
+	sessionNetworkFlag      string
+	sessionStatusFlag       string
+	sessionOutputFlag       string
+	sessionHistoryFlag      bool
+	sessionOlderThanFlag    string
+	sessionExportOutFlag    string
+	sessionDiffOutputFlag   string
 )
 
-// currentSessionData holds the active session context from debug command
-var currentSessionData *session.SessionData
+// currentSessionData holds the active session context from debug command.
+// It is guarded by currentSessionMu because the interrupt handler reads it
+// from another goroutine.
+var (
+	currentSessionData *session.SessionData
+	currentSessionMu   sync.Mutex
+)
 
 // SetCurrentSession stores the active session for later saving
 func SetCurrentSession(data *session.SessionData) {
+	currentSessionMu.Lock()
+	defer currentSessionMu.Unlock()
 	currentSessionData = data
 }
 
 // GetCurrentSession returns the active session if any
 func GetCurrentSession() *session.SessionData {
+	currentSessionMu.Lock()
+	defer currentSessionMu.Unlock()
 	return currentSessionData
 }
 
@@ -45,7 +73,12 @@ Available subcommands:
   save    - Save current session to disk
   resume  - Restore a saved session
   list    - View all saved sessions
-  delete  - Remove a saved session`,
+  tail    - Print new sessions as they are saved
+  delete  - Remove a saved session
+  export  - Write a saved session to a portable JSON file
+  import  - Load a session exported on another machine
+  diff    - Compare the simulations of two sessions
+  prune   - Remove old entries from the search history`,
 	Example: `  # Save current debug session
   erst session save
 
@@ -55,8 +88,18 @@ Available subcommands:
   # Resume a specific session
   erst session resume <session-id>
 
+  # Watch for new sessions
+  erst session tail
+
   # Delete a session
-  erst session delete <session-id>`,
+  erst session delete <session-id>
+
+  # Share a session with a teammate
+  erst session export <session-id> --out session.json
+  erst session import session.json
+
+  # Forget search history older than 30 days
+  erst session prune --older-than 30d`,
 }
 
 var sessionSaveCmd = &cobra.Command{
@@ -88,25 +131,8 @@ The session ID can be auto-generated or specified with --id flag.`,
 			data.ID = session.GenerateID(data.TxHash)
 		}
 
-		data.Status = "saved"
-		data.LastAccessAt = time.Now()
-
-		// Open session store
-		store, err := session.NewStore()
-		if err != nil {
-			return fmt.Errorf("failed to open session store: %w", err)
-		}
-		defer store.Close()
-
-		// Run cleanup before save
-		if err := store.Cleanup(ctx, session.DefaultTTL, session.DefaultMaxSessions); err != nil {
-			// Log but don't fail on cleanup errors
-			fmt.Fprintf(os.Stderr, "Warning: cleanup failed: %v\n", err)
-		}
-
-		// Save session
-		if err := store.Save(ctx, data); err != nil {
-			return fmt.Errorf("Error: failed to save session: %w", err)
+		if err := persistSession(ctx, data); err != nil {
+			return fmt.Errorf("Error: %w", err)
 		}
 
 		fmt.Printf("Session saved: %s\n", data.ID)
@@ -118,6 +144,30 @@ The session ID can be auto-generated or specified with --id flag.`,
 	},
 }
 
+// persistSession marks data as saved and writes it to the session store,
+// pruning expired and excess sessions first.
+func persistSession(ctx context.Context, data *session.SessionData) error {
+	data.Status = "saved"
+	data.LastAccessAt = time.Now()
+
+	store, err := session.NewStore()
+	if err != nil {
+		return fmt.Errorf("failed to open session store: %w", err)
+	}
+	defer store.Close()
+
+	// Run cleanup before save
+	if err := store.Cleanup(ctx, session.DefaultTTL, session.DefaultMaxSessions); err != nil {
+		// Log but don't fail on cleanup errors
+		fmt.Fprintf(os.Stderr, "Warning: cleanup failed: %v\n", err)
+	}
+
+	if err := store.Save(ctx, data); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
 var sessionResumeCmd = &cobra.Command{
 	Use:   "resume <session-id>",
 	Short: "Restore a saved debugging session",
@@ -203,13 +253,34 @@ var sessionListCmd = &cobra.Command{
 	Short: "List all saved debugging sessions",
 	Long: `List all saved debug sessions, ordered by most recently accessed.
 
-Displays session ID, network, last access time, and transaction hash.`,
+Displays session ID, network, last access time, and transaction hash.
+Filter with --network and --status, and use --output json or csv for
+machine-readable output.
+With --follow, keeps running and prints new sessions as they are saved.`,
 	Example: `  # List all sessions
-  erst session list`,
+  erst session list
+
+  # The last 10 testnet sessions as JSON
+  erst session list --network testnet --limit 10 --output json
+
+  # Export resumed sessions to a spreadsheet
+  erst session list --status resumed -o csv > sessions.csv
+
+  # List sessions, then keep watching for new ones
+  erst session list --follow`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
+		switch sessionOutputFlag {
+		case "table", "json", "csv":
+		default:
+			return fmt.Errorf("Error: invalid --output %q: must be table, json or csv", sessionOutputFlag)
+		}
+		if sessionFollowFlag && sessionOutputFlag != "table" {
+			return fmt.Errorf("Error: --follow only supports table output")
+		}
+
 		// Open session store
 		store, err := session.NewStore()
 		if err != nil {
@@ -223,31 +294,171 @@ Displays session ID, network, last access time, and transaction hash.`,
 		}
 
 		// List sessions
-		sessions, err := store.List(ctx, 50)
+		sessions, err := store.ListFiltered(ctx, session.ListParams{
+			Network: sessionNetworkFlag,
+			Status:  sessionStatusFlag,
+			Limit:   sessionLimitFlag,
+		})
 		if err != nil {
 			return fmt.Errorf("Error: failed to list sessions: %w", err)
 		}
 
+		if err := writeSessionList(cmd.OutOrStdout(), sessions, sessionOutputFlag); err != nil {
+			return err
+		}
+
+		if !sessionFollowFlag {
+			return nil
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), "\nWaiting for new sessions (Ctrl-C to stop)...")
+		return followSessions(ctx, store, sessionTailIntervalFlag, cmd.OutOrStdout())
+	},
+}
+
+// sessionSummary is the machine-readable form of a session list row.
+type sessionSummary struct {
+	ID           string    `json:"id"`
+	Network      string    `json:"network"`
+	Status       string    `json:"status"`
+	TxHash       string    `json:"tx_hash"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastAccessAt time.Time `json:"last_access_at"`
+}
+
+// writeSessionList renders sessions as a table, a JSON array or CSV.
+func writeSessionList(w io.Writer, sessions []*session.SessionData, format string) error {
+	summaries := make([]sessionSummary, 0, len(sessions))
+	for _, s := range sessions {
+		summaries = append(summaries, sessionSummary{
+			ID:           s.ID,
+			Network:      s.Network,
+			Status:       s.Status,
+			TxHash:       s.TxHash,
+			CreatedAt:    s.CreatedAt,
+			LastAccessAt: s.LastAccessAt,
+		})
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summaries)
+	case "csv":
+		cw := csv.NewWriter(w)
+		_ = cw.Write([]string{"id", "network", "status", "tx_hash", "created_at", "last_access_at"})
+		for _, s := range summaries {
+			_ = cw.Write([]string{
+				s.ID, s.Network, s.Status, s.TxHash,
+				s.CreatedAt.Format(time.RFC3339), s.LastAccessAt.Format(time.RFC3339),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
 		if len(sessions) == 0 {
-			fmt.Println("No saved sessions found.")
+			fmt.Fprintln(w, "No saved sessions found.")
 			return nil
 		}
+		fmt.Fprintf(w, "Saved sessions (%d):\n\n", len(sessions))
+		printSessionHeader(w)
+		for _, s := range sessions {
+			printSessionRow(w, s)
+		}
+		return nil
+	}
+}
+
+var sessionTailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Print new debugging sessions as they are saved",
+	Long: `Watch the session database and print each newly saved session as it arrives,
+similar to 'tail -f'. Only sessions saved after the command starts are shown.
 
-		fmt.Printf("Saved sessions (%d):\n\n", len(sessions))
-		fmt.Printf("%-20s %-12s %-20s %-66s\n", "ID", "Network", "Last Accessed", "Transaction Hash")
-		fmt.Println("--------------------------------------------------------------------------------")
+Press Ctrl-C to stop.`,
+	Example: `  # Watch for new sessions
+  erst session tail
+
+  # Poll less frequently
+  erst session tail --interval 5s`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := session.NewStore()
+		if err != nil {
+			return fmt.Errorf("Error: failed to open session store: %w", err)
+		}
+		defer store.Close()
+
+		fmt.Fprintln(cmd.OutOrStdout(), "Waiting for new sessions (Ctrl-C to stop)...")
+		return followSessions(cmd.Context(), store, sessionTailIntervalFlag, cmd.OutOrStdout())
+	},
+}
+
+// followSessions tails the store to w until the user interrupts the process.
+func followSessions(ctx context.Context, store *session.Store, interval time.Duration, w io.Writer) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cursor, err := store.LatestCursor(ctx)
+	if err != nil {
+		return fmt.Errorf("Error: failed to read session store: %w", err)
+	}
+
+	return tailSessions(ctx, store, cursor, interval, w)
+}
+
+// tailSessions polls the store for sessions created after cursor and writes
+// one row per new session to w. It returns nil once ctx is cancelled.
+func tailSessions(ctx context.Context, store *session.Store, cursor int64, interval time.Duration, w io.Writer) error {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	headerPrinted := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		sessions, next, err := store.ListAfter(ctx, cursor)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("Error: failed to poll sessions: %w", err)
+		}
+		cursor = next
 
 		for _, s := range sessions {
-			lastAccess := s.LastAccessAt.Format("2006-01-02 15:04")
-			txHash := s.TxHash
-			if len(txHash) > 64 {
-				txHash = txHash[:64] + "..."
+			if !headerPrinted {
+				printSessionHeader(w)
+				headerPrinted = true
 			}
-			fmt.Printf("%-20s %-12s %-20s %-66s\n", s.ID, s.Network, lastAccess, txHash)
+			printSessionRow(w, s)
 		}
+	}
+}
 
-		return nil
-	},
+func printSessionHeader(w io.Writer) {
+	fmt.Fprintf(w, "%-20s %-12s %-20s %-66s\n", "ID", "Network", "Last Accessed", "Transaction Hash")
+	fmt.Fprintln(w, "--------------------------------------------------------------------------------")
+}
+
+func printSessionRow(w io.Writer, s *session.SessionData) {
+	txHash := s.TxHash
+	if len(txHash) > 64 {
+		txHash = txHash[:64] + "..."
+	}
+	fmt.Fprintf(w, "%-20s %-12s %-20s %-66s\n", s.ID, s.Network, s.LastAccessAt.Format("2006-01-02 15:04"), txHash)
 }
 
 var sessionDeleteCmd = &cobra.Command{
@@ -255,14 +466,22 @@ var sessionDeleteCmd = &cobra.Command{
 	Short: "Remove a saved debugging session",
 	Long: `Delete a saved debug session by ID. This action cannot be undone.
 
-Use 'erst session list' to see available sessions.`,
+Use 'erst session list' to see available sessions. With --history, the ID
+is instead the numeric ID of a search history entry as shown by 'erst search'.`,
 	Example: `  # Delete a specific session
-  erst session delete abc123`,
+  erst session delete abc123
+
+  # Delete entry 42 from the search history
+  erst session delete --history 42`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 		sessionID := args[0]
 
+		if sessionHistoryFlag {
+			return deleteHistorySession(sessionID)
+		}
+
 		// Open session store
 		store, err := session.NewStore()
 		if err != nil {
@@ -280,13 +499,265 @@ Use 'erst session list' to see available sessions.`,
 	},
 }
 
+func deleteHistorySession(arg string) error {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return fmt.Errorf("Error: invalid history ID %q: must be a number", arg)
+	}
+
+	store, err := db.InitDB()
+	if err != nil {
+		return fmt.Errorf("Error: failed to initialize session database: %w", err)
+	}
+
+	if err := store.DeleteSession(id); err != nil {
+		return fmt.Errorf("Error: failed to delete history entry %d: %w", id, err)
+	}
+
+	fmt.Printf("History entry deleted: %d\n", id)
+	return nil
+}
+
+var sessionPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove old entries from the search history",
+	Long: `Delete every search history entry recorded before the --older-than window.
+These are the sessions shown by 'erst search' and 'erst stats'. Saved
+sessions expire on their own and are not affected.
+
+The window accepts Go durations ("36h") as well as days ("30d") and weeks ("2w").`,
+	Example: `  # Remove history older than 30 days
+  erst session prune --older-than 30d`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		window, err := parseSince(sessionOlderThanFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than: %w", err)
+		}
+
+		store, err := db.InitDB()
+		if err != nil {
+			return fmt.Errorf("Error: failed to initialize session database: %w", err)
+		}
+
+		removed, err := store.PruneSessions(time.Now().Add(-window))
+		if err != nil {
+			return fmt.Errorf("Error: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Pruned %d session(s) older than %s\n", removed, sessionOlderThanFlag)
+		return nil
+	},
+}
+
+var sessionExportCmd = &cobra.Command{
+	Use:   "export <session-id>",
+	Short: "Write a saved session to a portable JSON file",
+	Long: `Export a saved debug session, including the transaction XDR and the
+simulator request and response, as a JSON bundle. The bundle can be loaded
+on another machine with 'erst session import'.
+
+Without --out the bundle is written to stdout.`,
+	Example: `  # Export to a file
+  erst session export abc123 --out session.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessionID := args[0]
+
+		store, err := session.NewStore()
+		if err != nil {
+			return fmt.Errorf("Error: failed to open session store: %w", err)
+		}
+		defer store.Close()
+
+		data, err := store.Load(cmd.Context(), sessionID)
+		if err != nil {
+			return fmt.Errorf("Error: session '%s' not found or failed to load: %w", sessionID, err)
+		}
+
+		if sessionExportOutFlag == "" {
+			return session.WriteExport(cmd.OutOrStdout(), data)
+		}
+
+		f, err := os.Create(sessionExportOutFlag)
+		if err != nil {
+			return fmt.Errorf("Error: failed to create %s: %w", sessionExportOutFlag, err)
+		}
+		if err := session.WriteExport(f, data); err != nil {
+			f.Close()
+			return fmt.Errorf("Error: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("Error: failed to write %s: %w", sessionExportOutFlag, err)
+		}
+
+		fmt.Fprintf(cmd.ErrOrStderr(), "Session %s exported to %s\n", data.ID, sessionExportOutFlag)
+		return nil
+	},
+}
+
+var sessionImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Load a session exported on another machine",
+	Long: `Import a session bundle written by 'erst session export'. The session is
+stored under a new local ID so it never replaces an existing session, and
+can then be resumed with 'erst session resume'.
+
+Bundles written by a different session schema version are refused.`,
+	Example: `  # Import and resume a shared session
+  erst session import session.json
+  erst session resume <new-session-id>`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("Error: failed to open %s: %w", args[0], err)
+		}
+		defer f.Close()
+
+		data, err := session.ReadExport(f)
+		if err != nil {
+			return fmt.Errorf("Error: cannot import %s: %w", args[0], err)
+		}
+
+		store, err := session.NewStore()
+		if err != nil {
+			return fmt.Errorf("Error: failed to open session store: %w", err)
+		}
+		defer store.Close()
+
+		id, err := store.Import(cmd.Context(), data)
+		if err != nil {
+			return fmt.Errorf("Error: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Session imported: %s\n", id)
+		fmt.Fprintf(cmd.OutOrStdout(), "  Transaction: %s\n", data.TxHash)
+		fmt.Fprintf(cmd.OutOrStdout(), "  Network: %s\n", data.Network)
+		return nil
+	},
+}
+
+var sessionDiffCmd = &cobra.Command{
+	Use:   "diff <session-id> <session-id>",
+	Short: "Compare the simulations of two sessions",
+	Long: `Compare the simulation results stored in two saved sessions, for example the
+same transaction replayed against different ledger states or protocol versions.
+
+Reports changes in status, error, events, logs and budget usage. Events and
+logs present only in the second session are marked "+", those present only in
+the first "-".`,
+	Example: `  # Compare two replays of a transaction
+  erst session diff abc123-1700000000 abc123-1700003600
+
+  # Machine-readable diff
+  erst session diff abc123-1700000000 abc123-1700003600 -o json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch sessionDiffOutputFlag {
+		case "text", "json":
+		default:
+			return fmt.Errorf("Error: invalid --output %q: must be text or json", sessionDiffOutputFlag)
+		}
+
+		store, err := session.NewStore()
+		if err != nil {
+			return fmt.Errorf("Error: failed to open session store: %w", err)
+		}
+		defer store.Close()
+
+		sessions := make([]*session.SessionData, len(args))
+		for i, id := range args {
+			data, err := store.Load(cmd.Context(), id)
+			if err != nil {
+				return fmt.Errorf("Error: session '%s' not found or failed to load: %w", id, err)
+			}
+			sessions[i] = data
+		}
+
+		diff, err := session.Diff(sessions[0], sessions[1])
+		if err != nil {
+			return fmt.Errorf("Error: %w", err)
+		}
+
+		if sessionDiffOutputFlag == "json" {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(diff)
+		}
+		printSessionDiff(cmd.OutOrStdout(), diff)
+		return nil
+	},
+}
+
+func printSessionDiff(w io.Writer, d *session.SessionDiff) {
+	fmt.Fprintf(w, "Comparing %s -> %s\n", d.A, d.B)
+	if d.Empty() {
+		fmt.Fprintln(w, "\nNo differences.")
+		return
+	}
+
+	if d.Status != nil {
+		fmt.Fprintf(w, "\nStatus: %s -> %s\n", d.Status.From, d.Status.To)
+	}
+	if d.Error != nil {
+		fmt.Fprintf(w, "\nError:\n  - %s\n  + %s\n", orNone(d.Error.From), orNone(d.Error.To))
+	}
+	printAddedRemoved(w, "Events", d.EventsAdded, d.EventsRemoved)
+	printAddedRemoved(w, "Logs", d.LogsAdded, d.LogsRemoved)
+	if len(d.Budget) > 0 {
+		fmt.Fprintln(w, "\nBudget:")
+		for _, b := range d.Budget {
+			fmt.Fprintf(w, "  %-18s %d -> %d (%+d)\n", b.Metric, b.From, b.To, b.Delta)
+		}
+	}
+}
+
+func printAddedRemoved(w io.Writer, title string, added, removed []string) {
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s (+%d, -%d):\n", title, len(added), len(removed))
+	for _, s := range removed {
+		fmt.Fprintf(w, "  - %s\n", s)
+	}
+	for _, s := range added {
+		fmt.Fprintf(w, "  + %s\n", s)
+	}
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
 func init() {
 	sessionSaveCmd.Flags().StringVar(&sessionIDFlag, "id", "", "Custom session ID (default: auto-generated)")
 
+	sessionListCmd.Flags().IntVar(&sessionLimitFlag, "limit", 50, "Maximum number of sessions to list")
+	sessionListCmd.Flags().StringVar(&sessionNetworkFlag, "network", "", "Only list sessions for this network")
+	sessionListCmd.Flags().StringVar(&sessionStatusFlag, "status", "", "Only list sessions with this status (active, saved, resumed)")
+	sessionListCmd.Flags().StringVarP(&sessionOutputFlag, "output", "o", "table", "Output format: table, json or csv")
+	sessionListCmd.Flags().BoolVarP(&sessionFollowFlag, "follow", "f", false, "Keep running and print new sessions as they are saved")
+	sessionListCmd.Flags().DurationVar(&sessionTailIntervalFlag, "interval", time.Second, "Polling interval when following")
+	sessionTailCmd.Flags().DurationVar(&sessionTailIntervalFlag, "interval", time.Second, "Polling interval for new sessions")
+	sessionDeleteCmd.Flags().BoolVar(&sessionHistoryFlag, "history", false, "Delete a search history entry by its numeric ID")
+	sessionPruneCmd.Flags().StringVar(&sessionOlderThanFlag, "older-than", "", "Remove entries older than this window, e.g. 30d")
+	_ = sessionPruneCmd.MarkFlagRequired("older-than")
+	sessionDiffCmd.Flags().StringVarP(&sessionDiffOutputFlag, "output", "o", "text", "Output format: text or json")
+	sessionExportCmd.Flags().StringVar(&sessionExportOutFlag, "out", "", "File to write the bundle to (default: stdout)")
+
 	sessionCmd.AddCommand(sessionSaveCmd)
 	sessionCmd.AddCommand(sessionResumeCmd)
 	sessionCmd.AddCommand(sessionListCmd)
+	sessionCmd.AddCommand(sessionTailCmd)
 	sessionCmd.AddCommand(sessionDeleteCmd)
+	sessionCmd.AddCommand(sessionPruneCmd)
+	sessionCmd.AddCommand(sessionExportCmd)
+	sessionCmd.AddCommand(sessionImportCmd)
+	sessionCmd.AddCommand(sessionDiffCmd)
 
 	rootCmd.AddCommand(sessionCmd)
 }
@@ -0,0 +1,324 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/session"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer guards a bytes.Buffer so the test can read while tailSessions writes.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestTailSessions_PrintsNewSessions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := session.NewStore()
+	require.NoError(t, err)
+	defer store.Close()
+
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, &session.SessionData{
+		ID:      "existing-session",
+		Network: "testnet",
+		TxHash:  "oldhash",
+		Status:  "saved",
+	}))
+
+	cursor, err := store.LatestCursor(ctx)
+	require.NoError(t, err)
+
+	tailCtx, cancel := context.WithCancel(ctx)
+	out := &syncBuffer{}
+	done := make(chan error, 1)
+	go func() {
+		done <- tailSessions(tailCtx, store, cursor, 10*time.Millisecond, out)
+	}()
+
+	require.NoError(t, store.Save(ctx, &session.SessionData{
+		ID:      "new-session",
+		Network: "testnet",
+		TxHash:  "newhash",
+		Status:  "saved",
+	}))
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(out.String(), "new-session")
+	}, 2*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	require.NotContains(t, out.String(), "existing-session")
+}
+
+func TestListAfter_CursorSurvivesDeletes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := session.NewStore()
+	require.NoError(t, err)
+	defer store.Close()
+
+	ctx := context.Background()
+	for _, id := range []string{"first", "second"} {
+		require.NoError(t, store.Save(ctx, &session.SessionData{ID: id, Network: "testnet", TxHash: id, Status: "saved"}))
+	}
+	cursor, err := store.LatestCursor(ctx)
+	require.NoError(t, err)
+
+	// Deleting the newest session must not hand its cursor to the next one.
+	require.NoError(t, store.Delete(ctx, "second"))
+	require.NoError(t, store.Save(ctx, &session.SessionData{ID: "third", Network: "testnet", TxHash: "third", Status: "saved"}))
+
+	sessions, next, err := store.ListAfter(ctx, cursor)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, "third", sessions[0].ID)
+	require.Greater(t, next, cursor)
+
+	// Re-saving an existing session does not move it past the cursor.
+	require.NoError(t, store.Save(ctx, &session.SessionData{ID: "first", Network: "testnet", TxHash: "first", Status: "resumed"}))
+	sessions, _, err = store.ListAfter(ctx, next)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
+
+func seedSessions(t *testing.T) *session.Store {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := session.NewStore()
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	ctx := context.Background()
+	for _, s := range []*session.SessionData{
+		{ID: "tn-saved", Network: "testnet", Status: "saved", TxHash: "hash1"},
+		{ID: "tn-resumed", Network: "testnet", Status: "resumed", TxHash: "hash2"},
+		{ID: "mn-saved", Network: "mainnet", Status: "saved", TxHash: "hash3"},
+	} {
+		require.NoError(t, store.Save(ctx, s))
+	}
+	return store
+}
+
+func sessionIDs(sessions []*session.SessionData) []string {
+	ids := make([]string, 0, len(sessions))
+	for _, s := range sessions {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+func TestListFiltered(t *testing.T) {
+	store := seedSessions(t)
+	ctx := context.Background()
+
+	all, err := store.ListFiltered(ctx, session.ListParams{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+
+	testnet, err := store.ListFiltered(ctx, session.ListParams{Network: "testnet"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"tn-saved", "tn-resumed"}, sessionIDs(testnet))
+
+	saved, err := store.ListFiltered(ctx, session.ListParams{Network: "testnet", Status: "saved"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"tn-saved"}, sessionIDs(saved))
+
+	limited, err := store.ListFiltered(ctx, session.ListParams{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, limited, 2)
+}
+
+func TestWriteSessionList_Formats(t *testing.T) {
+	store := seedSessions(t)
+	sessions, err := store.ListFiltered(context.Background(), session.ListParams{Network: "testnet"})
+	require.NoError(t, err)
+
+	var table bytes.Buffer
+	require.NoError(t, writeSessionList(&table, sessions, "table"))
+	require.Contains(t, table.String(), "Saved sessions (2):")
+	require.Contains(t, table.String(), "tn-resumed")
+	require.NotContains(t, table.String(), "mn-saved")
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, writeSessionList(&jsonOut, sessions, "json"))
+	var decoded []sessionSummary
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &decoded))
+	require.Len(t, decoded, 2)
+	require.Equal(t, "testnet", decoded[0].Network)
+	require.NotContains(t, jsonOut.String(), "envelope_xdr")
+
+	var csvOut bytes.Buffer
+	require.NoError(t, writeSessionList(&csvOut, sessions, "csv"))
+	records, err := csv.NewReader(&csvOut).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, []string{"id", "network", "status", "tx_hash", "created_at", "last_access_at"}, records[0])
+
+	var empty bytes.Buffer
+	require.NoError(t, writeSessionList(&empty, nil, "json"))
+	require.Equal(t, "[]\n", empty.String())
+}
+
+func TestPersistSession(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	data := &session.SessionData{
+		ID:            "debug-save",
+		Network:       "testnet",
+		TxHash:        "abc123",
+		Status:        "active",
+		CreatedAt:     time.Now(),
+		EnvelopeXdr:   "AAAA",
+		SchemaVersion: session.SchemaVersion,
+	}
+	require.NoError(t, persistSession(context.Background(), data))
+	require.Equal(t, "saved", data.Status)
+
+	store, err := session.NewStore()
+	require.NoError(t, err)
+	defer store.Close()
+
+	saved, err := store.Load(context.Background(), "debug-save")
+	require.NoError(t, err)
+	require.Equal(t, "saved", saved.Status)
+	require.Equal(t, "abc123", saved.TxHash)
+}
+
+func TestSessionExportImport_RoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := session.NewStore()
+	require.NoError(t, err)
+	defer store.Close()
+
+	ctx := context.Background()
+	orig := &session.SessionData{
+		ID:              "abcdef12-1700000000",
+		Network:         "testnet",
+		TxHash:          "abcdef1234567890",
+		EnvelopeXdr:     "AAAA",
+		ResultXdr:       "BBBB",
+		ResultMetaXdr:   "CCCC",
+		SimRequestJSON:  `{"envelope_xdr":"AAAA"}`,
+		SimResponseJSON: `{"status":"success"}`,
+		Status:          "saved",
+	}
+	require.NoError(t, store.Save(ctx, orig))
+
+	var buf bytes.Buffer
+	require.NoError(t, session.WriteExport(&buf, orig))
+
+	data, err := session.ReadExport(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	first, err := store.Import(ctx, data)
+	require.NoError(t, err)
+	second, err := store.Import(ctx, data)
+	require.NoError(t, err)
+	require.NotEqual(t, orig.ID, first)
+	require.NotEqual(t, first, second)
+
+	loaded, err := store.Load(ctx, first)
+	require.NoError(t, err)
+	require.Equal(t, orig.EnvelopeXdr, loaded.EnvelopeXdr)
+	require.Equal(t, orig.ResultMetaXdr, loaded.ResultMetaXdr)
+	require.Equal(t, orig.SimResponseJSON, loaded.SimResponseJSON)
+
+	original, err := store.Load(ctx, orig.ID)
+	require.NoError(t, err)
+	require.Equal(t, orig.TxHash, original.TxHash)
+}
+
+func TestReadExport_RejectsSchemaMismatch(t *testing.T) {
+	for _, v := range []int{0, session.SchemaVersion + 1} {
+		bundle := fmt.Sprintf(`{"id":"x","tx_hash":"abc","schema_version":%d}`, v)
+		_, err := session.ReadExport(strings.NewReader(bundle))
+		require.Error(t, err, "schema v%d", v)
+		require.Contains(t, err.Error(), "schema")
+	}
+}
+
+func TestSessionDiff(t *testing.T) {
+	a := &session.SessionData{
+		ID:              "a",
+		SimResponseJSON: `{"status":"success","events":["transfer","mint","mint"],"logs":["start"],"budget_usage":{"cpu_instructions":1000,"memory_bytes":500,"operations_count":1}}`,
+	}
+	b := &session.SessionData{
+		ID:              "b",
+		SimResponseJSON: `{"status":"error","error":"HostError","events":["mint","burn"],"logs":["start"],"budget_usage":{"cpu_instructions":1200,"memory_bytes":500,"operations_count":1}}`,
+	}
+
+	diff, err := session.Diff(a, b)
+	require.NoError(t, err)
+	require.Equal(t, &session.FieldChange{From: "success", To: "error"}, diff.Status)
+	require.Equal(t, &session.FieldChange{From: "", To: "HostError"}, diff.Error)
+	require.Equal(t, []string{"burn"}, diff.EventsAdded)
+	require.Equal(t, []string{"transfer", "mint"}, diff.EventsRemoved)
+	require.Empty(t, diff.LogsAdded)
+	require.Empty(t, diff.LogsRemoved)
+	require.Equal(t, []session.BudgetChange{{Metric: "cpu_instructions", From: 1000, To: 1200, Delta: 200}}, diff.Budget)
+
+	var buf bytes.Buffer
+	printSessionDiff(&buf, diff)
+	out := buf.String()
+	require.Contains(t, out, "Status: success -> error")
+	require.Contains(t, out, "Events (+1, -2):\n  - transfer\n  - mint\n  + burn\n")
+	require.Contains(t, out, "cpu_instructions   1000 -> 1200 (+200)")
+	require.NotContains(t, out, "Logs")
+
+	same, err := session.Diff(a, a)
+	require.NoError(t, err)
+	require.True(t, same.Empty())
+
+	_, err = session.Diff(a, &session.SessionData{ID: "empty"})
+	require.ErrorContains(t, err, "session empty")
+}
+
+func TestSessionTail_BannerGoesToCommandOutput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"session", "tail"})
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+		sessionTailCmd.SetContext(context.Background())
+	})
+
+	// The deadline stops the tail once it has started polling. It is set on
+	// the tail command alone so later runs through rootCmd do not keep it.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	sessionTailCmd.SetContext(ctx)
+	require.NoError(t, Execute())
+	require.Equal(t, "Waiting for new sessions (Ctrl-C to stop)...\n", out.String())
+}
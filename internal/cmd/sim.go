@@ -0,0 +1,182 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	simCompareNetwork string
+	simCompareRPCURL  string
+	simCompareSimPath string
+)
+
+// cargoMissingHelp explains how to get a simulator when cargo is unavailable.
+const cargoMissingHelp = `The simulator is written in Rust and needs cargo to build. Install the
+toolchain with rustup:
+  curl --proto '=https' --tlsv1.2 -sSf https://sh.rustup.rs | sh
+then rerun "erst sim build", or build it by hand:
+  cd %s && cargo build --release
+Alternatively point erst at an existing binary with --sim-path or ERST_SIM_PATH.
+`
+
+var simCmd = &cobra.Command{
+	Use:   "sim",
+	Short: "Manage the local simulator",
+	Long: `Manage the erst-sim binary used to replay transactions locally.
+
+Available subcommands:
+  build    - Build the simulator from a local source tree
+  compare  - Compare the local simulator against the RPC's simulateTransaction`,
+}
+
+var simBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build the simulator binary with cargo",
+	Long: `Detect the simulator source tree (the "simulator" directory in the current
+directory or one of its parents) and run cargo build --release in it.
+
+On success the path of the built binary is printed; pass it with --sim-path
+or set ERST_SIM_PATH if erst does not pick it up automatically.`,
+	Example: `  # Build from the repository checkout
+  erst sim build`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		dir, err := simulator.FindSourceDir(cwd)
+		if err != nil {
+			return fmt.Errorf("simulator source not found: %w\nRun this command from an erst checkout, or download a prebuilt erst-sim and pass --sim-path", err)
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Building simulator in %s...\n", dir)
+		path, err := simulator.Build(cmd.Context(), dir, cmd.ErrOrStderr())
+		if errors.Is(err, simulator.ErrCargoNotFound) {
+			fmt.Fprintf(out, cargoMissingHelp, dir)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Simulator built: %s\n", path)
+		return nil
+	},
+}
+
+var simCompareCmd = &cobra.Command{
+	Use:   "compare <transaction-hash|url>",
+	Short: "Compare local and RPC simulation of a transaction",
+	Long: `Fetch a transaction, simulate it with the local erst-sim binary and with the
+RPC's simulateTransaction method, and report where the two disagree on
+status, events and budget.
+
+The command exits with an error when any difference is found, so it can be
+used as a regression check.`,
+	Example: `  erst sim compare --network testnet abc123...def789`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		txHash, err := rpc.ResolveTransactionRef(args[0])
+		if err != nil {
+			return err
+		}
+
+		opts := []rpc.ClientOption{
+			rpc.WithNetwork(rpc.Network(simCompareNetwork)),
+			rpc.WithUserAgent(resolveUserAgent()),
+		}
+		urlOpts, primaryURL, err := rpcURLOptions(simCompareRPCURL, "", "")
+		if err != nil {
+			return err
+		}
+		if primaryURL != "" {
+			urlOpts = append(urlOpts, rpc.WithSorobanURL(primaryURL))
+		}
+		client, err := rpcClients.Client(append(opts, urlOpts...)...)
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		resp, err := client.GetTransaction(ctx, txHash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch transaction: %w", err)
+		}
+		entries, err := rpc.ExtractLedgerEntriesFromMeta(resp.ResultMetaXdr)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not extract ledger entries from metadata: %v\n", err)
+		}
+
+		local, err := simulator.NewRunner(simCompareSimPath, false, simulator.WithMinVersion(simulator.MinSimulatorVersion))
+		if err != nil {
+			return fmt.Errorf("failed to initialize simulator: %w", err)
+		}
+
+		req := &simulator.SimulationRequest{
+			EnvelopeXdr:    resp.EnvelopeXdr,
+			ResultMetaXdr:  resp.ResultMetaXdr,
+			LedgerEntries:  entries,
+			LedgerSequence: simulator.EntriesLedgerSequence(entries),
+		}
+		diffs := runSimCompare(cmd.OutOrStdout(), req, local, simulator.NewRemoteRunner(client))
+		if len(diffs) > 0 {
+			return fmt.Errorf("local and remote simulation differ in %d field(s)", len(diffs))
+		}
+		return nil
+	},
+}
+
+// runSimCompare simulates req with both runners and prints their
+// differences. A runner error is compared as an error result rather than
+// aborting, since one side failing is itself a difference worth reporting.
+func runSimCompare(w io.Writer, req *simulator.SimulationRequest, local, remote simulator.RunnerInterface) []simulator.ResultDiff {
+	run := func(name string, runner simulator.RunnerInterface) *simulator.SimulationResponse {
+		reqCopy := *req
+		res, err := runner.Run(&reqCopy)
+		if err != nil {
+			fmt.Fprintf(w, "%s simulation failed: %v\n", name, err)
+			return &simulator.SimulationResponse{Status: simulator.StatusError, Error: err.Error()}
+		}
+		return res
+	}
+	localRes := run("Local", local)
+	remoteRes := run("Remote", remote)
+
+	diffs := simulator.CompareResults(localRes, remoteRes)
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "Local and remote simulation agree on status, events and budget.")
+		return nil
+	}
+
+	fmt.Fprintf(w, "Found %d difference(s):\n", len(diffs))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tLOCAL\tREMOTE")
+	for _, d := range diffs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", d.Field, d.Left, d.Right)
+	}
+	_ = tw.Flush()
+	return diffs
+}
+
+func init() {
+	simCompareCmd.Flags().StringVarP(&simCompareNetwork, "network", "n", string(rpc.Mainnet), "Stellar network to use (testnet, mainnet, futurenet)")
+	simCompareCmd.Flags().StringVar(&simCompareRPCURL, "rpc-url", "", "Custom RPC URL to use for fetching and remote simulation")
+	simCompareCmd.Flags().StringVar(&simCompareSimPath, "sim-path", "", "Path to the erst-sim binary (default: auto-discovered)")
+
+	requireInStrict(simCompareCmd, "network", "sim-path")
+	simCmd.AddCommand(simCompareCmd)
+	simCmd.AddCommand(simBuildCmd)
+	rootCmd.AddCommand(simCmd)
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimBuild_CargoMissingPrintsInstructions(t *testing.T) {
+	root := t.TempDir()
+	crate := filepath.Join(root, simulator.SourceDirName)
+	require.NoError(t, os.MkdirAll(crate, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(crate, "Cargo.toml"), []byte("[package]\n"), 0o644))
+
+	t.Chdir(root)
+	t.Setenv("PATH", t.TempDir())
+
+	var out bytes.Buffer
+	simBuildCmd.SetOut(&out)
+	simBuildCmd.SetErr(&out)
+	t.Cleanup(func() {
+		simBuildCmd.SetOut(nil)
+		simBuildCmd.SetErr(nil)
+	})
+
+	err := simBuildCmd.RunE(simBuildCmd, nil)
+	require.ErrorIs(t, err, simulator.ErrCargoNotFound)
+	require.Contains(t, out.String(), "rustup")
+	require.Contains(t, out.String(), "cargo build --release")
+	require.Contains(t, out.String(), "--sim-path")
+}
+
+func TestSimBuild_NoSourceTree(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	err := simBuildCmd.RunE(simBuildCmd, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "simulator source not found")
+}
+
+func TestRunSimCompare_ReportsDifferences(t *testing.T) {
+	local := simulator.NewMockRunner(func(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+		return &simulator.SimulationResponse{
+			Status:           simulator.StatusSuccess,
+			DiagnosticEvents: []simulator.DiagnosticEvent{{EventType: "contract"}},
+			BudgetUsage:      &simulator.BudgetUsage{CPUInstructions: 1000, MemoryBytes: 200},
+		}, nil
+	})
+	remote := simulator.NewMockRunner(func(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+		return &simulator.SimulationResponse{
+			Status:           simulator.StatusSuccess,
+			DiagnosticEvents: []simulator.DiagnosticEvent{{EventType: "contract"}},
+			BudgetUsage:      &simulator.BudgetUsage{CPUInstructions: 1100, MemoryBytes: 200},
+		}, nil
+	})
+
+	var out bytes.Buffer
+	diffs := runSimCompare(&out, &simulator.SimulationRequest{EnvelopeXdr: "AAAA"}, local, remote)
+
+	require.Equal(t, []simulator.ResultDiff{{Field: "cpu_instructions", Left: "1000", Right: "1100"}}, diffs)
+	require.Contains(t, out.String(), "Found 1 difference(s)")
+	require.Contains(t, out.String(), "cpu_instructions")
+	require.Contains(t, out.String(), "1100")
+}
+
+func TestRunSimCompare_RunnerErrorIsADifference(t *testing.T) {
+	local := simulator.NewDefaultMockRunner()
+	remote := simulator.NewMockRunner(func(req *simulator.SimulationRequest) (*simulator.SimulationResponse, error) {
+		return nil, errors.New("rpc unavailable")
+	})
+
+	var out bytes.Buffer
+	diffs := runSimCompare(&out, &simulator.SimulationRequest{EnvelopeXdr: "AAAA"}, local, remote)
+
+	require.NotEmpty(t, diffs)
+	require.Equal(t, simulator.ResultDiff{Field: "status", Left: "success", Right: "error"}, diffs[0])
+	require.Contains(t, out.String(), "Remote simulation failed: rpc unavailable")
+}
+
+func TestRunSimCompare_Agree(t *testing.T) {
+	runner := simulator.NewDefaultMockRunner()
+
+	var out bytes.Buffer
+	diffs := runSimCompare(&out, &simulator.SimulationRequest{EnvelopeXdr: "AAAA"}, runner, runner)
+
+	require.Empty(t, diffs)
+	require.Contains(t, out.String(), "agree")
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/dotandev/hintents/internal/snapshot"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	simulateStateFlag    string
+	simulateMetaFileFlag string
+	simulateSimPathFlag  string
+	simulateRepeatFlag   int
+)
+
+// placeholderResultMeta stands in for the result meta of a transaction that
+// was never applied; the simulator requires a non-empty value.
+const placeholderResultMeta = "AAAAAQ=="
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate <tx.xdr>",
+	Short: "Simulate a local transaction against a saved ledger state",
+	Long: `Replay a base64 TransactionEnvelope from a local file against the ledger
+entries of a state snapshot, without contacting the network.
+
+The state file is the JSON written by 'erst snapshot' (the same format
+'erst debug --snapshot' reads). Pass --meta-file with the transaction's
+result meta when replaying a transaction that was already applied.`,
+	Example: `  # Capture a contract's state, then simulate against it
+  erst snapshot --contract CA3D...XYZ --network testnet --out state.json
+  erst simulate ./tx.xdr --state state.json
+
+  # Check that the simulation is deterministic across 10 runs
+  erst simulate ./tx.xdr --state state.json --repeat 10`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if simulateStateFlag == "" {
+			return fmt.Errorf("flag --state is required")
+		}
+		if simulateRepeatFlag < 1 {
+			return fmt.Errorf("invalid repeat: %d. Must be at least 1", simulateRepeatFlag)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := cmd.OutOrStdout()
+		req, err := loadStateSimulation(args[0], simulateMetaFileFlag, simulateStateFlag)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Loaded %d ledger entries from %s\n", len(req.LedgerEntries), simulateStateFlag)
+
+		runner, err := simulator.NewRunner(simulateSimPathFlag, false, simulator.WithMinVersion(simulator.MinSimulatorVersion))
+		if err != nil {
+			return fmt.Errorf("failed to initialize simulator: %w", err)
+		}
+		var resp *simulator.SimulationResponse
+		if simulateRepeatFlag > 1 {
+			var stats repeatStats
+			resp, stats, err = runRepeated(runner, req, simulateRepeatFlag)
+			if err == nil {
+				printRepeatStats(w, stats)
+			}
+		} else {
+			resp, err = runner.Run(req)
+		}
+		if err != nil {
+			return fmt.Errorf("simulation failed: %w", err)
+		}
+		printSimulationResult(w, "state "+simulateStateFlag, resp)
+		return nil
+	},
+}
+
+// loadStateSimulation builds a simulation request from an envelope file,
+// an optional result meta file and a state snapshot. The ledger sequence
+// is taken from the snapshot's entries.
+func loadStateSimulation(envelopePath, metaPath, statePath string) (*simulator.SimulationRequest, error) {
+	var envelopeB64, metaB64 string
+	if metaPath != "" {
+		resp, err := loadTransactionFiles(envelopePath, metaPath)
+		if err != nil {
+			return nil, err
+		}
+		envelopeB64, metaB64 = resp.EnvelopeXdr, resp.ResultMetaXdr
+	} else {
+		b, err := os.ReadFile(envelopePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read envelope file: %w", err)
+		}
+		envelopeB64 = strings.Join(strings.Fields(string(b)), "")
+		raw, err := base64.StdEncoding.DecodeString(envelopeB64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode envelope base64: %w", err)
+		}
+		var env xdr.TransactionEnvelope
+		if err := xdr.SafeUnmarshal(raw, &env); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal TransactionEnvelope: %w", err)
+		}
+		metaB64 = placeholderResultMeta
+	}
+
+	snap, err := snapshot.Load(statePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+	entries := snap.ToMap()
+
+	return &simulator.SimulationRequest{
+		EnvelopeXdr:    envelopeB64,
+		ResultMetaXdr:  metaB64,
+		LedgerEntries:  entries,
+		LedgerSequence: simulator.EntriesLedgerSequence(entries),
+	}, nil
+}
+
+func init() {
+	simulateCmd.Flags().StringVar(&simulateStateFlag, "state", "", "State snapshot JSON file written by 'erst snapshot'")
+	simulateCmd.Flags().StringVar(&simulateMetaFileFlag, "meta-file", "", "File with the base64 TransactionResultMeta of an applied transaction")
+	simulateCmd.Flags().StringVar(&simulateSimPathFlag, "sim-path", "", "Path to the erst-sim binary (default: auto-discovered)")
+	simulateCmd.Flags().IntVar(&simulateRepeatFlag, "repeat", 1, "Run the simulation this many times, report min/median/max durations and flag responses that differ")
+
+	requireInStrict(simulateCmd, "sim-path")
+	rootCmd.AddCommand(simulateCmd)
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/snapshot"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeStateFile(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	account := xdr.MustAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	key, err := xdr.MarshalBase64(xdr.LedgerKey{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.LedgerKeyAccount{AccountId: account},
+	})
+	require.NoError(t, err)
+	entry, err := xdr.MarshalBase64(xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 4242,
+		Data: xdr.LedgerEntryData{
+			Type:    xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{AccountId: account, Balance: 100},
+		},
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "state.json")
+	require.NoError(t, snapshot.Save(path, snapshot.FromMap(map[string]string{key: entry})))
+	return path, key
+}
+
+func TestLoadStateSimulation(t *testing.T) {
+	dir := t.TempDir()
+	statePath, key := writeStateFile(t, dir)
+	_, envB64 := testEnvelope(t)
+	envPath := filepath.Join(dir, "tx.xdr")
+	require.NoError(t, os.WriteFile(envPath, []byte(envB64+"\n"), 0644))
+
+	req, err := loadStateSimulation(envPath, "", statePath)
+	require.NoError(t, err)
+	assert.Equal(t, envB64, req.EnvelopeXdr)
+	assert.Equal(t, placeholderResultMeta, req.ResultMetaXdr)
+	assert.Len(t, req.LedgerEntries, 1)
+	assert.Contains(t, req.LedgerEntries, key)
+	assert.Equal(t, uint32(4242), req.LedgerSequence)
+
+	metaPath := filepath.Join(dir, "meta.xdr")
+	require.NoError(t, os.WriteFile(metaPath, []byte(testResultMeta(t)), 0644))
+	req, err = loadStateSimulation(envPath, metaPath, statePath)
+	require.NoError(t, err)
+	assert.Equal(t, testResultMeta(t), req.ResultMetaXdr)
+}
+
+func TestLoadStateSimulation_Errors(t *testing.T) {
+	dir := t.TempDir()
+	statePath, _ := writeStateFile(t, dir)
+	envPath := filepath.Join(dir, "tx.xdr")
+	require.NoError(t, os.WriteFile(envPath, []byte("not base64!"), 0644))
+
+	_, err := loadStateSimulation(envPath, "", statePath)
+	assert.ErrorContains(t, err, "failed to decode envelope")
+
+	_, envB64 := testEnvelope(t)
+	require.NoError(t, os.WriteFile(envPath, []byte(envB64), 0644))
+	_, err = loadStateSimulation(envPath, "", filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "failed to load state")
+}
+
+func TestSimulateCommand_RequiresState(t *testing.T) {
+	t.Cleanup(func() { rootCmd.SetArgs(nil) })
+	rootCmd.SetArgs([]string{"simulate", "tx.xdr"})
+	assert.ErrorContains(t, Execute(), "flag --state is required")
+}
+
+func TestSimulateCommand_Repeat(t *testing.T) {
+	dir := t.TempDir()
+	statePath, _ := writeStateFile(t, dir)
+	_, envB64 := testEnvelope(t)
+	envPath := filepath.Join(dir, "tx.xdr")
+	require.NoError(t, os.WriteFile(envPath, []byte(envB64), 0644))
+	sim := fakeSimulatorBinary(t, `{"status":"success"}`)
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"simulate", envPath, "--state", statePath, "--sim-path", sim, "--repeat", "3"})
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+		resetCommandFlags(t, simulateCmd.Flags())
+	})
+
+	require.NoError(t, Execute())
+	assert.Contains(t, out.String(), "Simulation repeated 3 times")
+	assert.Contains(t, out.String(), "All runs produced identical responses.")
+	assert.Contains(t, out.String(), "Status: success")
+}
+
+func TestSimulateCommand_RejectsZeroRepeat(t *testing.T) {
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		resetCommandFlags(t, simulateCmd.Flags())
+	})
+	rootCmd.SetArgs([]string{"simulate", "tx.xdr", "--state", "state.json", "--repeat", "0"})
+	assert.ErrorContains(t, Execute(), "invalid repeat: 0")
+}
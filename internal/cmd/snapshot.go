@@ -0,0 +1,81 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/snapshot"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotContractFlag string
+	snapshotOutFlag      string
+	snapshotKeysFlag     []string
+	snapshotNetworkFlag  string
+	snapshotRPCURLFlag   string
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Capture a contract's ledger state to a snapshot file",
+	Long: `Fetch a contract's instance entry and Wasm code from the RPC and write them,
+together with any extra ledger keys, to a JSON snapshot file.
+
+The file can be passed to 'erst simulate --state' or 'erst debug --snapshot'
+for reproducible simulations. Soroban RPC cannot list a contract's persistent or temporary
+data entries, so pass the ones you need with --key (base64 LedgerKey XDR).`,
+	Example: `  # Capture a contract on testnet
+  erst snapshot --contract CA3D...XYZ --network testnet --out state.json
+
+  # Include a specific data entry
+  erst snapshot --contract CA3D...XYZ --key AAAABgAAAAH... --out state.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if snapshotContractFlag == "" {
+			return fmt.Errorf("flag --contract is required")
+		}
+		if snapshotOutFlag == "" {
+			return fmt.Errorf("flag --out is required")
+		}
+
+		opts := []rpc.ClientOption{
+			rpc.WithNetwork(rpc.Network(snapshotNetworkFlag)),
+			// Snapshots must reflect the ledger, not a possibly stale cache.
+			rpc.WithCacheEnabled(false),
+		}
+		if snapshotRPCURLFlag != "" {
+			opts = append(opts, rpc.WithHorizonURL(snapshotRPCURLFlag))
+		}
+		client, err := rpcClients.Client(opts...)
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		snap, err := snapshot.CaptureContract(cmd.Context(), client, snapshotContractFlag, snapshotKeysFlag)
+		if err != nil {
+			return err
+		}
+
+		if err := snapshot.Save(snapshotOutFlag, snap); err != nil {
+			return fmt.Errorf("failed to save snapshot: %w", err)
+		}
+
+		fmt.Printf("Snapshot of %s written to %s (%d entries)\n", snapshotContractFlag, snapshotOutFlag, len(snap.LedgerEntries))
+		return nil
+	},
+}
+
+func init() {
+	snapshotCmd.Flags().StringVar(&snapshotContractFlag, "contract", "", "Contract ID (C...) to capture")
+	snapshotCmd.Flags().StringVar(&snapshotOutFlag, "out", "", "Output file for the JSON snapshot")
+	snapshotCmd.Flags().StringSliceVar(&snapshotKeysFlag, "key", nil, "Additional base64 LedgerKey XDR to include (repeatable)")
+	snapshotCmd.Flags().StringVarP(&snapshotNetworkFlag, "network", "n", string(rpc.Mainnet), "Stellar network to use (testnet, mainnet, futurenet)")
+	snapshotCmd.Flags().StringVar(&snapshotRPCURLFlag, "rpc-url", "", "Custom RPC URL")
+
+	requireInStrict(snapshotCmd, "network")
+	rootCmd.AddCommand(snapshotCmd)
+}
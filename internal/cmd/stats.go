@@ -0,0 +1,139 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/db"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsSinceFlag   string
+	statsNetworkFlag string
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarise saved debugging sessions",
+	Long: `Summarise the history of debugging sessions: how many were run, their
+outcome, which networks they targeted and the most frequent errors.
+
+Use --since to restrict the summary to a recent window and --network to a
+single network.`,
+	Example: `  # Summary of all saved sessions
+  erst stats
+
+  # Last week on testnet
+  erst stats --since 7d --network testnet`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := db.StatsParams{Network: statsNetworkFlag}
+		if statsSinceFlag != "" {
+			window, err := parseSince(statsSinceFlag)
+			if err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+			params.Since = time.Now().Add(-window)
+		}
+
+		store, err := db.InitDB()
+		if err != nil {
+			return fmt.Errorf("Error: failed to initialize session database: %w", err)
+		}
+
+		stats, err := store.Stats(params)
+		if err != nil {
+			return fmt.Errorf("Error: stats failed: %w", err)
+		}
+
+		printStats(cmd.OutOrStdout(), stats, statsSinceFlag)
+		return nil
+	},
+}
+
+// parseSince parses a look-back window. In addition to time.ParseDuration
+// units it accepts whole days ("7d") and weeks ("2w").
+func parseSince(s string) (time.Duration, error) {
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+
+	var d time.Duration
+	if unit > 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		d = time.Duration(n) * unit
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: must be positive", s)
+	}
+	return d, nil
+}
+
+func printStats(w io.Writer, stats *db.Stats, since string) {
+	window := "all time"
+	if !stats.Since.IsZero() {
+		window = fmt.Sprintf("last %s, since %s", since, stats.Since.Format("2006-01-02 15:04:05"))
+	}
+	if stats.Network != "" {
+		window += ", network " + stats.Network
+	}
+	fmt.Fprintf(w, "Sessions: %d (%s)\n", stats.Total, window)
+	if stats.Total == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "Period: %s to %s\n", stats.Oldest.Format("2006-01-02 15:04:05"), stats.Newest.Format("2006-01-02 15:04:05"))
+
+	fmt.Fprintln(w, "\nBy status:")
+	for _, k := range sortedKeys(stats.ByStatus) {
+		fmt.Fprintf(w, "  %-12s %d\n", k, stats.ByStatus[k])
+	}
+
+	fmt.Fprintln(w, "\nBy network:")
+	for _, k := range sortedKeys(stats.ByNetwork) {
+		fmt.Fprintf(w, "  %-12s %d\n", k, stats.ByNetwork[k])
+	}
+
+	if len(stats.TopErrors) > 0 {
+		fmt.Fprintln(w, "\nTop errors:")
+		for _, e := range stats.TopErrors {
+			fmt.Fprintf(w, "  %4d  %s\n", e.Count, e.Message)
+		}
+	}
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsSinceFlag, "since", "", "Only include sessions from this recent window (e.g. 24h, 7d, 2w)")
+	statsCmd.Flags().StringVar(&statsNetworkFlag, "network", "", "Only include sessions for this network")
+
+	rootCmd.AddCommand(statsCmd)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"7d", 7 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+		{"90m", 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	for _, bad := range []string{"", "d", "xd", "-1d", "0h", "soon"} {
+		_, err := parseSince(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestStatsCommand_SinceWindow(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := db.InitDB()
+	require.NoError(t, err)
+	now := time.Now()
+	require.NoError(t, store.SaveSession(&db.Session{TxHash: "old", Network: "testnet", Status: "error", ErrorMsg: "panic", Timestamp: now.Add(-10 * 24 * time.Hour)}))
+	require.NoError(t, store.SaveSession(&db.Session{TxHash: "new", Network: "testnet", Status: "success", Timestamp: now.Add(-time.Hour)}))
+	require.NoError(t, store.SaveSession(&db.Session{TxHash: "main", Network: "mainnet", Status: "success", Timestamp: now.Add(-time.Hour)}))
+
+	window, err := parseSince("7d")
+	require.NoError(t, err)
+	stats, err := store.Stats(db.StatsParams{Since: now.Add(-window), Network: "testnet"})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	printStats(&buf, stats, "7d")
+	out := buf.String()
+
+	assert.Contains(t, out, "Sessions: 1 (last 7d, since ")
+	assert.Contains(t, out, "network testnet")
+	assert.Contains(t, out, "success      1")
+	assert.NotContains(t, out, "panic")
+	assert.NotContains(t, out, "mainnet")
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// strictFlag disables implicit discovery: simulator binary lookup, ERST_*
+// environment variables, the config file, the update check and the default
+// network. Every value that would otherwise be discovered must be passed as
+// a flag. --hermetic is an alias.
+var strictFlag bool
+
+// debugStrictFlags are the debug flags that have a discovered fallback and
+// therefore must be given explicitly in strict mode.
+var debugStrictFlags = []string{"network", "sim-path"}
+
+// strictAnnotation is the command annotation listing, comma separated, the
+// flags the root command requires in strict mode, see requireInStrict.
+const strictAnnotation = "erst_strict_flags"
+
+// requireInStrict marks flags of cmd that have a discovered fallback, so
+// strict mode refuses to run cmd unless they are set explicitly. Commands
+// whose requirements depend on their mode, like debug, check for
+// themselves instead.
+func requireInStrict(cmd *cobra.Command, flags ...string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[strictAnnotation] = strings.Join(flags, ",")
+}
+
+// checkStrictCommand enforces the flags cmd was marked with by
+// requireInStrict when strict mode is on.
+func checkStrictCommand(cmd *cobra.Command) error {
+	names := cmd.Annotations[strictAnnotation]
+	if !strictFlag || names == "" {
+		return nil
+	}
+	return checkStrict(cmd.Flags(), strings.Split(names, ","))
+}
+
+// checkStrict returns an error naming every flag in required that was not
+// set explicitly on the command line.
+func checkStrict(flags *pflag.FlagSet, required []string) error {
+	var missing []string
+	for _, name := range required {
+		if !flags.Changed(name) {
+			missing = append(missing, "--"+name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("strict mode: %s must be set explicitly", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// StrictRequested reports whether args enable strict mode. It lets main
+// decide on the update check before flags are parsed.
+func StrictRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		switch arg {
+		case "--strict", "--hermetic", "--strict=true", "--hermetic=true":
+			return true
+		}
+	}
+	return false
+}
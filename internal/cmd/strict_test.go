@@ -0,0 +1,88 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strictFlagSet(t *testing.T, args ...string) *pflag.FlagSet {
+	t.Helper()
+	fs := pflag.NewFlagSet("debug", pflag.ContinueOnError)
+	fs.StringP("network", "n", "mainnet", "")
+	fs.String("sim-path", "", "")
+	require.NoError(t, fs.Parse(args))
+	return fs
+}
+
+func TestCheckStrict_ErrorsOnDiscoveredValues(t *testing.T) {
+	// The simulator would be found through the environment and the network
+	// through its default, but strict mode accepts neither.
+	t.Setenv("ERST_SIM_PATH", "/usr/local/bin/erst-sim")
+
+	err := checkStrict(strictFlagSet(t), debugStrictFlags)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--network")
+	assert.Contains(t, err.Error(), "--sim-path")
+
+	err = checkStrict(strictFlagSet(t, "--network", "testnet"), debugStrictFlags)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "--network")
+	assert.Contains(t, err.Error(), "--sim-path")
+}
+
+func TestCheckStrict_ExplicitValues(t *testing.T) {
+	fs := strictFlagSet(t, "-n", "mainnet", "--sim-path", "./erst-sim")
+	assert.NoError(t, checkStrict(fs, debugStrictFlags))
+}
+
+func TestStrictMode_IgnoresEnvironment(t *testing.T) {
+	strictFlag = true
+	t.Cleanup(func() { strictFlag = false })
+	t.Setenv("ERST_USER_AGENT", "from-env")
+	t.Setenv("ERST_REDACT_FIELDS", "envelope_xdr")
+
+	assert.Empty(t, resolveUserAgent())
+
+	policy, err := resolveRedactionPolicy()
+	require.NoError(t, err)
+	assert.True(t, policy.Empty())
+}
+
+func TestStrictRequested(t *testing.T) {
+	assert.True(t, StrictRequested([]string{"debug", "--strict", "abc"}))
+	assert.True(t, StrictRequested([]string{"--hermetic=true", "debug"}))
+	assert.False(t, StrictRequested([]string{"debug", "abc"}))
+	assert.False(t, StrictRequested([]string{"debug", "--", "--strict"}))
+}
+
+func TestStrictMode_SimulateRequiresSimPath(t *testing.T) {
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		resetCommandFlags(t, simulateCmd.Flags())
+		resetCommandFlags(t, rootCmd.PersistentFlags())
+	})
+
+	rootCmd.SetArgs([]string{"simulate", "tx.xdr", "--state", "state.json", "--strict"})
+	err := Execute()
+	require.Error(t, err)
+	assert.Equal(t, "strict mode: --sim-path must be set explicitly", err.Error())
+}
+
+func TestStrictMode_SimCompareRequiresNetwork(t *testing.T) {
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		resetCommandFlags(t, simCompareCmd.Flags())
+		resetCommandFlags(t, rootCmd.PersistentFlags())
+	})
+
+	rootCmd.SetArgs([]string{"sim", "compare", "abc", "--sim-path", "./erst-sim", "--hermetic"})
+	err := Execute()
+	require.Error(t, err)
+	assert.Equal(t, "strict mode: --network must be set explicitly", err.Error())
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	teeFlag string
+	teeFile *os.File
+)
+
+// openTee creates path and returns a writer that duplicates everything
+// written to stdout into it. The caller closes the returned file.
+func openTee(stdout io.Writer, path string) (io.Writer, *os.File, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error: failed to open tee file: %w", err)
+	}
+	return io.MultiWriter(stdout, f), f, nil
+}
+
+// setupTee points the command output of the whole command tree at stdout
+// and the --tee file.
+func setupTee(cmd *cobra.Command) error {
+	if teeFlag == "" {
+		return nil
+	}
+	w, f, err := openTee(cmd.OutOrStdout(), teeFlag)
+	if err != nil {
+		return err
+	}
+	teeFile = f
+	cmd.Root().SetOut(w)
+	return nil
+}
+
+// closeTee flushes and closes the --tee file, if one is open.
+func closeTee() error {
+	if teeFile == nil {
+		return nil
+	}
+	err := teeFile.Close()
+	teeFile = nil
+	return err
+}
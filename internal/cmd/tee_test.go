@@ -0,0 +1,45 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTee_FileReceivesSameBytesAsStdout(t *testing.T) {
+	seedSessions(t)
+	path := filepath.Join(t.TempDir(), "out.json")
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"session", "list", "-o", "json", "--tee", path})
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+		teeFlag = ""
+		sessionOutputFlag = "table"
+	})
+
+	require.NoError(t, Execute())
+
+	teed, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotEmpty(t, stdout.Bytes())
+	require.Equal(t, stdout.Bytes(), teed)
+
+	var decoded []sessionSummary
+	require.NoError(t, json.Unmarshal(teed, &decoded))
+	require.Len(t, decoded, 3)
+}
+
+func TestOpenTee_InvalidPath(t *testing.T) {
+	_, _, err := openTee(&bytes.Buffer{}, filepath.Join(t.TempDir(), "missing", "out.txt"))
+	require.Error(t, err)
+}
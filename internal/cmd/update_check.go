@@ -0,0 +1,28 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+// noUpdateCheckFlag skips the background check for a newer release. The
+// check can also be disabled with ERST_NO_UPDATE_CHECK, CI=true or
+// check_for_updates: false in the config file.
+var noUpdateCheckFlag bool
+
+// UpdateCheckRequested reports whether main should start the update check
+// for args. It is false in strict mode and with --no-update-check, and is
+// decided before flags are parsed.
+func UpdateCheckRequested(args []string) bool {
+	if StrictRequested(args) {
+		return false
+	}
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		switch arg {
+		case "--no-update-check", "--no-update-check=true":
+			return false
+		}
+	}
+	return true
+}
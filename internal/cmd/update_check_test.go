@@ -0,0 +1,18 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateCheckRequested(t *testing.T) {
+	assert.True(t, UpdateCheckRequested([]string{"debug", "abc"}))
+	assert.True(t, UpdateCheckRequested([]string{"debug", "--", "--no-update-check"}))
+	assert.False(t, UpdateCheckRequested([]string{"--no-update-check", "debug", "abc"}))
+	assert.False(t, UpdateCheckRequested([]string{"debug", "--no-update-check=true"}))
+	assert.False(t, UpdateCheckRequested([]string{"debug", "--strict"}))
+}
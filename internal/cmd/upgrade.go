@@ -29,6 +29,7 @@ Example:
   erst simulate-upgrade 5c0a... --new-wasm ./new_v2.wasm --network mainnet`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		w := cmd.OutOrStdout()
 		txHash := args[0]
 
 		if newWasmPath == "" {
@@ -40,7 +41,7 @@ Example:
 		if err != nil {
 			return fmt.Errorf("failed to read WASM file: %w", err)
 		}
-		fmt.Printf("Loaded new WASM code: %d bytes\n", len(newWasmBytes))
+		fmt.Fprintf(w, "Loaded new WASM code: %d bytes\n", len(newWasmBytes))
 
 		// 2. Setup Client
 		opts := []rpc.ClientOption{
@@ -50,18 +51,22 @@ Example:
 			opts = append(opts, rpc.WithHorizonURL(rpcURLFlag))
 		}
 
-		client, err := rpc.NewClient(opts...)
+		client, err := rpcClients.Client(opts...)
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
 		// 3. Fetch Transaction
-		fmt.Printf("Fetching transaction: %s from %s\n", txHash, networkFlag)
+		fmt.Fprintf(w, "Fetching transaction: %s from %s\n", txHash, networkFlag)
 		resp, err := client.GetTransaction(cmd.Context(), txHash)
 		if err != nil {
 			return fmt.Errorf("failed to fetch transaction: %w", err)
 		}
 
+		if err := simulator.PreflightXDR(resp.EnvelopeXdr, resp.ResultMetaXdr); err != nil {
+			return err
+		}
+
 		// 4. Extract Keys & Fetch State
 		keys, err := extractLedgerKeys(resp.ResultMetaXdr)
 		if err != nil {
@@ -72,39 +77,40 @@ Example:
 		if err != nil {
 			return fmt.Errorf("failed to fetch ledger entries: %w", err)
 		}
-		fmt.Printf("Fetched %d ledger entries\n", len(entries))
+		fmt.Fprintf(w, "Fetched %d ledger entries\n", len(entries))
 
 		// 5. Identify Contract ID and Inject New Code
 		contractID, err := getContractIDFromEnvelope(resp.EnvelopeXdr)
 		if err != nil {
 			return fmt.Errorf("failed to identify contract from transaction: %w", err)
 		}
-		fmt.Printf("Identified target contract: %x\n", *contractID)
+		fmt.Fprintf(w, "Identified target contract: %x\n", *contractID)
 
 		if err := injectNewCode(entries, *contractID, newWasmBytes); err != nil {
 			return fmt.Errorf("failed to inject new code: %w", err)
 		}
-		fmt.Println("Injected new WASM code into simulation state.")
+		fmt.Fprintln(w, "Injected new WASM code into simulation state.")
 
 		// 6. Run Simulation
-		runner, err := simulator.NewRunner("", false)
+		runner, err := simulator.NewRunner("", false, simulator.WithMinVersion(simulator.MinSimulatorVersion))
 		if err != nil {
 			return fmt.Errorf("failed to initialize simulator runner: %w", err)
 		}
 
 		simReq := &simulator.SimulationRequest{
-			EnvelopeXdr:   resp.EnvelopeXdr,
-			ResultMetaXdr: resp.ResultMetaXdr,
-			LedgerEntries: entries,
+			EnvelopeXdr:    resp.EnvelopeXdr,
+			ResultMetaXdr:  resp.ResultMetaXdr,
+			LedgerEntries:  entries,
+			LedgerSequence: simulator.EntriesLedgerSequence(entries),
 		}
 
-		fmt.Println("Running simulation with upgraded code...")
+		fmt.Fprintln(w, "Running simulation with upgraded code...")
 		result, err := runner.Run(simReq)
 		if err != nil {
 			return fmt.Errorf("simulation failed: %w", err)
 		}
 
-		printSimulationResult("Upgraded Contract", result)
+		printSimulationResult(w, "Upgraded Contract", result)
 
 		return nil
 	},
@@ -118,6 +124,7 @@ func init() {
 	upgradeCmd.Flags().StringVarP(&networkFlag, "network", "n", string(rpc.Mainnet), "Stellar network to use")
 	upgradeCmd.Flags().StringVar(&rpcURLFlag, "rpc-url", "", "Custom Horizon RPC URL")
 
+	requireInStrict(upgradeCmd, "network")
 	rootCmd.AddCommand(upgradeCmd)
 }
 
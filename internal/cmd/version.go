@@ -6,9 +6,12 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"runtime"
 	"runtime/debug"
 	"time"
 
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/simulator"
 	"github.com/spf13/cobra"
 )
 
@@ -20,10 +23,21 @@ var (
 )
 
 type VersionInfo struct {
-	Version   string `json:"version"`
-	CommitSHA string `json:"commit_sha"`
-	BuildDate string `json:"build_date"`
-	GoVersion string `json:"go_version"`
+	Version          string `json:"version"`
+	CommitSHA        string `json:"commit_sha"`
+	BuildDate        string `json:"build_date"`
+	GoVersion        string `json:"go_version"`
+	SimulatorVersion string `json:"simulator_version"`
+}
+
+// simulatorVersion reports the version of the erst-sim binary NewRunner
+// resolves. Replaced in tests.
+var simulatorVersion = func() (string, error) {
+	runner, err := simulator.NewRunner("", false)
+	if err != nil {
+		return "", err
+	}
+	return runner.Version()
 }
 
 // versionCmd represents the version command
@@ -45,6 +59,7 @@ var versionCmd = &cobra.Command{
 			fmt.Printf("Commit SHA:   %s\n", info.CommitSHA)
 			fmt.Printf("Build Date:   %s\n", info.BuildDate)
 			fmt.Printf("Go Version:   %s\n", info.GoVersion)
+			fmt.Printf("Simulator:    %s\n", info.SimulatorVersion)
 		}
 		fmt.Printf("erst version %s\n", Version)
 	},
@@ -55,7 +70,13 @@ func getVersionInfo() VersionInfo {
 		Version:   Version,
 		CommitSHA: CommitSHA,
 		BuildDate: BuildDate,
-		GoVersion: "unknown",
+		GoVersion: runtime.Version(),
+	}
+
+	if v, err := simulatorVersion(); err == nil {
+		info.SimulatorVersion = v
+	} else {
+		info.SimulatorVersion = fmt.Sprintf("unavailable (%v)", err)
 	}
 
 	// Use runtime/debug as fallback
@@ -83,6 +104,8 @@ func getVersionInfo() VersionInfo {
 }
 
 func init() {
+	rpc.ClientVersion = Version
+
 	rootCmd.AddCommand(versionCmd)
 	versionCmd.Flags().Bool("json", false, "Output version information in JSON format")
 }
@@ -0,0 +1,38 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stubSimulatorVersion(t *testing.T, version string, err error) {
+	t.Helper()
+	orig := simulatorVersion
+	simulatorVersion = func() (string, error) { return version, err }
+	t.Cleanup(func() { simulatorVersion = orig })
+}
+
+func TestGetVersionInfo_PopulatesFields(t *testing.T) {
+	stubSimulatorVersion(t, "0.3.1", nil)
+
+	info := getVersionInfo()
+	assert.Equal(t, Version, info.Version)
+	assert.Equal(t, "0.3.1", info.SimulatorVersion)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.NotEmpty(t, info.CommitSHA)
+	assert.NotEmpty(t, info.BuildDate)
+}
+
+func TestGetVersionInfo_SimulatorUnavailable(t *testing.T) {
+	stubSimulatorVersion(t, "", errors.New("erst-sim binary not found"))
+
+	info := getVersionInfo()
+	assert.Contains(t, info.SimulatorVersion, "unavailable")
+	assert.Contains(t, info.SimulatorVersion, "not found")
+}
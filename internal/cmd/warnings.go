@@ -0,0 +1,110 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/dotandev/hintents/internal/simulator"
+)
+
+// Warning is a non-fatal issue noticed while running a command.
+type Warning struct {
+	Source  string `json:"source"`
+	Message string `json:"message"`
+}
+
+// WarningCollector accumulates warnings emitted by the individual steps of a
+// command so they can be reported together once the command finishes,
+// instead of getting lost in verbose output. It is safe for concurrent use;
+// a nil collector silently discards warnings.
+type WarningCollector struct {
+	mu       sync.Mutex
+	warnings []Warning
+}
+
+// NewWarningCollector returns an empty collector.
+func NewWarningCollector() *WarningCollector {
+	return &WarningCollector{}
+}
+
+// Add records a warning attributed to source.
+func (c *WarningCollector) Add(source, format string, args ...interface{}) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warnings = append(c.warnings, Warning{Source: source, Message: fmt.Sprintf(format, args...)})
+}
+
+// Len returns the number of collected warnings.
+func (c *WarningCollector) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.warnings)
+}
+
+// Warnings returns a copy of the collected warnings in insertion order.
+func (c *WarningCollector) Warnings() []Warning {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]Warning, len(c.warnings))
+	copy(out, c.warnings)
+	return out
+}
+
+// Render writes the consolidated "Warnings (N):" section. Nothing is written
+// when no warnings were collected.
+func (c *WarningCollector) Render(w io.Writer) {
+	warnings := c.Warnings()
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nWarnings (%d):\n", len(warnings))
+	for _, warn := range warnings {
+		fmt.Fprintf(w, "  - [%s] %s\n", warn.Source, warn.Message)
+	}
+}
+
+// MarshalJSON encodes the collector as a JSON array of warnings.
+func (c *WarningCollector) MarshalJSON() ([]byte, error) {
+	warnings := c.Warnings()
+	if warnings == nil {
+		warnings = []Warning{}
+	}
+	return json.Marshal(warnings)
+}
+
+// collectBudgetWarnings records a warning for each resource that is at or
+// above 80% of its limit.
+func collectBudgetWarnings(wc *WarningCollector, network string, res *simulator.SimulationResponse) {
+	if res == nil || res.BudgetUsage == nil {
+		return
+	}
+	if res.BudgetUsage.CPUUsagePercent >= 80.0 {
+		wc.Add("budget", "%s: CPU usage at %.2f%% of limit", network, res.BudgetUsage.CPUUsagePercent)
+	}
+	if res.BudgetUsage.MemoryUsagePercent >= 80.0 {
+		wc.Add("budget", "%s: memory usage at %.2f%% of limit", network, res.BudgetUsage.MemoryUsagePercent)
+	}
+}
+
+// collectStatusWarnings records a warning when the simulator reports a
+// status other than success, including ones this build does not recognize.
+func collectStatusWarnings(wc *WarningCollector, network string, res *simulator.SimulationResponse) {
+	if res == nil || res.Outcome() == simulator.OutcomeSuccess {
+		return
+	}
+	wc.Add("simulator", "%s: simulation status %s", network, simulator.DescribeStatus(res.Status))
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarningCollector_CollectsAndRenders(t *testing.T) {
+	wc := NewWarningCollector()
+	wc.Add("compare", "status mismatch: %s vs %s", "success", "error")
+	wc.Add("budget", "testnet: CPU usage at %.2f%% of limit", 91.5)
+
+	require.Equal(t, 2, wc.Len())
+
+	var buf bytes.Buffer
+	wc.Render(&buf)
+	out := buf.String()
+
+	assert.Contains(t, out, "Warnings (2):")
+	assert.Contains(t, out, "  - [compare] status mismatch: success vs error")
+	assert.Contains(t, out, "  - [budget] testnet: CPU usage at 91.50% of limit")
+	assert.Less(t, bytes.Index(buf.Bytes(), []byte("[compare]")), bytes.Index(buf.Bytes(), []byte("[budget]")))
+}
+
+func TestWarningCollector_EmptyRendersNothing(t *testing.T) {
+	var buf bytes.Buffer
+	NewWarningCollector().Render(&buf)
+	assert.Empty(t, buf.String())
+
+	var nilCollector *WarningCollector
+	nilCollector.Add("x", "ignored")
+	assert.Equal(t, 0, nilCollector.Len())
+}
+
+func TestWarningCollector_JSON(t *testing.T) {
+	wc := NewWarningCollector()
+	wc.Add("ledger", "fetched from network")
+
+	data, err := json.Marshal(struct {
+		Warnings *WarningCollector `json:"warnings"`
+	}{wc})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"warnings":[{"source":"ledger","message":"fetched from network"}]}`, string(data))
+
+	empty, err := json.Marshal(NewWarningCollector())
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(empty))
+}
+
+func TestWarningCollector_Concurrent(t *testing.T) {
+	wc := NewWarningCollector()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			wc.Add("worker", "warning %d", i)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 50, wc.Len())
+}
+
+func TestCollectBudgetWarnings(t *testing.T) {
+	wc := NewWarningCollector()
+	collectBudgetWarnings(wc, "mainnet", &simulator.SimulationResponse{
+		BudgetUsage: &simulator.BudgetUsage{CPUUsagePercent: 85, MemoryUsagePercent: 40},
+	})
+	collectBudgetWarnings(wc, "mainnet", &simulator.SimulationResponse{})
+
+	warnings := wc.Warnings()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "budget", warnings[0].Source)
+	assert.Contains(t, warnings[0].Message, "CPU usage at 85.00%")
+}
+
+func TestCollectStatusWarnings(t *testing.T) {
+	wc := NewWarningCollector()
+	collectStatusWarnings(wc, "testnet", &simulator.SimulationResponse{Status: "success"})
+	collectStatusWarnings(wc, "testnet", &simulator.SimulationResponse{Status: "reverted"})
+	collectStatusWarnings(wc, "testnet", &simulator.SimulationResponse{Status: "paused"})
+
+	warnings := wc.Warnings()
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0].Message, "reverted")
+	assert.Contains(t, warnings[1].Message, "paused (unrecognized status)")
+}
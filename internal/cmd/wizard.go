@@ -23,7 +23,7 @@ var wizardCmd = &cobra.Command{
 			return fmt.Errorf("account flag required: erst wizard --account <address>")
 		}
 
-		client, err := rpc.NewClient(rpc.WithNetwork(rpc.Network(network)))
+		client, err := rpcClients.Client(rpc.WithNetwork(rpc.Network(network)))
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
@@ -4,73 +4,235 @@
 package cmd
 
 import (
-	"encoding/base64"
+	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/dotandev/hintents/internal/decoder"
 	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/xdr"
 )
 
 var (
-	xdrFormat string
-	xdrData   string
-	xdrType   string
+	xdrFormat   string
+	xdrData     string
+	xdrType     string
+	xdrFile     string
+	xdrEncoding string
 )
 
+// maxXDRLineSize bounds a single line of a --file batch; contract code
+// entries can be several hundred kilobytes once base64-encoded.
+const maxXDRLineSize = 4 * 1024 * 1024
+
 var xdrCmd = &cobra.Command{
-	Use:   "xdr",
-	Short: "Format and decode XDR data",
-	Long:  `Decode and format XDR structures to JSON or table format for easy inspection.`,
-	RunE:  xdrExec,
+	Use:     "xdr",
+	Aliases: []string{"decode"},
+	Short:   "Format and decode XDR data",
+	Long: `Decode and format XDR structures to JSON, YAML, table or CSV format for easy inspection.
+The command is also available as "erst decode".
+
+Input may be base64 or hex; the encoding is detected automatically. Short
+base64 blobs made only of hex digits are read as hex; pass --encoding base64
+to decode them as base64.
+
+With --file, every non-empty line of the file is decoded as a separate
+blob. Lines that fail to decode are reported and skipped, and a summary of
+successes and failures is printed at the end. With --format csv the decoded
+blobs form a single CSV document and the errors and summary go to stderr.`,
+	Example: `  erst xdr --data AAAA... --type ledger-entry
+  erst xdr --data 00000000 --encoding base64
+  erst xdr --file blobs.txt --type ledger-entry
+  erst decode --file blobs.txt --type ledger-entry`,
+	RunE: xdrExec,
 }
 
 func xdrExec(cmd *cobra.Command, args []string) error {
+	encoding := decoder.XDREncoding(xdrEncoding)
+	if err := checkXDREncoding(encoding); err != nil {
+		return err
+	}
+	if err := checkXDRFormat(xdrFormat); err != nil {
+		return err
+	}
+
+	if xdrFile != "" {
+		f, err := os.Open(xdrFile)
+		if err != nil {
+			return fmt.Errorf("failed to open XDR file: %w", err)
+		}
+		defer f.Close()
+		_, err = decodeXDRBatch(cmd.OutOrStdout(), cmd.ErrOrStderr(), f, xdrType, encoding, xdrFormat)
+		return err
+	}
+
 	if xdrData == "" {
-		return fmt.Errorf("XDR data required (use --data or pipe via stdin)")
+		return fmt.Errorf("XDR data required (use --data or --file)")
 	}
 
-	data, err := base64.StdEncoding.DecodeString(xdrData)
+	result, err := formatXDR(xdrData, xdrType, encoding, xdrFormat)
 	if err != nil {
-		return fmt.Errorf("invalid base64 input: %w", err)
+		return err
 	}
 
-	var output interface{}
+	fmt.Fprintln(cmd.OutOrStdout(), result)
+	return nil
+}
+
+// formatXDR decodes one blob in the given encoding as xdrType and renders
+// it in format.
+func formatXDR(blob, xdrType string, encoding decoder.XDREncoding, format string) (string, error) {
+	output, err := decodeXDRObject(blob, xdrType, encoding)
+	if err != nil {
+		return "", err
+	}
+	return renderXDR(output, format)
+}
+
+// decodeXDRObject decodes one blob in the given encoding as xdrType.
+func decodeXDRObject(blob, xdrType string, encoding decoder.XDREncoding) (interface{}, error) {
+	data, err := decoder.DecodeXDR(blob, encoding)
+	if err != nil {
+		return nil, err
+	}
 
 	switch xdrType {
 	case "ledger-entry":
-		le, err := decoder.DecodeXDRBase64AsLedgerEntry(string(data))
-		if err != nil {
-			return fmt.Errorf("failed to decode ledger entry: %w", err)
+		var le xdr.LedgerEntry
+		if err := xdr.SafeUnmarshal(data, &le); err != nil {
+			return nil, fmt.Errorf("failed to decode ledger entry: %w", err)
 		}
-		output = le
+		return &le, nil
 
 	case "diagnostic-event":
-		event, err := decoder.DecodeXDRBase64AsDiagnosticEvent(string(data))
-		if err != nil {
-			return fmt.Errorf("failed to decode diagnostic event: %w", err)
+		var event xdr.DiagnosticEvent
+		if err := xdr.SafeUnmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("failed to decode diagnostic event: %w", err)
 		}
-		output = event
+		return &event, nil
 
 	default:
-		return fmt.Errorf("unsupported XDR type: %s (use: ledger-entry, diagnostic-event)", xdrType)
+		return nil, checkXDRType(xdrType)
 	}
+}
 
-	formatter := decoder.NewXDRFormatter(decoder.FormatType(xdrFormat))
+// renderXDR renders decoded XDR, a single object or a slice of them, in
+// format.
+func renderXDR(output interface{}, format string) (string, error) {
+	formatter := decoder.NewColorXDRFormatter(decoder.FormatType(format))
 	result, err := formatter.Format(output)
 	if err != nil {
-		return fmt.Errorf("formatting failed: %w", err)
+		return "", fmt.Errorf("formatting failed: %w", err)
 	}
+	return result, nil
+}
 
-	fmt.Println(result)
-	return nil
+func checkXDRType(xdrType string) error {
+	switch xdrType {
+	case "ledger-entry", "diagnostic-event":
+		return nil
+	}
+	return fmt.Errorf("unsupported XDR type: %s (use: ledger-entry, diagnostic-event)", xdrType)
+}
+
+func checkXDRFormat(format string) error {
+	switch decoder.FormatType(format) {
+	case decoder.FormatJSON, decoder.FormatYAML, decoder.FormatTable, decoder.FormatCSV:
+		return nil
+	}
+	return fmt.Errorf("unsupported format: %s (use: json, yaml, table, csv)", format)
+}
+
+func checkXDREncoding(encoding decoder.XDREncoding) error {
+	switch encoding {
+	case decoder.EncodingAuto, decoder.EncodingBase64, decoder.EncodingHex:
+		return nil
+	}
+	return fmt.Errorf("unsupported XDR encoding: %s (use: auto, base64, hex)", encoding)
+}
+
+// xdrBatchResult counts the outcome of a --file batch.
+type xdrBatchResult struct {
+	Decoded int
+	Failed  int
+}
+
+// decodeXDRBatch decodes each non-empty line of r, writing an indexed result
+// or error per line and a summary at the end. Individual failures do not
+// stop the batch; only an unsupported type or format or a read error does.
+// Results are indexed by line number.
+//
+// With the csv format the decoded objects are written to w as a single CSV
+// document once the whole input is read, and the per-line errors and the
+// summary go to errW so they do not break the CSV.
+func decodeXDRBatch(w, errW io.Writer, r io.Reader, xdrType string, encoding decoder.XDREncoding, format string) (xdrBatchResult, error) {
+	var res xdrBatchResult
+	if err := checkXDRType(xdrType); err != nil {
+		return res, err
+	}
+	if err := checkXDRFormat(format); err != nil {
+		return res, err
+	}
+	csvOutput := decoder.FormatType(format) == decoder.FormatCSV
+	if !csvOutput {
+		errW = w
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxXDRLineSize)
+
+	var objects []interface{}
+	line := 0
+	for scanner.Scan() {
+		line++
+		blob := strings.TrimSpace(scanner.Text())
+		if blob == "" {
+			continue
+		}
+
+		obj, err := decodeXDRObject(blob, xdrType, encoding)
+		if err != nil {
+			res.Failed++
+			fmt.Fprintf(errW, "[%d] error: %v\n", line, err)
+			continue
+		}
+		res.Decoded++
+		if csvOutput {
+			objects = append(objects, obj)
+			continue
+		}
+		out, err := renderXDR(obj, format)
+		if err != nil {
+			return res, err
+		}
+		fmt.Fprintf(w, "[%d] %s\n", line, out)
+	}
+	if err := scanner.Err(); err != nil {
+		return res, fmt.Errorf("failed to read XDR file at line %d: %w", line+1, err)
+	}
+
+	if csvOutput && len(objects) > 0 {
+		out, err := renderXDR(objects, format)
+		if err != nil {
+			return res, err
+		}
+		fmt.Fprint(w, out)
+	}
+
+	fmt.Fprintf(errW, "\nDecoded %d, failed %d (%d total)\n", res.Decoded, res.Failed, res.Decoded+res.Failed)
+	return res, nil
 }
 
 func init() {
 	rootCmd.AddCommand(xdrCmd)
 
-	xdrCmd.Flags().StringVar(&xdrData, "data", "", "Base64-encoded XDR data to decode")
-	xdrCmd.Flags().StringVar(&xdrFormat, "format", "json", "Output format: json or table")
+	xdrCmd.Flags().StringVar(&xdrData, "data", "", "Base64 or hex-encoded XDR data to decode")
+	xdrCmd.Flags().StringVar(&xdrFormat, "format", "json", "Output format: json, yaml, table or csv")
 	xdrCmd.Flags().StringVar(&xdrType, "type", "ledger-entry", "XDR type: ledger-entry, diagnostic-event")
+	xdrCmd.Flags().StringVar(&xdrFile, "file", "", "File with one base64 or hex-encoded XDR blob per line")
+	xdrCmd.Flags().StringVar(&xdrEncoding, "encoding", string(decoder.EncodingAuto), "Input encoding: auto, base64 or hex")
 
-	_ = xdrCmd.MarkFlagRequired("data")
+	xdrCmd.MarkFlagsMutuallyExclusive("data", "file")
 }
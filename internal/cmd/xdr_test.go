@@ -0,0 +1,158 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+func ledgerEntryB64(t *testing.T) string {
+	t.Helper()
+	entry := xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 7,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId: xdr.MustAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"),
+				Balance:   100,
+			},
+		},
+	}
+	b64, err := xdr.MarshalBase64(entry)
+	require.NoError(t, err)
+	return b64
+}
+
+func TestDecodeXDRBatch_MixedLines(t *testing.T) {
+	valid := ledgerEntryB64(t)
+	input := strings.Join([]string{
+		valid,
+		"not base64!",
+		"",
+		"AAAA",
+		valid,
+	}, "\n")
+
+	var out bytes.Buffer
+	res, err := decodeXDRBatch(&out, &out, strings.NewReader(input), "ledger-entry", decoder.EncodingAuto, "json")
+	require.NoError(t, err)
+	require.Equal(t, xdrBatchResult{Decoded: 2, Failed: 2}, res)
+
+	text := out.String()
+	require.Contains(t, text, "[1] {")
+	require.Contains(t, text, "[2] error: invalid base64 input")
+	require.Contains(t, text, "[4] error: failed to decode ledger entry")
+	require.Contains(t, text, "[5] {")
+	require.NotContains(t, text, "[3]")
+	require.Contains(t, text, "Decoded 2, failed 2 (4 total)")
+}
+
+func TestDecodeXDRBatch_UnsupportedType(t *testing.T) {
+	var out bytes.Buffer
+	_, err := decodeXDRBatch(&out, &out, strings.NewReader("AAAA\n"), "transaction", decoder.EncodingAuto, "json")
+	require.Error(t, err)
+	require.Empty(t, out.String())
+}
+
+func TestFormatXDR_AcceptsHex(t *testing.T) {
+	b64 := ledgerEntryB64(t)
+	raw, err := base64.StdEncoding.DecodeString(b64)
+	require.NoError(t, err)
+
+	fromBase64, err := formatXDR(b64, "ledger-entry", decoder.EncodingAuto, "json")
+	require.NoError(t, err)
+	fromHex, err := formatXDR(hex.EncodeToString(raw), "ledger-entry", decoder.EncodingAuto, "json")
+	require.NoError(t, err)
+	require.Equal(t, fromBase64, fromHex)
+}
+
+func TestFormatXDR_ExplicitEncoding(t *testing.T) {
+	b64 := ledgerEntryB64(t)
+	raw, err := base64.StdEncoding.DecodeString(b64)
+	require.NoError(t, err)
+
+	_, err = formatXDR(b64, "ledger-entry", decoder.EncodingHex, "json")
+	require.ErrorContains(t, err, "invalid hex input")
+
+	fromHex, err := formatXDR(hex.EncodeToString(raw), "ledger-entry", decoder.EncodingHex, "json")
+	require.NoError(t, err)
+	fromBase64, err := formatXDR(b64, "ledger-entry", decoder.EncodingBase64, "json")
+	require.NoError(t, err)
+	require.Equal(t, fromBase64, fromHex)
+}
+
+func TestXDRCommand_RejectsUnknownEncoding(t *testing.T) {
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		resetXDRFlags(t)
+	})
+
+	rootCmd.SetArgs([]string{"xdr", "--data", "AAAA", "--encoding", "base32"})
+	require.ErrorContains(t, Execute(), "unsupported XDR encoding: base32")
+}
+
+func TestDecodeCommand_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blobs.txt")
+	require.NoError(t, os.WriteFile(path, []byte(ledgerEntryB64(t)+"\nnot base64!\n"), 0644))
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+		resetXDRFlags(t)
+	})
+
+	rootCmd.SetArgs([]string{"decode", "--file", path, "--type", "ledger-entry"})
+	require.NoError(t, Execute())
+	require.Contains(t, out.String(), "[1] {")
+	require.Contains(t, out.String(), "[2] error: invalid base64 input")
+	require.Contains(t, out.String(), "Decoded 1, failed 1 (2 total)")
+}
+
+func TestDecodeXDRBatch_CSVIsOneDocument(t *testing.T) {
+	valid := ledgerEntryB64(t)
+	input := strings.Join([]string{valid, "not base64!", valid}, "\n")
+
+	var out, errOut bytes.Buffer
+	res, err := decodeXDRBatch(&out, &errOut, strings.NewReader(input), "ledger-entry", decoder.EncodingAuto, "csv")
+	require.NoError(t, err)
+	require.Equal(t, xdrBatchResult{Decoded: 2, Failed: 1}, res)
+
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3, "one header row and one row per decoded line")
+	require.Equal(t, records[1], records[2])
+	require.Contains(t, errOut.String(), "[2] error: invalid base64 input")
+	require.Contains(t, errOut.String(), "Decoded 2, failed 1 (3 total)")
+}
+
+func TestDecodeXDRBatch_UnsupportedFormat(t *testing.T) {
+	var out bytes.Buffer
+	_, err := decodeXDRBatch(&out, &out, strings.NewReader(ledgerEntryB64(t)+"\n"), "ledger-entry", decoder.EncodingAuto, "xml")
+	require.ErrorContains(t, err, "unsupported format: xml")
+	require.Empty(t, out.String())
+}
+
+// resetXDRFlags restores the xdr command's flags to their defaults so the
+// next command run through rootCmd does not see them as set.
+func resetXDRFlags(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"data", "file", "encoding", "type", "format"} {
+		f := xdrCmd.Flags().Lookup(name)
+		require.NoError(t, f.Value.Set(f.DefValue))
+		f.Changed = false
+	}
+}
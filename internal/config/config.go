@@ -35,6 +35,7 @@ type Config struct {
 	LogLevel      string  `json:"log_level,omitempty"`
 	CachePath     string  `json:"cache_path,omitempty"`
 	RPCToken      string  `json:"rpc_token,omitempty"`
+	UserAgent     string  `json:"user_agent,omitempty"`
 }
 
 var defaultConfig = &Config{
@@ -88,6 +89,7 @@ func Load() (*Config, error) {
 		LogLevel:      getEnv("ERST_LOG_LEVEL", defaultConfig.LogLevel),
 		CachePath:     getEnv("ERST_CACHE_PATH", defaultConfig.CachePath),
 		RPCToken:      getEnv("ERST_RPC_TOKEN", ""),
+		UserAgent:     getEnv("ERST_USER_AGENT", ""),
 	}
 
 	if err := cfg.loadFromFile(); err != nil {
@@ -160,6 +162,8 @@ func (c *Config) parseTOML(content string) error {
 			c.CachePath = value
 		case "rpc_token":
 			c.RPCToken = value
+		case "user_agent":
+			c.UserAgent = value
 		}
 	}
 
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"runtime"
 	"strings"
 
 	"github.com/dotandev/hintents/internal/logger"
@@ -21,9 +22,10 @@ import (
 
 // Server represents the JSON-RPC daemon server
 type Server struct {
-	rpcClient *stellarrpc.Client
-	simulator *simulator.Runner
-	authToken string
+	rpcClient   *stellarrpc.Client
+	simulator   *simulator.Runner
+	authToken   string
+	versionInfo func() interface{}
 }
 
 // Config holds daemon configuration
@@ -32,6 +34,12 @@ type Config struct {
 	Network   string
 	RPCURL    string
 	AuthToken string
+	// VersionInfo returns the build information served by /version. When
+	// nil, /version reports the simulator and Go runtime versions only.
+	VersionInfo func() interface{}
+	// Clients supplies the RPC client, so the server shares connections
+	// with the rest of the process. When nil a dedicated client is built.
+	Clients *stellarrpc.ClientProvider
 }
 
 // DebugTransactionRequest represents the debug_transaction RPC request
@@ -68,7 +76,11 @@ func NewServer(config Config) (*Server, error) {
 		opts = append(opts, stellarrpc.WithHorizonURL(config.RPCURL))
 	}
 
-	client, err := stellarrpc.NewClient(opts...)
+	newClient := stellarrpc.NewClient
+	if config.Clients != nil {
+		newClient = config.Clients.Client
+	}
+	client, err := newClient(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create RPC client: %w", err)
 	}
@@ -79,9 +91,10 @@ func NewServer(config Config) (*Server, error) {
 	}
 
 	return &Server{
-		rpcClient: client,
-		simulator: sim,
-		authToken: config.AuthToken,
+		rpcClient:   client,
+		simulator:   sim,
+		authToken:   config.AuthToken,
+		versionInfo: config.VersionInfo,
 	}, nil
 }
 
@@ -167,6 +180,25 @@ func (s *Server) GetTrace(r *http.Request, req *GetTraceRequest, resp *GetTraceR
 	return nil
 }
 
+// handleVersion serves the CLI build information as JSON.
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	var info interface{}
+	if s.versionInfo != nil {
+		info = s.versionInfo()
+	} else {
+		simVersion, err := s.simulator.Version()
+		if err != nil {
+			simVersion = fmt.Sprintf("unavailable (%v)", err)
+		}
+		info = map[string]string{
+			"go_version":        runtime.Version(),
+			"simulator_version": simVersion,
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(info)
+}
+
 // Start starts the JSON-RPC server
 func (s *Server) Start(ctx context.Context, port string) error {
 	server := rpc.NewServer()
@@ -185,6 +217,8 @@ func (s *Server) Start(ctx context.Context, port string) error {
 		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 	})
 
+	http.HandleFunc("/version", s.handleVersion)
+
 	logger.Logger.Info("Starting JSON-RPC server", "port", port)
 
 	srv := &http.Server{
@@ -5,7 +5,11 @@ package daemon
 
 import (
 	"context"
+	"encoding/json"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
@@ -120,3 +124,61 @@ func TestServer_StartStop(t *testing.T) {
 		t.Fatalf("Server start failed: %v", err)
 	}
 }
+
+func TestServer_Version(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake simulator script requires a POSIX shell")
+	}
+	sim := filepath.Join(t.TempDir(), "erst-sim")
+	if err := os.WriteFile(sim, []byte("#!/bin/sh\necho 'erst-sim 0.3.1'\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ERST_SIM_PATH", sim)
+
+	server, err := NewServer(Config{Network: string(stellarrpc.Testnet)})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	server.handleVersion(rec, httptest.NewRequest("GET", "/version", nil))
+
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body["simulator_version"] != "0.3.1" {
+		t.Errorf("expected simulator_version 0.3.1, got %q", body["simulator_version"])
+	}
+	if body["go_version"] != runtime.Version() {
+		t.Errorf("expected go_version %s, got %q", runtime.Version(), body["go_version"])
+	}
+}
+
+func TestServer_VersionUsesConfiguredInfo(t *testing.T) {
+	t.Setenv("ERST_SIM_PATH", "/bin/echo")
+
+	server, err := NewServer(Config{
+		Network: string(stellarrpc.Testnet),
+		VersionInfo: func() interface{} {
+			return map[string]string{"version": "1.2.3", "simulator_version": "0.3.1"}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	server.handleVersion(rec, httptest.NewRequest("GET", "/version", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected JSON content type, got %q", ct)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body["version"] != "1.2.3" || body["simulator_version"] != "0.3.1" {
+		t.Errorf("unexpected body: %v", body)
+	}
+}
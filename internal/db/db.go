@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -25,6 +26,14 @@ type Session struct {
 	Events    []string  `json:"events"`
 	Logs      []string  `json:"logs"`
 	Timestamp time.Time `json:"timestamp"`
+
+	// EventSignatures holds the topic signature of each event, see
+	// simulator.EventSignature.
+	EventSignatures []string `json:"event_signatures,omitempty"`
+
+	// MatchedFields lists the fields ("error", "events", "logs") in which a
+	// SearchParams.FullText query was found. It is not stored.
+	MatchedFields []string `json:"matched_fields,omitempty"`
 }
 
 // Store handles database operations
@@ -42,8 +51,12 @@ func InitDB() (*Store, error) {
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create data dir: %w", err)
 	}
-	dbPath := filepath.Join(dir, "sessions.db")
+	// The saved sessions of package session live in sessions.db, whose
+	// sessions table has a different schema.
+	return openStore(filepath.Join(dir, "history.db"))
+}
 
+func openStore(dbPath string) (*Store, error) {
 	db, err := sql.Open("sqlite", dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open db: %w", err)
@@ -67,7 +80,8 @@ func initSchema(db *sql.DB) error {
 		error_msg TEXT,
 		events TEXT,
 		logs TEXT,
-		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP
+		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
+		event_signatures TEXT
 	);
 	CREATE INDEX IF NOT EXISTS idx_sessions_tx_hash ON sessions(tx_hash);
 	CREATE INDEX IF NOT EXISTS idx_sessions_error ON sessions(error_msg);
@@ -76,80 +90,337 @@ func initSchema(db *sql.DB) error {
 	if err != nil {
 		return fmt.Errorf("failed to init schema: %w", err)
 	}
+	return migrateSchema(db)
+}
+
+// migrateSchema adds columns introduced after the initial schema to
+// databases created by older versions.
+func migrateSchema(db *sql.DB) error {
+	rows, err := db.Query("PRAGMA table_info(sessions)")
+	if err != nil {
+		return fmt.Errorf("failed to inspect schema: %w", err)
+	}
+	defer rows.Close()
+
+	hasSignatures := false
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return fmt.Errorf("failed to inspect schema: %w", err)
+		}
+		if name == "event_signatures" {
+			hasSignatures = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to inspect schema: %w", err)
+	}
+
+	if !hasSignatures {
+		if _, err := db.Exec("ALTER TABLE sessions ADD COLUMN event_signatures TEXT"); err != nil {
+			return fmt.Errorf("failed to migrate schema: %w", err)
+		}
+	}
+	return normalizeTimestamps(db)
+}
+
+// timestampLayout is how timestamps are stored: UTC with a fixed number of
+// fractional digits, so that comparing the stored text in SQL orders
+// sessions by time.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// timestampGlob matches timestamps already stored in timestampLayout.
+const timestampGlob = "[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9] [0-9][0-9]:[0-9][0-9]:[0-9][0-9].[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]"
+
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(timestampLayout)
+}
+
+// legacyTimestampLayouts are the forms timestamps took before
+// timestampLayout: time.Time.String without its zone abbreviation, and
+// SQLite's CURRENT_TIMESTAMP, which is UTC.
+var legacyTimestampLayouts = []string{
+	"2006-01-02 15:04:05.999999999 -0700",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+func parseLegacyTimestamp(raw string) (time.Time, error) {
+	// Drop the zone abbreviation, which time.Parse cannot read back reliably.
+	if fields := strings.Fields(raw); len(fields) == 4 {
+		raw = strings.Join(fields[:3], " ")
+	}
+	for _, layout := range legacyTimestampLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", raw)
+}
+
+// normalizeTimestamps rewrites timestamps stored by older versions, which
+// kept the local time zone, in timestampLayout.
+func normalizeTimestamps(db *sql.DB) error {
+	rows, err := db.Query("SELECT id, timestamp FROM sessions WHERE timestamp NOT GLOB ?", timestampGlob)
+	if err != nil {
+		return fmt.Errorf("failed to migrate timestamps: %w", err)
+	}
+	updates := map[int64]string{}
+	for rows.Next() {
+		var id int64
+		var raw string
+		if err := rows.Scan(&id, &raw); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to migrate timestamps: %w", err)
+		}
+		ts, err := parseLegacyTimestamp(raw)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to migrate timestamp of session %d: %w", id, err)
+		}
+		updates[id] = formatTimestamp(ts)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("failed to migrate timestamps: %w", err)
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to migrate timestamps: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	for id, ts := range updates {
+		if _, err := tx.Exec("UPDATE sessions SET timestamp = ? WHERE id = ?", ts, id); err != nil {
+			return fmt.Errorf("failed to migrate timestamps: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to migrate timestamps: %w", err)
+	}
 	return nil
 }
 
-// SaveSession persists a debugging session
+// SaveSession persists a debugging session. A zero Timestamp is recorded as
+// the current time.
 func (s *Store) SaveSession(session *Session) error {
+	ts := session.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
 	eventsJSON, _ := json.Marshal(session.Events)
 	logsJSON, _ := json.Marshal(session.Logs)
+	sigsJSON, _ := json.Marshal(session.EventSignatures)
 
 	query := `
-	INSERT INTO sessions (tx_hash, network, status, error_msg, events, logs, timestamp)
-	VALUES (?, ?, ?, ?, ?, ?, ?)
+	INSERT INTO sessions (tx_hash, network, status, error_msg, events, logs, timestamp, event_signatures)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
-	_, err := s.db.Exec(query, session.TxHash, session.Network, session.Status, session.ErrorMsg, string(eventsJSON), string(logsJSON), time.Now())
+	_, err := s.db.Exec(query, session.TxHash, session.Network, session.Status, session.ErrorMsg, string(eventsJSON), string(logsJSON), formatTimestamp(ts), string(sigsJSON))
 	if err != nil {
 		return fmt.Errorf("failed to insert session: %w", err)
 	}
 	return nil
 }
 
+// DeleteSession removes the session with the given id.
+func (s *Store) DeleteSession(id int64) error {
+	result, err := s.db.Exec("DELETE FROM sessions WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("session not found: %d", id)
+	}
+	return nil
+}
+
+// PruneSessions removes every session recorded before the given time and
+// returns how many were removed.
+func (s *Store) PruneSessions(before time.Time) (int, error) {
+	result, err := s.db.Exec("DELETE FROM sessions WHERE timestamp < ?", formatTimestamp(before))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune sessions: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
 // SearchParams defines the criteria for searching sessions
 type SearchParams struct {
 	TxHash     string
 	ErrorRegex string
 	EventRegex string
-	Limit      int
+	// EventSignature matches sessions containing an event with this exact
+	// topic signature.
+	EventSignature string
+	// FullText matches sessions whose error message, events or logs contain
+	// this phrase, ignoring ASCII case.
+	FullText string
+	Limit    int
+	// Offset skips this many matching sessions before results are returned.
+	Offset int
+	// SortBy orders the results by "timestamp", "network" or "status".
+	// Empty means newest first by timestamp, regardless of SortDesc.
+	SortBy   string
+	SortDesc bool
 }
 
-// SearchSessions searches for sessions matching the params
-func (s *Store) SearchSessions(params SearchParams) ([]Session, error) {
-	query := "SELECT id, tx_hash, network, status, error_msg, events, logs, timestamp FROM sessions WHERE 1=1"
-	args := []interface{}{}
-
-	if params.TxHash != "" {
-		query += " AND tx_hash = ?"
-		args = append(args, params.TxHash)
-	}
+// sortColumns whitelists the columns SearchParams.SortBy may name, so the
+// value never reaches the SQL text unchecked.
+var sortColumns = map[string]string{
+	"timestamp": "timestamp",
+	"network":   "network",
+	"status":    "status",
+}
 
-	query += " ORDER BY timestamp DESC"
+// SortFields returns the accepted SearchParams.SortBy values.
+func SortFields() []string {
+	return []string{"timestamp", "network", "status"}
+}
 
-	rows, err := s.db.Query(query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("query failed: %w", err)
+// orderBy builds the ORDER BY clause for params. Ties are broken by id in
+// the same direction so that pages are stable.
+func orderBy(params SearchParams) (string, error) {
+	if params.SortBy == "" {
+		return " ORDER BY timestamp DESC, id DESC", nil
 	}
-	defer rows.Close()
-
-	var results []Session
-	var errorRe *regexp.Regexp
-	var eventRe *regexp.Regexp
+	column, ok := sortColumns[strings.ToLower(params.SortBy)]
+	if !ok {
+		return "", fmt.Errorf("invalid sort field %q: must be one of %s", params.SortBy, strings.Join(SortFields(), ", "))
+	}
+	dir := "ASC"
+	if params.SortDesc {
+		dir = "DESC"
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, id %s", column, dir, dir), nil
+}
 
+// SearchSessions searches for sessions matching the params. It returns one
+// page of results, selected by Limit and Offset, together with the total
+// number of matching sessions.
+func (s *Store) SearchSessions(params SearchParams) ([]Session, int, error) {
+	var errorRe, eventRe *regexp.Regexp
+	var err error
 	if params.ErrorRegex != "" {
 		errorRe, err = regexp.Compile(params.ErrorRegex)
 		if err != nil {
-			return nil, fmt.Errorf("invalid error regex: %w", err)
+			return nil, 0, fmt.Errorf("invalid error regex: %w", err)
 		}
 	}
 	if params.EventRegex != "" {
 		eventRe, err = regexp.Compile(params.EventRegex)
 		if err != nil {
-			return nil, fmt.Errorf("invalid event regex: %w", err)
+			return nil, 0, fmt.Errorf("invalid event regex: %w", err)
 		}
 	}
+	if params.Offset < 0 {
+		return nil, 0, fmt.Errorf("invalid offset: %d", params.Offset)
+	}
+	order, err := orderBy(params)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	count := 0
-	for rows.Next() {
-		// Optimization: if we have enough results, stop
-		if params.Limit > 0 && count >= params.Limit {
-			break
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	if params.TxHash != "" {
+		where += " AND tx_hash = ?"
+		args = append(args, params.TxHash)
+	}
+	if params.FullText != "" {
+		where += ` AND (error_msg LIKE ? ESCAPE '\' OR events LIKE ? ESCAPE '\' OR logs LIKE ? ESCAPE '\')`
+		plain, encoded := likePattern(params.FullText), likePattern(jsonFragment(params.FullText))
+		args = append(args, plain, encoded, encoded)
+	}
+
+	// The regex and signature filters run in Go, so the page can only be
+	// cut in SQL when none of them is set. FullText is narrowed down in SQL
+	// but confirmed in Go, since a LIKE over the JSON array of events can
+	// also match across two entries.
+	if errorRe == nil && eventRe == nil && params.EventSignature == "" && params.FullText == "" {
+		var total int
+		if err := s.db.QueryRow("SELECT COUNT(*) FROM sessions"+where, args...).Scan(&total); err != nil {
+			return nil, 0, fmt.Errorf("count failed: %w", err)
+		}
+		limit := params.Limit
+		if limit <= 0 {
+			limit = -1
 		}
+		results, err := s.querySessions(where+order+" LIMIT ? OFFSET ?", append(args, limit, params.Offset)...)
+		if err != nil {
+			return nil, 0, err
+		}
+		return results, total, nil
+	}
+
+	all, err := s.querySessions(where+order, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var results []Session
+	total := 0
+	for _, sess := range all {
+		if !matchSession(sess, errorRe, eventRe, params.EventSignature) {
+			continue
+		}
+		if params.FullText != "" {
+			sess.MatchedFields = matchFullText(sess, params.FullText)
+			if len(sess.MatchedFields) == 0 {
+				continue
+			}
+		}
+		total++
+		if total <= params.Offset {
+			continue
+		}
+		if params.Limit > 0 && len(results) >= params.Limit {
+			continue
+		}
+		results = append(results, sess)
+	}
+
+	return results, total, nil
+}
+
+// querySessions loads the sessions selected by the given WHERE/ORDER BY
+// clause. Rows that fail to scan are skipped.
+func (s *Store) querySessions(clause string, args ...interface{}) ([]Session, error) {
+	query := "SELECT id, tx_hash, network, status, error_msg, events, logs, timestamp, event_signatures FROM sessions" + clause
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
 
+	var results []Session
+	for rows.Next() {
 		var sess Session
 		var eventsRaw, logsRaw string
+		var sigsRaw sql.NullString
 		var ts time.Time
 
-		if err := rows.Scan(&sess.ID, &sess.TxHash, &sess.Network, &sess.Status, &sess.ErrorMsg, &eventsRaw, &logsRaw, &ts); err != nil {
+		if err := rows.Scan(&sess.ID, &sess.TxHash, &sess.Network, &sess.Status, &sess.ErrorMsg, &eventsRaw, &logsRaw, &ts, &sigsRaw); err != nil {
 			continue
 		}
 		sess.Timestamp = ts
@@ -157,30 +428,83 @@ func (s *Store) SearchSessions(params SearchParams) ([]Session, error) {
 		// Deserialize JSON
 		_ = json.Unmarshal([]byte(eventsRaw), &sess.Events)
 		_ = json.Unmarshal([]byte(logsRaw), &sess.Logs)
+		if sigsRaw.Valid {
+			_ = json.Unmarshal([]byte(sigsRaw.String), &sess.EventSignatures)
+		}
+		results = append(results, sess)
+	}
+	return results, rows.Err()
+}
 
-		// Filter
-		if errorRe != nil {
-			if !errorRe.MatchString(sess.ErrorMsg) {
-				continue
-			}
+// matchFullText returns the fields of sess that contain phrase, ignoring
+// case.
+func matchFullText(sess Session, phrase string) []string {
+	var fields []string
+	if containsFold(sess.ErrorMsg, phrase) {
+		fields = append(fields, "error")
+	}
+	for _, e := range sess.Events {
+		if containsFold(e, phrase) {
+			fields = append(fields, "events")
+			break
 		}
+	}
+	for _, l := range sess.Logs {
+		if containsFold(l, phrase) {
+			fields = append(fields, "logs")
+			break
+		}
+	}
+	return fields
+}
 
-		if eventRe != nil {
-			found := false
-			for _, e := range sess.Events {
-				if eventRe.MatchString(e) {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// jsonFragment returns phrase as it appears inside a JSON string written by
+// encoding/json, so it can be matched against the stored events and logs.
+func jsonFragment(phrase string) string {
+	b, _ := json.Marshal(phrase)
+	return string(b[1 : len(b)-1])
+}
+
+// likePattern builds a LIKE pattern matching s anywhere, escaping the
+// wildcards with a backslash.
+func likePattern(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return "%" + r.Replace(s) + "%"
+}
+
+func matchSession(sess Session, errorRe, eventRe *regexp.Regexp, signature string) bool {
+	if errorRe != nil && !errorRe.MatchString(sess.ErrorMsg) {
+		return false
+	}
+
+	if eventRe != nil {
+		found := false
+		for _, e := range sess.Events {
+			if eventRe.MatchString(e) {
+				found = true
+				break
 			}
 		}
-
-		results = append(results, sess)
-		count++
+		if !found {
+			return false
+		}
 	}
 
-	return results, nil
+	if signature != "" {
+		found := false
+		for _, sig := range sess.EventSignatures {
+			if sig == signature {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,283 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchSessions_EventSignature(t *testing.T) {
+	store, err := openStore(filepath.Join(t.TempDir(), "sessions.db"))
+	require.NoError(t, err)
+
+	require.NoError(t, store.SaveSession(&Session{
+		TxHash:          "tx1",
+		Network:         "testnet",
+		Status:          "success",
+		EventSignatures: []string{"aaaa", "bbbb"},
+	}))
+	require.NoError(t, store.SaveSession(&Session{
+		TxHash:          "tx2",
+		Network:         "testnet",
+		Status:          "success",
+		EventSignatures: []string{"cccc"},
+	}))
+
+	results, total, err := store.SearchSessions(SearchParams{EventSignature: "bbbb"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, results, 1)
+	assert.Equal(t, "tx1", results[0].TxHash)
+	assert.Equal(t, []string{"aaaa", "bbbb"}, results[0].EventSignatures)
+}
+
+func TestSearchSessions_Offset(t *testing.T) {
+	store, err := openStore(filepath.Join(t.TempDir(), "sessions.db"))
+	require.NoError(t, err)
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		status := "success"
+		errMsg := ""
+		if i%2 == 0 {
+			status = "failed"
+			errMsg = "panic"
+		}
+		require.NoError(t, store.SaveSession(&Session{
+			TxHash:    fmt.Sprintf("tx%d", i),
+			Network:   "testnet",
+			Status:    status,
+			ErrorMsg:  errMsg,
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+
+	tests := []struct {
+		name      string
+		params    SearchParams
+		wantTotal int
+		want      []string
+	}{
+		{"first page", SearchParams{Limit: 2}, 5, []string{"tx4", "tx3"}},
+		{"second page", SearchParams{Limit: 2, Offset: 2}, 5, []string{"tx2", "tx1"}},
+		{"last page", SearchParams{Limit: 2, Offset: 4}, 5, []string{"tx0"}},
+		{"past the end", SearchParams{Limit: 2, Offset: 10}, 5, nil},
+		{"no limit", SearchParams{Offset: 3}, 5, []string{"tx1", "tx0"}},
+		{"filtered", SearchParams{ErrorRegex: "panic", Limit: 1, Offset: 1}, 3, []string{"tx2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, total, err := store.SearchSessions(tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, total)
+			var got []string
+			for _, s := range results {
+				got = append(got, s.TxHash)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, _, err = store.SearchSessions(SearchParams{Offset: -1})
+	assert.Error(t, err)
+}
+
+func TestSearchSessions_Sort(t *testing.T) {
+	store, err := openStore(filepath.Join(t.TempDir(), "sessions.db"))
+	require.NoError(t, err)
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, s := range []Session{
+		{TxHash: "a", Network: "testnet", Status: "success"},
+		{TxHash: "b", Network: "mainnet", Status: "failed"},
+		{TxHash: "c", Network: "futurenet", Status: "success"},
+	} {
+		s.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, store.SaveSession(&s))
+	}
+
+	tests := []struct {
+		name   string
+		params SearchParams
+		want   []string
+	}{
+		{"default newest first", SearchParams{}, []string{"c", "b", "a"}},
+		{"default ignores desc", SearchParams{SortDesc: true}, []string{"c", "b", "a"}},
+		{"timestamp ascending", SearchParams{SortBy: "timestamp"}, []string{"a", "b", "c"}},
+		{"network ascending", SearchParams{SortBy: "network"}, []string{"c", "b", "a"}},
+		{"network descending", SearchParams{SortBy: "network", SortDesc: true}, []string{"a", "b", "c"}},
+		{"status ties by id", SearchParams{SortBy: "STATUS"}, []string{"b", "a", "c"}},
+		{"sorted page", SearchParams{SortBy: "timestamp", Limit: 1, Offset: 1}, []string{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, _, err := store.SearchSessions(tt.params)
+			require.NoError(t, err)
+			var got []string
+			for _, s := range results {
+				got = append(got, s.TxHash)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, _, err = store.SearchSessions(SearchParams{SortBy: "timestamp; DROP TABLE sessions"})
+	assert.ErrorContains(t, err, "invalid sort field")
+}
+
+func TestOpenStore_MigratesLegacySchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.db")
+
+	legacy, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	_, err = legacy.Exec(`CREATE TABLE sessions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		tx_hash TEXT NOT NULL,
+		network TEXT NOT NULL,
+		status TEXT,
+		error_msg TEXT,
+		events TEXT,
+		logs TEXT,
+		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`)
+	require.NoError(t, err)
+	_, err = legacy.Exec(`INSERT INTO sessions (tx_hash, network, status, error_msg, events, logs) VALUES ('old', 'testnet', 'success', '', '[]', '[]')`)
+	require.NoError(t, err)
+	require.NoError(t, legacy.Close())
+
+	store, err := openStore(path)
+	require.NoError(t, err)
+
+	results, _, err := store.SearchSessions(SearchParams{TxHash: "old"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Empty(t, results[0].EventSignatures)
+}
+
+func TestOpenStore_NormalizesLegacyTimestamps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.db")
+	store, err := openStore(path)
+	require.NoError(t, err)
+
+	// Older versions stored the time with its local zone.
+	east := time.FixedZone("east", 10*3600)
+	legacyTime := time.Date(2025, 6, 1, 9, 0, 0, 0, east)
+	_, err = store.db.Exec(`INSERT INTO sessions (tx_hash, network, events, logs, timestamp) VALUES ('legacy', 'testnet', '[]', '[]', ?)`, legacyTime)
+	require.NoError(t, err)
+	require.NoError(t, store.db.Close())
+
+	store, err = openStore(path)
+	require.NoError(t, err)
+
+	var stored string
+	require.NoError(t, store.db.QueryRow("SELECT CAST(timestamp AS TEXT) FROM sessions WHERE tx_hash = 'legacy'").Scan(&stored))
+	assert.Equal(t, "2025-05-31 23:00:00.000000000", stored)
+
+	removed, err := store.PruneSessions(legacyTime.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
+
+// newMemoryStore opens a store on an in-memory database. The pool is held
+// to one connection because every new connection would see an empty
+// database.
+func newMemoryStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := openStore(":memory:")
+	require.NoError(t, err)
+	store.db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = store.db.Close() })
+	return store
+}
+
+func TestDeleteSession(t *testing.T) {
+	store := newMemoryStore(t)
+	require.NoError(t, store.SaveSession(&Session{TxHash: "keep", Network: "testnet"}))
+	require.NoError(t, store.SaveSession(&Session{TxHash: "drop", Network: "testnet"}))
+
+	results, _, err := store.SearchSessions(SearchParams{TxHash: "drop"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	require.NoError(t, store.DeleteSession(results[0].ID))
+
+	_, total, err := store.SearchSessions(SearchParams{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	assert.ErrorContains(t, store.DeleteSession(results[0].ID), "session not found")
+}
+
+func TestPruneSessions(t *testing.T) {
+	store := newMemoryStore(t)
+	now := time.Now()
+	for i, age := range []time.Duration{40 * 24 * time.Hour, 31 * 24 * time.Hour, 2 * time.Hour} {
+		require.NoError(t, store.SaveSession(&Session{
+			TxHash:    fmt.Sprintf("tx%d", i),
+			Network:   "testnet",
+			Timestamp: now.Add(-age),
+		}))
+	}
+
+	removed, err := store.PruneSessions(now.Add(-30 * 24 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	results, total, err := store.SearchSessions(SearchParams{})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, "tx2", results[0].TxHash)
+
+	removed, err = store.PruneSessions(now.Add(-30 * 24 * time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}
+
+func TestSearchSessions_FullText(t *testing.T) {
+	store := newMemoryStore(t)
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, s := range []Session{
+		{TxHash: "err", ErrorMsg: "HostError: Out of fuel"},
+		{TxHash: "log", Logs: []string{"step 1", "budget: out of fuel <cpu>"}},
+		{TxHash: "both", ErrorMsg: "out of fuel", Events: []string{"fuel: OUT OF FUEL"}},
+		{TxHash: "none", Logs: []string{"out of", "fuel"}},
+		{TxHash: "wild", Logs: []string{"100% done_now"}},
+	} {
+		s.Network = "testnet"
+		s.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, store.SaveSession(&s))
+	}
+
+	results, total, err := store.SearchSessions(SearchParams{FullText: "out of fuel"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, results, 3)
+	assert.Equal(t, "both", results[0].TxHash)
+	assert.Equal(t, []string{"error", "events"}, results[0].MatchedFields)
+	assert.Equal(t, "log", results[1].TxHash)
+	assert.Equal(t, []string{"logs"}, results[1].MatchedFields)
+	assert.Equal(t, "err", results[2].TxHash)
+	assert.Equal(t, []string{"error"}, results[2].MatchedFields)
+
+	results, _, err = store.SearchSessions(SearchParams{FullText: "fuel <cpu>"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "log", results[0].TxHash)
+
+	results, _, err = store.SearchSessions(SearchParams{FullText: "0% d"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "wild", results[0].TxHash)
+
+	_, total, err = store.SearchSessions(SearchParams{FullText: "1_0"})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
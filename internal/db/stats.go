@@ -0,0 +1,107 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// StatsParams scopes a stats aggregation. Zero values mean no bound.
+type StatsParams struct {
+	Since   time.Time
+	Network string
+}
+
+// ErrorCount is how often an error message was seen.
+type ErrorCount struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// Stats summarises saved debugging sessions.
+type Stats struct {
+	Since     time.Time      `json:"since,omitempty"`
+	Network   string         `json:"network,omitempty"`
+	Total     int            `json:"total"`
+	ByStatus  map[string]int `json:"by_status"`
+	ByNetwork map[string]int `json:"by_network"`
+	TopErrors []ErrorCount   `json:"top_errors"`
+	Oldest    time.Time      `json:"oldest,omitempty"`
+	Newest    time.Time      `json:"newest,omitempty"`
+}
+
+// maxTopErrors bounds Stats.TopErrors.
+const maxTopErrors = 5
+
+// Stats aggregates sessions matching params.
+func (s *Store) Stats(params StatsParams) (*Stats, error) {
+	query := "SELECT network, status, error_msg, timestamp FROM sessions WHERE 1=1"
+	args := []interface{}{}
+
+	if params.Network != "" {
+		query += " AND network = ?"
+		args = append(args, params.Network)
+	}
+	if !params.Since.IsZero() {
+		query += " AND timestamp >= ?"
+		args = append(args, formatTimestamp(params.Since))
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &Stats{
+		Since:     params.Since,
+		Network:   params.Network,
+		ByStatus:  map[string]int{},
+		ByNetwork: map[string]int{},
+	}
+	errorCounts := map[string]int{}
+
+	for rows.Next() {
+		var network string
+		var status, errorMsg sql.NullString
+		var ts time.Time
+		if err := rows.Scan(&network, &status, &errorMsg, &ts); err != nil {
+			return nil, fmt.Errorf("failed to read session: %w", err)
+		}
+
+		stats.Total++
+		stats.ByStatus[status.String]++
+		stats.ByNetwork[network]++
+		if errorMsg.String != "" {
+			errorCounts[errorMsg.String]++
+		}
+		if stats.Oldest.IsZero() || ts.Before(stats.Oldest) {
+			stats.Oldest = ts
+		}
+		if ts.After(stats.Newest) {
+			stats.Newest = ts
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+
+	for msg, n := range errorCounts {
+		stats.TopErrors = append(stats.TopErrors, ErrorCount{Message: msg, Count: n})
+	}
+	sort.Slice(stats.TopErrors, func(i, j int) bool {
+		if stats.TopErrors[i].Count != stats.TopErrors[j].Count {
+			return stats.TopErrors[i].Count > stats.TopErrors[j].Count
+		}
+		return stats.TopErrors[i].Message < stats.TopErrors[j].Message
+	})
+	if len(stats.TopErrors) > maxTopErrors {
+		stats.TopErrors = stats.TopErrors[:maxTopErrors]
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats_SinceAndNetwork(t *testing.T) {
+	store, err := openStore(filepath.Join(t.TempDir(), "sessions.db"))
+	require.NoError(t, err)
+
+	now := time.Now()
+	for _, s := range []Session{
+		{TxHash: "old", Network: "testnet", Status: "error", ErrorMsg: "panic", Timestamp: now.Add(-30 * 24 * time.Hour)},
+		{TxHash: "a", Network: "testnet", Status: "error", ErrorMsg: "insufficient balance", Timestamp: now.Add(-2 * 24 * time.Hour)},
+		{TxHash: "b", Network: "testnet", Status: "success", Timestamp: now.Add(-time.Hour)},
+		{TxHash: "c", Network: "mainnet", Status: "error", ErrorMsg: "insufficient balance", Timestamp: now.Add(-time.Minute)},
+	} {
+		s := s
+		require.NoError(t, store.SaveSession(&s))
+	}
+
+	all, err := store.Stats(StatsParams{})
+	require.NoError(t, err)
+	assert.Equal(t, 4, all.Total)
+
+	week, err := store.Stats(StatsParams{Since: now.Add(-7 * 24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, 3, week.Total)
+	assert.Equal(t, map[string]int{"error": 2, "success": 1}, week.ByStatus)
+	assert.Equal(t, map[string]int{"testnet": 2, "mainnet": 1}, week.ByNetwork)
+	require.Len(t, week.TopErrors, 1)
+	assert.Equal(t, ErrorCount{Message: "insufficient balance", Count: 2}, week.TopErrors[0])
+
+	testnetWeek, err := store.Stats(StatsParams{Since: now.Add(-7 * 24 * time.Hour), Network: "testnet"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, testnetWeek.Total)
+	assert.Equal(t, "testnet", testnetWeek.Network)
+
+	empty, err := store.Stats(StatsParams{Since: now.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, 0, empty.Total)
+	assert.Empty(t, empty.TopErrors)
+}
+
+func TestStats_NullStatusAndError(t *testing.T) {
+	store := newMemoryStore(t)
+	_, err := store.db.Exec(`INSERT INTO sessions (tx_hash, network, events, logs, timestamp) VALUES ('a', 'testnet', '[]', '[]', ?)`, formatTimestamp(time.Now()))
+	require.NoError(t, err)
+	require.NoError(t, store.SaveSession(&Session{TxHash: "b", Network: "testnet", Status: "error", ErrorMsg: "panic"}))
+
+	stats, err := store.Stats(StatsParams{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Total)
+	assert.Equal(t, map[string]int{"": 1, "error": 1}, stats.ByStatus)
+	assert.Equal(t, []ErrorCount{{Message: "panic", Count: 1}}, stats.TopErrors)
+}
+
+func TestStats_SinceAcrossTimeZones(t *testing.T) {
+	store := newMemoryStore(t)
+	now := time.Now()
+	east := time.FixedZone("east", 10*3600)
+	west := time.FixedZone("west", -8*3600)
+	require.NoError(t, store.SaveSession(&Session{TxHash: "old", Network: "testnet", Timestamp: now.Add(-3 * time.Hour).In(east)}))
+	require.NoError(t, store.SaveSession(&Session{TxHash: "new", Network: "testnet", Timestamp: now.Add(-time.Hour).In(west)}))
+
+	stats, err := store.Stats(StatsParams{Since: now.Add(-2 * time.Hour).In(east)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Total)
+}
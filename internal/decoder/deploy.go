@@ -0,0 +1,119 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// ContractID computes the ID of the contract a create-contract host function
+// deploys from its preimage, as the host does: the SHA-256 of a
+// HashIdPreimage binding the preimage to the network. networkID is the
+// SHA-256 of the network passphrase (see network.ID).
+func ContractID(preimage xdr.ContractIdPreimage, networkID [32]byte) (string, error) {
+	full := xdr.HashIdPreimage{
+		Type: xdr.EnvelopeTypeEnvelopeTypeContractId,
+		ContractId: &xdr.HashIdPreimageContractId{
+			NetworkId:          networkID,
+			ContractIdPreimage: preimage,
+		},
+	}
+	raw, err := full.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode contract id preimage: %w", err)
+	}
+	id := sha256.Sum256(raw)
+	return strkey.Encode(strkey.VersionByteContract, id[:])
+}
+
+// DeployedContracts returns the IDs (C...) of the contracts created by a
+// transaction's create-contract host functions, in operation order. It
+// returns nil if the transaction deploys nothing.
+//
+// The ID is read from the invocation's return value in resultMetaB64 when
+// the meta has one. Otherwise it is computed from the envelope for the
+// network identified by networkID.
+func DeployedContracts(envelopeB64, resultMetaB64 string, networkID [32]byte) ([]string, error) {
+	env, err := AnalyzeEnvelope(envelopeB64)
+	if err != nil {
+		return nil, fmt.Errorf("decode envelope: %w", err)
+	}
+	if env.InnerTx != nil {
+		env = env.InnerTx
+	}
+
+	var preimages []xdr.ContractIdPreimage
+	for _, op := range env.Operations {
+		if op.Body.Type != xdr.OperationTypeInvokeHostFunction || op.Body.InvokeHostFunctionOp == nil {
+			continue
+		}
+		fn := op.Body.InvokeHostFunctionOp.HostFunction
+		switch {
+		case fn.Type == xdr.HostFunctionTypeHostFunctionTypeCreateContract && fn.CreateContract != nil:
+			preimages = append(preimages, fn.CreateContract.ContractIdPreimage)
+		case fn.Type == xdr.HostFunctionTypeHostFunctionTypeCreateContractV2 && fn.CreateContractV2 != nil:
+			preimages = append(preimages, fn.CreateContractV2.ContractIdPreimage)
+		}
+	}
+	if len(preimages) == 0 {
+		return nil, nil
+	}
+
+	// A Soroban transaction has a single host function, whose return value
+	// for a deployment is the new contract's address.
+	if len(preimages) == 1 && resultMetaB64 != "" {
+		id, err := metaReturnedContract(resultMetaB64)
+		if err != nil {
+			return nil, err
+		}
+		if id != "" {
+			return []string{id}, nil
+		}
+	}
+
+	ids := make([]string, 0, len(preimages))
+	for _, preimage := range preimages {
+		id, err := ContractID(preimage, networkID)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// metaReturnedContract returns the contract address a base64
+// TransactionResultMeta reports as the invocation's return value, or "" if
+// the return value is missing or not a contract address.
+func metaReturnedContract(resultMetaB64 string) (string, error) {
+	var meta xdr.TransactionResultMeta
+	if err := xdr.SafeUnmarshalBase64(resultMetaB64, &meta); err != nil {
+		return "", fmt.Errorf("decode result meta: %w", err)
+	}
+
+	var ret *xdr.ScVal
+	tm := meta.TxApplyProcessing
+	switch tm.V {
+	case 3:
+		if tm.V3 != nil && tm.V3.SorobanMeta != nil {
+			ret = &tm.V3.SorobanMeta.ReturnValue
+		}
+	case 4:
+		if tm.V4 != nil && tm.V4.SorobanMeta != nil {
+			ret = tm.V4.SorobanMeta.ReturnValue
+		}
+	}
+	if ret == nil || ret.Type != xdr.ScValTypeScvAddress || ret.Address == nil {
+		return "", nil
+	}
+	addr := ret.Address
+	if addr.Type != xdr.ScAddressTypeScAddressTypeContract || addr.ContractId == nil {
+		return "", nil
+	}
+	return strkey.Encode(strkey.VersionByteContract, addr.ContractId[:])
+}
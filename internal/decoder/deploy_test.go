@@ -0,0 +1,163 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testnetID = network.ID(network.TestNetworkPassphrase)
+	mainnetID = network.ID(network.PublicNetworkPassphrase)
+)
+
+func nativeAssetPreimage() xdr.ContractIdPreimage {
+	native := xdr.MustNewNativeAsset()
+	return xdr.ContractIdPreimage{
+		Type:      xdr.ContractIdPreimageTypeContractIdPreimageFromAsset,
+		FromAsset: &native,
+	}
+}
+
+func createContractEnvelope(t *testing.T, fn xdr.HostFunction) string {
+	t.Helper()
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: muxed(t, 0x01),
+			Fee:           100,
+			SeqNum:        1,
+			Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+			Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+			Operations: []xdr.Operation{{Body: xdr.OperationBody{
+				Type:                 xdr.OperationTypeInvokeHostFunction,
+				InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{HostFunction: fn},
+			}}},
+		}},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	return b64
+}
+
+func createContractFn(preimage xdr.ContractIdPreimage) xdr.HostFunction {
+	return xdr.HostFunction{
+		Type: xdr.HostFunctionTypeHostFunctionTypeCreateContract,
+		CreateContract: &xdr.CreateContractArgs{
+			ContractIdPreimage: preimage,
+			Executable:         xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableStellarAsset},
+		},
+	}
+}
+
+func metaReturning(t *testing.T, contract xdr.ContractId) string {
+	t.Helper()
+	ret := xdr.ScVal{
+		Type: xdr.ScValTypeScvAddress,
+		Address: &xdr.ScAddress{
+			Type:       xdr.ScAddressTypeScAddressTypeContract,
+			ContractId: &contract,
+		},
+	}
+	meta := xdr.TransactionResultMeta{
+		Result: xdr.TransactionResultPair{Result: xdr.TransactionResult{
+			Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &[]xdr.OperationResult{}},
+		}},
+		TxApplyProcessing: xdr.TransactionMeta{
+			V:  4,
+			V4: &xdr.TransactionMetaV4{SorobanMeta: &xdr.SorobanTransactionMetaV2{ReturnValue: &ret}},
+		},
+	}
+	b64, err := xdr.MarshalBase64(meta)
+	require.NoError(t, err)
+	return b64
+}
+
+func TestContractID_NativeAsset(t *testing.T) {
+	// The well-known addresses of the native asset contract.
+	id, err := ContractID(nativeAssetPreimage(), testnetID)
+	require.NoError(t, err)
+	assert.Equal(t, "CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC", id)
+
+	id, err = ContractID(nativeAssetPreimage(), mainnetID)
+	require.NoError(t, err)
+	assert.Equal(t, "CAS3J7GYLGXMF6TDJBBYYSE3HQ6BBSMLNUQ34T6TZMYMW2EVH34XOWMA", id)
+}
+
+func TestDeployedContracts_ComputedFromEnvelope(t *testing.T) {
+	env := createContractEnvelope(t, createContractFn(nativeAssetPreimage()))
+
+	ids, err := DeployedContracts(env, "", testnetID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC"}, ids)
+}
+
+func TestDeployedContracts_CreateContractV2FromAddress(t *testing.T) {
+	deployer, err := xdr.NewAccountId(xdr.PublicKeyTypePublicKeyTypeEd25519, xdr.Uint256{0x01})
+	require.NoError(t, err)
+	preimage := xdr.ContractIdPreimage{
+		Type: xdr.ContractIdPreimageTypeContractIdPreimageFromAddress,
+		FromAddress: &xdr.ContractIdPreimageFromAddress{
+			Address: xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeAccount, AccountId: &deployer},
+			Salt:    xdr.Uint256{0x2a},
+		},
+	}
+	env := createContractEnvelope(t, xdr.HostFunction{
+		Type: xdr.HostFunctionTypeHostFunctionTypeCreateContractV2,
+		CreateContractV2: &xdr.CreateContractArgsV2{
+			ContractIdPreimage: preimage,
+			Executable: xdr.ContractExecutable{
+				Type:     xdr.ContractExecutableTypeContractExecutableWasm,
+				WasmHash: &xdr.Hash{0x01},
+			},
+			ConstructorArgs: []xdr.ScVal{},
+		},
+	})
+
+	want, err := ContractID(preimage, testnetID)
+	require.NoError(t, err)
+	ids, err := DeployedContracts(env, "", testnetID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{want}, ids)
+
+	// The salt is part of the preimage, so the same deployer gets another ID.
+	preimage.FromAddress.Salt = xdr.Uint256{0x2b}
+	other, err := ContractID(preimage, testnetID)
+	require.NoError(t, err)
+	assert.NotEqual(t, want, other)
+}
+
+func TestDeployedContracts_ReadFromMeta(t *testing.T) {
+	env := createContractEnvelope(t, createContractFn(nativeAssetPreimage()))
+	contract := xdr.ContractId{0x07, 0x08}
+	want, err := strkey.Encode(strkey.VersionByteContract, contract[:])
+	require.NoError(t, err)
+
+	// The meta takes precedence over computing the ID.
+	ids, err := DeployedContracts(env, metaReturning(t, contract), testnetID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{want}, ids)
+}
+
+func TestDeployedContracts_NoDeployment(t *testing.T) {
+	contract := xdr.ContractId{0x01}
+	env := createContractEnvelope(t, xdr.HostFunction{
+		Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+		InvokeContract: &xdr.InvokeContractArgs{
+			ContractAddress: xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contract},
+			FunctionName:    "hello",
+			Args:            []xdr.ScVal{},
+		},
+	})
+
+	ids, err := DeployedContracts(env, "", testnetID)
+	require.NoError(t, err)
+	assert.Nil(t, ids)
+}
@@ -10,11 +10,12 @@ import (
 )
 
 type DecodedEnvelope struct {
-	Type       string
-	Source     string
-	Fee        int64
-	Operations []xdr.Operation
-	InnerTx    *DecodedEnvelope // for FeeBump
+	Type        string
+	Source      string
+	Fee         int64
+	ResourceFee int64 // declared Soroban resource fee, 0 for classic transactions
+	Operations  []xdr.Operation
+	InnerTx     *DecodedEnvelope // for FeeBump
 }
 
 func AnalyzeEnvelope(b64 string) (*DecodedEnvelope, error) {
@@ -52,11 +53,16 @@ func decodeV0(tx xdr.TransactionV0) (*DecodedEnvelope, error) {
 	}, nil
 }
 func decodeV1(tx xdr.Transaction) (*DecodedEnvelope, error) {
+	var resourceFee int64
+	if data, ok := tx.Ext.GetSorobanData(); ok {
+		resourceFee = int64(data.ResourceFee)
+	}
 	return &DecodedEnvelope{
-		Type:       "TransactionV1",
-		Source:     tx.SourceAccount.Address(),
-		Fee:        int64(tx.Fee),
-		Operations: tx.Operations,
+		Type:        "TransactionV1",
+		Source:      tx.SourceAccount.Address(),
+		Fee:         int64(tx.Fee),
+		ResourceFee: resourceFee,
+		Operations:  tx.Operations,
 	}, nil
 }
 
@@ -67,10 +73,11 @@ func decodeFeeBump(fb xdr.FeeBumpTransaction) (*DecodedEnvelope, error) {
 	}
 
 	return &DecodedEnvelope{
-		Type:    "FeeBumpTransaction",
-		Source:  fb.FeeSource.Address(),
-		Fee:     int64(fb.Fee),
-		InnerTx: inner,
+		Type:        "FeeBumpTransaction",
+		Source:      fb.FeeSource.Address(),
+		Fee:         int64(fb.Fee),
+		ResourceFee: inner.ResourceFee,
+		InnerTx:     inner,
 	}, nil
 }
 
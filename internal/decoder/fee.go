@@ -0,0 +1,50 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// FeeSummary compares the fee a transaction bid with the fee it was actually
+// charged. Soroban transactions are refunded the unused part of their
+// declared resource fee, so Charged is often well below Bid.
+type FeeSummary struct {
+	// Bid is the maximum fee from the envelope (the outer fee for fee-bumps).
+	Bid int64
+	// ResourceFee is the declared Soroban resource fee included in Bid.
+	ResourceFee int64
+	// Charged is the fee reported in the transaction result.
+	Charged int64
+	// Refund is Bid minus Charged for Soroban transactions, or 0 if nothing
+	// was refunded. Classic transactions are never refunded: any gap between
+	// Bid and Charged is just the bid exceeding the fee the network asked for.
+	Refund int64
+}
+
+// SummarizeFees extracts the bid fee from a base64 TransactionEnvelope and
+// the charged fee from a base64 TransactionResult.
+func SummarizeFees(envelopeB64, resultB64 string) (*FeeSummary, error) {
+	env, err := AnalyzeEnvelope(envelopeB64)
+	if err != nil {
+		return nil, fmt.Errorf("decode envelope: %w", err)
+	}
+
+	var result xdr.TransactionResult
+	if err := xdr.SafeUnmarshalBase64(resultB64, &result); err != nil {
+		return nil, fmt.Errorf("decode result: %w", err)
+	}
+
+	summary := &FeeSummary{
+		Bid:         env.Fee,
+		ResourceFee: env.ResourceFee,
+		Charged:     int64(result.FeeCharged),
+	}
+	if summary.ResourceFee > 0 && summary.Bid > summary.Charged {
+		summary.Refund = summary.Bid - summary.Charged
+	}
+	return summary, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeResult(t *testing.T, feeCharged int64) string {
+	t.Helper()
+	results := []xdr.OperationResult{}
+	result := xdr.TransactionResult{
+		FeeCharged: xdr.Int64(feeCharged),
+		Result: xdr.TransactionResultResult{
+			Code:    xdr.TransactionResultCodeTxSuccess,
+			Results: &results,
+		},
+	}
+	b64, err := xdr.MarshalBase64(result)
+	require.NoError(t, err)
+	return b64
+}
+
+func TestSummarizeFees_SorobanRefund(t *testing.T) {
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: muxed(t, 0x01),
+			Fee:           1_000_000,
+			SeqNum:        1,
+			Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+			Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+			Operations: []xdr.Operation{{Body: xdr.OperationBody{
+				Type:                 xdr.OperationTypeExtendFootprintTtl,
+				ExtendFootprintTtlOp: &xdr.ExtendFootprintTtlOp{ExtendTo: 10},
+			}}},
+			Ext: xdr.TransactionExt{
+				V: 1,
+				SorobanData: &xdr.SorobanTransactionData{
+					Resources: xdr.SorobanResources{
+						Footprint: xdr.LedgerFootprint{ReadOnly: []xdr.LedgerKey{}, ReadWrite: []xdr.LedgerKey{}},
+					},
+					ResourceFee: 999_900,
+				},
+			},
+		}},
+	}
+	envB64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	summary, err := SummarizeFees(envB64, encodeResult(t, 250_000))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_000_000), summary.Bid)
+	assert.Equal(t, int64(999_900), summary.ResourceFee)
+	assert.Equal(t, int64(250_000), summary.Charged)
+	assert.Equal(t, int64(750_000), summary.Refund)
+}
+
+func TestSummarizeFees_ClassicNoRefund(t *testing.T) {
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: muxed(t, 0x01),
+			Fee:           100,
+			SeqNum:        1,
+			Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+			Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+			Operations: []xdr.Operation{{Body: xdr.OperationBody{
+				Type:           xdr.OperationTypeBumpSequence,
+				BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 2},
+			}}},
+		}},
+	}
+	envB64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	summary, err := SummarizeFees(envB64, encodeResult(t, 100))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), summary.ResourceFee)
+	assert.Equal(t, int64(0), summary.Refund)
+}
+
+func TestSummarizeFees_ClassicBidAboveCharged(t *testing.T) {
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: muxed(t, 0x01),
+			Fee:           10_000,
+			SeqNum:        1,
+			Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+			Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+			Operations: []xdr.Operation{{Body: xdr.OperationBody{
+				Type:           xdr.OperationTypeBumpSequence,
+				BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 2},
+			}}},
+		}},
+	}
+	envB64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	summary, err := SummarizeFees(envB64, encodeResult(t, 100))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10_000), summary.Bid)
+	assert.Equal(t, int64(100), summary.Charged)
+	assert.Equal(t, int64(0), summary.Refund, "classic transactions are charged, not refunded")
+}
+
+func TestSummarizeFees_InvalidResult(t *testing.T) {
+	_, err := SummarizeFees("AAAA", "")
+	assert.Error(t, err)
+}
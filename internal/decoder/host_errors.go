@@ -0,0 +1,187 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// HostErrorInfo is a readable explanation of a Soroban host error, which
+// the host reports as a (type, code) pair such as Error(Storage, ExceededLimit).
+type HostErrorInfo struct {
+	Type    string
+	Code    string
+	Message string
+	Causes  []string
+}
+
+// hostErrorTypes names each ScErrorType as the host prints it.
+var hostErrorTypes = map[xdr.ScErrorType]string{
+	xdr.ScErrorTypeSceContract: "Contract",
+	xdr.ScErrorTypeSceWasmVm:   "WasmVm",
+	xdr.ScErrorTypeSceContext:  "Context",
+	xdr.ScErrorTypeSceStorage:  "Storage",
+	xdr.ScErrorTypeSceObject:   "Object",
+	xdr.ScErrorTypeSceCrypto:   "Crypto",
+	xdr.ScErrorTypeSceEvents:   "Events",
+	xdr.ScErrorTypeSceBudget:   "Budget",
+	xdr.ScErrorTypeSceValue:    "Value",
+	xdr.ScErrorTypeSceAuth:     "Auth",
+}
+
+// hostErrorCodes names each ScErrorCode as the host prints it.
+var hostErrorCodes = map[xdr.ScErrorCode]string{
+	xdr.ScErrorCodeScecArithDomain:    "ArithDomain",
+	xdr.ScErrorCodeScecIndexBounds:    "IndexBounds",
+	xdr.ScErrorCodeScecInvalidInput:   "InvalidInput",
+	xdr.ScErrorCodeScecMissingValue:   "MissingValue",
+	xdr.ScErrorCodeScecExistingValue:  "ExistingValue",
+	xdr.ScErrorCodeScecExceededLimit:  "ExceededLimit",
+	xdr.ScErrorCodeScecInvalidAction:  "InvalidAction",
+	xdr.ScErrorCodeScecInternalError:  "InternalError",
+	xdr.ScErrorCodeScecUnexpectedType: "UnexpectedType",
+	xdr.ScErrorCodeScecUnexpectedSize: "UnexpectedSize",
+}
+
+// hostErrorCauses lists likely causes for specific (type, code) pairs.
+var hostErrorCauses = map[string][]string{
+	"Storage/ExceededLimit": {
+		"A ledger entry was accessed that is not in the transaction footprint",
+		"The transaction read or wrote more entries or bytes than its declared resources allow",
+	},
+	"Storage/MissingValue": {
+		"The contract read a storage key that does not exist",
+		"The entry was archived and must be restored before use",
+	},
+	"Budget/ExceededLimit": {
+		"The CPU instruction or memory limit of the transaction was exhausted",
+		"Re-simulate the transaction to get up-to-date resource limits",
+	},
+	"Auth/InvalidAction": {
+		"require_auth failed: a required signature or authorization entry is missing",
+		"The authorization entry does not match the invocation tree",
+	},
+	"Context/InvalidAction": {
+		"The contract panicked or called panic_with_error",
+	},
+	"WasmVm/InvalidAction": {
+		"The contract trapped, e.g. on unreachable code or an unwrap of None/Err",
+	},
+	"Value/UnexpectedType": {
+		"A function was called with an argument of the wrong type",
+	},
+	"Object/IndexBounds": {
+		"A vector or bytes index was out of range",
+	},
+	"Crypto/InvalidInput": {
+		"A signature or key passed to a crypto function is malformed",
+	},
+}
+
+// ExplainHostError explains a host error (type, code) pair.
+func ExplainHostError(errType xdr.ScErrorType, code xdr.ScErrorCode) HostErrorInfo {
+	return explainHostError(scErrorTypeName(errType), scErrorCodeName(code))
+}
+
+// ExplainScError explains an ScError, including contract-defined errors.
+func ExplainScError(e xdr.ScError) HostErrorInfo {
+	if e.Type == xdr.ScErrorTypeSceContract {
+		var code uint32
+		if e.ContractCode != nil {
+			code = uint32(*e.ContractCode)
+		}
+		return contractErrorInfo(code)
+	}
+	var code xdr.ScErrorCode
+	if e.Code != nil {
+		code = *e.Code
+	}
+	return ExplainHostError(e.Type, code)
+}
+
+// hostErrorPattern matches the host's Debug rendering of an error, e.g.
+// "Error(Storage, ExceededLimit)" or "Error(Contract, #3)".
+var hostErrorPattern = regexp.MustCompile(`Error\((\w+),\s*(#?\w+)\)`)
+
+// FindHostErrors extracts and explains every distinct host error mentioned
+// in text, in order of first appearance.
+func FindHostErrors(text string) []HostErrorInfo {
+	var out []HostErrorInfo
+	seen := map[string]bool{}
+	for _, m := range hostErrorPattern.FindAllStringSubmatch(text, -1) {
+		key := m[1] + "/" + m[2]
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if m[1] == "Contract" {
+			code, err := strconv.ParseUint(strings.TrimPrefix(m[2], "#"), 10, 32)
+			if err == nil {
+				out = append(out, contractErrorInfo(uint32(code)))
+				continue
+			}
+		}
+		out = append(out, explainHostError(m[1], m[2]))
+	}
+	return out
+}
+
+func explainHostError(typ, code string) HostErrorInfo {
+	return HostErrorInfo{
+		Type:    typ,
+		Code:    code,
+		Message: fmt.Sprintf("%s error: %s", splitCamel(typ, false), splitCamel(code, true)),
+		Causes:  hostErrorCauses[typ+"/"+code],
+	}
+}
+
+func contractErrorInfo(code uint32) HostErrorInfo {
+	return HostErrorInfo{
+		Type:    "Contract",
+		Code:    fmt.Sprintf("#%d", code),
+		Message: fmt.Sprintf("Contract error: code %d", code),
+		Causes:  []string{"The contract returned its own error code; see the contract's error enum"},
+	}
+}
+
+func scErrorTypeName(t xdr.ScErrorType) string {
+	if name, ok := hostErrorTypes[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown(%d)", int32(t))
+}
+
+func scErrorCodeName(c xdr.ScErrorCode) string {
+	if name, ok := hostErrorCodes[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown(%d)", int32(c))
+}
+
+// splitCamel turns "ExceededLimit" into "Exceeded limit", or "exceeded
+// limit" when lower is set. "WasmVm" is kept as "Wasm VM".
+func splitCamel(s string, lower bool) string {
+	if s == "WasmVm" {
+		return "Wasm VM"
+	}
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte(' ')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	if lower && out != "" {
+		out = strings.ToLower(out[:1]) + out[1:]
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+func TestExplainHostError(t *testing.T) {
+	tests := []struct {
+		name       string
+		errType    xdr.ScErrorType
+		code       xdr.ScErrorCode
+		wantMsg    string
+		wantCauses bool
+	}{
+		{"storage exceeded limit", xdr.ScErrorTypeSceStorage, xdr.ScErrorCodeScecExceededLimit, "Storage error: exceeded limit", true},
+		{"auth invalid action", xdr.ScErrorTypeSceAuth, xdr.ScErrorCodeScecInvalidAction, "Auth error: invalid action", true},
+		{"wasm vm", xdr.ScErrorTypeSceWasmVm, xdr.ScErrorCodeScecInvalidAction, "Wasm VM error: invalid action", true},
+		{"no known causes", xdr.ScErrorTypeSceEvents, xdr.ScErrorCodeScecInternalError, "Events error: internal error", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := ExplainHostError(tt.errType, tt.code)
+			if info.Message != tt.wantMsg {
+				t.Errorf("Message = %q, want %q", info.Message, tt.wantMsg)
+			}
+			if (len(info.Causes) > 0) != tt.wantCauses {
+				t.Errorf("Causes = %v, want causes: %v", info.Causes, tt.wantCauses)
+			}
+		})
+	}
+}
+
+func TestExplainScError_Contract(t *testing.T) {
+	code := xdr.Uint32(3)
+	info := ExplainScError(xdr.ScError{Type: xdr.ScErrorTypeSceContract, ContractCode: &code})
+	if info.Message != "Contract error: code 3" || info.Code != "#3" {
+		t.Errorf("unexpected contract error info: %+v", info)
+	}
+}
+
+func TestFindHostErrors(t *testing.T) {
+	text := `HostError: Error(Storage, ExceededLimit)
+Event log: [Error(Budget, ExceededLimit), Error(Storage, ExceededLimit), Error(Contract, #12)]`
+
+	got := FindHostErrors(text)
+	if len(got) != 3 {
+		t.Fatalf("FindHostErrors returned %d errors, want 3: %+v", len(got), got)
+	}
+	want := []string{
+		"Storage error: exceeded limit",
+		"Budget error: exceeded limit",
+		"Contract error: code 12",
+	}
+	for i, w := range want {
+		if got[i].Message != w {
+			t.Errorf("error %d = %q, want %q", i, got[i].Message, w)
+		}
+	}
+
+	if errs := FindHostErrors("transaction failed"); len(errs) != 0 {
+		t.Errorf("expected no host errors, got %+v", errs)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// OperationSummary is a one-line description of an operation in a transaction.
+type OperationSummary struct {
+	Index int
+	// Type is the kebab-case operation name, e.g. "invoke-host-function".
+	Type   string
+	Source string
+	// InheritedSource is true when the operation has no source of its own and
+	// runs as the transaction source account.
+	InheritedSource bool
+}
+
+// ListOperations decodes a base64 TransactionEnvelope and returns an indexed
+// summary of its operations. For fee-bump envelopes the inner transaction's
+// operations are listed.
+func ListOperations(envelopeB64 string) ([]OperationSummary, error) {
+	env, err := AnalyzeEnvelope(envelopeB64)
+	if err != nil {
+		return nil, err
+	}
+	if env.InnerTx != nil {
+		env = env.InnerTx
+	}
+
+	ops := make([]OperationSummary, 0, len(env.Operations))
+	for i, op := range env.Operations {
+		summary := OperationSummary{
+			Index:           i,
+			Type:            OperationTypeName(op.Body.Type),
+			Source:          env.Source,
+			InheritedSource: true,
+		}
+		if op.SourceAccount != nil {
+			summary.Source = op.SourceAccount.Address()
+			summary.InheritedSource = false
+		}
+		ops = append(ops, summary)
+	}
+	return ops, nil
+}
+
+// OperationTypeName converts an operation type to its kebab-case name, e.g.
+// OperationTypeInvokeHostFunction becomes "invoke-host-function".
+func OperationTypeName(t xdr.OperationType) string {
+	name := strings.TrimPrefix(t.String(), "OperationType")
+
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
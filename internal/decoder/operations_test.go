@@ -0,0 +1,93 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func muxed(t *testing.T, fill byte) xdr.MuxedAccount {
+	t.Helper()
+	var pk xdr.Uint256
+	for i := range pk {
+		pk[i] = fill
+	}
+	m, err := xdr.NewMuxedAccount(xdr.CryptoKeyTypeKeyTypeEd25519, pk)
+	require.NoError(t, err)
+	return m
+}
+
+func TestListOperations_MultiOperation(t *testing.T) {
+	txSource := muxed(t, 0x01)
+	opSource := muxed(t, 0x02)
+	dest := muxed(t, 0x03)
+
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: txSource,
+			Fee:           300,
+			SeqNum:        1,
+			Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+			Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+			Operations: []xdr.Operation{
+				{
+					Body: xdr.OperationBody{
+						Type: xdr.OperationTypePayment,
+						PaymentOp: &xdr.PaymentOp{
+							Destination: dest,
+							Asset:       xdr.Asset{Type: xdr.AssetTypeAssetTypeNative},
+							Amount:      100,
+						},
+					},
+				},
+				{
+					SourceAccount: &opSource,
+					Body: xdr.OperationBody{
+						Type:           xdr.OperationTypeBumpSequence,
+						BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 5},
+					},
+				},
+				{
+					Body: xdr.OperationBody{
+						Type:                 xdr.OperationTypeExtendFootprintTtl,
+						ExtendFootprintTtlOp: &xdr.ExtendFootprintTtlOp{ExtendTo: 10},
+					},
+				},
+			},
+		}},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	ops, err := ListOperations(b64)
+	require.NoError(t, err)
+	require.Len(t, ops, 3)
+
+	assert.Equal(t, 0, ops[0].Index)
+	assert.Equal(t, "payment", ops[0].Type)
+	assert.Equal(t, txSource.Address(), ops[0].Source)
+	assert.True(t, ops[0].InheritedSource)
+
+	assert.Equal(t, "bump-sequence", ops[1].Type)
+	assert.Equal(t, opSource.Address(), ops[1].Source)
+	assert.False(t, ops[1].InheritedSource)
+
+	assert.Equal(t, "extend-footprint-ttl", ops[2].Type)
+}
+
+func TestOperationTypeName(t *testing.T) {
+	assert.Equal(t, "invoke-host-function", OperationTypeName(xdr.OperationTypeInvokeHostFunction))
+	assert.Equal(t, "create-account", OperationTypeName(xdr.OperationTypeCreateAccount))
+	assert.Equal(t, "restore-footprint", OperationTypeName(xdr.OperationTypeRestoreFootprint))
+}
+
+func TestListOperations_InvalidEnvelope(t *testing.T) {
+	_, err := ListOperations("not-base64")
+	assert.Error(t, err)
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// DefaultSCValDepth is how many levels of nested vectors and maps
+// FormatSCVal expands before summarising them.
+const DefaultSCValDepth = 4
+
+// FormatSCVal renders an ScVal as human-readable text: numbers in decimal,
+// symbols bare, strings quoted, bytes as hex and addresses as strkeys.
+// Vectors and maps are spread over indented lines up to DefaultSCValDepth.
+func FormatSCVal(v xdr.ScVal) string {
+	return FormatSCValDepth(v, DefaultSCValDepth)
+}
+
+// FormatSCValDepth is FormatSCVal with a custom nesting limit. Vectors and
+// maps nested deeper than maxDepth are shown only by their size, so deeply
+// nested values stay readable.
+func FormatSCValDepth(v xdr.ScVal, maxDepth int) string {
+	var b strings.Builder
+	writeSCVal(&b, v, 0, maxDepth)
+	return b.String()
+}
+
+func writeSCVal(b *strings.Builder, v xdr.ScVal, level, maxDepth int) {
+	switch v.Type {
+	case xdr.ScValTypeScvVec:
+		var items []xdr.ScVal
+		if v.Vec != nil && *v.Vec != nil {
+			items = **v.Vec
+		}
+		writeSCVec(b, items, level, maxDepth)
+	case xdr.ScValTypeScvMap:
+		var entries []xdr.ScMapEntry
+		if v.Map != nil && *v.Map != nil {
+			entries = **v.Map
+		}
+		writeSCMap(b, entries, level, maxDepth)
+	case xdr.ScValTypeScvContractInstance:
+		if v.Instance == nil {
+			b.WriteString("contract instance")
+			return
+		}
+		inst := v.Instance
+		switch inst.Executable.Type {
+		case xdr.ContractExecutableTypeContractExecutableWasm:
+			if inst.Executable.WasmHash != nil {
+				fmt.Fprintf(b, "contract instance (wasm %s)", hex.EncodeToString(inst.Executable.WasmHash[:]))
+			} else {
+				b.WriteString("contract instance (wasm)")
+			}
+		case xdr.ContractExecutableTypeContractExecutableStellarAsset:
+			b.WriteString("contract instance (stellar asset)")
+		default:
+			b.WriteString("contract instance")
+		}
+		if inst.Storage != nil && len(*inst.Storage) > 0 {
+			b.WriteString(" storage ")
+			writeSCMap(b, *inst.Storage, level, maxDepth)
+		}
+	default:
+		b.WriteString(formatSCScalar(v))
+	}
+}
+
+func writeSCVec(b *strings.Builder, items []xdr.ScVal, level, maxDepth int) {
+	switch {
+	case len(items) == 0:
+		b.WriteString("[]")
+		return
+	case level >= maxDepth:
+		fmt.Fprintf(b, "[... %d items]", len(items))
+		return
+	}
+	b.WriteString("[\n")
+	for _, item := range items {
+		writeIndent(b, level+1)
+		writeSCVal(b, item, level+1, maxDepth)
+		b.WriteString("\n")
+	}
+	writeIndent(b, level)
+	b.WriteString("]")
+}
+
+func writeSCMap(b *strings.Builder, entries []xdr.ScMapEntry, level, maxDepth int) {
+	switch {
+	case len(entries) == 0:
+		b.WriteString("{}")
+		return
+	case level >= maxDepth:
+		fmt.Fprintf(b, "{... %d entries}", len(entries))
+		return
+	}
+	b.WriteString("{\n")
+	for _, e := range entries {
+		writeIndent(b, level+1)
+		writeSCVal(b, e.Key, level+1, maxDepth)
+		b.WriteString(": ")
+		writeSCVal(b, e.Val, level+1, maxDepth)
+		b.WriteString("\n")
+	}
+	writeIndent(b, level)
+	b.WriteString("}")
+}
+
+func writeIndent(b *strings.Builder, level int) {
+	b.WriteString(strings.Repeat("  ", level))
+}
+
+// formatSCScalar renders the ScVal arms that are not containers. The
+// pointer fields are checked directly because the union getters panic on
+// a missing arm.
+func formatSCScalar(v xdr.ScVal) string {
+	switch v.Type {
+	case xdr.ScValTypeScvBool:
+		if v.B != nil {
+			return fmt.Sprintf("%t", *v.B)
+		}
+	case xdr.ScValTypeScvVoid:
+		return "void"
+	case xdr.ScValTypeScvError:
+		if v.Error != nil {
+			return formatSCError(*v.Error)
+		}
+	case xdr.ScValTypeScvU32:
+		if v.U32 != nil {
+			return fmt.Sprintf("%d", *v.U32)
+		}
+	case xdr.ScValTypeScvI32:
+		if v.I32 != nil {
+			return fmt.Sprintf("%d", *v.I32)
+		}
+	case xdr.ScValTypeScvU64:
+		if v.U64 != nil {
+			return fmt.Sprintf("%d", *v.U64)
+		}
+	case xdr.ScValTypeScvI64:
+		if v.I64 != nil {
+			return fmt.Sprintf("%d", *v.I64)
+		}
+	case xdr.ScValTypeScvTimepoint:
+		if v.Timepoint != nil {
+			return fmt.Sprintf("timepoint(%d)", *v.Timepoint)
+		}
+	case xdr.ScValTypeScvDuration:
+		if v.Duration != nil {
+			return fmt.Sprintf("duration(%ds)", *v.Duration)
+		}
+	case xdr.ScValTypeScvU128:
+		if p := v.U128; p != nil {
+			return joinWords(false, uint64(p.Hi), uint64(p.Lo)).String()
+		}
+	case xdr.ScValTypeScvI128:
+		if p := v.I128; p != nil {
+			return joinWords(true, uint64(p.Hi), uint64(p.Lo)).String()
+		}
+	case xdr.ScValTypeScvU256:
+		if p := v.U256; p != nil {
+			return joinWords(false, uint64(p.HiHi), uint64(p.HiLo), uint64(p.LoHi), uint64(p.LoLo)).String()
+		}
+	case xdr.ScValTypeScvI256:
+		if p := v.I256; p != nil {
+			return joinWords(true, uint64(p.HiHi), uint64(p.HiLo), uint64(p.LoHi), uint64(p.LoLo)).String()
+		}
+	case xdr.ScValTypeScvBytes:
+		if v.Bytes != nil {
+			return "0x" + hex.EncodeToString(*v.Bytes)
+		}
+	case xdr.ScValTypeScvString:
+		if v.Str != nil {
+			return fmt.Sprintf("%q", string(*v.Str))
+		}
+	case xdr.ScValTypeScvSymbol:
+		if v.Sym != nil {
+			return string(*v.Sym)
+		}
+	case xdr.ScValTypeScvAddress:
+		if v.Address != nil {
+			addr, err := v.Address.String()
+			if err != nil {
+				return fmt.Sprintf("address(invalid: %v)", err)
+			}
+			return addr
+		}
+	case xdr.ScValTypeScvLedgerKeyContractInstance:
+		return "ledger key: contract instance"
+	case xdr.ScValTypeScvLedgerKeyNonce:
+		if v.NonceKey != nil {
+			return fmt.Sprintf("ledger key: nonce %d", v.NonceKey.Nonce)
+		}
+	default:
+		return fmt.Sprintf("<%s>", v.Type)
+	}
+	return fmt.Sprintf("<%s: missing value>", v.Type)
+}
+
+func formatSCError(e xdr.ScError) string {
+	if e.Type == xdr.ScErrorTypeSceContract && e.ContractCode != nil {
+		return fmt.Sprintf("error(Contract, #%d)", *e.ContractCode)
+	}
+	if e.Code != nil {
+		return fmt.Sprintf("error(%s, %s)", scErrorTypeName(e.Type), scErrorCodeName(*e.Code))
+	}
+	return fmt.Sprintf("error(%s)", scErrorTypeName(e.Type))
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+func TestFormatSCVal_Scalars(t *testing.T) {
+	u32 := xdr.Uint32(7)
+	i64 := xdr.Int64(-42)
+	b := true
+	sym := xdr.ScSymbol("balance")
+	str := xdr.ScString("hi")
+	bytes := xdr.ScBytes{0xde, 0xad}
+	i128 := xdr.Int128Parts{Hi: -1, Lo: math.MaxUint64 - 4}
+	u128 := xdr.UInt128Parts{Hi: 1, Lo: 0}
+	code := xdr.ScErrorCodeScecInvalidInput
+	contractCode := xdr.Uint32(3)
+
+	var account xdr.AccountId
+	if err := account.SetAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		val  xdr.ScVal
+		want string
+	}{
+		{"u32", xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &u32}, "7"},
+		{"i64", xdr.ScVal{Type: xdr.ScValTypeScvI64, I64: &i64}, "-42"},
+		{"bool", xdr.ScVal{Type: xdr.ScValTypeScvBool, B: &b}, "true"},
+		{"void", xdr.ScVal{Type: xdr.ScValTypeScvVoid}, "void"},
+		{"symbol", xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}, "balance"},
+		{"string", xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &str}, `"hi"`},
+		{"bytes", xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &bytes}, "0xdead"},
+		{"i128", xdr.ScVal{Type: xdr.ScValTypeScvI128, I128: &i128}, "-5"},
+		{"u128", xdr.ScVal{Type: xdr.ScValTypeScvU128, U128: &u128}, "18446744073709551616"},
+		{
+			"address",
+			xdr.ScVal{Type: xdr.ScValTypeScvAddress, Address: &xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeAccount, AccountId: &account}},
+			"GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7",
+		},
+		{
+			"host error",
+			xdr.ScVal{Type: xdr.ScValTypeScvError, Error: &xdr.ScError{Type: xdr.ScErrorTypeSceValue, Code: &code}},
+			"error(Value, InvalidInput)",
+		},
+		{
+			"contract error",
+			xdr.ScVal{Type: xdr.ScValTypeScvError, Error: &xdr.ScError{Type: xdr.ScErrorTypeSceContract, ContractCode: &contractCode}},
+			"error(Contract, #3)",
+		},
+		{"instance key", xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance}, "ledger key: contract instance"},
+		{"missing arm", xdr.ScVal{Type: xdr.ScValTypeScvU32}, "<ScValTypeScvU32: missing value>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatSCVal(tt.val); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatSCVal_Containers(t *testing.T) {
+	u32 := xdr.Uint32(1)
+	sym := xdr.ScSymbol("owner")
+	str := xdr.ScString("alice")
+	inner := &xdr.ScVec{{Type: xdr.ScValTypeScvU32, U32: &u32}}
+	m := &xdr.ScMap{
+		{
+			Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+			Val: xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &str},
+		},
+		{
+			Key: xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &u32},
+			Val: xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &inner},
+		},
+	}
+	val := xdr.ScVal{Type: xdr.ScValTypeScvMap, Map: &m}
+
+	want := "{\n" +
+		"  owner: \"alice\"\n" +
+		"  1: [\n" +
+		"    1\n" +
+		"  ]\n" +
+		"}"
+	if got := FormatSCVal(val); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	empty := &xdr.ScVec{}
+	if got := FormatSCVal(xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &empty}); got != "[]" {
+		t.Errorf("expected empty vector, got %q", got)
+	}
+}
+
+func TestFormatSCValDepth_SummarisesDeepValues(t *testing.T) {
+	u32 := xdr.Uint32(9)
+	val := xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &u32}
+	for i := 0; i < 10; i++ {
+		vec := &xdr.ScVec{val, val}
+		val = xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &vec}
+	}
+
+	if got := FormatSCValDepth(val, 0); got != "[... 2 items]" {
+		t.Errorf("expected a summary at depth 0, got %q", got)
+	}
+
+	want := "[\n" +
+		"  [... 2 items]\n" +
+		"  [... 2 items]\n" +
+		"]"
+	if got := FormatSCValDepth(val, 1); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// ScValToRPCJSON encodes val in the JSON form Stellar RPC returns with
+// xdrFormat=json and the SDKs accept, which is the serde encoding of the
+// stellar-xdr crate:
+//
+//   - unions are objects keyed by the snake_case arm name ({"u32":7}); arms
+//     without a value are bare strings ("void")
+//   - 64-bit and wider integers, timepoints and durations are decimal strings
+//   - bytes and hashes are lowercase hex; addresses are strkeys
+//   - strings and symbols escape bytes outside printable ASCII as \xNN
+//   - map entries are {"key":...,"val":...} objects
+//
+// This differs from json.Marshal of the Go XDR struct, which exposes the
+// generated field names and pointer layout.
+func ScValToRPCJSON(val xdr.ScVal) ([]byte, error) {
+	v, err := scValToRPC(val)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// rpcMapEntry keeps key before val in the encoded output.
+type rpcMapEntry struct {
+	Key interface{} `json:"key"`
+	Val interface{} `json:"val"`
+}
+
+func scValToRPC(val xdr.ScVal) (interface{}, error) {
+	arm := func(name string, v interface{}) interface{} {
+		return map[string]interface{}{name: v}
+	}
+
+	switch val.Type {
+	case xdr.ScValTypeScvBool:
+		return arm("bool", val.MustB()), nil
+	case xdr.ScValTypeScvVoid:
+		return "void", nil
+	case xdr.ScValTypeScvError:
+		e, err := scErrorToRPC(val.MustError())
+		if err != nil {
+			return nil, err
+		}
+		return arm("error", e), nil
+	case xdr.ScValTypeScvU32:
+		return arm("u32", uint32(val.MustU32())), nil
+	case xdr.ScValTypeScvI32:
+		return arm("i32", int32(val.MustI32())), nil
+	case xdr.ScValTypeScvU64:
+		return arm("u64", fmt.Sprintf("%d", uint64(val.MustU64()))), nil
+	case xdr.ScValTypeScvI64:
+		return arm("i64", fmt.Sprintf("%d", int64(val.MustI64()))), nil
+	case xdr.ScValTypeScvTimepoint:
+		return arm("timepoint", fmt.Sprintf("%d", uint64(val.MustTimepoint()))), nil
+	case xdr.ScValTypeScvDuration:
+		return arm("duration", fmt.Sprintf("%d", uint64(val.MustDuration()))), nil
+	case xdr.ScValTypeScvU128:
+		p := val.MustU128()
+		return arm("u128", joinWords(false, uint64(p.Hi), uint64(p.Lo)).String()), nil
+	case xdr.ScValTypeScvI128:
+		p := val.MustI128()
+		return arm("i128", joinWords(true, uint64(p.Hi), uint64(p.Lo)).String()), nil
+	case xdr.ScValTypeScvU256:
+		p := val.MustU256()
+		return arm("u256", joinWords(false, uint64(p.HiHi), uint64(p.HiLo), uint64(p.LoHi), uint64(p.LoLo)).String()), nil
+	case xdr.ScValTypeScvI256:
+		p := val.MustI256()
+		return arm("i256", joinWords(true, uint64(p.HiHi), uint64(p.HiLo), uint64(p.LoHi), uint64(p.LoLo)).String()), nil
+	case xdr.ScValTypeScvBytes:
+		return arm("bytes", hex.EncodeToString(val.MustBytes())), nil
+	case xdr.ScValTypeScvString:
+		return arm("string", escapeBytes([]byte(val.MustStr()))), nil
+	case xdr.ScValTypeScvSymbol:
+		return arm("symbol", escapeBytes([]byte(val.MustSym()))), nil
+	case xdr.ScValTypeScvVec:
+		vec := val.MustVec()
+		if vec == nil {
+			return arm("vec", nil), nil
+		}
+		items := make([]interface{}, 0, len(*vec))
+		for _, item := range *vec {
+			v, err := scValToRPC(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return arm("vec", items), nil
+	case xdr.ScValTypeScvMap:
+		m := val.MustMap()
+		if m == nil {
+			return arm("map", nil), nil
+		}
+		entries, err := scMapToRPC(*m)
+		if err != nil {
+			return nil, err
+		}
+		return arm("map", entries), nil
+	case xdr.ScValTypeScvAddress:
+		addr, err := val.MustAddress().String()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode address: %w", err)
+		}
+		return arm("address", addr), nil
+	case xdr.ScValTypeScvContractInstance:
+		inst, err := contractInstanceToRPC(val.MustInstance())
+		if err != nil {
+			return nil, err
+		}
+		return arm("contract_instance", inst), nil
+	case xdr.ScValTypeScvLedgerKeyContractInstance:
+		return "ledger_key_contract_instance", nil
+	case xdr.ScValTypeScvLedgerKeyNonce:
+		nonce := val.MustNonceKey()
+		return arm("ledger_key_nonce", map[string]interface{}{
+			"nonce": fmt.Sprintf("%d", int64(nonce.Nonce)),
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported ScVal type: %v", val.Type)
+	}
+}
+
+func scMapToRPC(m xdr.ScMap) ([]rpcMapEntry, error) {
+	entries := make([]rpcMapEntry, 0, len(m))
+	for _, e := range m {
+		k, err := scValToRPC(e.Key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := scValToRPC(e.Val)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, rpcMapEntry{Key: k, Val: v})
+	}
+	return entries, nil
+}
+
+func scErrorToRPC(e xdr.ScError) (interface{}, error) {
+	typ, ok := hostErrorTypes[e.Type]
+	if !ok {
+		return nil, fmt.Errorf("unsupported ScError type: %v", e.Type)
+	}
+	if e.Type == xdr.ScErrorTypeSceContract {
+		return map[string]interface{}{"contract": uint32(e.MustContractCode())}, nil
+	}
+	code, ok := hostErrorCodes[e.MustCode()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported ScError code: %v", e.MustCode())
+	}
+	return map[string]interface{}{snakeCase(typ): snakeCase(code)}, nil
+}
+
+func contractInstanceToRPC(inst xdr.ScContractInstance) (interface{}, error) {
+	var executable interface{}
+	switch inst.Executable.Type {
+	case xdr.ContractExecutableTypeContractExecutableWasm:
+		hash := inst.Executable.MustWasmHash()
+		executable = map[string]interface{}{"wasm": hex.EncodeToString(hash[:])}
+	case xdr.ContractExecutableTypeContractExecutableStellarAsset:
+		executable = "stellar_asset"
+	default:
+		return nil, fmt.Errorf("unsupported contract executable: %v", inst.Executable.Type)
+	}
+
+	var storage interface{}
+	if inst.Storage != nil {
+		entries, err := scMapToRPC(*inst.Storage)
+		if err != nil {
+			return nil, err
+		}
+		storage = entries
+	}
+	return map[string]interface{}{
+		"executable": executable,
+		"storage":    storage,
+	}, nil
+}
+
+// joinWords assembles big-endian 64-bit words into one integer. With signed
+// set the most significant word is read as two's complement.
+func joinWords(signed bool, words ...uint64) *big.Int {
+	n := new(big.Int)
+	for _, w := range words {
+		n.Lsh(n, 64)
+		n.Or(n, new(big.Int).SetUint64(w))
+	}
+	if signed && len(words) > 0 && words[0]>>63 == 1 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(64*len(words))))
+	}
+	return n
+}
+
+// snakeCase turns "ExceededLimit" into "exceeded_limit".
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// escapeBytes escapes b the way the stellar-xdr crate encodes string types:
+// printable ASCII is kept, \0 \t \r \n and the backslash get short escapes,
+// and every other byte becomes \xNN.
+func escapeBytes(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		switch {
+		case c == 0:
+			sb.WriteString(`\0`)
+		case c == '\t':
+			sb.WriteString(`\t`)
+		case c == '\r':
+			sb.WriteString(`\r`)
+		case c == '\n':
+			sb.WriteString(`\n`)
+		case c == '\\':
+			sb.WriteString(`\\`)
+		case c >= 0x20 && c <= 0x7e:
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, `\x%02x`, c)
+		}
+	}
+	return sb.String()
+}
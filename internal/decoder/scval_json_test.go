@@ -0,0 +1,85 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+func TestScValToRPCJSON_Fixtures(t *testing.T) {
+	u32 := xdr.Uint32(7)
+	i32 := xdr.Int32(-7)
+	u64 := xdr.Uint64(math.MaxUint64)
+	i64 := xdr.Int64(-42)
+	tp := xdr.TimePoint(1700000000)
+	b := true
+	sym := xdr.ScSymbol("transfer")
+	str := xdr.ScString("héllo")
+	bytes := xdr.ScBytes{0xde, 0xad, 0xbe, 0xef}
+	u128 := xdr.UInt128Parts{Hi: 1, Lo: 2}
+	i128 := xdr.Int128Parts{Hi: -1, Lo: math.MaxUint64 - 4}
+	i256 := xdr.Int256Parts{HiHi: -1, HiLo: math.MaxUint64, LoHi: math.MaxUint64, LoLo: math.MaxUint64}
+
+	var account xdr.AccountId
+	if err := account.SetAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"); err != nil {
+		t.Fatal(err)
+	}
+	addr := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeAccount, AccountId: &account}
+
+	vec := &xdr.ScVec{{Type: xdr.ScValTypeScvU32, U32: &u32}, {Type: xdr.ScValTypeScvVoid}}
+	m := &xdr.ScMap{{
+		Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+		Val: xdr.ScVal{Type: xdr.ScValTypeScvBool, B: &b},
+	}}
+	var nilVec *xdr.ScVec
+
+	contractCode := xdr.Uint32(3)
+	storageCode := xdr.ScErrorCodeScecExceededLimit
+
+	tests := []struct {
+		name string
+		val  xdr.ScVal
+		want string
+	}{
+		{"bool", xdr.ScVal{Type: xdr.ScValTypeScvBool, B: &b}, `{"bool":true}`},
+		{"void", xdr.ScVal{Type: xdr.ScValTypeScvVoid}, `"void"`},
+		{"u32", xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &u32}, `{"u32":7}`},
+		{"i32", xdr.ScVal{Type: xdr.ScValTypeScvI32, I32: &i32}, `{"i32":-7}`},
+		{"u64", xdr.ScVal{Type: xdr.ScValTypeScvU64, U64: &u64}, `{"u64":"18446744073709551615"}`},
+		{"i64", xdr.ScVal{Type: xdr.ScValTypeScvI64, I64: &i64}, `{"i64":"-42"}`},
+		{"timepoint", xdr.ScVal{Type: xdr.ScValTypeScvTimepoint, Timepoint: &tp}, `{"timepoint":"1700000000"}`},
+		{"u128", xdr.ScVal{Type: xdr.ScValTypeScvU128, U128: &u128}, `{"u128":"18446744073709551618"}`},
+		{"i128 negative", xdr.ScVal{Type: xdr.ScValTypeScvI128, I128: &i128}, `{"i128":"-5"}`},
+		{"i256 minus one", xdr.ScVal{Type: xdr.ScValTypeScvI256, I256: &i256}, `{"i256":"-1"}`},
+		{"bytes", xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &bytes}, `{"bytes":"deadbeef"}`},
+		{"string", xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &str}, `{"string":"h\\xc3\\xa9llo"}`},
+		{"symbol", xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}, `{"symbol":"transfer"}`},
+		{"vec", xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &vec}, `{"vec":[{"u32":7},"void"]}`},
+		{"vec none", xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &nilVec}, `{"vec":null}`},
+		{"map", xdr.ScVal{Type: xdr.ScValTypeScvMap, Map: &m}, `{"map":[{"key":{"symbol":"transfer"},"val":{"bool":true}}]}`},
+		{"address", xdr.ScVal{Type: xdr.ScValTypeScvAddress, Address: &addr}, `{"address":"GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"}`},
+		{"contract error", xdr.ScVal{Type: xdr.ScValTypeScvError, Error: &xdr.ScError{Type: xdr.ScErrorTypeSceContract, ContractCode: &contractCode}}, `{"error":{"contract":3}}`},
+		{"host error", xdr.ScVal{Type: xdr.ScValTypeScvError, Error: &xdr.ScError{Type: xdr.ScErrorTypeSceWasmVm, Code: &storageCode}}, `{"error":{"wasm_vm":"exceeded_limit"}}`},
+		{"instance key", xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance}, `"ledger_key_contract_instance"`},
+		{"nonce key", xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyNonce, NonceKey: &xdr.ScNonceKey{Nonce: 99}}, `{"ledger_key_nonce":{"nonce":"99"}}`},
+		{"stellar asset instance", xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &xdr.ScContractInstance{
+			Executable: xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableStellarAsset},
+		}}, `{"contract_instance":{"executable":"stellar_asset","storage":null}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScValToRPCJSON(tt.val)
+			if err != nil {
+				t.Fatalf("ScValToRPCJSON() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ScValToRPCJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
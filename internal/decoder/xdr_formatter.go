@@ -5,11 +5,18 @@ package decoder
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/dotandev/hintents/internal/visualizer"
 	"github.com/stellar/go-stellar-sdk/xdr"
+	"gopkg.in/yaml.v3"
 )
 
 type FormatType string
@@ -17,22 +24,36 @@ type FormatType string
 const (
 	FormatJSON  FormatType = "json"
 	FormatTable FormatType = "table"
+	FormatCSV   FormatType = "csv"
+	FormatYAML  FormatType = "yaml"
 )
 
 type XDRFormatter struct {
 	format FormatType
+	color  bool
 }
 
 func NewXDRFormatter(format FormatType) *XDRFormatter {
 	return &XDRFormatter{format: format}
 }
 
+// NewColorXDRFormatter is NewXDRFormatter with ANSI highlighting of labels,
+// addresses and numbers in the table format. Color is only used when
+// stdout is a terminal and NO_COLOR is not set; see visualizer.ColorEnabled.
+func NewColorXDRFormatter(format FormatType) *XDRFormatter {
+	return &XDRFormatter{format: format, color: visualizer.ColorEnabled()}
+}
+
 func (f *XDRFormatter) Format(data interface{}) (string, error) {
 	switch f.format {
 	case FormatJSON:
 		return f.formatJSON(data)
 	case FormatTable:
 		return f.formatTable(data)
+	case FormatCSV:
+		return f.formatCSV(data)
+	case FormatYAML:
+		return f.formatYAML(data)
 	default:
 		return "", fmt.Errorf("unsupported format: %s", f.format)
 	}
@@ -46,7 +67,50 @@ func (f *XDRFormatter) formatJSON(data interface{}) (string, error) {
 	return string(output), nil
 }
 
+// formatYAML renders the same document as formatJSON, with the same field
+// names and order, as block-style YAML.
+func (f *XDRFormatter) formatYAML(data interface{}) (string, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	// JSON is valid YAML, so decoding it into a node keeps the key order.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return "", fmt.Errorf("failed to convert JSON to YAML: %w", err)
+	}
+	clearYAMLStyle(&doc)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return "", fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// clearYAMLStyle drops the flow style and quoting a node inherited from its
+// JSON source, so the encoder picks the default block style.
+func clearYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearYAMLStyle(c)
+	}
+}
+
 func (f *XDRFormatter) formatTable(data interface{}) (string, error) {
+	out, err := f.plainTable(data)
+	if err != nil || !f.color {
+		return out, err
+	}
+	return colorizeTable(out, visualizer.Highlighter{Enabled: true}), nil
+}
+
+func (f *XDRFormatter) plainTable(data interface{}) (string, error) {
 	switch v := data.(type) {
 	case *xdr.LedgerEntry:
 		return formatLedgerEntryTable(v)
@@ -57,133 +121,424 @@ func (f *XDRFormatter) formatTable(data interface{}) (string, error) {
 	case []interface{}:
 		return formatGenericTable(v)
 	default:
-		var buf bytes.Buffer
-		w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
-		_, _ = fmt.Fprintf(w, "Type:\t%T\n", v)
-		_, _ = fmt.Fprintf(w, "Value:\t%v\n", v)
-		_ = w.Flush()
-		return buf.String(), nil
+		return genericFields(v).table(), nil
 	}
 }
 
-func formatLedgerEntryTable(entry *xdr.LedgerEntry) (string, error) {
+// colorizeTable highlights a rendered table: labels in bold, and addresses
+// and numbers in the values. It runs after alignment because the escape
+// codes would otherwise count towards the column widths. Lines starting
+// with whitespace continue the value of the previous field.
+func colorizeTable(table string, h visualizer.Highlighter) string {
+	lines := strings.Split(table, "\n")
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			if label, value, ok := strings.Cut(line, ":"); ok {
+				lines[i] = h.Label(label+":") + h.Values(value)
+				continue
+			}
+		}
+		lines[i] = h.Values(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatCSV writes a header row and one row per object: the object itself,
+// or each element of a slice. The columns are the labels of the table
+// format, in the order they first appear, so objects of different types
+// share the columns they have in common and leave the others empty.
+func (f *XDRFormatter) formatCSV(data interface{}) (string, error) {
+	var rows []fieldList
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < v.Len(); i++ {
+			rows = append(rows, objectFields(v.Index(i).Interface()))
+		}
+	} else {
+		rows = append(rows, objectFields(data))
+	}
+
+	var header []string
+	columns := make(map[string]int)
+	for _, row := range rows {
+		for _, fd := range row {
+			if _, ok := columns[fd.name]; !ok {
+				columns[fd.name] = len(header)
+				header = append(header, fd.name)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, row := range rows {
+		record := make([]string, len(header))
+		for _, fd := range row {
+			record[columns[fd.name]] = fd.value
+		}
+		if err := w.Write(record); err != nil {
+			return "", fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// field is one labelled value of a decoded XDR object.
+type field struct {
+	name  string
+	value string
+}
+
+// fieldList holds the fields of an object in display order. The table and
+// CSV formats render the same list.
+type fieldList []field
+
+func (l *fieldList) add(name, format string, args ...interface{}) {
+	*l = append(*l, field{name: name, value: fmt.Sprintf(format, args...)})
+}
+
+// table renders the fields as aligned "Name: value" lines. Values that
+// span several lines are continued in the value column.
+func (l fieldList) table() string {
 	var buf bytes.Buffer
 	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for _, fd := range l {
+		value := strings.ReplaceAll(fd.value, "\n", "\n\t")
+		_, _ = fmt.Fprintf(w, "%s:\t%s\n", fd.name, value)
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+// objectFields lists the fields of any object the formatter accepts.
+// Objects of unknown types are reduced to their type and value.
+func objectFields(data interface{}) fieldList {
+	switch v := data.(type) {
+	case *xdr.LedgerEntry:
+		if v != nil {
+			return ledgerEntryFields(v)
+		}
+	case xdr.LedgerEntry:
+		return ledgerEntryFields(&v)
+	case *xdr.TransactionEnvelope:
+		if v != nil {
+			return transactionEnvelopeFields(v)
+		}
+	case xdr.TransactionEnvelope:
+		return transactionEnvelopeFields(&v)
+	case *xdr.DiagnosticEvent:
+		if v != nil {
+			return diagnosticEventFields(v)
+		}
+	case xdr.DiagnosticEvent:
+		return diagnosticEventFields(&v)
+	}
+	return genericFields(data)
+}
+
+func genericFields(data interface{}) fieldList {
+	var fields fieldList
+	fields.add("Type", "%T", data)
+	fields.add("Value", "%v", data)
+	return fields
+}
+
+func formatLedgerEntryTable(entry *xdr.LedgerEntry) (string, error) {
+	return ledgerEntryFields(entry).table(), nil
+}
+
+// ledgerEntryFields lists the labelled fields shown for a ledger entry.
+func ledgerEntryFields(entry *xdr.LedgerEntry) fieldList {
+	var fields fieldList
 
-	_, _ = fmt.Fprintf(w, "Type:\t%v\n", entry.Data.Type)
-	_, _ = fmt.Fprintf(w, "Last Modified Ledger:\t%d\n", entry.LastModifiedLedgerSeq)
+	fields.add("Type", "%v", entry.Data.Type)
+	fields.add("Last Modified Ledger", "%d", entry.LastModifiedLedgerSeq)
 
 	switch entry.Data.Type {
 	case xdr.LedgerEntryTypeAccount:
 		if entry.Data.Account != nil {
 			acc := entry.Data.Account
-			_, _ = fmt.Fprintf(w, "Account ID:\t%s\n", acc.AccountId.Address())
-			_, _ = fmt.Fprintf(w, "Balance:\t%d\n", acc.Balance)
-			_, _ = fmt.Fprintf(w, "Sequence:\t%d\n", acc.SeqNum)
-			_, _ = fmt.Fprintf(w, "Flags:\t%d\n", acc.Flags)
+			fields.add("Account ID", "%s", acc.AccountId.Address())
+			fields.add("Balance", "%d", acc.Balance)
+			fields.add("Sequence", "%d", acc.SeqNum)
+			fields.add("Flags", "%d", acc.Flags)
 		}
 
 	case xdr.LedgerEntryTypeTrustline:
 		if entry.Data.TrustLine != nil {
 			tl := entry.Data.TrustLine
-			_, _ = fmt.Fprintf(w, "Account:\t%s\n", tl.AccountId.Address())
-			_, _ = fmt.Fprintf(w, "Asset Type:\t%v\n", tl.Asset.Type)
-			_, _ = fmt.Fprintf(w, "Balance:\t%d\n", tl.Balance)
-			_, _ = fmt.Fprintf(w, "Flags:\t%d\n", tl.Flags)
+			fields.add("Account", "%s", tl.AccountId.Address())
+			fields.add("Asset Type", "%v", tl.Asset.Type)
+			fields.add("Balance", "%d", tl.Balance)
+			fields.add("Flags", "%d", tl.Flags)
 		}
 
 	case xdr.LedgerEntryTypeOffer:
 		if entry.Data.Offer != nil {
 			offer := entry.Data.Offer
-			_, _ = fmt.Fprintf(w, "Seller:\t%s\n", offer.SellerId.Address())
-			_, _ = fmt.Fprintf(w, "Offer ID:\t%d\n", offer.OfferId)
-			_, _ = fmt.Fprintf(w, "Amount:\t%d\n", offer.Amount)
+			fields.add("Seller", "%s", offer.SellerId.Address())
+			fields.add("Offer ID", "%d", offer.OfferId)
+			fields.add("Amount", "%d", offer.Amount)
 		}
 
 	case xdr.LedgerEntryTypeData:
 		if entry.Data.Data != nil {
 			data := entry.Data.Data
-			_, _ = fmt.Fprintf(w, "Account:\t%s\n", data.AccountId.Address())
-			_, _ = fmt.Fprintf(w, "Data Name:\t%s\n", data.DataName)
-			_, _ = fmt.Fprintf(w, "Data Value (bytes):\t%d\n", len(data.DataValue))
+			fields.add("Account", "%s", data.AccountId.Address())
+			fields.add("Data Name", "%s", data.DataName)
+			fields.add("Data Value (bytes)", "%d", len(data.DataValue))
 		}
 
 	case xdr.LedgerEntryTypeClaimableBalance:
 		if entry.Data.ClaimableBalance != nil {
 			cb := entry.Data.ClaimableBalance
 			if cb.BalanceId.V0 != nil {
-				_, _ = fmt.Fprintf(w, "Balance ID:\t%x\n", *cb.BalanceId.V0)
+				fields.add("Balance ID", "%x", *cb.BalanceId.V0)
+			}
+			fields.add("Amount", "%d", cb.Amount)
+		}
+
+	case xdr.LedgerEntryTypeLiquidityPool:
+		if entry.Data.LiquidityPool != nil {
+			lp := entry.Data.LiquidityPool
+			fields.add("Pool ID", "%x", lp.LiquidityPoolId)
+			if cp := lp.Body.ConstantProduct; cp != nil {
+				fields.add("Pool Type", "constant product")
+				fields.add("Asset A", "%s", cp.Params.AssetA.StringCanonical())
+				fields.add("Reserve A", "%d", cp.ReserveA)
+				fields.add("Asset B", "%s", cp.Params.AssetB.StringCanonical())
+				fields.add("Reserve B", "%d", cp.ReserveB)
+				fields.add("Total Pool Shares", "%d", cp.TotalPoolShares)
+				fields.add("Fee", "%d bps (%.2f%%)", cp.Params.Fee, float64(cp.Params.Fee)/100)
 			}
-			_, _ = fmt.Fprintf(w, "Amount:\t%d\n", cb.Amount)
 		}
 
 	case xdr.LedgerEntryTypeContractData:
 		if entry.Data.ContractData != nil {
 			cd := entry.Data.ContractData
-			_, _ = fmt.Fprintf(w, "Durability:\t%v\n", cd.Durability)
+			if addr, err := cd.Contract.String(); err == nil {
+				fields.add("Contract", "%s", addr)
+			}
+			fields.add("Durability", "%v", cd.Durability)
+			fields.add("Key", "%s", FormatSCVal(cd.Key))
+			fields.add("Value", "%s", FormatSCVal(cd.Val))
 		}
 
 	case xdr.LedgerEntryTypeContractCode:
 		if entry.Data.ContractCode != nil {
 			cc := entry.Data.ContractCode
-			_, _ = fmt.Fprintf(w, "Code Hash:\t%x\n", cc.Hash)
-			_, _ = fmt.Fprintf(w, "Code Size:\t%d bytes\n", len(cc.Code))
+			fields.add("Code Hash", "%x", cc.Hash)
+			fields.add("Code Size", "%d bytes", len(cc.Code))
+		}
+
+	case xdr.LedgerEntryTypeTtl:
+		if entry.Data.Ttl != nil {
+			ttl := entry.Data.Ttl
+			fields.add("Key Hash", "%x", ttl.KeyHash)
+			fields.add("Live Until Ledger", "%d", ttl.LiveUntilLedgerSeq)
+		}
+
+	case xdr.LedgerEntryTypeConfigSetting:
+		if entry.Data.ConfigSetting != nil {
+			configSettingFields(&fields, entry.Data.ConfigSetting)
 		}
 	}
 
-	_ = w.Flush()
-	return buf.String(), nil
+	return fields
+}
+
+// ConfigSettingName returns the short name of a config setting id, e.g.
+// "ContractLedgerCostV0" for CONFIG_SETTING_CONTRACT_LEDGER_COST_V0.
+func ConfigSettingName(id xdr.ConfigSettingId) string {
+	name := strings.TrimPrefix(id.String(), "ConfigSettingIdConfigSetting")
+	if name == "" {
+		return fmt.Sprintf("unknown (%d)", int32(id))
+	}
+	return name
+}
+
+// configSettingFields adds the network settings held by a config setting
+// entry: the fee rates and the per-ledger and per-transaction limits.
+func configSettingFields(fields *fieldList, cs *xdr.ConfigSettingEntry) {
+	fields.add("Config Setting ID", "%s (%d)", ConfigSettingName(cs.ConfigSettingId), int32(cs.ConfigSettingId))
+
+	switch cs.ConfigSettingId {
+	case xdr.ConfigSettingIdConfigSettingContractMaxSizeBytes:
+		if v := cs.ContractMaxSizeBytes; v != nil {
+			fields.add("Contract Max Size", "%d bytes", *v)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractComputeV0:
+		if c := cs.ContractCompute; c != nil {
+			fields.add("Ledger Max Instructions", "%d", c.LedgerMaxInstructions)
+			fields.add("Tx Max Instructions", "%d", c.TxMaxInstructions)
+			fields.add("Fee per 10K Instructions", "%d stroops", c.FeeRatePerInstructionsIncrement)
+			fields.add("Tx Memory Limit", "%d bytes", c.TxMemoryLimit)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractLedgerCostV0:
+		if c := cs.ContractLedgerCost; c != nil {
+			fields.add("Tx Max Disk Read Entries", "%d", c.TxMaxDiskReadEntries)
+			fields.add("Tx Max Disk Read Bytes", "%d", c.TxMaxDiskReadBytes)
+			fields.add("Tx Max Write Entries", "%d", c.TxMaxWriteLedgerEntries)
+			fields.add("Tx Max Write Bytes", "%d", c.TxMaxWriteBytes)
+			fields.add("Fee per Disk Read Entry", "%d stroops", c.FeeDiskReadLedgerEntry)
+			fields.add("Fee per Write Entry", "%d stroops", c.FeeWriteLedgerEntry)
+			fields.add("Fee per 1KB Disk Read", "%d stroops", c.FeeDiskRead1Kb)
+			fields.add("Rent Fee per 1KB (low)", "%d stroops", c.RentFee1KbSorobanStateSizeLow)
+			fields.add("Rent Fee per 1KB (high)", "%d stroops", c.RentFee1KbSorobanStateSizeHigh)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractHistoricalDataV0:
+		if c := cs.ContractHistoricalData; c != nil {
+			fields.add("Fee per 1KB Historical", "%d stroops", c.FeeHistorical1Kb)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractEventsV0:
+		if c := cs.ContractEvents; c != nil {
+			fields.add("Tx Max Events Size", "%d bytes", c.TxMaxContractEventsSizeBytes)
+			fields.add("Fee per 1KB Events", "%d stroops", c.FeeContractEvents1Kb)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractBandwidthV0:
+		if c := cs.ContractBandwidth; c != nil {
+			fields.add("Ledger Max Txs Size", "%d bytes", c.LedgerMaxTxsSizeBytes)
+			fields.add("Tx Max Size", "%d bytes", c.TxMaxSizeBytes)
+			fields.add("Fee per 1KB Tx Size", "%d stroops", c.FeeTxSize1Kb)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractCostParamsCpuInstructions:
+		if c := cs.ContractCostParamsCpuInsns; c != nil {
+			fields.add("Cost Params", "%d entries", len(*c))
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractCostParamsMemoryBytes:
+		if c := cs.ContractCostParamsMemBytes; c != nil {
+			fields.add("Cost Params", "%d entries", len(*c))
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractDataKeySizeBytes:
+		if v := cs.ContractDataKeySizeBytes; v != nil {
+			fields.add("Contract Data Key Max Size", "%d bytes", *v)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractDataEntrySizeBytes:
+		if v := cs.ContractDataEntrySizeBytes; v != nil {
+			fields.add("Contract Data Entry Max Size", "%d bytes", *v)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingStateArchival:
+		if c := cs.StateArchivalSettings; c != nil {
+			fields.add("Max Entry TTL", "%d ledgers", c.MaxEntryTtl)
+			fields.add("Min Temporary TTL", "%d ledgers", c.MinTemporaryTtl)
+			fields.add("Min Persistent TTL", "%d ledgers", c.MinPersistentTtl)
+			fields.add("Persistent Rent Rate Denominator", "%d", c.PersistentRentRateDenominator)
+			fields.add("Temporary Rent Rate Denominator", "%d", c.TempRentRateDenominator)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractExecutionLanes:
+		if c := cs.ContractExecutionLanes; c != nil {
+			fields.add("Ledger Max Tx Count", "%d", c.LedgerMaxTxCount)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingLiveSorobanStateSizeWindow:
+		if c := cs.LiveSorobanStateSizeWindow; c != nil {
+			fields.add("Window Samples", "%d", len(*c))
+			if n := len(*c); n > 0 {
+				fields.add("Latest State Size", "%d bytes", (*c)[n-1])
+			}
+		}
+
+	case xdr.ConfigSettingIdConfigSettingEvictionIterator:
+		if c := cs.EvictionIterator; c != nil {
+			fields.add("Bucket List Level", "%d", c.BucketListLevel)
+			fields.add("Current Bucket", "%v", c.IsCurrBucket)
+			fields.add("Bucket File Offset", "%d", c.BucketFileOffset)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractParallelComputeV0:
+		if c := cs.ContractParallelCompute; c != nil {
+			fields.add("Ledger Max Dependent Tx Clusters", "%d", c.LedgerMaxDependentTxClusters)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingContractLedgerCostExtV0:
+		if c := cs.ContractLedgerCostExt; c != nil {
+			fields.add("Tx Max Footprint Entries", "%d", c.TxMaxFootprintEntries)
+			fields.add("Fee per 1KB Write", "%d stroops", c.FeeWrite1Kb)
+		}
+
+	case xdr.ConfigSettingIdConfigSettingScpTiming:
+		if c := cs.ContractScpTiming; c != nil {
+			fields.add("Target Close Time", "%d ms", c.LedgerTargetCloseTimeMilliseconds)
+		}
+	}
 }
 
 func formatTransactionEnvelopeTable(env *xdr.TransactionEnvelope) (string, error) {
-	var buf bytes.Buffer
-	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	return transactionEnvelopeFields(env).table(), nil
+}
+
+// transactionEnvelopeFields lists the labelled fields shown for an envelope.
+func transactionEnvelopeFields(env *xdr.TransactionEnvelope) fieldList {
+	var fields fieldList
 
-	_, _ = fmt.Fprintf(w, "Envelope Type:\t%v\n", env.Type)
+	fields.add("Envelope Type", "%v", env.Type)
 
 	switch env.Type {
 	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
 		if env.V0 != nil {
 			tx := env.V0.Tx
-			_, _ = fmt.Fprintf(w, "Fee:\t%d\n", tx.Fee)
-			_, _ = fmt.Fprintf(w, "Sequence Num:\t%d\n", tx.SeqNum)
-			_, _ = fmt.Fprintf(w, "Operations:\t%d\n", len(tx.Operations))
+			fields.add("Fee", "%d", tx.Fee)
+			fields.add("Sequence Num", "%d", tx.SeqNum)
+			fields.add("Operations", "%d", len(tx.Operations))
 		}
 
 	case xdr.EnvelopeTypeEnvelopeTypeTx:
 		if env.V1 != nil {
 			tx := env.V1.Tx
-			_, _ = fmt.Fprintf(w, "Source Account:\t%s\n", tx.SourceAccount.Address())
-			_, _ = fmt.Fprintf(w, "Fee:\t%d\n", tx.Fee)
-			_, _ = fmt.Fprintf(w, "Sequence Num:\t%d\n", tx.SeqNum)
-			_, _ = fmt.Fprintf(w, "Operations:\t%d\n", len(tx.Operations))
+			fields.add("Source Account", "%s", tx.SourceAccount.Address())
+			fields.add("Fee", "%d", tx.Fee)
+			fields.add("Sequence Num", "%d", tx.SeqNum)
+			fields.add("Operations", "%d", len(tx.Operations))
 		}
 
 	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
 		if env.FeeBump != nil {
 			feeBump := env.FeeBump.Tx
-			_, _ = fmt.Fprintf(w, "Fee Source:\t%s\n", feeBump.FeeSource.Address())
-			_, _ = fmt.Fprintf(w, "Fee:\t%d\n", feeBump.Fee)
+			fields.add("Fee Source", "%s", feeBump.FeeSource.Address())
+			fields.add("Fee", "%d", feeBump.Fee)
 		}
 	}
 
-	_ = w.Flush()
-	return buf.String(), nil
+	return fields
 }
 
 func formatDiagnosticEventTable(event *xdr.DiagnosticEvent) (string, error) {
-	var buf bytes.Buffer
-	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	return diagnosticEventFields(event).table(), nil
+}
 
-	_, _ = fmt.Fprintf(w, "Successful:\t%v\n", event.InSuccessfulContractCall)
-	_, _ = fmt.Fprintf(w, "Event Type:\t%v\n", event.Event.Type)
+// diagnosticEventFields lists the labelled fields shown for a diagnostic
+// event.
+func diagnosticEventFields(event *xdr.DiagnosticEvent) fieldList {
+	var fields fieldList
+
+	fields.add("Successful", "%v", event.InSuccessfulContractCall)
+	fields.add("Event Type", "%v", event.Event.Type)
 
 	if event.Event.ContractId != nil {
-		_, _ = fmt.Fprintf(w, "Contract ID:\t%x\n", event.Event.ContractId)
+		fields.add("Contract ID", "%x", event.Event.ContractId)
 	}
 
-	_ = w.Flush()
-	return buf.String(), nil
+	return fields
 }
 
 func formatGenericTable(items []interface{}) (string, error) {
@@ -203,22 +558,124 @@ func formatGenericTable(items []interface{}) (string, error) {
 	return buf.String(), nil
 }
 
+// XDREncoding names how an XDR blob is encoded as text.
+type XDREncoding string
+
+const (
+	// EncodingAuto sniffs the encoding, see DecodeXDRAuto.
+	EncodingAuto   XDREncoding = "auto"
+	EncodingBase64 XDREncoding = "base64"
+	EncodingHex    XDREncoding = "hex"
+)
+
+// DecodeXDRBase64AsLedgerEntry decodes a base64-encoded LedgerEntry.
 func DecodeXDRBase64AsLedgerEntry(data string) (*xdr.LedgerEntry, error) {
+	return decodeLedgerEntry(data, EncodingBase64)
+}
+
+// DecodeXDRBase64AsDiagnosticEvent decodes a base64-encoded DiagnosticEvent.
+func DecodeXDRBase64AsDiagnosticEvent(data string) (*xdr.DiagnosticEvent, error) {
+	return decodeDiagnosticEvent(data, EncodingBase64)
+}
+
+// DecodeXDRHexAsLedgerEntry decodes a hex-encoded LedgerEntry. A leading
+// "0x" is accepted.
+func DecodeXDRHexAsLedgerEntry(data string) (*xdr.LedgerEntry, error) {
+	return decodeLedgerEntry(data, EncodingHex)
+}
+
+// DecodeXDRHexAsDiagnosticEvent decodes a hex-encoded DiagnosticEvent. A
+// leading "0x" is accepted.
+func DecodeXDRHexAsDiagnosticEvent(data string) (*xdr.DiagnosticEvent, error) {
+	return decodeDiagnosticEvent(data, EncodingHex)
+}
+
+// DecodeXDRAuto returns the raw XDR bytes of data, which may be hex or
+// base64. Input with a "0x" prefix, or made only of an even number of hex
+// digits, is read as hex; anything else as base64. Base64 XDR that happens
+// to use only hex digits is therefore read as hex, which in practice only
+// affects very short blobs; use DecodeXDR with an explicit encoding for
+// those.
+func DecodeXDRAuto(data string) ([]byte, error) {
+	return DecodeXDR(data, EncodingAuto)
+}
+
+// DecodeXDR returns the raw XDR bytes of data in the given encoding. An
+// empty encoding is treated as EncodingAuto.
+func DecodeXDR(data string, encoding XDREncoding) ([]byte, error) {
+	data = strings.TrimSpace(data)
+	switch encoding {
+	case EncodingAuto, "":
+		if isHexXDR(data) {
+			return decodeHexXDR(data)
+		}
+		return decodeBase64XDR(data)
+	case EncodingBase64:
+		return decodeBase64XDR(data)
+	case EncodingHex:
+		return decodeHexXDR(data)
+	}
+	return nil, fmt.Errorf("unsupported XDR encoding: %s (use: auto, base64, hex)", encoding)
+}
+
+func isHexXDR(data string) bool {
+	if strings.HasPrefix(data, "0x") || strings.HasPrefix(data, "0X") {
+		return true
+	}
+	if data == "" || len(data)%2 != 0 {
+		return false
+	}
+	for _, c := range data {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeBase64XDR(data string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 input: %w", err)
+	}
+	return raw, nil
+}
+
+func decodeHexXDR(data string) ([]byte, error) {
+	data = strings.TrimPrefix(strings.TrimPrefix(data, "0x"), "0X")
+	raw, err := hex.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex input: %w", err)
+	}
+	return raw, nil
+}
+
+func decodeLedgerEntry(data string, encoding XDREncoding) (*xdr.LedgerEntry, error) {
 	var entry xdr.LedgerEntry
-	if err := entry.UnmarshalBinary([]byte(data)); err != nil {
+	if err := decodeInto(data, encoding, &entry); err != nil {
 		return nil, fmt.Errorf("failed to decode ledger entry: %w", err)
 	}
 	return &entry, nil
 }
 
-func DecodeXDRBase64AsDiagnosticEvent(data string) (*xdr.DiagnosticEvent, error) {
+func decodeDiagnosticEvent(data string, encoding XDREncoding) (*xdr.DiagnosticEvent, error) {
 	var event xdr.DiagnosticEvent
-	if err := event.UnmarshalBinary([]byte(data)); err != nil {
+	if err := decodeInto(data, encoding, &event); err != nil {
 		return nil, fmt.Errorf("failed to decode diagnostic event: %w", err)
 	}
 	return &event, nil
 }
 
+// decodeInto decodes data and unmarshals the XDR into v. Errors are left
+// unwrapped for the caller to name the type.
+func decodeInto(data string, encoding XDREncoding, v interface{}) error {
+	raw, err := DecodeXDR(data, encoding)
+	if err != nil {
+		return err
+	}
+	return xdr.SafeUnmarshal(raw, v)
+}
+
 func SummarizeXDRObject(data interface{}) string {
 	switch v := data.(type) {
 	case *xdr.LedgerEntry:
@@ -4,9 +4,18 @@
 package decoder
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNewXDRFormatter(t *testing.T) {
@@ -212,3 +221,622 @@ func BenchmarkFormatTable(b *testing.B) {
 		_, _ = formatter.Format(data)
 	}
 }
+
+func TestFormatLedgerEntryTable_LiquidityPool(t *testing.T) {
+	issuer := xdr.MustAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	usdc := xdr.Asset{
+		Type: xdr.AssetTypeAssetTypeCreditAlphanum4,
+		AlphaNum4: &xdr.AlphaNum4{
+			AssetCode: xdr.AssetCode4{'U', 'S', 'D', 'C'},
+			Issuer:    issuer,
+		},
+	}
+	entry := &xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 42,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeLiquidityPool,
+			LiquidityPool: &xdr.LiquidityPoolEntry{
+				LiquidityPoolId: xdr.PoolId{0xab, 0xcd},
+				Body: xdr.LiquidityPoolEntryBody{
+					Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
+					ConstantProduct: &xdr.LiquidityPoolEntryConstantProduct{
+						Params: xdr.LiquidityPoolConstantProductParameters{
+							AssetA: xdr.MustNewNativeAsset(),
+							AssetB: usdc,
+							Fee:    xdr.LiquidityPoolFeeV18,
+						},
+						ReserveA:                 5000000000,
+						ReserveB:                 1200000000,
+						TotalPoolShares:          2449489742,
+						PoolSharesTrustLineCount: 3,
+					},
+				},
+			},
+		},
+	}
+
+	output, err := NewXDRFormatter(FormatTable).Format(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fields := tableFields(t, output)
+	want := map[string]string{
+		"Pool ID":           "abcd" + strings.Repeat("0", 60),
+		"Pool Type":         "constant product",
+		"Asset A":           "native",
+		"Reserve A":         "5000000000",
+		"Asset B":           "USDC:" + issuer.Address(),
+		"Reserve B":         "1200000000",
+		"Total Pool Shares": "2449489742",
+		"Fee":               "30 bps (0.30%)",
+	}
+	for label, value := range want {
+		if fields[label] != value {
+			t.Errorf("%s: expected %q, got %q", label, value, fields[label])
+		}
+	}
+}
+
+func tableFields(t *testing.T, output string) map[string]string {
+	t.Helper()
+	fields := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if label, value, ok := strings.Cut(line, ":"); ok {
+			fields[label] = strings.TrimSpace(value)
+		}
+	}
+	return fields
+}
+
+func TestFormatLedgerEntryTable_ConfigSetting(t *testing.T) {
+	entry := xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 50000,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeConfigSetting,
+			ConfigSetting: &xdr.ConfigSettingEntry{
+				ConfigSettingId: xdr.ConfigSettingIdConfigSettingContractLedgerCostV0,
+				ContractLedgerCost: &xdr.ConfigSettingContractLedgerCostV0{
+					TxMaxDiskReadEntries:           100,
+					TxMaxDiskReadBytes:             200000,
+					TxMaxWriteLedgerEntries:        50,
+					TxMaxWriteBytes:                132096,
+					FeeDiskReadLedgerEntry:         6250,
+					FeeWriteLedgerEntry:            10000,
+					FeeDiskRead1Kb:                 1786,
+					RentFee1KbSorobanStateSizeLow:  -17000,
+					RentFee1KbSorobanStateSizeHigh: 10000,
+				},
+			},
+		},
+	}
+
+	// Decode from XDR as a fetched entry would be.
+	b64, err := xdr.MarshalBase64(entry)
+	if err != nil {
+		t.Fatalf("failed to encode entry: %v", err)
+	}
+	var decoded xdr.LedgerEntry
+	if err := xdr.SafeUnmarshalBase64(b64, &decoded); err != nil {
+		t.Fatalf("failed to decode entry: %v", err)
+	}
+
+	output, err := NewXDRFormatter(FormatTable).Format(&decoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fields := tableFields(t, output)
+	want := map[string]string{
+		"Config Setting ID":        "ContractLedgerCostV0 (2)",
+		"Tx Max Disk Read Entries": "100",
+		"Tx Max Write Bytes":       "132096",
+		"Fee per Disk Read Entry":  "6250 stroops",
+		"Fee per Write Entry":      "10000 stroops",
+		"Fee per 1KB Disk Read":    "1786 stroops",
+		"Rent Fee per 1KB (low)":   "-17000 stroops",
+	}
+	for label, value := range want {
+		if fields[label] != value {
+			t.Errorf("%s: expected %q, got %q", label, value, fields[label])
+		}
+	}
+}
+
+func TestFormatLedgerEntryTable_ConfigSettingScalar(t *testing.T) {
+	size := xdr.Uint32(65536)
+	entry := &xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeConfigSetting,
+			ConfigSetting: &xdr.ConfigSettingEntry{
+				ConfigSettingId:      xdr.ConfigSettingIdConfigSettingContractMaxSizeBytes,
+				ContractMaxSizeBytes: &size,
+			},
+		},
+	}
+
+	output, err := NewXDRFormatter(FormatTable).Format(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fields := tableFields(t, output)
+	if got := fields["Config Setting ID"]; got != "ContractMaxSizeBytes (0)" {
+		t.Errorf("unexpected setting id %q", got)
+	}
+	if got := fields["Contract Max Size"]; got != "65536 bytes" {
+		t.Errorf("unexpected max size %q", got)
+	}
+}
+
+func TestConfigSettingName(t *testing.T) {
+	if got := ConfigSettingName(xdr.ConfigSettingIdConfigSettingStateArchival); got != "StateArchival" {
+		t.Errorf("expected StateArchival, got %q", got)
+	}
+	if got := ConfigSettingName(xdr.ConfigSettingId(99)); got != "unknown (99)" {
+		t.Errorf("expected unknown (99), got %q", got)
+	}
+}
+
+func testAccountID(t *testing.T, fill byte) xdr.AccountId {
+	t.Helper()
+	var key xdr.Uint256
+	for i := range key {
+		key[i] = fill
+	}
+	id, err := xdr.NewAccountId(xdr.PublicKeyTypePublicKeyTypeEd25519, key)
+	if err != nil {
+		t.Fatalf("failed to build account id: %v", err)
+	}
+	return id
+}
+
+func readCSV(t *testing.T, output string) [][]string {
+	t.Helper()
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, output)
+	}
+	return records
+}
+
+func TestFormatCSV_LedgerEntry(t *testing.T) {
+	account := testAccountID(t, 0x01)
+	entry := &xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 42,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId: account,
+				Balance:   1000,
+				SeqNum:    7,
+			},
+		},
+	}
+
+	output, err := NewXDRFormatter(FormatCSV).Format(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := readCSV(t, output)
+	if len(records) != 2 {
+		t.Fatalf("expected a header and one row, got %d records", len(records))
+	}
+	wantHeader := []string{"Type", "Last Modified Ledger", "Account ID", "Balance", "Sequence", "Flags"}
+	if strings.Join(records[0], "|") != strings.Join(wantHeader, "|") {
+		t.Errorf("header = %v, want %v", records[0], wantHeader)
+	}
+	wantRow := []string{"LedgerEntryTypeAccount", "42", account.Address(), "1000", "7", "0"}
+	if strings.Join(records[1], "|") != strings.Join(wantRow, "|") {
+		t.Errorf("row = %v, want %v", records[1], wantRow)
+	}
+}
+
+func TestFormatCSV_SliceOfMixedEntries(t *testing.T) {
+	seller := testAccountID(t, 0x02)
+	entries := []*xdr.LedgerEntry{
+		{
+			Data: xdr.LedgerEntryData{
+				Type:  xdr.LedgerEntryTypeOffer,
+				Offer: &xdr.OfferEntry{SellerId: seller, OfferId: 9, Amount: 500},
+			},
+		},
+		{
+			Data: xdr.LedgerEntryData{
+				Type:         xdr.LedgerEntryTypeContractCode,
+				ContractCode: &xdr.ContractCodeEntry{Hash: xdr.Hash{0xff}, Code: []byte{1, 2, 3}},
+			},
+		},
+	}
+
+	output, err := NewXDRFormatter(FormatCSV).Format(entries)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := readCSV(t, output)
+	if len(records) != 3 {
+		t.Fatalf("expected a header and two rows, got %d records", len(records))
+	}
+	col := make(map[string]int)
+	for i, name := range records[0] {
+		col[name] = i
+	}
+	for _, name := range []string{"Type", "Seller", "Offer ID", "Amount", "Code Hash", "Code Size"} {
+		if _, ok := col[name]; !ok {
+			t.Fatalf("missing column %q in header %v", name, records[0])
+		}
+	}
+
+	offer, code := records[1], records[2]
+	if offer[col["Seller"]] != seller.Address() || offer[col["Offer ID"]] != "9" {
+		t.Errorf("unexpected offer row: %v", offer)
+	}
+	if offer[col["Code Size"]] != "" {
+		t.Errorf("expected empty code size for offer, got %q", offer[col["Code Size"]])
+	}
+	if code[col["Code Size"]] != "3 bytes" || code[col["Seller"]] != "" {
+		t.Errorf("unexpected contract code row: %v", code)
+	}
+}
+
+func TestFormatCSV_UnknownTypeDegradesToTypeValue(t *testing.T) {
+	output, err := NewXDRFormatter(FormatCSV).Format([]interface{}{42, "a,b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := readCSV(t, output)
+	want := [][]string{{"Type", "Value"}, {"int", "42"}, {"string", "a,b"}}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(records), len(want))
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("record %d = %v, want %v", i, records[i], want[i])
+		}
+	}
+}
+
+func TestFormatYAML_LedgerEntry(t *testing.T) {
+	account := testAccountID(t, 0x03)
+	entry := &xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 42,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId:  account,
+				Balance:    123456789,
+				SeqNum:     7,
+				HomeDomain: "example.com",
+			},
+		},
+	}
+
+	output, err := NewXDRFormatter(FormatYAML).Format(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(output, "{") {
+		t.Errorf("expected block-style YAML, got:\n%s", output)
+	}
+
+	var doc struct {
+		Data struct {
+			Account struct {
+				AccountID struct {
+					Ed25519 []byte `yaml:"Ed25519"`
+				} `yaml:"AccountId"`
+				Balance    int64  `yaml:"Balance"`
+				HomeDomain string `yaml:"HomeDomain"`
+			} `yaml:"Account"`
+		} `yaml:"Data"`
+	}
+	if err := yaml.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("output is not valid YAML: %v\n%s", err, output)
+	}
+	if got := doc.Data.Account.AccountID.Ed25519; len(got) != 32 || got[0] != 0x03 {
+		t.Errorf("account key = %v, want the account's ed25519 key", got)
+	}
+	if doc.Data.Account.Balance != 123456789 {
+		t.Errorf("balance = %d, want 123456789", doc.Data.Account.Balance)
+	}
+	if doc.Data.Account.HomeDomain != "example.com" {
+		t.Errorf("home domain = %q, want example.com", doc.Data.Account.HomeDomain)
+	}
+}
+
+func TestFormatYAML_MatchesJSON(t *testing.T) {
+	inputs := []interface{}{
+		map[string]interface{}{"a": 1, "b": "test", "c": []interface{}{true, nil, "1"}},
+		[]interface{}{1, 2, 3},
+		struct{ Name string }{Name: "yes"},
+	}
+
+	for _, in := range inputs {
+		jsonOut, err := NewXDRFormatter(FormatJSON).Format(in)
+		if err != nil {
+			t.Fatalf("JSON formatting failed: %v", err)
+		}
+		yamlOut, err := NewXDRFormatter(FormatYAML).Format(in)
+		if err != nil {
+			t.Fatalf("YAML formatting failed: %v", err)
+		}
+
+		var fromJSON, fromYAML interface{}
+		if err := json.Unmarshal([]byte(jsonOut), &fromJSON); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if err := yaml.Unmarshal([]byte(yamlOut), &fromYAML); err != nil {
+			t.Fatalf("invalid YAML: %v", err)
+		}
+		// Normalise the YAML document through JSON so numbers compare alike.
+		normalized, err := json.Marshal(fromYAML)
+		if err != nil {
+			t.Fatalf("YAML document is not JSON-compatible: %v", err)
+		}
+		want, _ := json.Marshal(fromJSON)
+		if string(normalized) != string(want) {
+			t.Errorf("YAML %s does not match JSON %s", normalized, want)
+		}
+	}
+}
+
+func TestFormatLedgerEntryTable_ContractData(t *testing.T) {
+	contractID := xdr.ContractId{0x01, 0x02}
+	sym := xdr.ScSymbol("Balance")
+	amount := xdr.Int128Parts{Hi: 0, Lo: 1000}
+	key := &xdr.ScVec{{Type: xdr.ScValTypeScvSymbol, Sym: &sym}}
+	entry := &xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 42,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeContractData,
+			ContractData: &xdr.ContractDataEntry{
+				Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractID},
+				Key:        xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &key},
+				Durability: xdr.ContractDataDurabilityPersistent,
+				Val:        xdr.ScVal{Type: xdr.ScValTypeScvI128, I128: &amount},
+			},
+		},
+	}
+
+	output, err := NewXDRFormatter(FormatTable).Format(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fields := tableFields(t, output)
+	wantContract, err := entry.Data.ContractData.Contract.String()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields["Contract"] != wantContract {
+		t.Errorf("Contract: expected %q, got %q", wantContract, fields["Contract"])
+	}
+	if fields["Value"] != "1000" {
+		t.Errorf("Value: expected %q, got %q", "1000", fields["Value"])
+	}
+	if fields["Key"] != "[" {
+		t.Errorf("Key: expected a multi-line vector, got %q", fields["Key"])
+	}
+	if !strings.Contains(output, "Balance") {
+		t.Errorf("expected the key symbol in the output:\n%s", output)
+	}
+}
+
+// An account ledger entry for GAAZI4TC... with a balance of 100 XLM and
+// sequence number 42, last modified in ledger 123.
+const (
+	accountEntryBase64 = "AAAAewAAAAAAAAAAAZRyYo7njrknFNItA5CWPCTZJ+oAmZlIbokfrC2qnCEAAAAAO5rKAAAAAAAAAAAqAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+	accountEntryHex    = "0000007b0000000000000000019472628ee78eb92714d22d0390963c24d927ea009999486e891fac2daa9c21000000003b9aca00000000000000002a0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+func TestDecodeXDRLedgerEntry_Base64AndHex(t *testing.T) {
+	fromBase64, err := DecodeXDRBase64AsLedgerEntry(accountEntryBase64)
+	if err != nil {
+		t.Fatalf("base64: unexpected error: %v", err)
+	}
+	fromHex, err := DecodeXDRHexAsLedgerEntry(accountEntryHex)
+	if err != nil {
+		t.Fatalf("hex: unexpected error: %v", err)
+	}
+	fromPrefixedHex, err := DecodeXDRHexAsLedgerEntry("0x" + accountEntryHex)
+	if err != nil {
+		t.Fatalf("0x hex: unexpected error: %v", err)
+	}
+
+	if fromBase64.LastModifiedLedgerSeq != 123 || fromBase64.Data.Account == nil {
+		t.Fatalf("unexpected entry: %+v", fromBase64)
+	}
+	if got := fromBase64.Data.Account.AccountId.Address(); got != "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7" {
+		t.Errorf("unexpected account %s", got)
+	}
+	if fromBase64.Data.Account.Balance != 1000000000 || fromBase64.Data.Account.SeqNum != 42 {
+		t.Errorf("unexpected account fields: %+v", fromBase64.Data.Account)
+	}
+	if !reflect.DeepEqual(fromBase64, fromHex) || !reflect.DeepEqual(fromBase64, fromPrefixedHex) {
+		t.Errorf("hex and base64 decoded differently:\n%+v\n%+v", fromBase64, fromHex)
+	}
+}
+
+func TestDecodeXDRDiagnosticEvent_Base64AndHex(t *testing.T) {
+	event := xdr.DiagnosticEvent{
+		InSuccessfulContractCall: true,
+		Event: xdr.ContractEvent{
+			Type: xdr.ContractEventTypeDiagnostic,
+			Body: xdr.ContractEventBody{V: 0, V0: &xdr.ContractEventV0{Data: xdr.ScVal{Type: xdr.ScValTypeScvVoid}}},
+		},
+	}
+	raw, err := event.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromBase64, err := DecodeXDRBase64AsDiagnosticEvent(base64.StdEncoding.EncodeToString(raw))
+	if err != nil {
+		t.Fatalf("base64: unexpected error: %v", err)
+	}
+	fromHex, err := DecodeXDRHexAsDiagnosticEvent(hex.EncodeToString(raw))
+	if err != nil {
+		t.Fatalf("hex: unexpected error: %v", err)
+	}
+	if !fromBase64.InSuccessfulContractCall || !reflect.DeepEqual(fromBase64, fromHex) {
+		t.Errorf("hex and base64 decoded differently:\n%+v\n%+v", fromBase64, fromHex)
+	}
+}
+
+func TestDecodeXDRAuto(t *testing.T) {
+	want, err := base64.StdEncoding.DecodeString(accountEntryBase64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, input := range map[string]string{
+		"base64":     accountEntryBase64,
+		"hex":        accountEntryHex,
+		"upper hex":  strings.ToUpper(accountEntryHex),
+		"0x hex":     "0x" + accountEntryHex,
+		"whitespace": "  " + accountEntryBase64 + "\n",
+	} {
+		got, err := DecodeXDRAuto(input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: decoded to different bytes", name)
+		}
+	}
+
+	for _, input := range []string{"not xdr!", "0xzz"} {
+		if _, err := DecodeXDRAuto(input); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
+
+func TestDecodeXDRBase64AsLedgerEntry_RejectsHexAndGarbage(t *testing.T) {
+	if _, err := DecodeXDRBase64AsLedgerEntry("!!!"); err == nil {
+		t.Error("expected an error for invalid base64")
+	}
+	if _, err := DecodeXDRHexAsLedgerEntry(accountEntryBase64); err == nil {
+		t.Error("expected an error for base64 passed as hex")
+	}
+}
+
+func TestDecodeXDRLedgerEntry_WrapsErrorOnce(t *testing.T) {
+	for name, decode := range map[string]func(string) (*xdr.LedgerEntry, error){
+		"base64": DecodeXDRBase64AsLedgerEntry,
+		"hex":    DecodeXDRHexAsLedgerEntry,
+	} {
+		for _, input := range []string{"!!!", "AAAA", "00"} {
+			_, err := decode(input)
+			if err == nil {
+				t.Errorf("%s: expected an error for %q", name, input)
+				continue
+			}
+			if n := strings.Count(err.Error(), "failed to decode ledger entry"); n != 1 {
+				t.Errorf("%s: expected the error wrapped once, got %q", name, err)
+			}
+		}
+	}
+}
+
+func TestDecodeXDR_ExplicitEncoding(t *testing.T) {
+	// "abcd" is valid base64 that auto-detection reads as hex.
+	auto, err := DecodeXDR("abcd", EncodingAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(auto, []byte{0xab, 0xcd}) {
+		t.Errorf("auto: expected hex bytes, got %x", auto)
+	}
+
+	forced, err := DecodeXDR("abcd", EncodingBase64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := base64.StdEncoding.DecodeString("abcd"); !bytes.Equal(forced, want) {
+		t.Errorf("base64: expected %x, got %x", want, forced)
+	}
+
+	if _, err := DecodeXDR(accountEntryBase64, EncodingHex); err == nil {
+		t.Error("expected an error for base64 forced to hex")
+	}
+	if _, err := DecodeXDR("abcd", "rot13"); err == nil {
+		t.Error("expected an error for an unknown encoding")
+	}
+}
+
+func TestFormatLedgerEntryTable_TTL(t *testing.T) {
+	entry := &xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 1000,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeTtl,
+			Ttl: &xdr.TtlEntry{
+				KeyHash:            xdr.Hash{0xfe, 0xed},
+				LiveUntilLedgerSeq: 535679,
+			},
+		},
+	}
+
+	output, err := NewXDRFormatter(FormatTable).Format(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fields := tableFields(t, output)
+	want := map[string]string{
+		"Type":              "LedgerEntryTypeTtl",
+		"Key Hash":          "feed" + strings.Repeat("0", 60),
+		"Live Until Ledger": "535679",
+	}
+	for label, value := range want {
+		if fields[label] != value {
+			t.Errorf("%s: expected %q, got %q", label, value, fields[label])
+		}
+	}
+}
+
+func TestFormatTable_Color(t *testing.T) {
+	entry := &xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 42,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId: xdr.MustAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"),
+				Balance:   100,
+			},
+		},
+	}
+
+	plain, err := NewXDRFormatter(FormatTable).Format(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	colored, err := (&XDRFormatter{format: FormatTable, color: true}).Format(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"\033[1mBalance:\033[0m",
+		"\033[36mGAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7\033[0m",
+		"\033[33m100\033[0m",
+	} {
+		if !strings.Contains(colored, want) {
+			t.Errorf("expected %q in colored output:\n%q", want, colored)
+		}
+	}
+
+	stripped := regexp.MustCompile("\033\\[[0-9;]*m").ReplaceAllString(colored, "")
+	if stripped != plain {
+		t.Errorf("colored output differs from plain once escapes are removed:\n%s\nvs\n%s", stripped, plain)
+	}
+}
+
+func TestNewColorXDRFormatter_NoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if NewColorXDRFormatter(FormatTable).color {
+		t.Error("expected color to be disabled when NO_COLOR is set")
+	}
+}
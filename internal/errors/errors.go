@@ -6,6 +6,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Sentinel errors for comparison with errors.Is
@@ -18,6 +19,13 @@ var (
 	ErrMarshalFailed        = errors.New("failed to marshal request")
 	ErrUnmarshalFailed      = errors.New("failed to unmarshal response")
 	ErrSimulationLogicError = errors.New("simulation logic error")
+	ErrSimulationTimeout    = errors.New("simulation timed out")
+	ErrInvalidEnvelopeXDR   = errors.New("invalid envelope XDR")
+	ErrInvalidResultMetaXDR = errors.New("invalid result meta XDR")
+	ErrInvalidRequest       = errors.New("invalid simulation request")
+	ErrUnsupportedProtocol  = errors.New("unsupported protocol version")
+	ErrLedgerEntryLimit     = errors.New("ledger entry limit exceeded")
+	ErrCircuitOpen          = errors.New("circuit breaker open")
 )
 
 // Wrap functions for consistent error wrapping
@@ -25,6 +33,12 @@ func WrapTransactionNotFound(err error) error {
 	return fmt.Errorf("%w: %w", ErrTransactionNotFound, err)
 }
 
+// IsTransactionNotFound reports whether err indicates the transaction does not
+// exist (yet) on the queried endpoint.
+func IsTransactionNotFound(err error) bool {
+	return errors.Is(err, ErrTransactionNotFound)
+}
+
 func WrapRPCConnectionFailed(err error) error {
 	return fmt.Errorf("%w: %w", ErrRPCConnectionFailed, err)
 }
@@ -52,3 +66,37 @@ func WrapUnmarshalFailed(err error, output string) error {
 func WrapSimulationLogicError(msg string) error {
 	return fmt.Errorf("%w: %s", ErrSimulationLogicError, msg)
 }
+
+func WrapSimulationTimeout(msg string) error {
+	if msg == "" {
+		return ErrSimulationTimeout
+	}
+	return fmt.Errorf("%w: %s", ErrSimulationTimeout, msg)
+}
+
+func WrapInvalidEnvelopeXDR(err error) error {
+	return fmt.Errorf("%w: %w", ErrInvalidEnvelopeXDR, err)
+}
+
+func WrapInvalidResultMetaXDR(err error) error {
+	return fmt.Errorf("%w: %w", ErrInvalidResultMetaXDR, err)
+}
+
+func WrapInvalidRequest(err error) error {
+	return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+}
+
+func WrapUnsupportedProtocol(version uint32) error {
+	return fmt.Errorf("%w: %d", ErrUnsupportedProtocol, version)
+}
+
+func WrapLedgerEntryLimit(msg string) error {
+	return fmt.Errorf("%w: %s", ErrLedgerEntryLimit, msg)
+}
+
+func WrapCircuitOpen(host string, retryIn time.Duration) error {
+	if retryIn <= 0 {
+		return fmt.Errorf("%w: %s failed repeatedly, waiting on a probe request", ErrCircuitOpen, host)
+	}
+	return fmt.Errorf("%w: %s failed repeatedly, next attempt in %s", ErrCircuitOpen, host, retryIn.Round(time.Second))
+}
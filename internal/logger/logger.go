@@ -10,17 +10,24 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
+
+	"github.com/dotandev/hintents/internal/redact"
 )
 
+// Logger is the process-wide logger. It is safe for concurrent use; its
+// minimum level is read atomically on every call, so SetLevel may be called
+// while other goroutines are logging.
 var (
 	Logger *slog.Logger
 	level  = new(slog.LevelVar)
 	mu     sync.Mutex
+	policy atomic.Pointer[redact.Policy]
 )
 
 func init() {
-	lvl := parseLevelFromEnv()
-	initLogger(lvl, os.Stderr, false)
+	level.Set(parseLevelFromEnv())
+	initLogger(os.Stderr, false)
 }
 
 func parseLevelFromEnv() slog.Level {
@@ -39,39 +46,81 @@ func parseLevelFromEnv() slog.Level {
 	}
 }
 
-func initLogger(lvl slog.Level, w io.Writer, useJSON bool) {
+func initLogger(w io.Writer, useJSON bool) {
 	if w == nil {
 		w = os.Stderr
 	}
 
-	level.Set(lvl)
-
 	var handler slog.Handler
 	if useJSON {
 		handler = slog.NewJSONHandler(w, &slog.HandlerOptions{
-			Level:     level,
-			AddSource: true,
+			Level:       level,
+			AddSource:   true,
+			ReplaceAttr: redactAttr,
 		})
 	} else {
 		handler = NewTextHandler(w, &slog.HandlerOptions{
-			Level:     level,
-			AddSource: true,
+			Level:       level,
+			AddSource:   true,
+			ReplaceAttr: redactAttr,
 		})
 	}
 
 	Logger = slog.New(handler)
 }
 
+// SetLevel changes the minimum level of Logger. The level is held in a
+// slog.LevelVar shared by every handler, so the change takes effect
+// immediately for all goroutines and is safe to call concurrently.
 func SetLevel(lvl slog.Level) {
-	mu.Lock()
-	defer mu.Unlock()
 	level.Set(lvl)
 }
 
+// Level returns the current minimum level of Logger.
+func Level() slog.Level {
+	return level.Level()
+}
+
+// SetRedaction sets the policy applied to every log record: attributes
+// named by the policy are replaced with redact.Redacted, and its pattern is
+// applied to the message and string attributes. A nil policy disables
+// redaction, which is the default.
+func SetRedaction(p *redact.Policy) {
+	policy.Store(p)
+}
+
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	p := policy.Load()
+	if p.Empty() {
+		return a
+	}
+	if len(groups) == 0 {
+		switch a.Key {
+		case slog.TimeKey, slog.LevelKey, slog.SourceKey:
+			return a
+		}
+	}
+	if p.HasField(a.Key) {
+		return slog.String(a.Key, redact.Redacted)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, p.String(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, p.String(err.Error()))
+		}
+	}
+	return a
+}
+
+// SetOutput replaces Logger with one writing to w. The current level is
+// kept. Callers that log from other goroutines should finish configuring
+// output before starting them, since Logger itself is reassigned.
 func SetOutput(w io.Writer, useJSON bool) {
 	mu.Lock()
 	defer mu.Unlock()
-	initLogger(level.Level(), w, useJSON)
+	initLogger(w, useJSON)
 }
 
 type TextHandler struct {
@@ -8,7 +8,10 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/dotandev/hintents/internal/redact"
 )
 
 func TestParseLevelFromEnv(t *testing.T) {
@@ -146,6 +149,96 @@ func TestLoggerConcurrency(t *testing.T) {
 	}
 }
 
+func TestSetLevelWhileLogging(t *testing.T) {
+	buf := &bytes.Buffer{}
+	SetOutput(buf, false)
+	defer SetLevel(slog.LevelInfo)
+
+	levels := []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				Logger.Debug("concurrent debug", "id", id)
+				Logger.Info("concurrent info", "id", id)
+				_ = Level()
+			}
+		}(i)
+	}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				SetLevel(levels[j%len(levels)])
+			}
+		}()
+	}
+	wg.Wait()
+
+	SetLevel(slog.LevelWarn)
+	if Level() != slog.LevelWarn {
+		t.Errorf("Level() = %v after SetLevel(Warn)", Level())
+	}
+}
+
+func TestSetOutputKeepsLevel(t *testing.T) {
+	SetLevel(slog.LevelError)
+	defer SetLevel(slog.LevelInfo)
+
+	buf := &bytes.Buffer{}
+	SetOutput(buf, true)
+
+	if Level() != slog.LevelError {
+		t.Errorf("SetOutput changed level to %v", Level())
+	}
+	Logger.Warn("should be filtered")
+	if buf.Len() != 0 {
+		t.Errorf("expected no output at ERROR level, got %q", buf.String())
+	}
+}
+
+func TestSetRedaction(t *testing.T) {
+	p, err := redact.NewPolicy([]string{"envelope_xdr"}, `secret-[a-z]+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetRedaction(p)
+	defer SetRedaction(nil)
+
+	buf := &bytes.Buffer{}
+	SetOutput(buf, true)
+	SetLevel(slog.LevelInfo)
+
+	Logger.Info("request secret-token", "envelope_xdr", "AAAAenvelope", "url", "https://rpc/secret-key", "count", 3)
+
+	output := buf.String()
+	for _, leaked := range []string{"AAAAenvelope", "secret-token", "secret-key"} {
+		if strings.Contains(output, leaked) {
+			t.Errorf("output leaked %q: %s", leaked, output)
+		}
+	}
+	for _, kept := range []string{`"envelope_xdr":"[REDACTED]"`, `"url":"https://rpc/[REDACTED]"`, `"count":3`} {
+		if !strings.Contains(output, kept) {
+			t.Errorf("output missing %s: %s", kept, output)
+		}
+	}
+}
+
+func TestSetRedaction_DefaultKeepsValues(t *testing.T) {
+	buf := &bytes.Buffer{}
+	SetOutput(buf, true)
+	SetLevel(slog.LevelInfo)
+
+	Logger.Info("request", "envelope_xdr", "AAAAenvelope")
+	if !strings.Contains(buf.String(), "AAAAenvelope") {
+		t.Errorf("value redacted without a policy: %s", buf.String())
+	}
+}
+
 func TestSetOutputWithNilWriter(t *testing.T) {
 	defer func() {
 		buf := &bytes.Buffer{}
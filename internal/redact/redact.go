@@ -0,0 +1,131 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redact removes sensitive values from logs and diagnostic output
+// according to a user-configured policy.
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces values removed by redaction.
+const Redacted = "[REDACTED]"
+
+// Policy names the fields whose values are replaced with Redacted, and
+// optionally a pattern whose matches are replaced in every string value.
+// Field names are the snake_case names used in JSON output and log
+// attributes, e.g. "envelope_xdr" or "ledger_entries". The zero value and a
+// nil policy redact nothing.
+type Policy struct {
+	fields  map[string]bool
+	pattern *regexp.Regexp
+}
+
+// NewPolicy builds a policy from field names and a regular expression. Empty
+// inputs are ignored.
+func NewPolicy(fields []string, pattern string) (*Policy, error) {
+	p := &Policy{fields: map[string]bool{}}
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			p.fields[f] = true
+		}
+	}
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern: %w", err)
+		}
+		p.pattern = re
+	}
+	return p, nil
+}
+
+// Empty reports whether the policy redacts nothing.
+func (p *Policy) Empty() bool {
+	return p == nil || (len(p.fields) == 0 && p.pattern == nil)
+}
+
+// HasField reports whether values of the named field are redacted.
+func (p *Policy) HasField(name string) bool {
+	return p != nil && p.fields[name]
+}
+
+// Value redacts a single value belonging to the named field.
+func (p *Policy) Value(name, value string) string {
+	if p == nil || value == "" {
+		return value
+	}
+	if p.fields[name] {
+		return Redacted
+	}
+	return p.String(value)
+}
+
+// String replaces every match of the policy pattern in s.
+func (p *Policy) String(s string) string {
+	if p == nil || p.pattern == nil {
+		return s
+	}
+	return p.pattern.ReplaceAllString(s, Redacted)
+}
+
+// JSON redacts a JSON document: values under a redacted field name are
+// replaced (for objects, only their values, so keys stay visible) and the
+// pattern is applied to every remaining string.
+func (p *Policy) JSON(data []byte) ([]byte, error) {
+	if p.Empty() {
+		return data, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(p.walk(v), "", "  ")
+}
+
+func (p *Policy) walk(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if p.fields[k] {
+				t[k] = redactAll(child)
+			} else {
+				t[k] = p.walk(child)
+			}
+		}
+		return t
+	case []interface{}:
+		for i, child := range t {
+			t[i] = p.walk(child)
+		}
+		return t
+	case string:
+		return p.String(t)
+	default:
+		return v
+	}
+}
+
+// redactAll replaces a value wholesale, keeping object keys and list length.
+func redactAll(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k := range t {
+			t[k] = Redacted
+		}
+		return t
+	case []interface{}:
+		for i := range t {
+			t[i] = Redacted
+		}
+		return t
+	case nil:
+		return nil
+	default:
+		return Redacted
+	}
+}
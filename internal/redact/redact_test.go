@@ -0,0 +1,61 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package redact
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_NilRedactsNothing(t *testing.T) {
+	var p *Policy
+	assert.True(t, p.Empty())
+	assert.Equal(t, "value", p.Value("envelope_xdr", "value"))
+
+	data := []byte(`{"envelope_xdr":"AAAA"}`)
+	out, err := p.JSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, data, out)
+}
+
+func TestPolicy_Value(t *testing.T) {
+	p, err := NewPolicy([]string{"envelope_xdr", " "}, `token=\w+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, Redacted, p.Value("envelope_xdr", "AAAA"))
+	assert.Equal(t, "", p.Value("envelope_xdr", ""))
+	assert.Equal(t, "https://rpc?"+Redacted, p.Value("rpc_url", "https://rpc?token=abc"))
+	assert.Equal(t, "plain", p.Value("result_xdr", "plain"))
+}
+
+func TestPolicy_JSON(t *testing.T) {
+	p, err := NewPolicy([]string{"ledger_entries", "envelope_xdr"}, "")
+	require.NoError(t, err)
+
+	out, err := p.JSON([]byte(`{
+		"envelope_xdr": "AAAA",
+		"result_meta_xdr": "BBBB",
+		"ledger_entries": {"k1": "v1", "k2": "v2"},
+		"nested": [{"envelope_xdr": "CCCC", "count": 2}]
+	}`))
+	require.NoError(t, err)
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &got))
+	assert.Equal(t, Redacted, got["envelope_xdr"])
+	assert.Equal(t, "BBBB", got["result_meta_xdr"])
+	assert.Equal(t, map[string]interface{}{"k1": Redacted, "k2": Redacted}, got["ledger_entries"])
+
+	nested := got["nested"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, Redacted, nested["envelope_xdr"])
+	assert.Equal(t, float64(2), nested["count"])
+}
+
+func TestNewPolicy_InvalidPattern(t *testing.T) {
+	_, err := NewPolicy(nil, "(")
+	assert.Error(t, err)
+}
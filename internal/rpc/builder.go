@@ -5,7 +5,9 @@ package rpc
 
 import (
 	"fmt"
+	"math"
 	"net/http"
+	"net/url"
 	"os"
 
 	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
@@ -16,12 +18,19 @@ type ClientOption func(*clientBuilder) error
 type clientBuilder struct {
 	network      Network
 	token        string
+	headers      map[string]string
 	horizonURL   string
 	sorobanURL   string
 	altURLs      []string
 	cacheEnabled bool
 	config       *NetworkConfig
 	httpClient   *http.Client
+	proxyURL     *url.URL
+	userAgent    string
+	maxInFlight  int
+	rateLimit    float64
+	failover     FailoverPolicy
+	breaker      *CircuitBreakerConfig
 }
 
 func newBuilder() *clientBuilder {
@@ -115,7 +124,108 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
+// WithProxy routes all outgoing requests through the given proxy URL,
+// overriding HTTP_PROXY/HTTPS_PROXY/NO_PROXY from the environment.
+func WithProxy(proxy string) ClientOption {
+	return func(b *clientBuilder) error {
+		if proxy == "" {
+			return nil
+		}
+		parsed, err := url.Parse(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch parsed.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("invalid proxy URL: scheme must be http, https or socks5, got %q", parsed.Scheme)
+		}
+		if parsed.Host == "" {
+			return fmt.Errorf("invalid proxy URL: missing host")
+		}
+		b.proxyURL = parsed
+		return nil
+	}
+}
+
+// WithUserAgent overrides the User-Agent sent on every request. An empty
+// value keeps the default "erst/<version>".
+func WithUserAgent(userAgent string) ClientOption {
+	return func(b *clientBuilder) error {
+		b.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithMaxConcurrentRequests caps the number of requests in flight to the
+// provider at once. Further requests block until a slot frees up or their
+// context is done. Zero, the default, means no cap. The option has no effect
+// together with WithHTTPClient.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(b *clientBuilder) error {
+		if n < 0 {
+			return fmt.Errorf("invalid max concurrent requests: %d, must not be negative", n)
+		}
+		b.maxInFlight = n
+		return nil
+	}
+}
+
+// WithRateLimit throttles outgoing requests to rps requests per second. The
+// limiter sits above the concurrency cap and the retry layer, so a request
+// takes one token however many attempts it needs. Requests over the rate
+// wait for their turn or until their context is done. Zero, the default,
+// means no limit. The option has no effect together with WithHTTPClient.
+func WithRateLimit(rps float64) ClientOption {
+	return func(b *clientBuilder) error {
+		if rps < 0 || math.IsNaN(rps) || math.IsInf(rps, 0) {
+			return fmt.Errorf("invalid rate limit: %v, must be a non-negative number of requests per second", rps)
+		}
+		b.rateLimit = rps
+		return nil
+	}
+}
+
+// WithCircuitBreaker stops sending requests to an endpoint that keeps
+// failing, see CircuitBreakerTransport. The breaker wraps the retry layer,
+// so once a host's circuit is open further calls fail fast instead of each
+// paying a full backoff sequence. The option has no effect together with
+// WithHTTPClient.
+func WithCircuitBreaker(config CircuitBreakerConfig) ClientOption {
+	return func(b *clientBuilder) error {
+		if config.FailureThreshold < 0 || config.Window < 0 || config.Cooldown < 0 {
+			return fmt.Errorf("invalid circuit breaker config: %+v, values must not be negative", config)
+		}
+		b.breaker = &config
+		return nil
+	}
+}
+
+// WithFailoverPolicy sets which errors move a request on to the next
+// endpoint of WithAltURLs. The default is FailoverOnAnyError.
+func WithFailoverPolicy(policy FailoverPolicy) ClientOption {
+	return func(b *clientBuilder) error {
+		switch policy {
+		case FailoverOnAnyError, FailoverOnServerError, FailoverDisabled:
+		default:
+			return fmt.Errorf("invalid failover policy: %d", int(policy))
+		}
+		b.failover = policy
+		return nil
+	}
+}
+
 func NewClient(opts ...ClientOption) (*Client, error) {
+	builder, err := newConfiguredBuilder(opts)
+	if err != nil {
+		return nil, err
+	}
+	return builder.build()
+}
+
+// newConfiguredBuilder applies opts on top of the defaults and the
+// ERST_RPC_TOKEN environment variable and validates the result.
+func newConfiguredBuilder(opts []ClientOption) (*clientBuilder, error) {
 	builder := newBuilder()
 
 	if builder.token == "" {
@@ -132,7 +242,7 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		return nil, err
 	}
 
-	return builder.build()
+	return builder, nil
 }
 
 func (b *clientBuilder) validate() error {
@@ -191,7 +301,7 @@ func (b *clientBuilder) build() (*Client, error) {
 	}
 
 	if b.httpClient == nil {
-		b.httpClient = createHTTPClient(b.token)
+		b.httpClient = createHTTPClient(b.token, b.headers, b.proxyURL, b.userAgent, b.maxInFlight, b.rateLimit, b.breaker)
 	}
 
 	if len(b.altURLs) == 0 && b.horizonURL != "" {
@@ -212,11 +322,17 @@ func (b *clientBuilder) build() (*Client, error) {
 			HorizonURL: b.horizonURL,
 			HTTP:       b.httpClient,
 		},
-		Network:      b.network,
-		SorobanURL:   b.sorobanURL,
-		AltURLs:      b.altURLs,
-		token:        b.token,
-		Config:       *b.config,
-		CacheEnabled: b.cacheEnabled,
+		Network:        b.network,
+		SorobanURL:     b.sorobanURL,
+		AltURLs:        b.altURLs,
+		token:          b.token,
+		proxyURL:       b.proxyURL,
+		userAgent:      b.userAgent,
+		maxInFlight:    b.maxInFlight,
+		rateLimit:      b.rateLimit,
+		httpClient:     b.httpClient,
+		failoverPolicy: b.failover,
+		Config:         *b.config,
+		CacheEnabled:   b.cacheEnabled,
 	}, nil
 }
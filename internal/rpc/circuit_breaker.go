@@ -0,0 +1,229 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+// CircuitBreakerConfig defines when a CircuitBreakerTransport stops sending
+// requests to a failing host.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens
+	// the circuit.
+	FailureThreshold int
+	// Window bounds how far apart those failures may be: a failure more
+	// than Window after the first of the streak starts a new streak.
+	Window time.Duration
+	// Cooldown is how long an open circuit fails fast before letting a
+	// single probe request through.
+	Cooldown time.Duration
+}
+
+// DefaultCircuitBreakerConfig returns a sensible default circuit breaker
+// configuration
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: 5,
+		Window:           time.Minute,
+		Cooldown:         time.Minute,
+	}
+}
+
+// CircuitState is the state of the circuit for one host.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails every request fast with errors.ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe through to test recovery.
+	CircuitHalfOpen
+)
+
+// String implements fmt.Stringer.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// circuit tracks the failures of one host.
+type circuit struct {
+	state        CircuitState
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	probing      bool
+}
+
+// CircuitBreakerTransport stops sending requests to a host that keeps
+// failing. After FailureThreshold consecutive failures within Window the
+// host's circuit opens and requests fail fast with errors.ErrCircuitOpen.
+// Once Cooldown has passed one probe request goes through: success closes
+// the circuit, failure opens it for another Cooldown.
+//
+// A transport error or a 5xx response counts as a failure. Wrapped around a
+// RetryTransport, a whole retry sequence counts once, so a dead endpoint
+// costs one backoff sequence per streak instead of one per call. Circuits
+// are kept per host, so failing over to another endpoint is not blocked.
+type CircuitBreakerTransport struct {
+	config    CircuitBreakerConfig
+	transport http.RoundTripper
+	now       func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// NewCircuitBreakerTransport wraps transport with a circuit breaker. A nil
+// transport uses http.DefaultTransport.
+func NewCircuitBreakerTransport(config CircuitBreakerConfig, transport http.RoundTripper) *CircuitBreakerTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 1
+	}
+	return &CircuitBreakerTransport{
+		config:    config,
+		transport: transport,
+		now:       time.Now,
+		circuits:  make(map[string]*circuit),
+	}
+}
+
+// RoundTrip implements http.RoundTripper interface
+func (t *CircuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if err := t.allow(host); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// The caller gave up; that says nothing about the host.
+		t.release(host)
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		t.recordFailure(host)
+	default:
+		t.recordSuccess(host)
+	}
+	return resp, err
+}
+
+// State returns the circuit state for host.
+func (t *CircuitBreakerTransport) State(host string) CircuitState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if c, ok := t.circuits[host]; ok {
+		if c.state == CircuitOpen && t.now().Sub(c.openedAt) >= t.config.Cooldown {
+			return CircuitHalfOpen
+		}
+		return c.state
+	}
+	return CircuitClosed
+}
+
+// Stats forwards the retry counts of the wrapped transport so
+// Client.RetryStats keeps working through the breaker.
+func (t *CircuitBreakerTransport) Stats() RetryStats {
+	if rt, ok := t.transport.(interface{ Stats() RetryStats }); ok {
+		return rt.Stats()
+	}
+	return RetryStats{}
+}
+
+func (t *CircuitBreakerTransport) circuitFor(host string) *circuit {
+	c, ok := t.circuits[host]
+	if !ok {
+		c = &circuit{}
+		t.circuits[host] = c
+	}
+	return c
+}
+
+// allow reports whether a request to host may go out, moving an open
+// circuit whose cooldown has passed to half-open.
+func (t *CircuitBreakerTransport) allow(host string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := t.circuitFor(host)
+	switch c.state {
+	case CircuitOpen:
+		elapsed := t.now().Sub(c.openedAt)
+		if elapsed < t.config.Cooldown {
+			return errors.WrapCircuitOpen(host, t.config.Cooldown-elapsed)
+		}
+		c.state = CircuitHalfOpen
+		c.probing = true
+		logger.Logger.Info("Circuit half-open, probing endpoint", "host", host)
+	case CircuitHalfOpen:
+		if c.probing {
+			return errors.WrapCircuitOpen(host, 0)
+		}
+		c.probing = true
+	}
+	return nil
+}
+
+// release hands back a probe slot without judging the host.
+func (t *CircuitBreakerTransport) release(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.circuitFor(host).probing = false
+}
+
+func (t *CircuitBreakerTransport) recordSuccess(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := t.circuitFor(host)
+	if c.state != CircuitClosed {
+		logger.Logger.Info("Circuit closed, endpoint recovered", "host", host)
+	}
+	*c = circuit{}
+}
+
+func (t *CircuitBreakerTransport) recordFailure(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	c := t.circuitFor(host)
+	c.probing = false
+
+	if c.state == CircuitHalfOpen {
+		c.state = CircuitOpen
+		c.openedAt = now
+		logger.Logger.Warn("Circuit re-opened, probe failed", "host", host, "cooldown", t.config.Cooldown)
+		return
+	}
+
+	if c.failures == 0 || (t.config.Window > 0 && now.Sub(c.firstFailure) > t.config.Window) {
+		c.failures = 0
+		c.firstFailure = now
+	}
+	c.failures++
+	if c.failures >= t.config.FailureThreshold {
+		c.state = CircuitOpen
+		c.openedAt = now
+		logger.Logger.Warn("Circuit opened, failing fast", "host", host, "failures", c.failures, "cooldown", t.config.Cooldown)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyServer answers 500 while healthy is false and 200 otherwise.
+func flakyServer(t *testing.T) (*httptest.Server, *atomic.Bool, *atomic.Int32) {
+	t.Helper()
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &healthy, &calls
+}
+
+// fakeClock is a manually advanced time source for the breaker.
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestBreaker(cfg CircuitBreakerConfig, base http.RoundTripper) (*CircuitBreakerTransport, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	breaker := NewCircuitBreakerTransport(cfg, base)
+	breaker.now = clock.Now
+	return breaker, clock
+}
+
+func breakerGet(t *testing.T, client *http.Client, target string) error {
+	t.Helper()
+	resp, err := client.Get(target)
+	if err == nil {
+		resp.Body.Close()
+	}
+	return err
+}
+
+func TestCircuitBreaker_Transitions(t *testing.T) {
+	server, healthy, calls := flakyServer(t)
+	host := mustHost(t, server.URL)
+
+	breaker, clock := newTestBreaker(CircuitBreakerConfig{
+		FailureThreshold: 3,
+		Window:           time.Minute,
+		Cooldown:         30 * time.Second,
+	}, http.DefaultTransport)
+	client := &http.Client{Transport: breaker}
+
+	// Closed: failures go through until the threshold is reached.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, breakerGet(t, client, server.URL))
+	}
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+	assert.Equal(t, int32(3), calls.Load())
+
+	// Open: requests fail fast without reaching the server.
+	err := breakerGet(t, client, server.URL)
+	assert.True(t, stderrors.Is(err, errors.ErrCircuitOpen), "got %v", err)
+	assert.Equal(t, int32(3), calls.Load())
+
+	// Half-open after the cooldown: a failed probe re-opens the circuit.
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, CircuitHalfOpen, breaker.State(host))
+	require.NoError(t, breakerGet(t, client, server.URL))
+	assert.Equal(t, int32(4), calls.Load())
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+	assert.ErrorIs(t, breakerGet(t, client, server.URL), errors.ErrCircuitOpen)
+
+	// A successful probe closes it again.
+	healthy.Store(true)
+	clock.Advance(30 * time.Second)
+	require.NoError(t, breakerGet(t, client, server.URL))
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+	require.NoError(t, breakerGet(t, client, server.URL))
+	assert.Equal(t, int32(6), calls.Load())
+}
+
+func TestCircuitBreaker_FailuresOutsideWindowDoNotOpen(t *testing.T) {
+	server, _, _ := flakyServer(t)
+	host := mustHost(t, server.URL)
+
+	breaker, clock := newTestBreaker(CircuitBreakerConfig{
+		FailureThreshold: 2,
+		Window:           10 * time.Second,
+		Cooldown:         time.Minute,
+	}, http.DefaultTransport)
+	client := &http.Client{Transport: breaker}
+
+	require.NoError(t, breakerGet(t, client, server.URL))
+	clock.Advance(11 * time.Second)
+	require.NoError(t, breakerGet(t, client, server.URL))
+	assert.Equal(t, CircuitClosed, breaker.State(host), "the first failure fell out of the window")
+
+	require.NoError(t, breakerGet(t, client, server.URL))
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+}
+
+func TestCircuitBreaker_SuccessResetsStreak(t *testing.T) {
+	server, healthy, _ := flakyServer(t)
+	host := mustHost(t, server.URL)
+
+	breaker, _ := newTestBreaker(CircuitBreakerConfig{FailureThreshold: 2, Window: time.Minute, Cooldown: time.Minute}, http.DefaultTransport)
+	client := &http.Client{Transport: breaker}
+
+	require.NoError(t, breakerGet(t, client, server.URL))
+	healthy.Store(true)
+	require.NoError(t, breakerGet(t, client, server.URL))
+	healthy.Store(false)
+	require.NoError(t, breakerGet(t, client, server.URL))
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+}
+
+func TestCircuitBreaker_PerHost(t *testing.T) {
+	down, _, _ := flakyServer(t)
+	up, healthy, upCalls := flakyServer(t)
+	healthy.Store(true)
+
+	breaker, _ := newTestBreaker(CircuitBreakerConfig{FailureThreshold: 1, Window: time.Minute, Cooldown: time.Minute}, http.DefaultTransport)
+	client := &http.Client{Transport: breaker}
+
+	require.NoError(t, breakerGet(t, client, down.URL))
+	assert.ErrorIs(t, breakerGet(t, client, down.URL), errors.ErrCircuitOpen)
+	require.NoError(t, breakerGet(t, client, up.URL), "another host is not affected")
+	assert.Equal(t, int32(1), upCalls.Load())
+}
+
+func TestCircuitBreaker_WrapsRetryTransport(t *testing.T) {
+	server, _, calls := flakyServer(t)
+
+	retryCfg := DefaultRetryConfig()
+	retryCfg.MaxRetries = 2
+	retryCfg.InitialBackoff = time.Millisecond
+	retryCfg.MaxBackoff = time.Millisecond
+	retryCfg.StatusCodesToRetry = []int{http.StatusInternalServerError}
+
+	breaker, _ := newTestBreaker(CircuitBreakerConfig{FailureThreshold: 2, Window: time.Minute, Cooldown: time.Minute},
+		NewRetryTransport(retryCfg, http.DefaultTransport))
+	client := &http.Client{Transport: breaker}
+
+	// Each call exhausts its retries and counts as one failure.
+	for i := 0; i < 2; i++ {
+		assert.Error(t, breakerGet(t, client, server.URL))
+	}
+	assert.Equal(t, int32(6), calls.Load())
+
+	err := breakerGet(t, client, server.URL)
+	assert.ErrorIs(t, err, errors.ErrCircuitOpen)
+	assert.Equal(t, int32(6), calls.Load(), "an open circuit skips the retry sequence")
+	assert.Equal(t, int64(4), breaker.Stats().Retries)
+}
+
+func TestCircuitBreaker_CallerCancellationNotCounted(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	breaker, _ := newTestBreaker(CircuitBreakerConfig{FailureThreshold: 1, Window: time.Minute, Cooldown: time.Minute}, http.DefaultTransport)
+	client := &http.Client{Transport: breaker}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	_, err := client.Do(req)
+	require.Error(t, err)
+	assert.Equal(t, CircuitClosed, breaker.State(mustHost(t, server.URL)))
+}
+
+func TestCircuitState_String(t *testing.T) {
+	assert.Equal(t, "closed", CircuitClosed.String())
+	assert.Equal(t, "open", CircuitOpen.String())
+	assert.Equal(t, "half-open", CircuitHalfOpen.String())
+}
+
+func mustHost(t *testing.T, raw string) string {
+	t.Helper()
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	return u.Host
+}
+
+func TestWithCircuitBreaker_WrapsRetryLayer(t *testing.T) {
+	client, err := NewClient(WithCircuitBreaker(DefaultCircuitBreakerConfig()), WithMaxConcurrentRequests(2), WithRateLimit(5))
+	require.NoError(t, err)
+
+	limited, ok := client.httpClient.Transport.(*rateLimitTransport)
+	require.True(t, ok)
+	capped, ok := limited.transport.(*concurrencyTransport)
+	require.True(t, ok)
+	breaker, ok := capped.transport.(*CircuitBreakerTransport)
+	require.True(t, ok, "the breaker sits beneath the concurrency cap")
+	_, ok = breaker.transport.(*RetryTransport)
+	assert.True(t, ok, "the breaker wraps the retry layer")
+}
+
+func TestWithCircuitBreaker_RejectsNegativeConfig(t *testing.T) {
+	_, err := NewClient(WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, Window: -time.Second}))
+	assert.Error(t, err)
+}
+
+func TestClientProvider_KeysOnCircuitBreaker(t *testing.T) {
+	p := NewClientProvider()
+	plain, err := p.Client(WithNetwork(Testnet))
+	require.NoError(t, err)
+	guarded, err := p.Client(WithNetwork(Testnet), WithCircuitBreaker(DefaultCircuitBreakerConfig()))
+	require.NoError(t, err)
+	assert.NotSame(t, plain, guarded)
+}
@@ -10,8 +10,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"sync"
+	"time"
 
+	"github.com/dotandev/hintents/internal/errors"
 	"github.com/dotandev/hintents/internal/logger"
 
 	"github.com/dotandev/hintents/internal/telemetry"
@@ -58,12 +61,40 @@ func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return t.transport.RoundTrip(req)
 }
 
+// ClientVersion is reported in the default User-Agent. The CLI sets it to
+// its build version at startup.
+var ClientVersion = "dev"
+
+// DefaultUserAgent returns the User-Agent sent when none is configured.
+func DefaultUserAgent() string {
+	return "erst/" + ClientVersion
+}
+
+// userAgentTransport sets the User-Agent header on every request. It sits
+// beneath the retry layer and works on a clone of the request, so each
+// retried attempt carries the header.
+type userAgentTransport struct {
+	userAgent string
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper interface
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.transport.RoundTrip(req)
+}
+
 // NetworkConfig represents a Stellar network configuration
 type NetworkConfig struct {
 	Name              string
 	HorizonURL        string
 	NetworkPassphrase string
 	SorobanRPCURL     string
+	// NetworkID identifies a private network by its raw id instead of
+	// NetworkPassphrase. The zero value means the id is derived from the
+	// passphrase; see ID.
+	NetworkID [32]byte
 }
 
 // Predefined network configurations
@@ -92,16 +123,24 @@ var (
 
 // Client handles interactions with the Stellar Network
 type Client struct {
-	Horizon      horizonclient.ClientInterface
-	HorizonURL   string
-	Network      Network
-	SorobanURL   string
-	AltURLs      []string
-	currIndex    int
-	mu           sync.RWMutex
-	token        string // stored for reference, not logged
-	Config       NetworkConfig
-	CacheEnabled bool
+	Horizon    horizonclient.ClientInterface
+	HorizonURL string
+	Network    Network
+	SorobanURL string
+	AltURLs    []string
+	currIndex  int
+	// failoverPolicy decides which errors move a request to the next of
+	// AltURLs.
+	failoverPolicy FailoverPolicy
+	mu             sync.RWMutex
+	token          string // stored for reference, not logged
+	proxyURL       *url.URL
+	userAgent      string
+	maxInFlight    int
+	rateLimit      float64
+	httpClient     *http.Client
+	Config         NetworkConfig
+	CacheEnabled   bool
 }
 
 // NewClientDefault creates a new RPC client with sensible defaults
@@ -151,18 +190,37 @@ func (c *Client) rotateURL() bool {
 	c.HorizonURL = c.AltURLs[c.currIndex]
 	c.Horizon = &horizonclient.Client{
 		HorizonURL: c.HorizonURL,
-		HTTP:       createHTTPClient(c.token),
+		HTTP:       c.getHTTPClient(),
 	}
 
-	logger.Logger.Warn("RPC failover triggered", "new_url", c.HorizonURL)
 	return true
 }
 
-// createHTTPClient creates an HTTP client with optional authentication
-func createHTTPClient(token string) *http.Client {
+// createHTTPClient creates an HTTP client with optional authentication.
+// The proxy and User-Agent are applied on the base transport, beneath the
+// retry layer, so every retried attempt goes through the same proxy and
+// carries the same User-Agent. The retry layer is wrapped, from the inside
+// out, in a circuit breaker when breaker is non-nil, a semaphore capping
+// concurrent requests when maxInFlight is positive and a limiter allowing
+// rateLimit requests per second when rateLimit is positive.
+func createHTTPClient(token string, headers map[string]string, proxyURL *url.URL, userAgent string, maxInFlight int, rateLimit float64, breaker *CircuitBreakerConfig) *http.Client {
 	cfg := DefaultRetryConfig()
 
-	var baseTransport http.RoundTripper = http.DefaultTransport
+	if userAgent == "" {
+		userAgent = DefaultUserAgent()
+	}
+	var baseTransport http.RoundTripper = &userAgentTransport{
+		userAgent: userAgent,
+		transport: newBaseTransport(proxyURL),
+	}
+
+	if len(headers) > 0 {
+		logger.Logger.Debug("Sending custom headers with RPC requests", "headers", maskedHeaders(headers))
+		baseTransport = &headerTransport{
+			headers:   headers,
+			transport: baseTransport,
+		}
+	}
 
 	var transport http.RoundTripper = baseTransport
 	if token != "" {
@@ -173,12 +231,48 @@ func createHTTPClient(token string) *http.Client {
 	}
 
 	transport = NewRetryTransport(cfg, transport)
+	if breaker != nil {
+		transport = NewCircuitBreakerTransport(*breaker, transport)
+	}
+	transport = newConcurrencyTransport(maxInFlight, transport)
+	transport = newRateLimitTransport(rateLimit, transport)
 
 	return &http.Client{
 		Transport: transport,
 	}
 }
 
+// newBaseTransport clones the default transport and configures its proxy.
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY are honored unless proxyURL is set,
+// in which case it is used for every request.
+func newBaseTransport(proxyURL *url.URL) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyURL != nil {
+		transport.Proxy = http.ProxyURL(proxyURL)
+	} else {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+	return transport
+}
+
+// getHTTPClient returns the client's configured HTTP client, falling back to
+// http.DefaultClient for clients constructed without the builder.
+func (c *Client) getHTTPClient() *http.Client {
+	if c.httpClient != nil {
+		return c.httpClient
+	}
+	return http.DefaultClient
+}
+
+// RetryStats returns the retry counts of the client's HTTP transport. It is
+// zero when the transport does not retry.
+func (c *Client) RetryStats() RetryStats {
+	if rt, ok := c.getHTTPClient().Transport.(interface{ Stats() RetryStats }); ok {
+		return rt.Stats()
+	}
+	return RetryStats{}
+}
+
 // NewCustomClient creates a new RPC client for a custom/private network
 // Deprecated: Use NewClient with WithNetworkConfig instead
 func NewCustomClient(config NetworkConfig) (*Client, error) {
@@ -205,23 +299,56 @@ func NewCustomClient(config NetworkConfig) (*Client, error) {
 	}, nil
 }
 
+// txNotFoundPollInterval is how long WaitForTransaction sleeps between
+// lookups of a transaction that is not queryable yet.
+var txNotFoundPollInterval = time.Second
+
 // GetTransaction fetches the transaction details and full XDR data
 func (c *Client) GetTransaction(ctx context.Context, hash string) (*TransactionResponse, error) {
-	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
-		resp, err := c.getTransactionAttempt(ctx, hash)
-		if err == nil {
-			return resp, nil
+	var resp *TransactionResponse
+	err := c.withFailover(ctx, func() error {
+		var err error
+		resp, err = c.getTransactionAttempt(ctx, hash)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("all RPC endpoints failed: %w", err)
+	}
+	return resp, nil
+}
+
+// WaitForTransaction fetches a transaction, retrying for up to wait while the
+// transaction is not found. A freshly submitted transaction is not queryable
+// until it has been included in a closed ledger, and the "not found" answer is
+// a regular response rather than a retryable HTTP status, so the transport
+// level retries do not cover it. Any other error is returned immediately.
+func (c *Client) WaitForTransaction(ctx context.Context, hash string, wait time.Duration) (*TransactionResponse, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		resp, err := c.GetTransaction(ctx, hash)
+		if err == nil || !errors.IsTransactionNotFound(err) {
+			return resp, err
 		}
 
-		// Only rotate if this isn't the last possible URL
-		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying with fallback RPC...", "error", err)
-			if !c.rotateURL() {
-				break
-			}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("transaction %s not found after waiting %s: %w", hash, wait, err)
+		}
+
+		delay := txNotFoundPollInterval
+		if delay > remaining {
+			delay = remaining
+		}
+		logger.Logger.Info("Transaction not found yet, retrying", "hash", hash, "retry_in", delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
 		}
 	}
-	return nil, fmt.Errorf("all RPC endpoints failed")
 }
 
 func (c *Client) getTransactionAttempt(ctx context.Context, hash string) (*TransactionResponse, error) {
@@ -239,6 +366,10 @@ func (c *Client) getTransactionAttempt(ctx context.Context, hash string) (*Trans
 	tx, err := c.Horizon.TransactionDetail(hash)
 	if err != nil {
 		span.RecordError(err)
+		if horizonclient.IsNotFoundError(err) {
+			logger.Logger.Debug("Transaction not found", "hash", hash, "url", c.HorizonURL)
+			return nil, errors.WrapTransactionNotFound(fmt.Errorf("%s: %w", c.HorizonURL, err))
+		}
 		logger.Logger.Error("Failed to fetch transaction", "hash", hash, "error", err, "url", c.HorizonURL)
 		return nil, fmt.Errorf("failed to fetch transaction from %s: %w", c.HorizonURL, err)
 	}
@@ -469,38 +600,77 @@ func (c *Client) GetLedgerEntries(ctx context.Context, keys []string) (map[strin
 	}
 
 	logger.Logger.Debug("Fetching ledger entries from RPC", "count", len(keysToFetch), "url", c.SorobanURL)
-	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
-		entries, err := c.getLedgerEntriesAttempt(ctx, keysToFetch)
-		if err == nil {
-			return entries, nil
-		}
-
-		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying with fallback Soroban RPC...", "error", err)
-			if !c.rotateURL() {
-				break
-			}
-			continue
-		}
+	var fetched map[string]string
+	err := c.withFailover(ctx, func() error {
+		var err error
+		fetched, err = c.getLedgerEntriesAttempt(ctx, keysToFetch)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
-	return nil, fmt.Errorf("all Soroban RPC endpoints failed")
+	return fetched, nil
 }
 
 func (c *Client) getLedgerEntriesAttempt(ctx context.Context, keysToFetch []string) (map[string]string, error) {
 	logger.Logger.Debug("Fetching ledger entries", "count", len(keysToFetch), "url", c.HorizonURL)
-	reqBody := GetLedgerEntriesRequest{
-		Jsonrpc: "2.0",
-		ID:      1,
-		Method:  "getLedgerEntries",
-		Params:  []interface{}{keysToFetch},
+
+	entries := make(map[string]string)
+	fetchedCount := 0
+	var targetURL string
+	for _, chunk := range ledgerKeyChunks(keysToFetch) {
+		rpcResp, url, err := c.postGetLedgerEntries(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		targetURL = url
+
+		for _, entry := range rpcResp.Result.Entries {
+			entries[entry.Key] = entry.Xdr
+			fetchedCount++
+
+			// Cache the new entry
+			if c.CacheEnabled {
+				if err := Set(entry.Key, entry.Xdr); err != nil {
+					logger.Logger.Warn("Failed to cache entry", "key", entry.Key, "error", err)
+				}
+			}
+		}
 	}
 
-	bodyBytes, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	logger.Logger.Info("Ledger entries fetched",
+		// 		"total_requested", len(keys),
+		"total_requested", len(keysToFetch),
+		"from_cache", len(keysToFetch)-fetchedCount,
+		"from_rpc", fetchedCount,
+		"url", targetURL,
+	)
+
+	return entries, nil
+}
+
+// MaxLedgerEntriesPerRequest is the number of keys Soroban RPC accepts in a
+// single getLedgerEntries call. Larger requests are split into chunks.
+const MaxLedgerEntriesPerRequest = 200
+
+// ledgerKeyChunks splits keys into slices of at most
+// MaxLedgerEntriesPerRequest keys.
+func ledgerKeyChunks(keys []string) [][]string {
+	chunks := make([][]string, 0, (len(keys)+MaxLedgerEntriesPerRequest-1)/MaxLedgerEntriesPerRequest)
+	for len(keys) > MaxLedgerEntriesPerRequest {
+		chunks = append(chunks, keys[:MaxLedgerEntriesPerRequest])
+		keys = keys[MaxLedgerEntriesPerRequest:]
+	}
+	if len(keys) > 0 {
+		chunks = append(chunks, keys)
 	}
+	return chunks
+}
 
+// postGetLedgerEntries issues a single getLedgerEntries JSON-RPC call and
+// returns the decoded response together with the URL it was sent to.
+// Transport failures wrap errors.ErrRPCConnectionFailed.
+func (c *Client) postGetLedgerEntries(ctx context.Context, keys []string) (*GetLedgerEntriesResponse, string, error) {
 	targetURL := c.HorizonURL
 	if c.Network == Testnet && targetURL == "" {
 		targetURL = TestnetSorobanURL
@@ -508,55 +678,108 @@ func (c *Client) getLedgerEntriesAttempt(ctx context.Context, keysToFetch []stri
 		targetURL = MainnetSorobanURL
 	}
 
+	reqBody := GetLedgerEntriesRequest{
+		Jsonrpc: "2.0",
+		ID:      1,
+		Method:  "getLedgerEntries",
+		Params:  []interface{}{keys},
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, targetURL, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, "POST", targetURL, bytes.NewBuffer(bodyBytes))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, targetURL, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.getHTTPClient().Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request to %s: %w", targetURL, err)
+		return nil, targetURL, errors.WrapRPCConnectionFailed(fmt.Errorf("failed to execute request to %s: %w", targetURL, err))
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, targetURL, fmt.Errorf("getLedgerEntries on %s: %w", targetURL, &HTTPStatusError{StatusCode: resp.StatusCode})
+	}
+
 	respBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, targetURL, errors.WrapRPCConnectionFailed(fmt.Errorf("failed to read response: %w", err))
 	}
 
 	var rpcResp GetLedgerEntriesResponse
 	if err := json.Unmarshal(respBytes, &rpcResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, targetURL, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	if rpcResp.Error != nil {
-		return nil, fmt.Errorf("rpc error from %s: %s (code %d)", targetURL, rpcResp.Error.Message, rpcResp.Error.Code)
+		return nil, targetURL, fmt.Errorf("rpc error from %s: %s (code %d)", targetURL, rpcResp.Error.Message, rpcResp.Error.Code)
 	}
 
-	entries := make(map[string]string)
-	fetchedCount := 0
-	for _, entry := range rpcResp.Result.Entries {
-		entries[entry.Key] = entry.Xdr
-		fetchedCount++
-
-		// Cache the new entry
-		if c.CacheEnabled {
-			if err := Set(entry.Key, entry.Xdr); err != nil {
-				logger.Logger.Warn("Failed to cache entry", "key", entry.Key, "error", err)
-			}
-		}
+	return &rpcResp, targetURL, nil
+}
+
+// LedgerEntryState is a ledger entry together with its TTL information as
+// reported by Soroban RPC.
+type LedgerEntryState struct {
+	Key                string
+	Xdr                string
+	LastModifiedLedger uint32
+	// LiveUntilLedger is zero for entries without a TTL (e.g. accounts).
+	LiveUntilLedger uint32
+}
+
+// LedgerEntryStates is the result of GetLedgerEntryStates.
+type LedgerEntryStates struct {
+	Entries      map[string]LedgerEntryState
+	LatestLedger uint32
+}
+
+// GetLedgerEntryStates fetches ledger entries including their TTL and the
+// latest ledger known to the RPC node, which is needed to tell whether an
+// entry has been archived. Unlike GetLedgerEntries it bypasses the cache,
+// since cached entries carry no TTL information.
+func (c *Client) GetLedgerEntryStates(ctx context.Context, keys []string) (*LedgerEntryStates, error) {
+	if len(keys) == 0 {
+		return &LedgerEntryStates{Entries: map[string]LedgerEntryState{}}, nil
 	}
 
-	logger.Logger.Info("Ledger entries fetched",
-		// 		"total_requested", len(keys),
-		"total_requested", len(keysToFetch),
-		"from_cache", len(keysToFetch)-fetchedCount,
-		"from_rpc", fetchedCount,
-		"url", targetURL,
-	)
+	var states *LedgerEntryStates
+	err := c.withFailover(ctx, func() error {
+		var err error
+		states, err = c.getLedgerEntryStatesAttempt(ctx, keys)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return states, nil
+}
 
-	return entries, nil
+func (c *Client) getLedgerEntryStatesAttempt(ctx context.Context, keys []string) (*LedgerEntryStates, error) {
+	states := &LedgerEntryStates{Entries: make(map[string]LedgerEntryState, len(keys))}
+	for _, chunk := range ledgerKeyChunks(keys) {
+		rpcResp, _, err := c.postGetLedgerEntries(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		if latest := uint32(rpcResp.Result.LatestLedger); latest > states.LatestLedger {
+			states.LatestLedger = latest
+		}
+		for _, entry := range rpcResp.Result.Entries {
+			states.Entries[entry.Key] = LedgerEntryState{
+				Key:                entry.Key,
+				Xdr:                entry.Xdr,
+				LastModifiedLedger: uint32(entry.LastModifiedLedger),
+				LiveUntilLedger:    uint32(entry.LiveUntilLedger),
+			}
+		}
+	}
+	return states, nil
 }
 
 type TransactionSummary struct {
@@ -613,8 +836,10 @@ type SimulateTransactionResponse struct {
 	Result  struct {
 		// Soroban RPC returns these in various versions. Keep fields optional.
 		// We only need minimal pieces for fee/budget estimation.
-		MinResourceFee  string `json:"minResourceFee,omitempty"`
-		TransactionData string `json:"transactionData,omitempty"`
+		MinResourceFee  string   `json:"minResourceFee,omitempty"`
+		TransactionData string   `json:"transactionData,omitempty"`
+		Events          []string `json:"events,omitempty"`
+		Error           string   `json:"error,omitempty"`
 		Cost            struct {
 			CpuInsns  int64 `json:"cpuInsns,omitempty"`
 			MemBytes  int64 `json:"memBytes,omitempty"`
@@ -651,7 +876,7 @@ func (c *Client) SimulateTransaction(ctx context.Context, envelopeXdr string) (*
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.getHTTPClient().Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -0,0 +1,70 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// concurrencyTransport caps the number of requests in flight to a provider.
+// A request holds its slot from the first attempt until the response body is
+// closed, so retries of the same request never take a second slot. Callers
+// over the cap block until a slot frees up or their context is done.
+type concurrencyTransport struct {
+	slots     chan struct{}
+	transport http.RoundTripper
+}
+
+// newConcurrencyTransport wraps transport with a semaphore of size max. A
+// non-positive max disables the cap and returns transport unchanged.
+func newConcurrencyTransport(max int, transport http.RoundTripper) http.RoundTripper {
+	if max <= 0 {
+		return transport
+	}
+	return &concurrencyTransport{
+		slots:     make(chan struct{}, max),
+		transport: transport,
+	}
+}
+
+// RoundTrip implements http.RoundTripper interface
+func (t *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp == nil || resp.Body == nil {
+		<-t.slots
+		return resp, err
+	}
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: func() { <-t.slots }}
+	return resp, nil
+}
+
+// Stats forwards the retry counts of the wrapped transport so
+// Client.RetryStats keeps working when a cap is configured.
+func (t *concurrencyTransport) Stats() RetryStats {
+	if rt, ok := t.transport.(interface{ Stats() RetryStats }); ok {
+		return rt.Stats()
+	}
+	return RetryStats{}
+}
+
+// releaseOnClose frees a concurrency slot the first time the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
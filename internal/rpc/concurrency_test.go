@@ -0,0 +1,106 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyTransport_NeverExceedsCap(t *testing.T) {
+	const limit = 3
+	var inFlight, peak int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := createHTTPClient("", nil, nil, "", limit, 0, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("request failed: %v", err)
+				return
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if peak > limit {
+		t.Errorf("expected at most %d requests in flight, saw %d", limit, peak)
+	}
+	if peak == 0 {
+		t.Error("expected the server to see requests")
+	}
+}
+
+func TestConcurrencyTransport_RespectsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := createHTTPClient("", nil, nil, "", 1, 0, nil)
+
+	go func() {
+		resp, err := client.Get(server.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	_, err := client.Do(req)
+	if err == nil {
+		t.Fatal("expected the blocked request to fail when its context expires")
+	}
+	if ctx.Err() == nil {
+		t.Errorf("expected the context to be done, got %v", err)
+	}
+}
+
+func TestWithMaxConcurrentRequests(t *testing.T) {
+	if _, err := NewClient(WithMaxConcurrentRequests(-1)); err == nil {
+		t.Error("expected error for a negative cap")
+	}
+
+	client, err := NewClient(WithMaxConcurrentRequests(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := client.getHTTPClient().Transport.(*concurrencyTransport); !ok {
+		t.Errorf("expected a concurrency-limited transport, got %T", client.getHTTPClient().Transport)
+	}
+	if stats := client.RetryStats(); stats.Requests != 0 {
+		t.Errorf("expected zero retry stats, got %+v", stats)
+	}
+}
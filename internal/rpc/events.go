@@ -0,0 +1,170 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+const (
+	// DefaultEventsPageLimit is the page size GetEvents requests.
+	DefaultEventsPageLimit = 100
+	// MaxEventFilters is the number of filters Soroban RPC accepts in a
+	// single getEvents call.
+	MaxEventFilters = 5
+)
+
+// EventFilter selects events in a getEvents call. Empty fields match
+// everything.
+type EventFilter struct {
+	// Type is "contract", "system" or "diagnostic".
+	Type string `json:"type,omitempty"`
+	// ContractIDs are strkey contract addresses (C...).
+	ContractIDs []string `json:"contractIds,omitempty"`
+	// Topics lists topic matchers; an event matches if any of them does.
+	// Each matcher holds one base64 ScVal per topic segment, "*" to match
+	// any single segment or a trailing "**" to match any remaining ones.
+	Topics [][]string `json:"topics,omitempty"`
+}
+
+// ContractEvent is an event emitted on-chain, as returned by getEvents.
+// Topics and value are base64 ScVal XDR.
+type ContractEvent struct {
+	Type                     string   `json:"type"`
+	Ledger                   uint32   `json:"ledger"`
+	LedgerClosedAt           string   `json:"ledgerClosedAt"`
+	ContractID               string   `json:"contractId"`
+	ID                       string   `json:"id"`
+	TxHash                   string   `json:"txHash"`
+	InSuccessfulContractCall bool     `json:"inSuccessfulContractCall"`
+	Topic                    []string `json:"topic"`
+	Value                    string   `json:"value"`
+}
+
+// EventsRequest is a single getEvents call. Cursor continues a previous
+// page and takes the place of StartLedger.
+type EventsRequest struct {
+	StartLedger uint32
+	Filters     []EventFilter
+	Cursor      string
+	Limit       int
+}
+
+// EventsPage is one page of getEvents results. Cursor is passed back in
+// EventsRequest.Cursor to fetch the next page.
+type EventsPage struct {
+	Events       []ContractEvent `json:"events"`
+	Cursor       string          `json:"cursor"`
+	LatestLedger uint32          `json:"latestLedger"`
+}
+
+type getEventsPagination struct {
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+type getEventsParams struct {
+	StartLedger uint32              `json:"startLedger,omitempty"`
+	Filters     []EventFilter       `json:"filters"`
+	Pagination  getEventsPagination `json:"pagination"`
+}
+
+type getEventsResponse struct {
+	Jsonrpc string     `json:"jsonrpc"`
+	ID      int        `json:"id"`
+	Result  EventsPage `json:"result"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// GetEvents returns every event matching filters from startLedger up to the
+// latest ledger, following the pagination cursor until the RPC runs out of
+// results.
+func (c *Client) GetEvents(ctx context.Context, startLedger uint32, filters []EventFilter) ([]ContractEvent, error) {
+	req := EventsRequest{StartLedger: startLedger, Filters: filters, Limit: DefaultEventsPageLimit}
+
+	var events []ContractEvent
+	for {
+		page, err := c.GetEventsPage(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page.Events...)
+		if len(page.Events) < req.Limit || page.Cursor == "" || page.Cursor == req.Cursor {
+			return events, nil
+		}
+		req.Cursor = page.Cursor
+	}
+}
+
+// GetEventsPage issues a single getEvents call.
+func (c *Client) GetEventsPage(ctx context.Context, req EventsRequest) (*EventsPage, error) {
+	if len(req.Filters) > MaxEventFilters {
+		return nil, fmt.Errorf("too many event filters: %d (max %d)", len(req.Filters), MaxEventFilters)
+	}
+	if req.Cursor == "" && req.StartLedger == 0 {
+		return nil, fmt.Errorf("getEvents needs a start ledger or a cursor")
+	}
+
+	params := getEventsParams{
+		Filters:    req.Filters,
+		Pagination: getEventsPagination{Cursor: req.Cursor, Limit: req.Limit},
+	}
+	if params.Filters == nil {
+		params.Filters = []EventFilter{}
+	}
+	if req.Cursor == "" {
+		params.StartLedger = req.StartLedger
+	}
+
+	logger.Logger.Debug("Fetching events", "url", c.SorobanURL, "start_ledger", params.StartLedger, "cursor", req.Cursor)
+
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getEvents",
+		"params":  params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.SorobanURL, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.getHTTPClient().Do(httpReq)
+	if err != nil {
+		return nil, errors.WrapRPCConnectionFailed(fmt.Errorf("failed to execute request to %s: %w", c.SorobanURL, err))
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WrapRPCConnectionFailed(fmt.Errorf("failed to read response: %w", err))
+	}
+
+	var rpcResp getEventsResponse
+	if err := json.Unmarshal(respBytes, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("rpc error: %s (code %d)", rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+
+	return &rpcResp.Result, nil
+}
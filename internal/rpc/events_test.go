@@ -0,0 +1,151 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type getEventsCall struct {
+	Method string          `json:"method"`
+	Params getEventsParams `json:"params"`
+}
+
+// eventsServer serves total events, one per ledger starting at 1000, and
+// uses the index of the last event of a page as its cursor.
+func eventsServer(t *testing.T, total int, calls *[]getEventsCall) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var call getEventsCall
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&call))
+		*calls = append(*calls, call)
+
+		start := 0
+		if call.Params.Pagination.Cursor != "" {
+			n, err := strconv.Atoi(call.Params.Pagination.Cursor)
+			require.NoError(t, err)
+			start = n + 1
+		}
+		end := start + call.Params.Pagination.Limit
+		if end > total {
+			end = total
+		}
+
+		page := EventsPage{Events: []ContractEvent{}, LatestLedger: uint32(1000 + total)}
+		for i := start; i < end; i++ {
+			page.Events = append(page.Events, ContractEvent{
+				Type:       "contract",
+				Ledger:     uint32(1000 + i),
+				ContractID: "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE",
+				ID:         fmt.Sprintf("%019d-0000000001", i),
+				Topic:      []string{"AAAADwAAAAh0cmFuc2Zlcg=="},
+				Value:      "AAAAAQ==",
+			})
+		}
+		if end > start {
+			page.Cursor = strconv.Itoa(end - 1)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": page})
+	}))
+}
+
+func TestGetEvents_FollowsCursor(t *testing.T) {
+	var calls []getEventsCall
+	server := eventsServer(t, 250, &calls)
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	filters := []EventFilter{{
+		Type:        "contract",
+		ContractIDs: []string{"CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE"},
+		Topics:      [][]string{{"AAAADwAAAAh0cmFuc2Zlcg==", "*", "**"}},
+	}}
+	events, err := client.GetEvents(context.Background(), 1000, filters)
+	require.NoError(t, err)
+
+	require.Len(t, events, 250)
+	assert.Equal(t, uint32(1000), events[0].Ledger)
+	assert.Equal(t, uint32(1249), events[249].Ledger)
+
+	require.Len(t, calls, 3)
+	assert.Equal(t, "getEvents", calls[0].Method)
+	assert.Equal(t, uint32(1000), calls[0].Params.StartLedger)
+	assert.Empty(t, calls[0].Params.Pagination.Cursor)
+	assert.Equal(t, filters, calls[0].Params.Filters)
+
+	assert.Zero(t, calls[1].Params.StartLedger, "startLedger must be omitted when paging")
+	assert.Equal(t, "99", calls[1].Params.Pagination.Cursor)
+	assert.Equal(t, "199", calls[2].Params.Pagination.Cursor)
+}
+
+func TestGetEvents_Empty(t *testing.T) {
+	var calls []getEventsCall
+	server := eventsServer(t, 0, &calls)
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	events, err := client.GetEvents(context.Background(), 1000, nil)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Len(t, calls, 1)
+}
+
+func TestGetEventsPage(t *testing.T) {
+	var calls []getEventsCall
+	server := eventsServer(t, 30, &calls)
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	page, err := client.GetEventsPage(context.Background(), EventsRequest{StartLedger: 1000, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, page.Events, 10)
+	assert.Equal(t, "9", page.Cursor)
+	assert.Equal(t, uint32(1030), page.LatestLedger)
+
+	page, err = client.GetEventsPage(context.Background(), EventsRequest{Cursor: page.Cursor, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1010), page.Events[0].Ledger)
+}
+
+func TestGetEventsPage_Validation(t *testing.T) {
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL("http://127.0.0.1:1"))
+	require.NoError(t, err)
+
+	_, err = client.GetEventsPage(context.Background(), EventsRequest{})
+	assert.ErrorContains(t, err, "start ledger or a cursor")
+
+	_, err = client.GetEventsPage(context.Background(), EventsRequest{StartLedger: 1, Filters: make([]EventFilter, MaxEventFilters+1)})
+	assert.ErrorContains(t, err, "too many event filters")
+}
+
+func TestGetEventsPage_RPCError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"startLedger must be positive"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.GetEvents(context.Background(), 1, nil)
+	assert.ErrorContains(t, err, "startLedger must be positive")
+}
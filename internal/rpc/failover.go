@@ -0,0 +1,107 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+)
+
+// FailoverPolicy decides which errors move a request on to the next
+// endpoint configured with WithAltURLs.
+type FailoverPolicy int
+
+const (
+	// FailoverOnAnyError tries the next endpoint whatever the error,
+	// including "not found" answers from a node that may be lagging.
+	FailoverOnAnyError FailoverPolicy = iota
+	// FailoverOnServerError only leaves an endpoint that is unhealthy: it
+	// answered with a 5xx status or could not be reached at all.
+	FailoverOnServerError
+	// FailoverDisabled always stays on the first endpoint.
+	FailoverDisabled
+)
+
+// String implements fmt.Stringer.
+func (p FailoverPolicy) String() string {
+	switch p {
+	case FailoverOnAnyError:
+		return "any-error"
+	case FailoverOnServerError:
+		return "server-error"
+	case FailoverDisabled:
+		return "disabled"
+	default:
+		return fmt.Sprintf("FailoverPolicy(%d)", int(p))
+	}
+}
+
+// allows reports whether err should move the request to the next endpoint.
+func (p FailoverPolicy) allows(err error) bool {
+	switch p {
+	case FailoverOnAnyError:
+		return true
+	case FailoverOnServerError:
+		return isServerError(err)
+	default:
+		return false
+	}
+}
+
+// HTTPStatusError is returned when an endpoint answers with an error status,
+// either directly or after the retry transport gave up on it.
+type HTTPStatusError struct {
+	StatusCode int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("status code %d", e.StatusCode)
+}
+
+// isServerError reports whether err means the endpoint itself failed: a
+// 5xx status, or a connection that could not be made or completed.
+func isServerError(err error) bool {
+	var statusErr *HTTPStatusError
+	if stderrors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	var horizonErr *horizonclient.Error
+	if stderrors.As(err, &horizonErr) {
+		return horizonErr.Problem.Status >= http.StatusInternalServerError
+	}
+	// Failures of the round trip itself: refused connections, resets, TLS.
+	var urlErr *url.Error
+	return stderrors.Is(err, errors.ErrRPCConnectionFailed) || stderrors.As(err, &urlErr)
+}
+
+// withFailover runs attempt against the current endpoint and then, as long
+// as the client's policy allows it, against each remaining endpoint in turn.
+// Every attempt shares ctx, so failing over never extends the caller's
+// deadline; once ctx is done no further endpoint is tried.
+func (c *Client) withFailover(ctx context.Context, attempt func() error) error {
+	var lastErr error
+	for i := 0; i < len(c.AltURLs) || i == 0; i++ {
+		lastErr = attempt()
+		if lastErr == nil {
+			return nil
+		}
+		if i >= len(c.AltURLs)-1 || ctx.Err() != nil || !c.failoverPolicy.allows(lastErr) {
+			break
+		}
+		failed := c.HorizonURL
+		if !c.rotateURL() {
+			break
+		}
+		logger.Logger.Warn("Endpoint failed, failing over to the next RPC endpoint",
+			"url", failed, "new_url", c.HorizonURL, "policy", c.failoverPolicy.String(), "error", lastErr)
+	}
+	return lastErr
+}
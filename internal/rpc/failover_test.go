@@ -4,10 +4,20 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/dotandev/hintents/internal/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestClient_Rotation(t *testing.T) {
@@ -45,3 +55,147 @@ func TestClient_GetTransaction_Failover_Logic(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "all RPC endpoints failed")
 }
+
+// horizonTxServer answers /transactions/<hash> with status, counting hits.
+func horizonTxServer(t *testing.T, status int, hits *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			_, _ = fmt.Fprintf(w, `{"type":"https://stellar.org/horizon-errors/x","title":"%s","status":%d}`, http.StatusText(status), status)
+			return
+		}
+		_, _ = w.Write([]byte(`{"hash":"abc","envelope_xdr":"AAAA","result_xdr":"BBBB","result_meta_xdr":"CCCC"}`))
+	}))
+}
+
+func TestClient_FailoverPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       FailoverPolicy
+		primary      int
+		wantFailover bool
+	}{
+		{"any error fails over on 404", FailoverOnAnyError, http.StatusNotFound, true},
+		{"server error fails over on 500", FailoverOnServerError, http.StatusInternalServerError, true},
+		{"server error stays on 404", FailoverOnServerError, http.StatusNotFound, false},
+		{"server error stays on 400", FailoverOnServerError, http.StatusBadRequest, false},
+		{"disabled stays on 500", FailoverDisabled, http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var primaryHits, backupHits int32
+			primary := horizonTxServer(t, tt.primary, &primaryHits)
+			defer primary.Close()
+			backup := horizonTxServer(t, http.StatusOK, &backupHits)
+			defer backup.Close()
+
+			client, err := NewClient(
+				WithNetwork(Testnet),
+				WithAltURLs([]string{primary.URL + "/", backup.URL + "/"}),
+				WithFailoverPolicy(tt.policy),
+			)
+			require.NoError(t, err)
+
+			resp, err := client.GetTransaction(context.Background(), "abc")
+			assert.Equal(t, int32(1), atomic.LoadInt32(&primaryHits))
+			if tt.wantFailover {
+				require.NoError(t, err)
+				assert.Equal(t, "AAAA", resp.EnvelopeXdr)
+				assert.Equal(t, int32(1), atomic.LoadInt32(&backupHits))
+				assert.Equal(t, backup.URL+"/", client.HorizonURL)
+			} else {
+				assert.Error(t, err)
+				assert.Zero(t, atomic.LoadInt32(&backupHits))
+			}
+		})
+	}
+}
+
+func TestClient_FailoverOnConnectionError(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL + "/"
+	down.Close()
+
+	var backupHits int32
+	backup := horizonTxServer(t, http.StatusOK, &backupHits)
+	defer backup.Close()
+
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithAltURLs([]string{downURL, backup.URL + "/"}),
+		WithFailoverPolicy(FailoverOnServerError),
+		WithHTTPClient(&http.Client{}),
+	)
+	require.NoError(t, err)
+
+	logs := &bytes.Buffer{}
+	logger.SetOutput(logs, false)
+	t.Cleanup(func() { logger.SetOutput(os.Stderr, false) })
+
+	_, err = client.GetTransaction(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&backupHits))
+	assert.Equal(t, 1, strings.Count(logs.String(), "level=WARN"), "one warning per failover")
+	assert.Contains(t, logs.String(), "new_url="+backup.URL)
+}
+
+func TestClient_FailoverKeepsDeadline(t *testing.T) {
+	release := make(chan struct{})
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer primary.Close()
+	defer close(release)
+	backup, sizes := ledgerEntriesServer(t)
+	defer backup.Close()
+
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithAltURLs([]string{primary.URL, backup.URL}),
+		WithCacheEnabled(false),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = client.GetLedgerEntries(ctx, []string{"k"})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Empty(t, sizes(), "an expired deadline must not be extended by failing over")
+}
+
+func TestClient_LedgerEntriesFailover(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer primary.Close()
+	backup, sizes := ledgerEntriesServer(t)
+	defer backup.Close()
+
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithAltURLs([]string{primary.URL, backup.URL}),
+		WithFailoverPolicy(FailoverOnServerError),
+		WithCacheEnabled(false),
+	)
+	require.NoError(t, err)
+
+	entries, err := client.GetLedgerEntries(context.Background(), []string{"k"})
+	require.NoError(t, err)
+	assert.Equal(t, "xdr-k", entries["k"])
+	assert.Equal(t, []int{1}, sizes())
+}
+
+func TestWithFailoverPolicy_Invalid(t *testing.T) {
+	_, err := NewClient(WithFailoverPolicy(FailoverPolicy(42)))
+	assert.ErrorContains(t, err, "invalid failover policy")
+}
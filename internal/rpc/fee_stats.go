@@ -0,0 +1,99 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+// FeeDistribution is the distribution of inclusion fees (in stroops) over
+// the ledger window reported by getFeeStats. Soroban RPC encodes the
+// 64-bit values as strings.
+type FeeDistribution struct {
+	Max              uint64 `json:"max,string"`
+	Min              uint64 `json:"min,string"`
+	Mode             uint64 `json:"mode,string"`
+	P10              uint64 `json:"p10,string"`
+	P20              uint64 `json:"p20,string"`
+	P30              uint64 `json:"p30,string"`
+	P40              uint64 `json:"p40,string"`
+	P50              uint64 `json:"p50,string"`
+	P60              uint64 `json:"p60,string"`
+	P70              uint64 `json:"p70,string"`
+	P80              uint64 `json:"p80,string"`
+	P90              uint64 `json:"p90,string"`
+	P95              uint64 `json:"p95,string"`
+	P99              uint64 `json:"p99,string"`
+	TransactionCount uint64 `json:"transactionCount,string"`
+	LedgerCount      uint32 `json:"ledgerCount"`
+}
+
+// FeeStats is the result of the getFeeStats RPC method.
+type FeeStats struct {
+	// SorobanInclusionFee covers Soroban transactions; their resource fee
+	// is charged on top of it.
+	SorobanInclusionFee FeeDistribution `json:"sorobanInclusionFee"`
+	// InclusionFee covers classic transactions.
+	InclusionFee FeeDistribution `json:"inclusionFee"`
+	LatestLedger uint32          `json:"latestLedger"`
+}
+
+type getFeeStatsResponse struct {
+	Jsonrpc string   `json:"jsonrpc"`
+	ID      int      `json:"id"`
+	Result  FeeStats `json:"result"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// GetFeeStats returns recent inclusion fee statistics from Soroban RPC.
+func (c *Client) GetFeeStats(ctx context.Context) (*FeeStats, error) {
+	logger.Logger.Debug("Fetching fee stats", "url", c.SorobanURL)
+
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getFeeStats",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.SorobanURL, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.getHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var rpcResp getFeeStatsResponse
+	if err := json.Unmarshal(respBytes, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("rpc error: %s (code %d)", rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+
+	return &rpcResp.Result, nil
+}
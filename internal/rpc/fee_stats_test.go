@@ -0,0 +1,76 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const feeStatsResponse = `{
+  "jsonrpc": "2.0",
+  "id": 1,
+  "result": {
+    "sorobanInclusionFee": {
+      "max": "210", "min": "100", "mode": "100",
+      "p10": "100", "p20": "100", "p30": "100", "p40": "100", "p50": "100",
+      "p60": "100", "p70": "100", "p80": "100", "p90": "120", "p95": "150", "p99": "210",
+      "transactionCount": "7", "ledgerCount": 50
+    },
+    "inclusionFee": {
+      "max": "5000", "min": "100", "mode": "100",
+      "p10": "100", "p20": "100", "p30": "100", "p40": "100", "p50": "100",
+      "p60": "100", "p70": "100", "p80": "200", "p90": "400", "p95": "1000", "p99": "5000",
+      "transactionCount": "523", "ledgerCount": 10
+    },
+    "latestLedger": 4519945
+  }
+}`
+
+func TestGetFeeStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getFeeStats", req["method"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(feeStatsResponse))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	stats, err := client.GetFeeStats(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, uint32(4519945), stats.LatestLedger)
+	assert.Equal(t, uint64(100), stats.SorobanInclusionFee.P50)
+	assert.Equal(t, uint64(210), stats.SorobanInclusionFee.P99)
+	assert.Equal(t, uint64(7), stats.SorobanInclusionFee.TransactionCount)
+	assert.Equal(t, uint32(50), stats.SorobanInclusionFee.LedgerCount)
+	assert.Equal(t, uint64(400), stats.InclusionFee.P90)
+	assert.Equal(t, uint64(5000), stats.InclusionFee.Max)
+}
+
+func TestGetFeeStats_RPCError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.GetFeeStats(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "method not found")
+}
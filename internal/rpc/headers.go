@@ -0,0 +1,76 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/redact"
+)
+
+// WithHeaders adds headers, such as an API key, to every request the client
+// sends. Repeated calls merge, with later values winning. A header set here
+// replaces the Authorization header of WithToken; the User-Agent is set
+// with WithUserAgent instead. The option has no effect together with
+// WithHTTPClient.
+func WithHeaders(headers map[string]string) ClientOption {
+	return func(b *clientBuilder) error {
+		for name, value := range headers {
+			if !validHeaderName(name) {
+				return fmt.Errorf("invalid header name: %q", name)
+			}
+			if strings.ContainsAny(value, "\r\n") {
+				return fmt.Errorf("invalid value for header %s: must not contain line breaks", name)
+			}
+			if b.headers == nil {
+				b.headers = make(map[string]string)
+			}
+			b.headers[http.CanonicalHeaderKey(name)] = value
+		}
+		return nil
+	}
+}
+
+// validHeaderName reports whether name is a non-empty RFC 7230 token.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r <= ' ' || r >= 0x7f || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// maskedHeaders renders headers for logging with every value redacted.
+func maskedHeaders(headers map[string]string) []string {
+	out := make([]string, 0, len(headers))
+	for name := range headers {
+		out = append(out, name+": "+redact.Redacted)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// headerTransport sets the configured headers on every request. Like
+// userAgentTransport it sits beneath the retry layer and works on a clone
+// of the request, so each retried attempt carries them.
+type headerTransport struct {
+	headers   map[string]string
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper interface
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+	return t.transport.RoundTrip(req)
+}
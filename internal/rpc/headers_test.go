@@ -0,0 +1,149 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// headerServer fails the first request with 503 so the retry transport
+// sends a second one, and records the headers of every request.
+func headerServer(t *testing.T) (*httptest.Server, func() []http.Header) {
+	t.Helper()
+	var mu sync.Mutex
+	var seen []http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Clone())
+		attempt := len(seen)
+		mu.Unlock()
+
+		if attempt == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"hash":            "abc",
+			"envelope_xdr":    "AAAA",
+			"result_xdr":      "BBBB",
+			"result_meta_xdr": "CCCC",
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []http.Header {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]http.Header(nil), seen...)
+	}
+}
+
+func TestWithHeaders_SentOnInitialRequestAndRetry(t *testing.T) {
+	server, seen := headerServer(t)
+
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithHorizonURL(server.URL+"/"),
+		WithHeaders(map[string]string{"x-api-key": "secret-key"}),
+		WithHeaders(map[string]string{"X-Tenant": "team-a"}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetTransaction(context.Background(), "abc")
+	require.NoError(t, err)
+
+	got := seen()
+	require.Len(t, got, 2)
+	for i, h := range got {
+		assert.Equal(t, "secret-key", h.Get("X-Api-Key"), "attempt %d", i+1)
+		assert.Equal(t, "team-a", h.Get("X-Tenant"), "attempt %d", i+1)
+	}
+}
+
+func TestWithHeaders_OverridesToken(t *testing.T) {
+	server, seen := headerServer(t)
+
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithHorizonURL(server.URL+"/"),
+		WithToken("token"),
+		WithHeaders(map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetTransaction(context.Background(), "abc")
+	require.NoError(t, err)
+
+	for _, h := range seen() {
+		assert.Equal(t, "Basic dXNlcjpwYXNz", h.Get("Authorization"))
+	}
+}
+
+func TestWithHeaders_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{"empty name", map[string]string{"": "v"}},
+		{"space in name", map[string]string{"X Api": "v"}},
+		{"colon in name", map[string]string{"X-Api:": "v"}},
+		{"line break in value", map[string]string{"X-Api-Key": "v\r\nX-Injected: 1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(WithNetwork(Testnet), WithHeaders(tt.headers))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWithHeaders_ValuesMaskedInLogs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf, false)
+	prev := logger.Level()
+	logger.SetLevel(slog.LevelDebug)
+	t.Cleanup(func() {
+		logger.SetLevel(prev)
+		logger.SetOutput(os.Stderr, false)
+	})
+
+	_, err := NewClient(
+		WithNetwork(Testnet),
+		WithHeaders(map[string]string{"X-Api-Key": "secret-key"}),
+	)
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "X-Api-Key: [REDACTED]")
+	assert.NotContains(t, out, "secret-key")
+}
+
+func TestClientProvider_DistinguishesHeaders(t *testing.T) {
+	p := NewClientProvider()
+
+	a, err := p.Client(WithNetwork(Testnet), WithHeaders(map[string]string{"X-Api-Key": "a"}))
+	require.NoError(t, err)
+	b, err := p.Client(WithNetwork(Testnet), WithHeaders(map[string]string{"X-Api-Key": "b"}))
+	require.NoError(t, err)
+	again, err := p.Client(WithNetwork(Testnet), WithHeaders(map[string]string{"x-api-key": "a"}))
+	require.NoError(t, err)
+
+	assert.NotSame(t, a, b)
+	assert.Same(t, a, again)
+}
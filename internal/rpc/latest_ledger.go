@@ -0,0 +1,98 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+type latestLedgerResult struct {
+	ID              string `json:"id"`
+	ProtocolVersion uint32 `json:"protocolVersion"`
+	Sequence        uint32 `json:"sequence"`
+}
+
+type getLatestLedgerResponse struct {
+	Jsonrpc string             `json:"jsonrpc"`
+	ID      int                `json:"id"`
+	Result  latestLedgerResult `json:"result"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// GetLatestLedger returns the sequence number of the latest ledger known to
+// the Soroban RPC node.
+func (c *Client) GetLatestLedger(ctx context.Context) (uint32, error) {
+	result, err := c.latestLedger(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if result.Sequence == 0 {
+		return 0, fmt.Errorf("rpc returned no latest ledger")
+	}
+	return result.Sequence, nil
+}
+
+// GetProtocolVersion returns the protocol version of the latest ledger known
+// to the Soroban RPC node, that is the network's current protocol.
+func (c *Client) GetProtocolVersion(ctx context.Context) (uint32, error) {
+	result, err := c.latestLedger(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if result.ProtocolVersion == 0 {
+		return 0, fmt.Errorf("rpc returned no protocol version")
+	}
+	return result.ProtocolVersion, nil
+}
+
+func (c *Client) latestLedger(ctx context.Context) (*latestLedgerResult, error) {
+	logger.Logger.Debug("Fetching latest ledger", "url", c.SorobanURL)
+
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getLatestLedger",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.SorobanURL, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.getHTTPClient().Do(req)
+	if err != nil {
+		return nil, errors.WrapRPCConnectionFailed(fmt.Errorf("failed to execute request to %s: %w", c.SorobanURL, err))
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WrapRPCConnectionFailed(fmt.Errorf("failed to read response: %w", err))
+	}
+
+	var rpcResp getLatestLedgerResponse
+	if err := json.Unmarshal(respBytes, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("rpc error: %s (code %d)", rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+	return &rpcResp.Result, nil
+}
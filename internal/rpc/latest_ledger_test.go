@@ -0,0 +1,73 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const latestLedgerResponse = `{
+  "jsonrpc": "2.0",
+  "id": 1,
+  "result": {
+    "id": "c73c5eac58a441d4eb733c35253ae85f783e018f7be5ef974258fed067aabb36",
+    "protocolVersion": 22,
+    "sequence": 2539605
+  }
+}`
+
+func TestGetLatestLedger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getLatestLedger", req["method"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(latestLedgerResponse))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	seq, err := client.GetLatestLedger(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2539605), seq)
+}
+
+func TestGetLatestLedger_RPCError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.GetLatestLedger(context.Background())
+	assert.ErrorContains(t, err, "method not found")
+}
+
+func TestGetProtocolVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(latestLedgerResponse))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	version, err := client.GetProtocolVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(22), version)
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ledgerEntriesServer answers getLedgerEntries with an entry for every
+// requested key and records the size of each request.
+func ledgerEntriesServer(t *testing.T) (*httptest.Server, func() []int) {
+	t.Helper()
+	var mu sync.Mutex
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GetLedgerEntriesRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getLedgerEntries", req.Method)
+		keys := req.Params[0].([]interface{})
+
+		mu.Lock()
+		sizes = append(sizes, len(keys))
+		latest := 100 + len(sizes)
+		mu.Unlock()
+
+		entries := make([]map[string]interface{}, 0, len(keys))
+		for _, k := range keys {
+			entries = append(entries, map[string]interface{}{"key": k, "xdr": "xdr-" + k.(string)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  map[string]interface{}{"entries": entries, "latestLedger": latest},
+		})
+	}))
+	return server, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), sizes...)
+	}
+}
+
+func testLedgerKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%03d", i)
+	}
+	return keys
+}
+
+func TestGetLedgerEntries_ChunksLargeRequests(t *testing.T) {
+	server, sizes := ledgerEntriesServer(t)
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithHorizonURL(server.URL), WithCacheEnabled(false))
+	require.NoError(t, err)
+
+	keys := testLedgerKeys(450)
+	entries, err := client.GetLedgerEntries(context.Background(), keys)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{200, 200, 50}, sizes())
+	assert.Len(t, entries, len(keys))
+	for _, k := range keys {
+		assert.Equal(t, "xdr-"+k, entries[k])
+	}
+}
+
+func TestGetLedgerEntryStates_ChunksLargeRequests(t *testing.T) {
+	server, sizes := ledgerEntriesServer(t)
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithHorizonURL(server.URL))
+	require.NoError(t, err)
+
+	states, err := client.GetLedgerEntryStates(context.Background(), testLedgerKeys(201))
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{200, 1}, sizes())
+	assert.Len(t, states.Entries, 201)
+	assert.Equal(t, uint32(102), states.LatestLedger, "latest ledger is the newest seen across chunks")
+}
+
+func TestGetLedgerEntries_ConnectionFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithHorizonURL(url),
+		WithCacheEnabled(false),
+		WithHTTPClient(&http.Client{}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetLedgerEntries(context.Background(), []string{"key"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrRPCConnectionFailed)
+}
+
+func TestLedgerKeyChunks(t *testing.T) {
+	assert.Empty(t, ledgerKeyChunks(nil))
+	assert.Len(t, ledgerKeyChunks(testLedgerKeys(200)), 1)
+
+	chunks := ledgerKeyChunks(testLedgerKeys(401))
+	require.Len(t, chunks, 3)
+	assert.Len(t, chunks[0], 200)
+	assert.Len(t, chunks[1], 200)
+	assert.Len(t, chunks[2], 1)
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/network"
+)
+
+// ParseNetworkID parses a network id given as 64 hex characters, with or
+// without a 0x prefix.
+func ParseNetworkID(s string) ([32]byte, error) {
+	var id [32]byte
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(s), "0x"))
+	if err != nil {
+		return id, fmt.Errorf("invalid network id %q: must be hex encoded", s)
+	}
+	if len(raw) != len(id) {
+		return id, fmt.Errorf("invalid network id %q: must be %d bytes, got %d", s, len(id), len(raw))
+	}
+	copy(id[:], raw)
+	return id, nil
+}
+
+// HasNetworkID reports whether the config carries an explicit NetworkID.
+func (c NetworkConfig) HasNetworkID() bool {
+	return c.NetworkID != [32]byte{}
+}
+
+// ID returns the id transactions on the network are signed and hashed
+// with: NetworkID when set, otherwise the SHA-256 of NetworkPassphrase.
+func (c NetworkConfig) ID() [32]byte {
+	if c.HasNetworkID() {
+		return c.NetworkID
+	}
+	return network.ID(c.NetworkPassphrase)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testnetIDHex is the SHA-256 of the testnet passphrase.
+const testnetIDHex = "cee0302d59844d32bdca915c8203dd44b33fbb7edc19051ea37abedf28ecd472"
+
+func TestParseNetworkID(t *testing.T) {
+	for _, in := range []string{testnetIDHex, "0x" + testnetIDHex, strings.ToUpper(testnetIDHex), " " + testnetIDHex + "\n"} {
+		id, err := ParseNetworkID(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, testnetIDHex, hex.EncodeToString(id[:]))
+	}
+}
+
+func TestParseNetworkID_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ""},
+		{"not hex", strings.Repeat("g", 64)},
+		{"odd length", testnetIDHex[:63]},
+		{"31 bytes", testnetIDHex[:62]},
+		{"33 bytes", testnetIDHex + "00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseNetworkID(tt.in)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNetworkConfigID(t *testing.T) {
+	id := TestnetConfig.ID()
+	assert.Equal(t, testnetIDHex, hex.EncodeToString(id[:]))
+
+	// An explicit id wins over the passphrase.
+	cfg := TestnetConfig
+	cfg.NetworkID = [32]byte{0x01}
+	assert.Equal(t, [32]byte{0x01}, cfg.ID())
+}
+
+func TestValidateNetworkConfig_NetworkIDWithoutPassphrase(t *testing.T) {
+	cfg := NetworkConfig{
+		Name:          "custom",
+		SorobanRPCURL: "https://rpc.example.com",
+		NetworkID:     [32]byte{0x01},
+	}
+	assert.NoError(t, ValidateNetworkConfig(cfg))
+}
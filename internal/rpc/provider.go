@@ -0,0 +1,68 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ClientProvider hands out one Client per configuration for the lifetime of
+// the process. Commands that resolve to the same network, URLs and
+// credentials share a Client, and with it the connection pool, the retry
+// and concurrency transports and any failover state, which matters when
+// several commands run in-process (serve, watch, batch debug).
+type ClientProvider struct {
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// NewClientProvider returns an empty provider.
+func NewClientProvider() *ClientProvider {
+	return &ClientProvider{clients: make(map[string]*Client)}
+}
+
+// Client returns the Client for opts, building it on first use. Options are
+// applied exactly as NewClient would apply them; two calls whose options
+// resolve to the same configuration get the same Client.
+func (p *ClientProvider) Client(opts ...ClientOption) (*Client, error) {
+	builder, err := newConfiguredBuilder(opts)
+	if err != nil {
+		return nil, err
+	}
+	key := builder.key()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if client, ok := p.clients[key]; ok {
+		return client, nil
+	}
+	client, err := builder.build()
+	if err != nil {
+		return nil, err
+	}
+	p.clients[key] = client
+	return client, nil
+}
+
+// key identifies the configuration a builder would produce. An explicit
+// HTTP client is compared by identity.
+func (b *clientBuilder) key() string {
+	var cfg NetworkConfig
+	if b.config != nil {
+		cfg = *b.config
+	}
+	proxy := ""
+	if b.proxyURL != nil {
+		proxy = b.proxyURL.String()
+	}
+	breaker := "none"
+	if b.breaker != nil {
+		breaker = fmt.Sprintf("%+v", *b.breaker)
+	}
+	return fmt.Sprintf("%s|%q|%q|%s|%s|%q|%t|%+v|%p|%s|%q|%d|%g|%d|%s",
+		b.network, b.token, b.headers, b.horizonURL, b.sorobanURL, b.altURLs, b.cacheEnabled,
+		cfg, b.httpClient, proxy, b.userAgent, b.maxInFlight, b.rateLimit, b.failover, breaker)
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientProvider_ReusesClientForSameConfig(t *testing.T) {
+	p := NewClientProvider()
+
+	a, err := p.Client(WithNetwork(Testnet), WithToken("secret"))
+	require.NoError(t, err)
+	b, err := p.Client(WithNetwork(Testnet), WithToken("secret"))
+	require.NoError(t, err)
+
+	assert.Same(t, a, b)
+	assert.Same(t, a.getHTTPClient().Transport, b.getHTTPClient().Transport)
+}
+
+func TestClientProvider_SeparatesDifferentConfigs(t *testing.T) {
+	p := NewClientProvider()
+
+	base, err := p.Client(WithNetwork(Testnet))
+	require.NoError(t, err)
+
+	for name, opts := range map[string][]ClientOption{
+		"network":  {WithNetwork(Mainnet)},
+		"token":    {WithNetwork(Testnet), WithToken("other")},
+		"url":      {WithNetwork(Testnet), WithHorizonURL("https://horizon.example.com")},
+		"cache":    {WithNetwork(Testnet), WithCacheEnabled(false)},
+		"inflight": {WithNetwork(Testnet), WithMaxConcurrentRequests(2)},
+		"http":     {WithNetwork(Testnet), WithHTTPClient(&http.Client{})},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, err := p.Client(opts...)
+			require.NoError(t, err)
+			assert.NotSame(t, base, c)
+		})
+	}
+}
+
+func TestClientProvider_InvalidOption(t *testing.T) {
+	_, err := NewClientProvider().Client(WithHorizonURL("not a url"))
+	assert.Error(t, err)
+}
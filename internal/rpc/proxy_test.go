@@ -0,0 +1,73 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithProxy_RequestsFlowThroughProxy(t *testing.T) {
+	var mu sync.Mutex
+	var proxied []string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		proxied = append(proxied, r.URL.String())
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"hash":            "abc",
+			"envelope_xdr":    "AAAA",
+			"result_xdr":      "BBBB",
+			"result_meta_xdr": "CCCC",
+		})
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithHorizonURL("http://horizon.invalid/"),
+		WithProxy(proxy.URL),
+	)
+	require.NoError(t, err)
+
+	resp, err := client.GetTransaction(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "AAAA", resp.EnvelopeXdr)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, proxied, 1)
+	assert.Equal(t, "http://horizon.invalid/transactions/abc", proxied[0])
+}
+
+func TestWithProxy_InvalidURL(t *testing.T) {
+	_, err := NewClient(WithProxy("ftp://proxy.local:21"))
+	assert.Error(t, err)
+
+	_, err = NewClient(WithProxy("http://"))
+	assert.Error(t, err)
+}
+
+func TestNewBaseTransport_DefaultsToEnvironment(t *testing.T) {
+	transport := newBaseTransport(nil)
+	require.NotNil(t, transport.Proxy)
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+	require.NoError(t, err)
+
+	// Requests to localhost are never proxied by ProxyFromEnvironment.
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Nil(t, proxyURL)
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket holding a single token that refills every
+// interval, so requests leave at most once per interval with no bursts.
+// Callers reserve the next free slot under the lock and then sleep until it
+// comes up; a caller whose context ends first hands its slot back if no one
+// queued behind it.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter returns a limiter allowing rps requests per second, or nil
+// when rps is not positive.
+func newRateLimiter(rps float64) *rateLimiter {
+	if rps <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / rps)}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := at.Sub(now)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		if l.next.Equal(at.Add(l.interval)) {
+			l.next = at
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// rateLimitTransport throttles requests to the limiter's rate. It is the
+// outermost layer of the client's transport, so a request takes its token
+// before waiting for a concurrency slot and before any retry.
+type rateLimitTransport struct {
+	limiter   *rateLimiter
+	transport http.RoundTripper
+}
+
+// newRateLimitTransport wraps transport with a limiter of rps requests per
+// second. A non-positive rps disables throttling and returns transport
+// unchanged.
+func newRateLimitTransport(rps float64, transport http.RoundTripper) http.RoundTripper {
+	limiter := newRateLimiter(rps)
+	if limiter == nil {
+		return transport
+	}
+	return &rateLimitTransport{limiter: limiter, transport: transport}
+}
+
+// RoundTrip implements http.RoundTripper interface
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// Stats forwards the retry counts of the wrapped transport so
+// Client.RetryStats keeps working when a rate limit is configured.
+func (t *rateLimitTransport) Stats() RetryStats {
+	if rt, ok := t.transport.(interface{ Stats() RetryStats }); ok {
+		return rt.Stats()
+	}
+	return RetryStats{}
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Throttles(t *testing.T) {
+	const rps = 50
+	limiter := newRateLimiter(rps)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The first token is free, the other five are 20ms apart.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected 6 requests at %d rps to take at least 100ms, took %v", rps, elapsed)
+	}
+}
+
+func TestRateLimiter_RespectsContext(t *testing.T) {
+	limiter := newRateLimiter(1)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected Wait to return when the context expired, took %v", elapsed)
+	}
+
+	// The abandoned slot is handed back rather than pushing later callers out.
+	limiter.mu.Lock()
+	next := limiter.next
+	limiter.mu.Unlock()
+	if wait := time.Until(next); wait > time.Second {
+		t.Errorf("expected the cancelled reservation to be released, next token in %v", wait)
+	}
+}
+
+func TestRateLimitTransport_ThrottlesRetries(t *testing.T) {
+	var attempts atomic.Int32
+	var mu sync.Mutex
+	var times []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := DefaultRetryConfig()
+	cfg.InitialBackoff = time.Millisecond
+	cfg.MaxBackoff = time.Millisecond
+	cfg.JitterFraction = 0
+	retrier := NewRetrier(cfg, &http.Client{Transport: newRateLimitTransport(20, http.DefaultTransport)})
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := retrier.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if len(times) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(times))
+	}
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap < 40*time.Millisecond {
+			t.Errorf("expected retries to wait for a token, attempt %d came %v after the previous one", i+1, gap)
+		}
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	if _, err := NewClient(WithRateLimit(-1)); err == nil {
+		t.Error("expected error for a negative rate")
+	}
+
+	client, err := NewClient(WithRateLimit(5))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.rateLimit != 5 {
+		t.Errorf("expected rate limit 5, got %v", client.rateLimit)
+	}
+	limited, ok := client.getHTTPClient().Transport.(*rateLimitTransport)
+	if !ok {
+		t.Fatalf("expected the rate limiter on top, got %T", client.getHTTPClient().Transport)
+	}
+	if _, ok := limited.transport.(*RetryTransport); !ok {
+		t.Errorf("expected the retry layer beneath the rate limiter, got %T", limited.transport)
+	}
+	if stats := client.RetryStats(); stats.Requests != 0 {
+		t.Errorf("expected zero retry stats, got %+v", stats)
+	}
+}
+
+func TestTransportOrder_RateLimitConcurrencyRetry(t *testing.T) {
+	client, err := NewClient(WithRateLimit(5), WithMaxConcurrentRequests(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	limited, ok := client.getHTTPClient().Transport.(*rateLimitTransport)
+	if !ok {
+		t.Fatalf("expected the rate limiter on top, got %T", client.getHTTPClient().Transport)
+	}
+	capped, ok := limited.transport.(*concurrencyTransport)
+	if !ok {
+		t.Fatalf("expected the concurrency cap beneath the rate limiter, got %T", limited.transport)
+	}
+	if _, ok := capped.transport.(*RetryTransport); !ok {
+		t.Errorf("expected the retry layer beneath the concurrency cap, got %T", capped.transport)
+	}
+}
+
+func BenchmarkRateLimitedClient(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	for _, rps := range []float64{0, 1000, 100} {
+		name := "Unlimited"
+		if rps > 0 {
+			name = time.Duration(float64(time.Second) / rps).String()
+		}
+		b.Run(name, func(b *testing.B) {
+			client := createHTTPClient("", nil, nil, "", 0, rps, nil)
+			b.ResetTimer()
+			start := time.Now()
+			for i := 0; i < b.N; i++ {
+				resp, err := client.Get(server.URL)
+				if err != nil {
+					b.Fatal(err)
+				}
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+			b.ReportMetric(float64(b.N)/time.Since(start).Seconds(), "req/s")
+		})
+	}
+}
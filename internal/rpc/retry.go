@@ -5,11 +5,16 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
+	"net"
 	"net/http"
 	"strconv"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/dotandev/hintents/internal/logger"
@@ -22,22 +27,59 @@ type RetryConfig struct {
 	MaxBackoff         time.Duration
 	JitterFraction     float64
 	StatusCodesToRetry []int
+	// PerAttemptTimeout bounds each individual attempt. The caller's
+	// context still bounds the whole sequence. Zero disables it.
+	PerAttemptTimeout time.Duration
+	// MaxRetryAfter caps how long a Retry-After header can make a retry
+	// wait, so a misbehaving server cannot stall the caller for hours.
+	// Zero leaves it uncapped.
+	MaxRetryAfter time.Duration
+	// RetryOnNetworkError retries requests that failed in transport with a
+	// transient error: a refused or reset connection, a timeout, an
+	// unexpected EOF or a temporary DNS failure. Other transport errors,
+	// such as a malformed URL or a TLS failure, fail fast either way.
+	RetryOnNetworkError bool
 }
 
 // DefaultRetryConfig returns a sensible default retry configuration
 func DefaultRetryConfig() RetryConfig {
 	return RetryConfig{
-		MaxRetries:         3,
-		InitialBackoff:     1 * time.Second,
-		MaxBackoff:         10 * time.Second,
-		JitterFraction:     0.1,
-		StatusCodesToRetry: []int{429, 503, 504},
+		MaxRetries:          3,
+		InitialBackoff:      1 * time.Second,
+		MaxBackoff:          10 * time.Second,
+		JitterFraction:      0.1,
+		StatusCodesToRetry:  []int{429, 503, 504},
+		MaxRetryAfter:       60 * time.Second,
+		RetryOnNetworkError: true,
+	}
+}
+
+// RetryStats counts the requests sent through a Retrier or RetryTransport.
+type RetryStats struct {
+	// Requests is the number of calls to Do or RoundTrip.
+	Requests int64
+	// Retries is the number of attempts made after the first one.
+	Retries int64
+}
+
+// retryPolicy holds the retry loop shared by Retrier and RetryTransport.
+type retryPolicy struct {
+	config   RetryConfig
+	requests atomic.Int64
+	retries  atomic.Int64
+}
+
+// Stats returns the request and retry counts so far.
+func (p *retryPolicy) Stats() RetryStats {
+	return RetryStats{
+		Requests: p.requests.Load(),
+		Retries:  p.retries.Load(),
 	}
 }
 
 // Retrier handles HTTP request retries with exponential backoff and jitter
 type Retrier struct {
-	config RetryConfig
+	retryPolicy
 	client *http.Client
 }
 
@@ -47,37 +89,71 @@ func NewRetrier(config RetryConfig, client *http.Client) *Retrier {
 		client = http.DefaultClient
 	}
 	return &Retrier{
-		config: config,
-		client: client,
+		retryPolicy: retryPolicy{config: config},
+		client:      client,
 	}
 }
 
 // Do executes an HTTP request with retry logic
 func (r *Retrier) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return r.do(ctx, req, func(attempt *http.Request) (*http.Response, error) {
+		return r.client.Do(attempt)
+	})
+}
+
+// WithRetry returns a copy of client whose transport retries with config.
+// It lets callers outside this package, such as the remote simulator
+// runner, share the RPC layer's retry behavior. A nil client starts from
+// http.DefaultClient.
+func WithRetry(client *http.Client, config RetryConfig) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	wrapped := *client
+	wrapped.Transport = NewRetryTransport(config, client.Transport)
+	return &wrapped
+}
+
+// do runs send until it returns a non-retryable result or the retries are
+// exhausted. Request bodies are rewound through GetBody before each retry.
+func (p *retryPolicy) do(ctx context.Context, req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
 	var lastErr error
-	backoff := r.config.InitialBackoff
+	backoff := p.config.InitialBackoff
+	p.requests.Add(1)
 
-	for attempt := 0; attempt <= r.config.MaxRetries; attempt++ {
+	for attempt := 0; attempt <= p.config.MaxRetries; attempt++ {
 		if attempt > 0 {
-			if err := r.waitWithContext(ctx, backoff); err != nil {
+			if err := p.waitWithContext(ctx, backoff); err != nil {
 				return nil, fmt.Errorf("retry cancelled: %w", err)
 			}
+			p.retries.Add(1)
 		}
 
-		resp, err := r.client.Do(req.Clone(ctx))
+		attemptCtx, cancel := attemptContext(ctx, p.config.PerAttemptTimeout)
+		attemptReq, err := rewind(req.Clone(attemptCtx), attempt)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		resp, err := send(attemptReq)
 		if err != nil {
+			retry := p.retryableError(ctx, attemptCtx, err)
+			cancel()
+			if !retry {
+				return nil, err
+			}
 			lastErr = err
-			if attempt < r.config.MaxRetries {
+			if attempt < p.config.MaxRetries {
 				logger.Logger.Debug("Request failed, will retry", "attempt", attempt+1, "error", err)
 			}
-			backoff = r.nextBackoff(backoff)
+			backoff = p.nextBackoff(backoff)
 			continue
 		}
 
 		// Check if response status is retryable
-		if r.shouldRetry(resp.StatusCode) {
-			lastErr = fmt.Errorf("status code %d", resp.StatusCode)
-			retryAfter := r.getRetryAfter(resp)
+		if p.shouldRetry(resp.StatusCode) {
+			lastErr = &HTTPStatusError{StatusCode: resp.StatusCode}
+			retryAfter := p.getRetryAfter(resp)
 
 			logger.Logger.Warn("Rate limited or temporary failure, will retry",
 				"attempt", attempt+1,
@@ -86,14 +162,15 @@ func (r *Retrier) Do(ctx context.Context, req *http.Request) (*http.Response, er
 			)
 
 			resp.Body.Close()
+			cancel()
 
 			if retryAfter > 0 {
 				backoff = retryAfter
 			} else {
-				backoff = r.nextBackoff(backoff)
+				backoff = p.nextBackoff(backoff)
 			}
 
-			if attempt < r.config.MaxRetries {
+			if attempt < p.config.MaxRetries {
 				continue
 			}
 			// If we've exhausted retries on a retryable error, return error
@@ -101,153 +178,93 @@ func (r *Retrier) Do(ctx context.Context, req *http.Request) (*http.Response, er
 		}
 
 		// Success or non-retryable error
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
 		return resp, nil
 	}
 
 	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
-// shouldRetry determines if the response status code warrants a retry
-func (r *Retrier) shouldRetry(statusCode int) bool {
-	for _, code := range r.config.StatusCodesToRetry {
-		if statusCode == code {
-			return true
-		}
+// retryableError reports whether an attempt that failed in transport should
+// be retried. An attempt cut short by PerAttemptTimeout always is; nothing
+// is once the caller's own context is done.
+func (p *retryPolicy) retryableError(ctx, attemptCtx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
 	}
-	return false
+	if attemptCtx.Err() != nil {
+		return true
+	}
+	return p.config.RetryOnNetworkError && isTransientNetworkError(err)
 }
 
-// getRetryAfter parses the Retry-After header and returns the duration
-// Supports both "seconds" and "HTTP-date" formats (RFC 7231)
-func (r *Retrier) getRetryAfter(resp *http.Response) time.Duration {
-	retryAfter := resp.Header.Get("Retry-After")
-	if retryAfter == "" {
-		return 0
+// isTransientNetworkError reports whether a transport error is likely to go
+// away on its own, so the request is worth sending again.
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) {
+		return true
 	}
 
-	// Try parsing as seconds (integer)
-	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds > 0 {
-		return time.Duration(seconds) * time.Second
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		// A name that does not exist will not start existing on retry.
+		return !dnsErr.IsNotFound
 	}
 
-	// Try parsing as HTTP-date
-	if t, err := time.Parse(time.RFC1123, retryAfter); err == nil {
-		dur := time.Until(t)
-		if dur > 0 {
-			return dur
-		}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
 	}
 
-	return 0
+	// Dial, read and write failures on the connection itself.
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
 }
 
-// nextBackoff calculates the next backoff duration with exponential backoff and jitter
-func (r *Retrier) nextBackoff(current time.Duration) time.Duration {
-	// Exponential backoff: double the current duration
-	next := time.Duration(float64(current) * 2)
-	if next > r.config.MaxBackoff {
-		next = r.config.MaxBackoff
+// rewind gives a retried request a fresh copy of its body. The first
+// attempt uses the body as is.
+func rewind(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
 	}
-
-	// Add jitter: ±JitterFraction of the duration
-	if r.config.JitterFraction > 0 {
-		jitterAmount := float64(next) * r.config.JitterFraction
-		jitterRange := math.Round(jitterAmount)
-		jitter := time.Duration(rand.Int63n(int64(jitterRange)*2) - int64(jitterRange))
-		next = next + jitter
-		if next < 0 {
-			next = 0
-		}
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("cannot retry request: body is not rewindable")
 	}
-
-	return next
-}
-
-// waitWithContext waits for the specified duration or until context is cancelled
-func (r *Retrier) waitWithContext(ctx context.Context, duration time.Duration) error {
-	select {
-	case <-time.After(duration):
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewind request body: %w", err)
 	}
+	req.Body = body
+	return req, nil
 }
 
-// RetryTransport is an http.RoundTripper that adds retry logic to requests
-type RetryTransport struct {
-	config    RetryConfig
-	transport http.RoundTripper
-}
-
-// NewRetryTransport creates a new RetryTransport with the given config
-func NewRetryTransport(config RetryConfig, transport http.RoundTripper) *RetryTransport {
-	if transport == nil {
-		transport = http.DefaultTransport
-	}
-	return &RetryTransport{
-		config:    config,
-		transport: transport,
+// attemptContext derives the context for a single attempt. With a positive
+// timeout the attempt gets its own deadline beneath ctx.
+func attemptContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
 	}
+	return context.WithTimeout(ctx, timeout)
 }
 
-// RoundTrip implements http.RoundTripper interface with retry logic
-func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	var lastErr error
-	backoff := rt.config.InitialBackoff
-
-	for attempt := 0; attempt <= rt.config.MaxRetries; attempt++ {
-		if attempt > 0 {
-			if err := rt.waitWithContext(req.Context(), backoff); err != nil {
-				return nil, fmt.Errorf("retry cancelled: %w", err)
-			}
-		}
-
-		resp, err := rt.transport.RoundTrip(req)
-		if err != nil {
-			lastErr = err
-			if attempt < rt.config.MaxRetries {
-				logger.Logger.Debug("RoundTrip failed, will retry", "attempt", attempt+1, "error", err)
-			}
-			backoff = rt.nextBackoff(backoff)
-			continue
-		}
-
-		// Check if response status is retryable
-		if rt.shouldRetry(resp.StatusCode) {
-			lastErr = fmt.Errorf("status code %d", resp.StatusCode)
-			retryAfter := rt.getRetryAfter(resp)
-
-			logger.Logger.Warn("Rate limited or temporary failure, will retry",
-				"attempt", attempt+1,
-				"status_code", resp.StatusCode,
-				"retry_after", retryAfter,
-			)
-
-			resp.Body.Close()
-
-			if retryAfter > 0 {
-				backoff = retryAfter
-			} else {
-				backoff = rt.nextBackoff(backoff)
-			}
-
-			if attempt < rt.config.MaxRetries {
-				continue
-			}
-			// If we've exhausted retries on a retryable error, return error
-			return nil, lastErr
-		}
-
-		// Success or non-retryable error
-		return resp, nil
-	}
+// cancelOnClose releases an attempt context once the response body has been
+// consumed, so the per-attempt deadline does not cut off reading it early.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
 
-	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
 }
 
 // shouldRetry determines if the response status code warrants a retry
-func (rt *RetryTransport) shouldRetry(statusCode int) bool {
-	for _, code := range rt.config.StatusCodesToRetry {
+func (p *retryPolicy) shouldRetry(statusCode int) bool {
+	for _, code := range p.config.StatusCodesToRetry {
 		if statusCode == code {
 			return true
 		}
@@ -256,39 +273,48 @@ func (rt *RetryTransport) shouldRetry(statusCode int) bool {
 }
 
 // getRetryAfter parses the Retry-After header and returns the duration
-func (rt *RetryTransport) getRetryAfter(resp *http.Response) time.Duration {
+// Supports both "seconds" and "HTTP-date" formats (RFC 7231). The result is
+// clamped to MaxRetryAfter.
+func (p *retryPolicy) getRetryAfter(resp *http.Response) time.Duration {
 	retryAfter := resp.Header.Get("Retry-After")
 	if retryAfter == "" {
 		return 0
 	}
 
-	// Try parsing as seconds (integer)
+	var dur time.Duration
 	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds > 0 {
-		return time.Duration(seconds) * time.Second
+		// Try parsing as seconds (integer)
+		dur = time.Duration(seconds) * time.Second
+	} else if t, err := time.Parse(time.RFC1123, retryAfter); err == nil {
+		// Try parsing as HTTP-date
+		dur = time.Until(t)
 	}
-
-	// Try parsing as HTTP-date
-	if t, err := time.Parse(time.RFC1123, retryAfter); err == nil {
-		dur := time.Until(t)
-		if dur > 0 {
-			return dur
-		}
+	if dur <= 0 {
+		return 0
 	}
 
-	return 0
+	if limit := p.config.MaxRetryAfter; limit > 0 && dur > limit {
+		logger.Logger.Warn("Retry-After exceeds the maximum wait, clamping",
+			"retry_after", retryAfter,
+			"requested", dur,
+			"clamped", limit,
+		)
+		return limit
+	}
+	return dur
 }
 
 // nextBackoff calculates the next backoff duration with exponential backoff and jitter
-func (rt *RetryTransport) nextBackoff(current time.Duration) time.Duration {
+func (p *retryPolicy) nextBackoff(current time.Duration) time.Duration {
 	// Exponential backoff: double the current duration
 	next := time.Duration(float64(current) * 2)
-	if next > rt.config.MaxBackoff {
-		next = rt.config.MaxBackoff
+	if next > p.config.MaxBackoff {
+		next = p.config.MaxBackoff
 	}
 
 	// Add jitter: ±JitterFraction of the duration
-	if rt.config.JitterFraction > 0 {
-		jitterAmount := float64(next) * rt.config.JitterFraction
+	if p.config.JitterFraction > 0 {
+		jitterAmount := float64(next) * p.config.JitterFraction
 		jitterRange := math.Round(jitterAmount)
 		jitter := time.Duration(rand.Int63n(int64(jitterRange)*2) - int64(jitterRange))
 		next = next + jitter
@@ -301,7 +327,7 @@ func (rt *RetryTransport) nextBackoff(current time.Duration) time.Duration {
 }
 
 // waitWithContext waits for the specified duration or until context is cancelled
-func (rt *RetryTransport) waitWithContext(ctx context.Context, duration time.Duration) error {
+func (p *retryPolicy) waitWithContext(ctx context.Context, duration time.Duration) error {
 	select {
 	case <-time.After(duration):
 		return nil
@@ -309,3 +335,25 @@ func (rt *RetryTransport) waitWithContext(ctx context.Context, duration time.Dur
 		return ctx.Err()
 	}
 }
+
+// RetryTransport is an http.RoundTripper that adds retry logic to requests
+type RetryTransport struct {
+	retryPolicy
+	transport http.RoundTripper
+}
+
+// NewRetryTransport creates a new RetryTransport with the given config
+func NewRetryTransport(config RetryConfig, transport http.RoundTripper) *RetryTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &RetryTransport{
+		retryPolicy: retryPolicy{config: config},
+		transport:   transport,
+	}
+}
+
+// RoundTrip implements http.RoundTripper interface with retry logic
+func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return rt.do(req.Context(), req, rt.transport.RoundTrip)
+}
@@ -6,9 +6,14 @@ package rpc
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -25,6 +30,9 @@ func TestDefaultRetryConfig(t *testing.T) {
 	if cfg.MaxBackoff != 10*time.Second {
 		t.Errorf("expected MaxBackoff=10s, got %v", cfg.MaxBackoff)
 	}
+	if cfg.MaxRetryAfter != 60*time.Second {
+		t.Errorf("expected MaxRetryAfter=60s, got %v", cfg.MaxRetryAfter)
+	}
 	if len(cfg.StatusCodesToRetry) == 0 {
 		t.Errorf("expected StatusCodesToRetry to have values")
 	}
@@ -473,3 +481,350 @@ func BenchmarkRetryTransport(b *testing.B) {
 		}
 	}
 }
+
+// hangFirstServer blocks the first request until its context is cancelled,
+// then answers every later request immediately.
+func hangFirstServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("success"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func perAttemptConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries:         2,
+		InitialBackoff:     10 * time.Millisecond,
+		MaxBackoff:         50 * time.Millisecond,
+		StatusCodesToRetry: []int{503},
+		PerAttemptTimeout:  100 * time.Millisecond,
+	}
+}
+
+func TestRetryerPerAttemptTimeout(t *testing.T) {
+	server, calls := hangFirstServer(t)
+	retrier := NewRetrier(perAttemptConfig(), server.Client())
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := retrier.Do(ctx, req)
+	if err != nil {
+		t.Fatalf("expected success after per-attempt timeout, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "success" {
+		t.Errorf("expected 'success', got '%s'", string(body))
+	}
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("first attempt was not cut short: took %v", elapsed)
+	}
+}
+
+func TestRetryTransportPerAttemptTimeout(t *testing.T) {
+	server, calls := hangFirstServer(t)
+	client := &http.Client{Transport: NewRetryTransport(perAttemptConfig(), http.DefaultTransport)}
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("expected success after per-attempt timeout, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "success" {
+		t.Errorf("expected 'success', got '%s'", string(body))
+	}
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestRetryerPerAttemptTimeoutBoundedByOverallContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	cfg := perAttemptConfig()
+	cfg.MaxRetries = 10
+	retrier := NewRetrier(cfg, server.Client())
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := retrier.Do(ctx, req); err == nil {
+		t.Fatal("expected error when every attempt hangs")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("overall context did not bound retries: took %v", elapsed)
+	}
+}
+
+func TestRetryTransportStats(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := DefaultRetryConfig()
+	cfg.InitialBackoff = 5 * time.Millisecond
+	cfg.MaxBackoff = 10 * time.Millisecond
+	cfg.JitterFraction = 0
+	client := WithRetry(server.Client(), cfg)
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(server.URL, "application/json", bytes.NewBufferString(`{}`))
+		if err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+		resp.Body.Close()
+	}
+
+	stats := client.Transport.(*RetryTransport).Stats()
+	if stats.Requests != 2 {
+		t.Errorf("expected 2 requests, got %d", stats.Requests)
+	}
+	if stats.Retries != 2 {
+		t.Errorf("expected 2 retries, got %d", stats.Retries)
+	}
+}
+
+func TestParseRetryAfterSecondsClamped(t *testing.T) {
+	cfg := DefaultRetryConfig()
+	cfg.MaxRetryAfter = 10 * time.Second
+	retrier := NewRetrier(cfg, nil)
+
+	resp := &http.Response{
+		Header: http.Header{
+			"Retry-After": []string{"3600"},
+		},
+	}
+
+	duration := retrier.getRetryAfter(resp)
+	if duration != 10*time.Second {
+		t.Errorf("expected Retry-After to be clamped to 10s, got %v", duration)
+	}
+}
+
+func TestParseRetryAfterHTTPDateClamped(t *testing.T) {
+	cfg := DefaultRetryConfig()
+	cfg.MaxRetryAfter = 10 * time.Second
+	retrier := NewRetrier(cfg, nil)
+
+	dateStr := time.Now().Add(time.Hour).UTC().Format(time.RFC1123)
+	resp := &http.Response{
+		Header: http.Header{
+			"Retry-After": []string{dateStr},
+		},
+	}
+
+	duration := retrier.getRetryAfter(resp)
+	if duration != 10*time.Second {
+		t.Errorf("expected Retry-After to be clamped to 10s, got %v", duration)
+	}
+}
+
+func TestParseRetryAfterUncapped(t *testing.T) {
+	cfg := DefaultRetryConfig()
+	cfg.MaxRetryAfter = 0
+	retrier := NewRetrier(cfg, nil)
+
+	resp := &http.Response{
+		Header: http.Header{
+			"Retry-After": []string{"3600"},
+		},
+	}
+
+	if duration := retrier.getRetryAfter(resp); duration != time.Hour {
+		t.Errorf("expected 1h with no cap, got %v", duration)
+	}
+}
+
+func TestRetryAfterClampedOnTheWire(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := DefaultRetryConfig()
+	cfg.MaxRetryAfter = 50 * time.Millisecond
+	retrier := NewRetrier(cfg, server.Client())
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	start := time.Now()
+	resp, err := retrier.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the clamped wait, took %v", elapsed)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+// dropFirstServer closes the connection without a response on the first
+// request and answers the rest normally.
+func dropFirstServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("hijack failed: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func networkRetryConfig() RetryConfig {
+	cfg := DefaultRetryConfig()
+	cfg.InitialBackoff = time.Millisecond
+	cfg.MaxBackoff = 5 * time.Millisecond
+	return cfg
+}
+
+func TestRetryerRetriesDroppedConnection(t *testing.T) {
+	server, calls := dropFirstServer(t)
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	retrier := NewRetrier(networkRetryConfig(), client)
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := retrier.Do(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected the dropped connection to be retried, got %v", err)
+	}
+	resp.Body.Close()
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestRetryerRetryOnNetworkErrorDisabled(t *testing.T) {
+	server, calls := dropFirstServer(t)
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	cfg := networkRetryConfig()
+	cfg.RetryOnNetworkError = false
+	retrier := NewRetrier(cfg, client)
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	if _, err := retrier.Do(context.Background(), req); err == nil {
+		t.Fatal("expected the dropped connection to fail")
+	}
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+	if stats := retrier.Stats(); stats.Retries != 0 {
+		t.Errorf("expected no retries, got %d", stats.Retries)
+	}
+}
+
+func TestRetryerRetriesConnectionRefused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	target := server.URL
+	server.Close()
+
+	cfg := networkRetryConfig()
+	cfg.MaxRetries = 2
+	retrier := NewRetrier(cfg, nil)
+
+	req, _ := http.NewRequest("GET", target, nil)
+	if _, err := retrier.Do(context.Background(), req); err == nil {
+		t.Fatal("expected an error from a closed server")
+	}
+	if stats := retrier.Stats(); stats.Retries != 2 {
+		t.Errorf("expected 2 retries of a refused connection, got %d", stats.Retries)
+	}
+}
+
+func TestRetryerBadURLFailsFast(t *testing.T) {
+	retrier := NewRetrier(networkRetryConfig(), nil)
+
+	req, _ := http.NewRequest("GET", "ftp://example.com/file", nil)
+	if _, err := retrier.Do(context.Background(), req); err == nil {
+		t.Fatal("expected an error for an unsupported scheme")
+	}
+	if stats := retrier.Stats(); stats.Retries != 0 {
+		t.Errorf("expected a bad URL not to be retried, got %d retries", stats.Retries)
+	}
+}
+
+func TestIsTransientNetworkError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"eof", io.EOF, true},
+		{"unexpected eof", io.ErrUnexpectedEOF, true},
+		{"refused", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, true},
+		{"reset", &url.Error{Op: "Get", URL: "http://x", Err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}}, true},
+		{"dns timeout", &net.DNSError{Err: "i/o timeout", Name: "x", IsTimeout: true}, true},
+		{"dns temporary", &net.DNSError{Err: "server misbehaving", Name: "x", IsTemporary: true}, true},
+		{"dns not found", &net.DNSError{Err: "no such host", Name: "x", IsNotFound: true}, false},
+		{"other", fmt.Errorf("unsupported protocol scheme"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientNetworkError(tt.err); got != tt.want {
+				t.Errorf("isTransientNetworkError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
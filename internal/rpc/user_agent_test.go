@@ -0,0 +1,82 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userAgentServer fails the first request with 503 so the retry transport
+// sends a second one, and records the User-Agent of every request.
+func userAgentServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var agents []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		agents = append(agents, r.Header.Get("User-Agent"))
+		attempt := len(agents)
+		mu.Unlock()
+
+		if attempt == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"hash":            "abc",
+			"envelope_xdr":    "AAAA",
+			"result_xdr":      "BBBB",
+			"result_meta_xdr": "CCCC",
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), agents...)
+	}
+}
+
+func TestUserAgent_DefaultOnInitialRequestAndRetry(t *testing.T) {
+	server, agents := userAgentServer(t)
+
+	client, err := NewClient(WithNetwork(Testnet), WithHorizonURL(server.URL+"/"))
+	require.NoError(t, err)
+
+	_, err = client.GetTransaction(context.Background(), "abc")
+	require.NoError(t, err)
+
+	got := agents()
+	require.Len(t, got, 2)
+	assert.Equal(t, []string{DefaultUserAgent(), DefaultUserAgent()}, got)
+	assert.Equal(t, "erst/"+ClientVersion, DefaultUserAgent())
+}
+
+func TestUserAgent_OverrideOnInitialRequestAndRetry(t *testing.T) {
+	server, agents := userAgentServer(t)
+
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithHorizonURL(server.URL+"/"),
+		WithUserAgent("my-ci/1.0"),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetTransaction(context.Background(), "abc")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"my-ci/1.0", "my-ci/1.0"}, agents())
+}
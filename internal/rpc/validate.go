@@ -38,8 +38,8 @@ func ValidateNetworkConfig(config NetworkConfig) error {
 		return fmt.Errorf("network name is required")
 	}
 
-	if config.NetworkPassphrase == "" {
-		return fmt.Errorf("network passphrase is required")
+	if config.NetworkPassphrase == "" && !config.HasNetworkID() {
+		return fmt.Errorf("network passphrase or network id is required")
 	}
 
 	if config.HorizonURL == "" && config.SorobanRPCURL == "" {
@@ -62,9 +62,5 @@ func ValidateNetworkConfig(config NetworkConfig) error {
 		return fmt.Errorf("at least one of HorizonURL or SorobanRPCURL must be provided")
 	}
 
-	if config.NetworkPassphrase == "" {
-		return fmt.Errorf("network passphrase is required")
-	}
-
 	return nil
 }
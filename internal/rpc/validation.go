@@ -6,6 +6,8 @@ package rpc
 import (
 	"encoding/hex"
 	"fmt"
+	"net/url"
+	"strings"
 )
 
 // ValidateTransactionHash checks if the provided string is a valid Stellar transaction hash.
@@ -21,3 +23,42 @@ func ValidateTransactionHash(hash string) error {
 	}
 	return nil
 }
+
+// txPathSegments are the path segments that precede a transaction hash in
+// recognized URLs: stellar.expert uses /explorer/<network>/tx/<hash>,
+// Horizon uses /transactions/<hash>.
+var txPathSegments = map[string]bool{
+	"tx":           true,
+	"transactions": true,
+}
+
+// ResolveTransactionRef accepts a transaction hash or a stellar.expert or
+// Horizon transaction URL and returns the hash. Anything else is an error.
+func ResolveTransactionRef(ref string) (string, error) {
+	if !strings.Contains(ref, "://") {
+		return ref, ValidateTransactionHash(ref)
+	}
+	return TransactionHashFromURL(ref)
+}
+
+// TransactionHashFromURL extracts the transaction hash from a stellar.expert
+// or Horizon transaction URL.
+func TransactionHashFromURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid transaction URL: %s", raw)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if !txPathSegments[segments[i]] {
+			continue
+		}
+		hash := segments[i+1]
+		if err := ValidateTransactionHash(hash); err != nil {
+			return "", fmt.Errorf("URL %s does not contain a transaction hash: %w", raw, err)
+		}
+		return hash, nil
+	}
+	return "", fmt.Errorf("unrecognized transaction URL: %s (expected .../tx/<hash> or .../transactions/<hash>)", raw)
+}
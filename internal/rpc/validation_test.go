@@ -59,3 +59,35 @@ func TestValidateTransactionHash(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveTransactionRef(t *testing.T) {
+	hash := "5c0a1234567890abcdef1234567890abcdef1234567890abcdef1234567890ab"
+
+	tests := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{name: "bare hash", ref: hash, want: hash},
+		{name: "stellar.expert public", ref: "https://stellar.expert/explorer/public/tx/" + hash, want: hash},
+		{name: "stellar.expert testnet with query", ref: "https://stellar.expert/explorer/testnet/tx/" + hash + "?filter=ops", want: hash},
+		{name: "horizon", ref: "https://horizon.stellar.org/transactions/" + hash, want: hash},
+		{name: "horizon testnet trailing slash", ref: "https://horizon-testnet.stellar.org/transactions/" + hash + "/", want: hash},
+		{name: "stellar.expert numeric id", ref: "https://stellar.expert/explorer/public/tx/215658914567237632", wantErr: true},
+		{name: "unrecognized url", ref: "https://example.com/accounts/GABC", wantErr: true},
+		{name: "invalid bare hash", ref: "not-a-hash", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveTransactionRef(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveTransactionRef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want && !tt.wantErr {
+				t.Errorf("ResolveTransactionRef() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
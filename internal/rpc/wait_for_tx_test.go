@@ -0,0 +1,104 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	erstErrors "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func notFoundError() error {
+	return &horizonclient.Error{Problem: problem.P{
+		Type:   "https://stellar.org/horizon-errors/not_found",
+		Status: 404,
+	}}
+}
+
+func withFastTxPolling(t *testing.T) {
+	t.Helper()
+	prev := txNotFoundPollInterval
+	txNotFoundPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() { txNotFoundPollInterval = prev })
+}
+
+func TestGetTransaction_NotFoundIsTyped(t *testing.T) {
+	mock := &mockHorizonClient{
+		TransactionDetailFunc: func(hash string) (hProtocol.Transaction, error) {
+			return hProtocol.Transaction{}, notFoundError()
+		},
+	}
+	c := newTestClient(mock)
+
+	_, err := c.GetTransaction(context.Background(), "abc")
+	require.Error(t, err)
+	assert.True(t, erstErrors.IsTransactionNotFound(err))
+}
+
+func TestWaitForTransaction_NotFoundThenFound(t *testing.T) {
+	withFastTxPolling(t)
+
+	calls := 0
+	mock := &mockHorizonClient{
+		TransactionDetailFunc: func(hash string) (hProtocol.Transaction, error) {
+			calls++
+			if calls < 3 {
+				return hProtocol.Transaction{}, notFoundError()
+			}
+			return hProtocol.Transaction{
+				EnvelopeXdr:   "envelope-xdr",
+				ResultXdr:     "result-xdr",
+				ResultMetaXdr: "meta-xdr",
+			}, nil
+		},
+	}
+	c := newTestClient(mock)
+
+	resp, err := c.WaitForTransaction(context.Background(), "abc", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "envelope-xdr", resp.EnvelopeXdr)
+	assert.Equal(t, 3, calls)
+}
+
+func TestWaitForTransaction_GivesUpAfterWait(t *testing.T) {
+	withFastTxPolling(t)
+
+	mock := &mockHorizonClient{
+		TransactionDetailFunc: func(hash string) (hProtocol.Transaction, error) {
+			return hProtocol.Transaction{}, notFoundError()
+		},
+	}
+	c := newTestClient(mock)
+
+	_, err := c.WaitForTransaction(context.Background(), "abc", 30*time.Millisecond)
+	require.Error(t, err)
+	assert.True(t, erstErrors.IsTransactionNotFound(err))
+	assert.Contains(t, err.Error(), "not found after waiting")
+}
+
+func TestWaitForTransaction_OtherErrorsFailFast(t *testing.T) {
+	withFastTxPolling(t)
+
+	calls := 0
+	mock := &mockHorizonClient{
+		TransactionDetailFunc: func(hash string) (hProtocol.Transaction, error) {
+			calls++
+			return hProtocol.Transaction{}, errors.New("connection refused")
+		},
+	}
+	c := newTestClient(mock)
+
+	_, err := c.WaitForTransaction(context.Background(), "abc", time.Second)
+	require.Error(t, err)
+	assert.False(t, erstErrors.IsTransactionNotFound(err))
+	assert.Equal(t, 1, calls)
+}
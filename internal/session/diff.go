@@ -0,0 +1,121 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"fmt"
+)
+
+// SessionDiff describes how the simulation stored in session B differs from
+// the one in session A. Added and removed entries are relative to A.
+type SessionDiff struct {
+	A string `json:"a"`
+	B string `json:"b"`
+
+	Status *FieldChange `json:"status,omitempty"`
+	Error  *FieldChange `json:"error,omitempty"`
+
+	EventsAdded   []string `json:"events_added,omitempty"`
+	EventsRemoved []string `json:"events_removed,omitempty"`
+	LogsAdded     []string `json:"logs_added,omitempty"`
+	LogsRemoved   []string `json:"logs_removed,omitempty"`
+
+	Budget []BudgetChange `json:"budget,omitempty"`
+}
+
+// FieldChange is a scalar field whose value differs between two sessions.
+type FieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// BudgetChange is a budget metric whose value differs between two sessions.
+type BudgetChange struct {
+	Metric string `json:"metric"`
+	From   uint64 `json:"from"`
+	To     uint64 `json:"to"`
+	Delta  int64  `json:"delta"`
+}
+
+// Empty reports whether the two simulations matched.
+func (d *SessionDiff) Empty() bool {
+	return d.Status == nil && d.Error == nil &&
+		len(d.EventsAdded) == 0 && len(d.EventsRemoved) == 0 &&
+		len(d.LogsAdded) == 0 && len(d.LogsRemoved) == 0 &&
+		len(d.Budget) == 0
+}
+
+// Diff compares the simulation results stored in two sessions. Events and
+// logs are compared as multisets: order is ignored, repeats are not. Both
+// sessions must hold a simulation response.
+func Diff(a, b *SessionData) (*SessionDiff, error) {
+	respA, err := a.ToSimulationResponse()
+	if err != nil {
+		return nil, fmt.Errorf("session %s: %w", a.ID, err)
+	}
+	respB, err := b.ToSimulationResponse()
+	if err != nil {
+		return nil, fmt.Errorf("session %s: %w", b.ID, err)
+	}
+
+	d := &SessionDiff{A: a.ID, B: b.ID}
+	if respA.Status != respB.Status {
+		d.Status = &FieldChange{From: respA.Status, To: respB.Status}
+	}
+	if respA.Error != respB.Error {
+		d.Error = &FieldChange{From: respA.Error, To: respB.Error}
+	}
+	d.EventsAdded, d.EventsRemoved = diffStrings(respA.Events, respB.Events)
+	d.LogsAdded, d.LogsRemoved = diffStrings(respA.Logs, respB.Logs)
+
+	if respA.BudgetUsage != nil || respB.BudgetUsage != nil {
+		var cpuA, cpuB, memA, memB, opsA, opsB uint64
+		if u := respA.BudgetUsage; u != nil {
+			cpuA, memA, opsA = u.CPUInstructions, u.MemoryBytes, uint64(u.OperationsCount)
+		}
+		if u := respB.BudgetUsage; u != nil {
+			cpuB, memB, opsB = u.CPUInstructions, u.MemoryBytes, uint64(u.OperationsCount)
+		}
+		for _, m := range []BudgetChange{
+			{Metric: "cpu_instructions", From: cpuA, To: cpuB},
+			{Metric: "memory_bytes", From: memA, To: memB},
+			{Metric: "operations", From: opsA, To: opsB},
+		} {
+			if m.From != m.To {
+				m.Delta = int64(m.To) - int64(m.From)
+				d.Budget = append(d.Budget, m)
+			}
+		}
+	}
+	return d, nil
+}
+
+// diffStrings returns the entries of b missing from a (added) and of a
+// missing from b (removed), each in its original order.
+func diffStrings(a, b []string) (added, removed []string) {
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		if counts[s] > 0 {
+			counts[s]--
+			continue
+		}
+		added = append(added, s)
+	}
+
+	counts = make(map[string]int, len(b))
+	for _, s := range b {
+		counts[s]++
+	}
+	for _, s := range a {
+		if counts[s] > 0 {
+			counts[s]--
+			continue
+		}
+		removed = append(removed, s)
+	}
+	return added, removed
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteExport writes data to w as an indented JSON bundle that can be
+// loaded on another machine with ReadExport.
+func WriteExport(w io.Writer, data *SessionData) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	return nil
+}
+
+// ReadExport decodes a bundle written by WriteExport. Bundles from a
+// different SchemaVersion are refused: there is no migration between
+// versions, and storing them as-is could leave fields silently empty.
+func ReadExport(r io.Reader) (*SessionData, error) {
+	var data SessionData
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode session bundle: %w", err)
+	}
+
+	switch {
+	case data.SchemaVersion == 0:
+		return nil, fmt.Errorf("session bundle has no schema_version; was it written by 'erst session export'?")
+	case data.SchemaVersion > SchemaVersion:
+		return nil, fmt.Errorf("session bundle uses schema v%d but this erst supports v%d. Please upgrade erst", data.SchemaVersion, SchemaVersion)
+	case data.SchemaVersion < SchemaVersion:
+		return nil, fmt.Errorf("session bundle uses schema v%d but this erst only imports v%d. Re-export it with a current erst", data.SchemaVersion, SchemaVersion)
+	}
+	return &data, nil
+}
+
+// Import saves an exported session under a fresh ID derived from its
+// transaction hash, so it never overwrites a local session, and returns
+// that ID. The original creation time is kept.
+func (s *Store) Import(ctx context.Context, data *SessionData) (string, error) {
+	base := GenerateID(data.TxHash)
+	id := base
+	for n := 2; ; n++ {
+		var count int
+		if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sessions WHERE id = ?`, id).Scan(&count); err != nil {
+			return "", fmt.Errorf("failed to check session ID: %w", err)
+		}
+		if count == 0 {
+			break
+		}
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+
+	imported := *data
+	imported.ID = id
+	imported.Status = "saved"
+	if err := s.Save(ctx, &imported); err != nil {
+		return "", err
+	}
+	return id, nil
+}
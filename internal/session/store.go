@@ -113,6 +113,28 @@ func (s *Store) initSchema() error {
 	
 	CREATE INDEX IF NOT EXISTS idx_last_access ON sessions(last_access_at);
 	CREATE INDEX IF NOT EXISTS idx_tx_hash ON sessions(tx_hash);
+
+	-- session_seq numbers sessions in the order they were created. Unlike
+	-- the rowid, an AUTOINCREMENT key is never reused once a session is
+	-- deleted, so it can serve as a cursor for following new sessions.
+	CREATE TABLE IF NOT EXISTS session_seq (
+		seq INTEGER PRIMARY KEY AUTOINCREMENT,
+		session_id TEXT NOT NULL UNIQUE
+	);
+
+	CREATE TRIGGER IF NOT EXISTS session_seq_insert AFTER INSERT ON sessions
+	BEGIN
+		INSERT OR IGNORE INTO session_seq (session_id) VALUES (NEW.id);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS session_seq_delete AFTER DELETE ON sessions
+	BEGIN
+		DELETE FROM session_seq WHERE session_id = OLD.id;
+	END;
+
+	-- Number sessions saved before session_seq existed.
+	INSERT OR IGNORE INTO session_seq (session_id)
+	SELECT id FROM sessions ORDER BY rowid;
 	`
 
 	if _, err := s.db.Exec(query); err != nil {
@@ -220,6 +242,19 @@ func (s *Store) Load(ctx context.Context, sessionID string) (*SessionData, error
 
 // List returns recent sessions, ordered by last_access_at descending
 func (s *Store) List(ctx context.Context, limit int) ([]*SessionData, error) {
+	return s.ListFiltered(ctx, ListParams{Limit: limit})
+}
+
+// ListParams narrows ListFiltered results. Empty fields match everything.
+type ListParams struct {
+	Network string
+	Status  string
+	Limit   int
+}
+
+// ListFiltered returns sessions matching params, most recently accessed first.
+func (s *Store) ListFiltered(ctx context.Context, params ListParams) ([]*SessionData, error) {
+	limit := params.Limit
 	if limit <= 0 {
 		limit = 50
 	}
@@ -229,11 +264,22 @@ func (s *Store) List(ctx context.Context, limit int) ([]*SessionData, error) {
 	       envelope_xdr, result_xdr, result_meta_xdr,
 	       sim_request_json, sim_response_json, erst_version, schema_version
 	FROM sessions
-	ORDER BY last_access_at DESC
-	LIMIT ?
-	`
+	WHERE 1=1`
+	args := []interface{}{}
+
+	if params.Network != "" {
+		query += " AND network = ?"
+		args = append(args, params.Network)
+	}
+	if params.Status != "" {
+		query += " AND status = ?"
+		args = append(args, params.Status)
+	}
+
+	query += " ORDER BY last_access_at DESC LIMIT ?"
+	args = append(args, limit)
 
-	rows, err := s.db.QueryContext(ctx, query, limit)
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list sessions: %w", err)
 	}
@@ -241,29 +287,11 @@ func (s *Store) List(ctx context.Context, limit int) ([]*SessionData, error) {
 
 	var sessions []*SessionData
 	for rows.Next() {
-		var data SessionData
-		var createdAt, lastAccessAt string
-
-		err := rows.Scan(
-			&data.ID, &createdAt, &lastAccessAt, &data.Status,
-			&data.Network, &data.HorizonURL, &data.TxHash,
-			&data.EnvelopeXdr, &data.ResultXdr, &data.ResultMetaXdr,
-			&data.SimRequestJSON, &data.SimResponseJSON,
-			&data.ErstVersion, &data.SchemaVersion,
-		)
+		data, _, err := scanSession(rows, false)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan session: %w", err)
-		}
-
-		// Parse timestamps
-		if data.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
-			return nil, fmt.Errorf("failed to parse created_at: %w", err)
+			return nil, err
 		}
-		if data.LastAccessAt, err = time.Parse(time.RFC3339, lastAccessAt); err != nil {
-			return nil, fmt.Errorf("failed to parse last_access_at: %w", err)
-		}
-
-		sessions = append(sessions, &data)
+		sessions = append(sessions, data)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -273,6 +301,89 @@ func (s *Store) List(ctx context.Context, limit int) ([]*SessionData, error) {
 	return sessions, nil
 }
 
+// LatestCursor returns the insertion cursor of the most recently created
+// session, or 0 if the store is empty. Pass it to ListAfter to only receive
+// sessions created from now on.
+func (s *Store) LatestCursor(ctx context.Context) (int64, error) {
+	var cursor sql.NullInt64
+	if err := s.db.QueryRowContext(ctx, `SELECT MAX(seq) FROM session_seq`).Scan(&cursor); err != nil {
+		return 0, fmt.Errorf("failed to read latest session cursor: %w", err)
+	}
+	return cursor.Int64, nil
+}
+
+// ListAfter returns sessions created after the given cursor, oldest first,
+// together with the cursor of the last returned session. When no new sessions
+// exist the input cursor is returned unchanged. Cursors are never reused, so
+// deleting sessions cannot hide ones created later.
+func (s *Store) ListAfter(ctx context.Context, cursor int64) ([]*SessionData, int64, error) {
+	query := `
+	SELECT s.id, s.created_at, s.last_access_at, s.status, s.network, s.horizon_url, s.tx_hash,
+	       s.envelope_xdr, s.result_xdr, s.result_meta_xdr,
+	       s.sim_request_json, s.sim_response_json, s.erst_version, s.schema_version, q.seq
+	FROM sessions s
+	JOIN session_seq q ON q.session_id = s.id
+	WHERE q.seq > ?
+	ORDER BY q.seq ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, cursor)
+	if err != nil {
+		return nil, cursor, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*SessionData
+	for rows.Next() {
+		data, seq, err := scanSession(rows, true)
+		if err != nil {
+			return nil, cursor, err
+		}
+		sessions = append(sessions, data)
+		cursor = seq
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, cursor, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	return sessions, cursor, nil
+}
+
+// scanSession reads a single session row. When withSeq is set the row is
+// expected to carry a trailing session_seq column, which is returned as well.
+func scanSession(rows *sql.Rows, withSeq bool) (*SessionData, int64, error) {
+	var data SessionData
+	var createdAt, lastAccessAt string
+	var seq int64
+
+	dest := []interface{}{
+		&data.ID, &createdAt, &lastAccessAt, &data.Status,
+		&data.Network, &data.HorizonURL, &data.TxHash,
+		&data.EnvelopeXdr, &data.ResultXdr, &data.ResultMetaXdr,
+		&data.SimRequestJSON, &data.SimResponseJSON,
+		&data.ErstVersion, &data.SchemaVersion,
+	}
+	if withSeq {
+		dest = append(dest, &seq)
+	}
+
+	err := rows.Scan(dest...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to scan session: %w", err)
+	}
+
+	// Parse timestamps
+	if data.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	if data.LastAccessAt, err = time.Parse(time.RFC3339, lastAccessAt); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse last_access_at: %w", err)
+	}
+
+	return &data, seq, nil
+}
+
 // Delete removes a session by ID
 func (s *Store) Delete(ctx context.Context, sessionID string) error {
 	query := `DELETE FROM sessions WHERE id = ?`
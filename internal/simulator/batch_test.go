@@ -0,0 +1,127 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchSimulator writes a fake simulator that answers a JSON array of
+// requests with an array of responses, echoing each envelope in the logs and
+// failing envelopes named FAIL. Every invocation appends a line to the
+// returned counter file.
+func batchSimulator(t testing.TB) (path, counter string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake simulator script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	path = filepath.Join(dir, "erst-sim")
+	counter = filepath.Join(dir, "invocations")
+	script := `#!/bin/sh
+echo run >> '` + counter + `'
+input=$(cat)
+respond() {
+	if [ "$1" = FAIL ]; then
+		printf '{"status":"error","error":"bad envelope"}'
+	else
+		printf '{"status":"success","logs":["%s"]}' "$1"
+	fi
+}
+case "$input" in
+'['*)
+	printf '['
+	sep=''
+	for env in $(printf '%s' "$input" | grep -o '"envelope_xdr":"[^"]*"' | cut -d'"' -f4); do
+		printf '%s' "$sep"
+		respond "$env"
+		sep=','
+	done
+	printf ']'
+	;;
+*)
+	respond "$(printf '%s' "$input" | grep -o '"envelope_xdr":"[^"]*"' | cut -d'"' -f4)"
+	;;
+esac
+`
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	return path, counter
+}
+
+func invocations(t testing.TB, counter string) int {
+	t.Helper()
+	data, err := os.ReadFile(counter)
+	require.NoError(t, err)
+	return strings.Count(string(data), "run")
+}
+
+func TestRunner_RunBatch_SingleInvocationPreservesOrder(t *testing.T) {
+	path, counter := batchSimulator(t)
+	runner := &Runner{BinaryPath: path}
+
+	reqs := []*SimulationRequest{{EnvelopeXdr: "AAAA"}, {EnvelopeXdr: "BBBB"}, {EnvelopeXdr: "CCCC"}}
+	resps, err := runner.RunBatch(reqs)
+	require.NoError(t, err)
+	require.Len(t, resps, 3)
+
+	for i, want := range []string{"AAAA", "BBBB", "CCCC"} {
+		assert.Equal(t, StatusSuccess, resps[i].Status)
+		assert.Equal(t, []string{want}, resps[i].Logs)
+		assert.NotNil(t, resps[i].ProtocolVersion)
+	}
+	assert.Equal(t, 1, invocations(t, counter))
+}
+
+func TestRunner_RunBatch_FailedRequestDoesNotAbortBatch(t *testing.T) {
+	path, _ := batchSimulator(t)
+	runner := &Runner{BinaryPath: path}
+
+	bad := uint32(1)
+	reqs := []*SimulationRequest{
+		{EnvelopeXdr: "AAAA"},
+		{EnvelopeXdr: "FAIL"},
+		{EnvelopeXdr: "BBBB", ProtocolVersion: &bad},
+		{EnvelopeXdr: "CCCC"},
+	}
+	resps, err := runner.RunBatch(reqs)
+	require.NoError(t, err)
+	require.Len(t, resps, 4)
+
+	assert.Equal(t, StatusSuccess, resps[0].Status)
+	assert.Equal(t, StatusError, resps[1].Status)
+	assert.Equal(t, "bad envelope", resps[1].Error)
+	assert.Equal(t, StatusError, resps[2].Status)
+	assert.NotEmpty(t, resps[2].Error)
+	assert.Equal(t, []string{"CCCC"}, resps[3].Logs)
+}
+
+func TestRunner_RunBatch_Empty(t *testing.T) {
+	runner := &Runner{BinaryPath: "/nonexistent/erst-sim"}
+	resps, err := runner.RunBatch(nil)
+	require.NoError(t, err)
+	assert.Empty(t, resps)
+}
+
+func TestRunEach_FoldsErrorsIntoSlots(t *testing.T) {
+	mock := NewMockRunner(func(req *SimulationRequest) (*SimulationResponse, error) {
+		if req.EnvelopeXdr == "FAIL" {
+			return nil, assert.AnError
+		}
+		return &SimulationResponse{Status: StatusSuccess}, nil
+	})
+
+	resps, err := mock.RunBatch([]*SimulationRequest{{EnvelopeXdr: "FAIL"}, {EnvelopeXdr: "OK"}})
+	require.NoError(t, err)
+	require.Len(t, resps, 2)
+	assert.Equal(t, StatusError, resps[0].Status)
+	assert.Equal(t, assert.AnError.Error(), resps[0].Error)
+	assert.Equal(t, StatusSuccess, resps[1].Status)
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var (
+	instructionUnits = []string{"K", "M", "B", "T"}
+	byteUnits        = []string{"KiB", "MiB", "GiB", "TiB"}
+)
+
+// FormatInstructions renders an instruction count with a decimal suffix,
+// e.g. 999 -> "999", 1000 -> "1.0K", 1500000 -> "1.5M".
+func FormatInstructions(n uint64) string {
+	if n < 1000 {
+		return strconv.FormatUint(n, 10)
+	}
+	v, unit := scale(n, 1000, instructionUnits)
+	return fmt.Sprintf("%.1f%s", v, unit)
+}
+
+// FormatBytes renders a byte count with a binary suffix, e.g. 1000 ->
+// "1000 B", 1<<20 -> "1.0 MiB".
+func FormatBytes(n uint64) string {
+	if n < 1024 {
+		return strconv.FormatUint(n, 10) + " B"
+	}
+	v, unit := scale(n, 1024, byteUnits)
+	return fmt.Sprintf("%.1f %s", v, unit)
+}
+
+// scale divides n by base until it fits the unit. It moves to the next unit
+// once rounding to one decimal would print the base itself, so 999999
+// reads "1.0M" rather than "1000.0K".
+func scale(n, base uint64, units []string) (float64, string) {
+	v := float64(n)
+	for _, unit := range units {
+		v /= float64(base)
+		if v < float64(base)-0.05 {
+			return v, unit
+		}
+	}
+	return v, units[len(units)-1]
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// CallBudget is the budget attributed to a single contract call. The costs
+// are exclusive: they cover the call's own work and not that of the calls it
+// made, so summing them over the tree gives the transaction total.
+type CallBudget struct {
+	ContractID      string `json:"contract_id"`
+	Function        string `json:"function,omitempty"`
+	Depth           int    `json:"depth"`
+	CPUInstructions uint64 `json:"cpu_instructions"`
+	MemoryBytes     uint64 `json:"memory_bytes"`
+}
+
+// ContractBudget is the budget consumed by one contract across all of its
+// calls in the tree.
+type ContractBudget struct {
+	ContractID      string
+	Calls           int
+	CPUInstructions uint64
+	MemoryBytes     uint64
+	CPUShare        float64 // percent of the attributed CPU total
+}
+
+// ExplainBudget groups the per-call attribution in usage by contract and
+// sorts the result by CPU, then memory, most expensive first. It returns nil
+// when the simulator reported no attribution.
+func ExplainBudget(usage *BudgetUsage) []ContractBudget {
+	if usage == nil || len(usage.Calls) == 0 {
+		return nil
+	}
+
+	index := make(map[string]int)
+	var out []ContractBudget
+	var totalCPU uint64
+	for _, call := range usage.Calls {
+		i, ok := index[call.ContractID]
+		if !ok {
+			i = len(out)
+			index[call.ContractID] = i
+			out = append(out, ContractBudget{ContractID: call.ContractID})
+		}
+		out[i].Calls++
+		out[i].CPUInstructions += call.CPUInstructions
+		out[i].MemoryBytes += call.MemoryBytes
+		totalCPU += call.CPUInstructions
+	}
+
+	for i := range out {
+		if totalCPU > 0 {
+			out[i].CPUShare = float64(out[i].CPUInstructions) / float64(totalCPU) * 100
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].CPUInstructions != out[j].CPUInstructions {
+			return out[i].CPUInstructions > out[j].CPUInstructions
+		}
+		return out[i].MemoryBytes > out[j].MemoryBytes
+	})
+	return out
+}
+
+// FormatBudgetBreakdown renders the per-contract breakdown as a table of
+// contract, call count, CPU, CPU share and memory. When raw is set, exact
+// counts are printed instead of human-friendly units.
+func FormatBudgetBreakdown(breakdown []ContractBudget, raw bool) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CONTRACT\tCALLS\tCPU\tSHARE\tMEMORY")
+	for _, c := range breakdown {
+		cpu, mem := FormatInstructions(c.CPUInstructions), FormatBytes(c.MemoryBytes)
+		if raw {
+			cpu, mem = fmt.Sprintf("%d", c.CPUInstructions), fmt.Sprintf("%d", c.MemoryBytes)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%.1f%%\t%s\n", c.ContractID, c.Calls, cpu, c.CPUShare, mem)
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+// FormatCallTree renders the calls in execution order, indented by depth,
+// with each call's own CPU and memory.
+func FormatCallTree(calls []CallBudget, raw bool) string {
+	var buf bytes.Buffer
+	for _, call := range calls {
+		name := call.ContractID
+		if call.Function != "" {
+			name += "::" + call.Function
+		}
+		cpu, mem := FormatInstructions(call.CPUInstructions), FormatBytes(call.MemoryBytes)
+		if raw {
+			cpu, mem = fmt.Sprintf("%d", call.CPUInstructions), fmt.Sprintf("%d", call.MemoryBytes)
+		}
+		fmt.Fprintf(&buf, "%s%s  cpu=%s mem=%s\n", strings.Repeat("  ", call.Depth), name, cpu, mem)
+	}
+	return buf.String()
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func twoContractUsage() *BudgetUsage {
+	return &BudgetUsage{
+		CPUInstructions: 1_000_000,
+		MemoryBytes:     40_000,
+		Calls: []CallBudget{
+			{ContractID: "CROUTER", Function: "swap", Depth: 0, CPUInstructions: 100_000, MemoryBytes: 10_000},
+			{ContractID: "CPOOL", Function: "get_reserves", Depth: 1, CPUInstructions: 300_000, MemoryBytes: 5_000},
+			{ContractID: "CPOOL", Function: "swap", Depth: 1, CPUInstructions: 500_000, MemoryBytes: 20_000},
+			{ContractID: "CROUTER", Function: "settle", Depth: 1, CPUInstructions: 100_000, MemoryBytes: 5_000},
+		},
+	}
+}
+
+func TestExplainBudget_GroupsAndSortsByCPU(t *testing.T) {
+	breakdown := ExplainBudget(twoContractUsage())
+	require.Len(t, breakdown, 2)
+
+	assert.Equal(t, "CPOOL", breakdown[0].ContractID)
+	assert.Equal(t, 2, breakdown[0].Calls)
+	assert.Equal(t, uint64(800_000), breakdown[0].CPUInstructions)
+	assert.Equal(t, uint64(25_000), breakdown[0].MemoryBytes)
+	assert.InDelta(t, 80.0, breakdown[0].CPUShare, 0.001)
+
+	assert.Equal(t, "CROUTER", breakdown[1].ContractID)
+	assert.Equal(t, 2, breakdown[1].Calls)
+	assert.Equal(t, uint64(200_000), breakdown[1].CPUInstructions)
+	assert.InDelta(t, 20.0, breakdown[1].CPUShare, 0.001)
+}
+
+func TestExplainBudget_NoAttribution(t *testing.T) {
+	assert.Nil(t, ExplainBudget(nil))
+	assert.Nil(t, ExplainBudget(&BudgetUsage{CPUInstructions: 10}))
+}
+
+func TestFormatBudgetBreakdown(t *testing.T) {
+	out := FormatBudgetBreakdown(ExplainBudget(twoContractUsage()), false)
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "CONTRACT")
+	assert.Contains(t, lines[1], "CPOOL")
+	assert.Contains(t, lines[1], "800.0K")
+	assert.Contains(t, lines[1], "80.0%")
+	assert.Contains(t, lines[2], "CROUTER")
+
+	raw := FormatBudgetBreakdown(ExplainBudget(twoContractUsage()), true)
+	assert.Contains(t, raw, "800000")
+}
+
+func TestFormatCallTree(t *testing.T) {
+	out := FormatCallTree(twoContractUsage().Calls, true)
+	assert.Equal(t,
+		"CROUTER::swap  cpu=100000 mem=10000\n"+
+			"  CPOOL::get_reserves  cpu=300000 mem=5000\n"+
+			"  CPOOL::swap  cpu=500000 mem=20000\n"+
+			"  CROUTER::settle  cpu=100000 mem=5000\n",
+		out)
+}
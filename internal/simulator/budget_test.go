@@ -190,3 +190,44 @@ func TestBudgetUsageJSONMarshaling(t *testing.T) {
 		t.Errorf("MemoryUsagePercent mismatch after marshal/unmarshal")
 	}
 }
+
+func TestFormatInstructions(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1.0K"},
+		{1 << 20, "1.0M"},
+		{999_999, "1.0M"},
+		{100_000_000, "100.0M"},
+		{2_500_000_000, "2.5B"},
+	}
+	for _, tt := range tests {
+		if got := FormatInstructions(tt.n); got != tt.want {
+			t.Errorf("FormatInstructions(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{0, "0 B"},
+		{999, "999 B"},
+		{1000, "1000 B"},
+		{1024, "1.0 KiB"},
+		{1 << 20, "1.0 MiB"},
+		{(1 << 20) - 1, "1.0 MiB"},
+		{5033165, "4.8 MiB"},
+		{1 << 30, "1.0 GiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
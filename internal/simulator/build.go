@@ -0,0 +1,79 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// SourceDirName is the directory holding the simulator's Rust crate, the same
+// one NewRunner searches for dev builds.
+const SourceDirName = "simulator"
+
+// ErrCargoNotFound is returned by Build when cargo is not on PATH.
+var ErrCargoNotFound = errors.New("cargo not found on PATH")
+
+// lookPath is exec.LookPath, replaceable in tests.
+var lookPath = exec.LookPath
+
+// binaryNames are the file names cargo may produce for the simulator crate,
+// in order of preference.
+var binaryNames = []string{"erst-sim", "simulator"}
+
+// FindSourceDir looks for the simulator crate in start and its parents and
+// returns the directory that contains Cargo.toml.
+func FindSourceDir(start string) (string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+	for {
+		candidate := filepath.Join(dir, SourceDirName)
+		if info, err := os.Stat(filepath.Join(candidate, "Cargo.toml")); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no %s/Cargo.toml found in %s or its parents", SourceDirName, start)
+		}
+		dir = parent
+	}
+}
+
+// Build runs cargo build --release in the simulator source directory and
+// returns the path of the resulting binary. Cargo output is streamed to out.
+func Build(ctx context.Context, sourceDir string, out io.Writer) (string, error) {
+	cargo, err := lookPath("cargo")
+	if err != nil {
+		return "", ErrCargoNotFound
+	}
+
+	cmd := exec.CommandContext(ctx, cargo, "build", "--release")
+	cmd.Dir = sourceDir
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("cargo build failed: %w", err)
+	}
+
+	return ReleaseBinary(sourceDir)
+}
+
+// ReleaseBinary returns the release binary built from sourceDir.
+func ReleaseBinary(sourceDir string) (string, error) {
+	releaseDir := filepath.Join(sourceDir, "target", "release")
+	for _, name := range binaryNames {
+		p := filepath.Join(releaseDir, name)
+		if isExecutable(p) {
+			return abs(p), nil
+		}
+	}
+	return "", fmt.Errorf("no simulator binary found in %s", releaseDir)
+}
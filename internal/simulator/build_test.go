@@ -0,0 +1,76 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeCrate(t *testing.T, root string) string {
+	t.Helper()
+	dir := filepath.Join(root, SourceDirName)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte("[package]\n"), 0o644))
+	return dir
+}
+
+func TestFindSourceDir_SearchesParents(t *testing.T) {
+	root := t.TempDir()
+	want := writeCrate(t, root)
+
+	nested := filepath.Join(root, "internal", "cmd")
+	require.NoError(t, os.MkdirAll(nested, 0o755))
+
+	got, err := FindSourceDir(nested)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	got, err = FindSourceDir(root)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestFindSourceDir_NotFound(t *testing.T) {
+	root := t.TempDir()
+	// A directory named simulator without a crate does not count.
+	require.NoError(t, os.MkdirAll(filepath.Join(root, SourceDirName), 0o755))
+
+	_, err := FindSourceDir(root)
+	require.Error(t, err)
+}
+
+func TestBuild_CargoMissing(t *testing.T) {
+	orig := lookPath
+	lookPath = func(string) (string, error) { return "", errors.New("not found") }
+	t.Cleanup(func() { lookPath = orig })
+
+	_, err := Build(context.Background(), writeCrate(t, t.TempDir()), io.Discard)
+	require.ErrorIs(t, err, ErrCargoNotFound)
+}
+
+func TestReleaseBinary_PrefersErstSim(t *testing.T) {
+	dir := t.TempDir()
+	release := filepath.Join(dir, "target", "release")
+	require.NoError(t, os.MkdirAll(release, 0o755))
+
+	_, err := ReleaseBinary(dir)
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(release, "simulator"), nil, 0o755))
+	got, err := ReleaseBinary(dir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(release, "simulator"), got)
+
+	require.NoError(t, os.WriteFile(filepath.Join(release, "erst-sim"), nil, 0o755))
+	got, err = ReleaseBinary(dir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(release, "erst-sim"), got)
+}
@@ -18,10 +18,11 @@ import (
 //		WithLedgerEntry("key1", "value1").
 //		Build()
 type SimulationRequestBuilder struct {
-	envelopeXdr   string
-	resultMetaXdr string
-	ledgerEntries map[string]string
-	errors        []string
+	envelopeXdr    string
+	resultMetaXdr  string
+	ledgerEntries  map[string]string
+	ledgerSequence uint32
+	errors         []string
 }
 
 // NewSimulationRequestBuilder creates a new builder instance.
@@ -85,6 +86,14 @@ func (b *SimulationRequestBuilder) WithLedgerEntries(entries map[string]string)
 	return b
 }
 
+// WithLedgerSequence sets the ledger the ledger entries were read at. When
+// it is not set, Build derives it from the entries, see
+// EntriesLedgerSequence.
+func (b *SimulationRequestBuilder) WithLedgerSequence(seq uint32) *SimulationRequestBuilder {
+	b.ledgerSequence = seq
+	return b
+}
+
 // Build constructs and validates the final SimulationRequest.
 // Returns an error if required fields are missing or validation fails.
 func (b *SimulationRequestBuilder) Build() (*SimulationRequest, error) {
@@ -111,6 +120,10 @@ func (b *SimulationRequestBuilder) Build() (*SimulationRequest, error) {
 	// Only set ledger entries if there are any
 	if len(b.ledgerEntries) > 0 {
 		req.LedgerEntries = b.ledgerEntries
+		req.LedgerSequence = b.ledgerSequence
+		if req.LedgerSequence == 0 {
+			req.LedgerSequence = EntriesLedgerSequence(b.ledgerEntries)
+		}
 	}
 
 	return req, nil
@@ -131,6 +144,7 @@ func (b *SimulationRequestBuilder) Reset() *SimulationRequestBuilder {
 	b.envelopeXdr = ""
 	b.resultMetaXdr = ""
 	b.ledgerEntries = make(map[string]string)
+	b.ledgerSequence = 0
 	b.errors = make([]string, 0)
 	return b
 }
@@ -0,0 +1,61 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ResultDiff is one field on which two simulation results disagree.
+type ResultDiff struct {
+	Field string `json:"field"`
+	Left  string `json:"left"`
+	Right string `json:"right"`
+}
+
+// CompareResults diffs the status, events and budget of two simulation
+// results, typically the local simulator's against the RPC's. Event
+// payloads are not compared since each side renders them differently; the
+// count and the type of each event are. Budget figures are only compared
+// when both sides report them. An empty result means the two agree.
+func CompareResults(left, right *SimulationResponse) []ResultDiff {
+	var diffs []ResultDiff
+	add := func(field, l, r string) {
+		if l != r {
+			diffs = append(diffs, ResultDiff{Field: field, Left: l, Right: r})
+		}
+	}
+
+	add("status", left.Status, right.Status)
+
+	add("events", strconv.Itoa(eventCount(left)), strconv.Itoa(eventCount(right)))
+	if len(left.DiagnosticEvents) > 0 && len(right.DiagnosticEvents) > 0 {
+		n := len(left.DiagnosticEvents)
+		if len(right.DiagnosticEvents) < n {
+			n = len(right.DiagnosticEvents)
+		}
+		for i := 0; i < n; i++ {
+			add(fmt.Sprintf("events[%d].type", i), left.DiagnosticEvents[i].EventType, right.DiagnosticEvents[i].EventType)
+		}
+	}
+
+	if left.BudgetUsage != nil && right.BudgetUsage != nil {
+		add("cpu_instructions",
+			strconv.FormatUint(left.BudgetUsage.CPUInstructions, 10),
+			strconv.FormatUint(right.BudgetUsage.CPUInstructions, 10))
+		add("memory_bytes",
+			strconv.FormatUint(left.BudgetUsage.MemoryBytes, 10),
+			strconv.FormatUint(right.BudgetUsage.MemoryBytes, 10))
+	}
+	return diffs
+}
+
+// eventCount prefers the structured events and falls back to the raw ones.
+func eventCount(res *SimulationResponse) int {
+	if len(res.DiagnosticEvents) > 0 {
+		return len(res.DiagnosticEvents)
+	}
+	return len(res.Events)
+}
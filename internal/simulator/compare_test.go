@@ -0,0 +1,46 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareResults_Identical(t *testing.T) {
+	res := &SimulationResponse{
+		Status:           StatusSuccess,
+		DiagnosticEvents: []DiagnosticEvent{{EventType: "contract"}},
+		BudgetUsage:      &BudgetUsage{CPUInstructions: 100, MemoryBytes: 10},
+	}
+	assert.Empty(t, CompareResults(res, res))
+}
+
+func TestCompareResults_ReportsDifferences(t *testing.T) {
+	local := &SimulationResponse{
+		Status:           StatusSuccess,
+		DiagnosticEvents: []DiagnosticEvent{{EventType: "diagnostic"}, {EventType: "contract"}},
+		BudgetUsage:      &BudgetUsage{CPUInstructions: 1000, MemoryBytes: 64},
+	}
+	remote := &SimulationResponse{
+		Status:           StatusError,
+		DiagnosticEvents: []DiagnosticEvent{{EventType: "diagnostic"}, {EventType: "system"}, {EventType: "contract"}},
+		BudgetUsage:      &BudgetUsage{CPUInstructions: 1200, MemoryBytes: 64},
+	}
+
+	assert.Equal(t, []ResultDiff{
+		{Field: "status", Left: "success", Right: "error"},
+		{Field: "events", Left: "2", Right: "3"},
+		{Field: "events[1].type", Left: "contract", Right: "system"},
+		{Field: "cpu_instructions", Left: "1000", Right: "1200"},
+	}, CompareResults(local, remote))
+}
+
+func TestCompareResults_RawEventsAndMissingBudget(t *testing.T) {
+	local := &SimulationResponse{Status: StatusSuccess, Events: []string{"a", "b"}, BudgetUsage: &BudgetUsage{CPUInstructions: 5}}
+	remote := &SimulationResponse{Status: StatusSuccess, Events: []string{"a"}}
+
+	assert.Equal(t, []ResultDiff{{Field: "events", Left: "2", Right: "1"}}, CompareResults(local, remote))
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// LedgerEntryLimit caps the ledger entries injected into a simulation
+// request. Size is measured as the length of each base64 key plus value,
+// which is what the request carries. Zero fields are unlimited.
+type LedgerEntryLimit struct {
+	MaxEntries int
+	MaxBytes   int
+}
+
+// Enabled reports whether any cap is set.
+func (l LedgerEntryLimit) Enabled() bool {
+	return l.MaxEntries > 0 || l.MaxBytes > 0
+}
+
+// Apply checks entries against the limit. Within the limit entries are
+// returned as is. Over it, Apply fails with errors.ErrLedgerEntryLimit
+// unless truncate is set, in which case it keeps entries in key order until
+// the next one would cross a cap and reports how many it dropped.
+func (l LedgerEntryLimit) Apply(entries map[string]string, truncate bool) (map[string]string, int, error) {
+	if !l.Enabled() {
+		return entries, 0, nil
+	}
+
+	size := 0
+	for k, v := range entries {
+		size += len(k) + len(v)
+	}
+	if l.within(len(entries), size) {
+		return entries, 0, nil
+	}
+	if !truncate {
+		return nil, 0, errors.WrapLedgerEntryLimit(fmt.Sprintf("%d entries (%d bytes) exceed the limit of %s", len(entries), size, l))
+	}
+
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kept := make(map[string]string)
+	size = 0
+	for _, k := range keys {
+		n := len(k) + len(entries[k])
+		if !l.within(len(kept)+1, size+n) {
+			break
+		}
+		kept[k] = entries[k]
+		size += n
+	}
+	return kept, len(entries) - len(kept), nil
+}
+
+func (l LedgerEntryLimit) within(count, size int) bool {
+	return (l.MaxEntries <= 0 || count <= l.MaxEntries) && (l.MaxBytes <= 0 || size <= l.MaxBytes)
+}
+
+// String implements fmt.Stringer.
+func (l LedgerEntryLimit) String() string {
+	switch {
+	case l.MaxEntries > 0 && l.MaxBytes > 0:
+		return fmt.Sprintf("%d entries / %d bytes", l.MaxEntries, l.MaxBytes)
+	case l.MaxEntries > 0:
+		return fmt.Sprintf("%d entries", l.MaxEntries)
+	case l.MaxBytes > 0:
+		return fmt.Sprintf("%d bytes", l.MaxBytes)
+	default:
+		return "unlimited"
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func limitTestEntries() map[string]string {
+	return map[string]string{
+		"a": "1111",
+		"b": "2222",
+		"c": "3333",
+	}
+}
+
+func TestLedgerEntryLimit_UnderLimit(t *testing.T) {
+	entries := limitTestEntries()
+	for _, limit := range []LedgerEntryLimit{
+		{},
+		{MaxEntries: 3},
+		{MaxBytes: 15},
+		{MaxEntries: 10, MaxBytes: 100},
+	} {
+		got, dropped, err := limit.Apply(entries, false)
+		require.NoError(t, err, limit.String())
+		assert.Equal(t, entries, got)
+		assert.Zero(t, dropped)
+	}
+}
+
+func TestLedgerEntryLimit_ErrorOnExceed(t *testing.T) {
+	for _, limit := range []LedgerEntryLimit{
+		{MaxEntries: 2},
+		{MaxBytes: 14},
+	} {
+		got, _, err := limit.Apply(limitTestEntries(), false)
+		require.Error(t, err, limit.String())
+		assert.ErrorIs(t, err, errors.ErrLedgerEntryLimit)
+		assert.Contains(t, err.Error(), "3 entries (15 bytes)")
+		assert.Nil(t, got)
+	}
+}
+
+func TestLedgerEntryLimit_Truncate(t *testing.T) {
+	got, dropped, err := LedgerEntryLimit{MaxEntries: 2}.Apply(limitTestEntries(), true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1111", "b": "2222"}, got)
+	assert.Equal(t, 1, dropped)
+
+	got, dropped, err = LedgerEntryLimit{MaxBytes: 9}.Apply(limitTestEntries(), true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1111"}, got)
+	assert.Equal(t, 2, dropped)
+}
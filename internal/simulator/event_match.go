@@ -0,0 +1,129 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EventSetDiff lists the events found on only one side of a comparison
+// between simulated and on-chain events.
+type EventSetDiff struct {
+	OnlySimulated []DiagnosticEvent `json:"only_simulated,omitempty"`
+	OnlyOnChain   []DiagnosticEvent `json:"only_on_chain,omitempty"`
+}
+
+// Empty reports whether the two event sets matched.
+func (d EventSetDiff) Empty() bool {
+	return len(d.OnlySimulated) == 0 && len(d.OnlyOnChain) == 0
+}
+
+// CompareEventSets matches the simulated events against the on-chain ones
+// (see MetaDiagnosticEvents). Only contract and system events emitted by
+// successful calls are compared: diagnostic events are host tracing and
+// events of failed calls are rolled back, so neither is part of the ledger.
+//
+// Order is ignored but multiplicity is not. Events match on type, contract
+// and topic count, and on topics and data as well when both sides render
+// them as RPC JSON. The local simulator renders payloads in its own debug
+// format, so against it only the payload-independent fields are compared.
+func CompareEventSets(simulated, onChain []DiagnosticEvent) EventSetDiff {
+	sim := ledgerEvents(simulated)
+	chain := ledgerEvents(onChain)
+
+	used := make([]bool, len(chain))
+	var diff EventSetDiff
+	for _, s := range sim {
+		found := false
+		for i, c := range chain {
+			if !used[i] && eventsMatch(s, c) {
+				used[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff.OnlySimulated = append(diff.OnlySimulated, s)
+		}
+	}
+	for i, c := range chain {
+		if !used[i] {
+			diff.OnlyOnChain = append(diff.OnlyOnChain, c)
+		}
+	}
+	return diff
+}
+
+// DescribeEvent renders a one-line summary of an event for mismatch reports.
+func DescribeEvent(ev DiagnosticEvent) string {
+	var b strings.Builder
+	b.WriteString(ev.EventType)
+	b.WriteString(" event")
+	if ev.ContractID != nil {
+		fmt.Fprintf(&b, " from %s", *ev.ContractID)
+	}
+	if len(ev.Topics) > 0 {
+		fmt.Fprintf(&b, " topics=[%s]", strings.Join(ev.Topics, ", "))
+	}
+	return b.String()
+}
+
+// ledgerEvents keeps the events that end up in the ledger.
+func ledgerEvents(events []DiagnosticEvent) []DiagnosticEvent {
+	var out []DiagnosticEvent
+	for _, ev := range events {
+		if (ev.EventType == "contract" || ev.EventType == "system") && ev.InSuccessfulContractCall {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+func eventsMatch(a, b DiagnosticEvent) bool {
+	if a.EventType != b.EventType || len(a.Topics) != len(b.Topics) {
+		return false
+	}
+	if (a.ContractID == nil) != (b.ContractID == nil) {
+		return false
+	}
+	if a.ContractID != nil && *a.ContractID != *b.ContractID {
+		return false
+	}
+	if !rpcJSONPayload(a) || !rpcJSONPayload(b) {
+		return true
+	}
+	for i := range a.Topics {
+		if !sameJSON(a.Topics[i], b.Topics[i]) {
+			return false
+		}
+	}
+	return sameJSON(a.Data, b.Data)
+}
+
+// rpcJSONPayload reports whether the event's topics and data are RPC JSON
+// rather than the simulator's debug rendering.
+func rpcJSONPayload(ev DiagnosticEvent) bool {
+	for _, t := range ev.Topics {
+		if !json.Valid([]byte(t)) {
+			return false
+		}
+	}
+	return ev.Data == "" || json.Valid([]byte(ev.Data))
+}
+
+// sameJSON compares two JSON documents ignoring formatting.
+func sameJSON(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return string(ja) == string(jb)
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func ledgerEvent(contract, topic, data string) DiagnosticEvent {
+	return DiagnosticEvent{
+		EventType:                "contract",
+		ContractID:               strPtr(contract),
+		Topics:                   []string{topic},
+		Data:                     data,
+		InSuccessfulContractCall: true,
+	}
+}
+
+func TestCompareEventSets_Matching(t *testing.T) {
+	cid := xdr.ContractId{0xAA}
+	meta := encodeV3Meta(t, xdr.SorobanTransactionMeta{
+		ReturnValue: xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+		Events: []xdr.ContractEvent{
+			contractEvent(cid, xdr.ContractEventTypeContract, "transfer", 50),
+			contractEvent(cid, xdr.ContractEventTypeContract, "mint", 7),
+		},
+	})
+	onChain, err := MetaDiagnosticEvents(meta)
+	require.NoError(t, err)
+	id := *onChain[0].ContractID
+
+	simulated := []DiagnosticEvent{
+		{EventType: "diagnostic", ContractID: strPtr(id), Topics: []string{`{"symbol":"fn_call"}`}, InSuccessfulContractCall: true},
+		// Same events in another order and with different JSON spacing.
+		ledgerEvent(id, `{"symbol": "mint"}`, `{"u32": 7}`),
+		ledgerEvent(id, `{"symbol":"transfer"}`, `{"u32":50}`),
+		// Rolled back with its failing call, so never on chain.
+		{EventType: "contract", ContractID: strPtr(id), Topics: []string{`{"symbol":"burn"}`}},
+	}
+
+	diff := CompareEventSets(simulated, onChain)
+	assert.True(t, diff.Empty(), "unexpected diff: %+v", diff)
+}
+
+func TestCompareEventSets_Divergent(t *testing.T) {
+	simulated := []DiagnosticEvent{
+		ledgerEvent("CA", `{"symbol":"transfer"}`, `{"u32":50}`),
+		ledgerEvent("CA", `{"symbol":"transfer"}`, `{"u32":50}`),
+		ledgerEvent("CA", `{"symbol":"mint"}`, `{"u32":7}`),
+	}
+	onChain := []DiagnosticEvent{
+		ledgerEvent("CA", `{"symbol":"transfer"}`, `{"u32":50}`),
+		ledgerEvent("CA", `{"symbol":"mint"}`, `{"u32":8}`),
+		ledgerEvent("CB", `{"symbol":"approve"}`, `{"u32":1}`),
+	}
+
+	diff := CompareEventSets(simulated, onChain)
+	require.False(t, diff.Empty())
+	assert.Equal(t, []DiagnosticEvent{simulated[1], simulated[2]}, diff.OnlySimulated, "duplicates count")
+	assert.Equal(t, []DiagnosticEvent{onChain[1], onChain[2]}, diff.OnlyOnChain)
+}
+
+func TestCompareEventSets_SimulatorDebugPayloads(t *testing.T) {
+	// The local simulator renders payloads with Rust's Debug format, which
+	// cannot be compared with RPC JSON; type, contract and arity still are.
+	simulated := []DiagnosticEvent{
+		ledgerEvent("CA", `Symbol(ScSymbol(StringM(transfer)))`, `U32(50)`),
+	}
+	assert.True(t, CompareEventSets(simulated, []DiagnosticEvent{
+		ledgerEvent("CA", `{"symbol":"transfer"}`, `{"u32":50}`),
+	}).Empty())
+
+	diff := CompareEventSets(simulated, []DiagnosticEvent{
+		ledgerEvent("CB", `{"symbol":"transfer"}`, `{"u32":50}`),
+	})
+	assert.Len(t, diff.OnlySimulated, 1)
+	assert.Len(t, diff.OnlyOnChain, 1)
+}
+
+func TestDescribeEvent(t *testing.T) {
+	ev := ledgerEvent("CA", `{"symbol":"transfer"}`, `{"u32":50}`)
+	assert.Equal(t, `contract event from CA topics=[{"symbol":"transfer"}]`, DescribeEvent(ev))
+	assert.Equal(t, "system event", DescribeEvent(DiagnosticEvent{EventType: "system"}))
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+)
+
+// DefaultEventDataWidth is the number of characters of event data shown in
+// an events table before truncation.
+const DefaultEventDataWidth = 48
+
+// FormatDiagnosticEventsTable renders events as an aligned table with index,
+// type, contract and data columns. Data longer than dataWidth characters is
+// truncated; dataWidth <= 0 means DefaultEventDataWidth.
+func FormatDiagnosticEventsTable(events []DiagnosticEvent, dataWidth int) string {
+	rows := make([]eventRow, len(events))
+	for i, e := range events {
+		rows[i] = eventRow{typ: e.EventType, contract: e.ContractID, data: e.Data}
+	}
+	return formatEventRows(rows, dataWidth)
+}
+
+// FormatCategorizedEventsTable is FormatDiagnosticEventsTable for
+// categorized events.
+func FormatCategorizedEventsTable(events []CategorizedEvent, dataWidth int) string {
+	rows := make([]eventRow, len(events))
+	for i, e := range events {
+		rows[i] = eventRow{typ: e.EventType, contract: e.ContractID, data: e.Data}
+	}
+	return formatEventRows(rows, dataWidth)
+}
+
+type eventRow struct {
+	typ      string
+	contract *string
+	data     string
+}
+
+func formatEventRows(rows []eventRow, dataWidth int) string {
+	if dataWidth <= 0 {
+		dataWidth = DefaultEventDataWidth
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "#\tTYPE\tCONTRACT\tDATA")
+	for i, r := range rows {
+		contract := "-"
+		if r.contract != nil && *r.contract != "" {
+			contract = *r.contract
+		}
+		data := r.data
+		if data == "" {
+			data = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", i+1, r.typ, contract, truncate(data, dataWidth))
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+// truncate shortens s to at most width runes, marking the cut with "...".
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	if width <= 3 {
+		return string(r[:width])
+	}
+	return string(r[:width-3]) + "..."
+}
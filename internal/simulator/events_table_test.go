@@ -0,0 +1,52 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatDiagnosticEventsTable_Alignment(t *testing.T) {
+	contract := "CCONTRACTID"
+	out := FormatDiagnosticEventsTable([]DiagnosticEvent{
+		{EventType: "contract", ContractID: &contract, Data: "short"},
+		{EventType: "diagnostic", Data: ""},
+	}, 0)
+
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "#"))
+
+	// Every column starts at the same offset on every line.
+	for _, col := range []string{"TYPE", "CONTRACT", "DATA"} {
+		offset := strings.Index(lines[0], col)
+		require.Positive(t, offset)
+		for _, line := range lines[1:] {
+			assert.NotEqual(t, byte(' '), line[offset], "column %s misaligned in %q", col, line)
+			assert.Equal(t, byte(' '), line[offset-1], "column %s misaligned in %q", col, line)
+		}
+	}
+
+	assert.Contains(t, lines[1], "CCONTRACTID")
+	assert.Contains(t, lines[2], "diagnostic  -")
+}
+
+func TestFormatCategorizedEventsTable_TruncatesData(t *testing.T) {
+	long := strings.Repeat("x", 100)
+	out := FormatCategorizedEventsTable([]CategorizedEvent{{EventType: "contract", Data: long}}, 20)
+
+	assert.Contains(t, out, strings.Repeat("x", 17)+"...")
+	assert.NotContains(t, out, strings.Repeat("x", 18))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 3))
+	assert.Equal(t, "ab", truncate("abcdef", 2))
+	assert.Equal(t, "a...", truncate("abcdef", 4))
+	assert.Equal(t, "é...", truncate("éééééé", 4))
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import "sort"
+
+// FootprintDiff compares the footprint a transaction declared with the
+// ledger keys it actually accessed. Keys are base64 LedgerKey XDR.
+type FootprintDiff struct {
+	// Undeclared keys were accessed but missing from the footprint. The host
+	// traps on these, so any entry here explains a failure.
+	Undeclared []string `json:"undeclared,omitempty"`
+	// Unused keys were declared but never accessed. They only cost fees.
+	Unused []string `json:"unused,omitempty"`
+}
+
+// HasUndeclared reports whether any accessed key was missing from the
+// footprint.
+func (d *FootprintDiff) HasUndeclared() bool {
+	return d != nil && len(d.Undeclared) > 0
+}
+
+// DiffFootprint compares the footprint declared in a base64 envelope with
+// the keys accessed during simulation (SimulationResponse.AccessedKeys).
+func DiffFootprint(envelopeXdr string, accessed []string) (*FootprintDiff, error) {
+	declared, err := FootprintKeys(envelopeXdr)
+	if err != nil {
+		return nil, err
+	}
+	return diffKeys(declared, accessed), nil
+}
+
+func diffKeys(declared, accessed []string) *FootprintDiff {
+	declaredSet := make(map[string]bool, len(declared))
+	for _, k := range declared {
+		declaredSet[k] = true
+	}
+	accessedSet := make(map[string]bool, len(accessed))
+	for _, k := range accessed {
+		accessedSet[k] = true
+	}
+
+	diff := &FootprintDiff{}
+	for k := range accessedSet {
+		if !declaredSet[k] {
+			diff.Undeclared = append(diff.Undeclared, k)
+		}
+	}
+	for k := range declaredSet {
+		if !accessedSet[k] {
+			diff.Unused = append(diff.Unused, k)
+		}
+	}
+	sort.Strings(diff.Undeclared)
+	sort.Strings(diff.Unused)
+	return diff
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFootprint_AccessedButUndeclared(t *testing.T) {
+	declared := contractDataKey(t, "balance", xdr.ContractDataDurabilityPersistent)
+	unused := contractDataKey(t, "admin", xdr.ContractDataDurabilityPersistent)
+	missing := contractDataKey(t, "allowance", xdr.ContractDataDurabilityTemporary)
+
+	env := sorobanEnvelope(t, []xdr.LedgerKey{unused}, []xdr.LedgerKey{declared})
+
+	diff, err := DiffFootprint(env, []string{encodeKey(t, declared), encodeKey(t, missing), encodeKey(t, missing)})
+	require.NoError(t, err)
+
+	assert.True(t, diff.HasUndeclared())
+	assert.Equal(t, []string{encodeKey(t, missing)}, diff.Undeclared)
+	assert.Equal(t, []string{encodeKey(t, unused)}, diff.Unused)
+}
+
+func TestDiffFootprint_Complete(t *testing.T) {
+	key := contractDataKey(t, "balance", xdr.ContractDataDurabilityPersistent)
+	env := sorobanEnvelope(t, nil, []xdr.LedgerKey{key})
+
+	diff, err := DiffFootprint(env, []string{encodeKey(t, key)})
+	require.NoError(t, err)
+	assert.False(t, diff.HasUndeclared())
+	assert.Empty(t, diff.Unused)
+}
+
+func TestDiffFootprint_InvalidEnvelope(t *testing.T) {
+	_, err := DiffFootprint("not-xdr", nil)
+	assert.Error(t, err)
+}
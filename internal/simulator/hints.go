@@ -0,0 +1,61 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import "strings"
+
+// errorHint pairs a fragment of a Soroban error message with advice on what
+// usually causes it. Patterns are matched case-insensitively.
+type errorHint struct {
+	patterns []string
+	hint     string
+}
+
+// errorHints is the pattern to hint table, most specific first.
+var errorHints = []errorHint{
+	{
+		patterns: []string{"trying to get non-existing value", "non-existing value from storage"},
+		hint:     "A storage entry the contract reads does not exist. Check that its key is in the footprint and that its TTL has not expired; archived entries need a restore (--restore-archived).",
+	},
+	{
+		patterns: []string{"contract not found", "contract instance not found", "missing contract instance"},
+		hint:     "Verify the contract is deployed on this network and that the contract ID is correct.",
+	},
+	{
+		patterns: []string{"wasm code not found", "contract code not found", "missing contract code"},
+		hint:     "The contract's WASM is not installed or its code entry has expired; upload the WASM or extend its TTL.",
+	},
+	{
+		patterns: []string{"archived", "entry is expired", "ttl expired"},
+		hint:     "A ledger entry in the footprint is archived. Restore it with a RestoreFootprint operation before invoking the contract.",
+	},
+	{
+		patterns: []string{"outside of the footprint", "not in the footprint", "not in footprint"},
+		hint:     "The contract accessed a key missing from the transaction footprint. Re-simulate the transaction to rebuild the footprint.",
+	},
+	{
+		patterns: []string{"exceededlimit", "exceeded_limit", "budget exceeded", "cpu limit exceeded", "memory limit exceeded"},
+		hint:     "The invocation ran out of CPU or memory budget. Raise the resource limits or reduce work per call; --explain-budget shows which contract consumed it.",
+	},
+	{
+		patterns: []string{"not authorized", "require_auth", "invalidaction", "missing authorization"},
+		hint:     "A required authorization is missing or does not match the invocation. Check the auth entries and that the right account signed them.",
+	},
+}
+
+// FindHints returns the hints whose patterns occur in text, in table order
+// and without duplicates.
+func FindHints(text string) []string {
+	lower := strings.ToLower(text)
+	var hints []string
+	for _, h := range errorHints {
+		for _, p := range h.patterns {
+			if strings.Contains(lower, p) {
+				hints = append(hints, h.hint)
+				break
+			}
+		}
+	}
+	return hints
+}
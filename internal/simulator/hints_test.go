@@ -0,0 +1,36 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindHints_MissingStorageValue(t *testing.T) {
+	hints := FindHints("HostError: Error(Storage, MissingValue)\nDebugInfo: trying to get non-existing value from storage")
+	require.Len(t, hints, 1)
+	assert.Contains(t, hints[0], "footprint")
+	assert.Contains(t, hints[0], "TTL")
+}
+
+func TestFindHints_ContractNotFound(t *testing.T) {
+	hints := FindHints("simulation error: Contract Not Found")
+	require.Len(t, hints, 1)
+	assert.Contains(t, hints[0], "deployed")
+}
+
+func TestFindHints_MultipleAndDeduplicated(t *testing.T) {
+	hints := FindHints("Error(Budget, ExceededLimit)\nexceeded_limit again\ncontract not found")
+	require.Len(t, hints, 2)
+	assert.Contains(t, hints[0], "deployed")
+	assert.Contains(t, hints[1], "budget")
+}
+
+func TestFindHints_NoMatch(t *testing.T) {
+	assert.Empty(t, FindHints("Error(Contract, #3)"))
+	assert.Empty(t, FindHints(""))
+}
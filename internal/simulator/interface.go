@@ -6,6 +6,10 @@ package simulator
 // RunnerInterface defines the contract for simulator execution
 type RunnerInterface interface {
 	Run(req *SimulationRequest) (*SimulationResponse, error)
+	// RunBatch simulates reqs and returns one response per request, in
+	// order. A failed request carries its error in the response's Error
+	// field instead of aborting the batch.
+	RunBatch(reqs []*SimulationRequest) ([]*SimulationResponse, error)
 }
 
 // NewRunnerInterface creates a RunnerInterface implementation
@@ -20,3 +24,20 @@ func ExampleUsage(runner RunnerInterface, req *SimulationRequest) (*SimulationRe
 	// This enables easy testing with mocks and flexible production usage
 	return runner.Run(req)
 }
+
+// RunEach implements RunBatch for runners without a native batch mode by
+// calling run once per request. Errors are folded into the response slot.
+func RunEach(run func(*SimulationRequest) (*SimulationResponse, error), reqs []*SimulationRequest) []*SimulationResponse {
+	out := make([]*SimulationResponse, len(reqs))
+	for i, req := range reqs {
+		resp, err := run(req)
+		if err != nil {
+			if resp == nil {
+				resp = &SimulationResponse{Status: StatusError}
+			}
+			resp.Error = err.Error()
+		}
+		out[i] = resp
+	}
+	return out
+}
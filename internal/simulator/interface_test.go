@@ -58,3 +58,7 @@ func (m *mockRunnerForTest) Run(req *SimulationRequest) (*SimulationResponse, er
 		Events: []string{"mock-event"},
 	}, nil
 }
+
+func (m *mockRunnerForTest) RunBatch(reqs []*SimulationRequest) ([]*SimulationResponse, error) {
+	return RunEach(m.Run, reqs), nil
+}
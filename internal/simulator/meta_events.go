@@ -0,0 +1,108 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// MetaDiagnosticEvents extracts the events recorded on chain in a base64
+// TransactionResultMeta, in the same shape the simulator reports them.
+// Diagnostic events are returned when the meta has them; since most nodes
+// do not store diagnostics, the contract events are used otherwise. Topics
+// and data are rendered as RPC JSON (see decoder.ScValToRPCJSON).
+func MetaDiagnosticEvents(resultMetaXdr string) ([]DiagnosticEvent, error) {
+	var meta xdr.TransactionResultMeta
+	if err := xdr.SafeUnmarshalBase64(resultMetaXdr, &meta); err != nil {
+		return nil, fmt.Errorf("failed to decode result meta: %w", err)
+	}
+
+	diagnostic, contract := metaEvents(meta.TxApplyProcessing)
+	out := make([]DiagnosticEvent, 0, len(diagnostic)+len(contract))
+	if len(diagnostic) > 0 {
+		for _, de := range diagnostic {
+			ev, err := convertContractEvent(de.Event, de.InSuccessfulContractCall)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, ev)
+		}
+		return out, nil
+	}
+	for _, ce := range contract {
+		ev, err := convertContractEvent(ce, true)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, ev)
+	}
+	return out, nil
+}
+
+// metaEvents returns the diagnostic and contract events of a V3 or V4 meta.
+func metaEvents(tm xdr.TransactionMeta) ([]xdr.DiagnosticEvent, []xdr.ContractEvent) {
+	switch tm.V {
+	case 3:
+		if tm.V3 != nil && tm.V3.SorobanMeta != nil {
+			return tm.V3.SorobanMeta.DiagnosticEvents, tm.V3.SorobanMeta.Events
+		}
+	case 4:
+		if tm.V4 != nil {
+			var contract []xdr.ContractEvent
+			for _, op := range tm.V4.Operations {
+				contract = append(contract, op.Events...)
+			}
+			return tm.V4.DiagnosticEvents, contract
+		}
+	}
+	return nil, nil
+}
+
+func convertContractEvent(ce xdr.ContractEvent, successful bool) (DiagnosticEvent, error) {
+	ev := DiagnosticEvent{
+		EventType:                eventTypeName(ce.Type),
+		Topics:                   []string{},
+		InSuccessfulContractCall: successful,
+	}
+	if ce.ContractId != nil {
+		id, err := strkey.Encode(strkey.VersionByteContract, ce.ContractId[:])
+		if err != nil {
+			return DiagnosticEvent{}, fmt.Errorf("failed to encode contract id: %w", err)
+		}
+		ev.ContractID = &id
+	}
+
+	body, ok := ce.Body.GetV0()
+	if !ok {
+		return ev, nil
+	}
+	for _, topic := range body.Topics {
+		s, err := decoder.ScValToRPCJSON(topic)
+		if err != nil {
+			return DiagnosticEvent{}, fmt.Errorf("failed to encode event topic: %w", err)
+		}
+		ev.Topics = append(ev.Topics, string(s))
+	}
+	data, err := decoder.ScValToRPCJSON(body.Data)
+	if err != nil {
+		return DiagnosticEvent{}, fmt.Errorf("failed to encode event data: %w", err)
+	}
+	ev.Data = string(data)
+	return ev, nil
+}
+
+func eventTypeName(t xdr.ContractEventType) string {
+	switch t {
+	case xdr.ContractEventTypeContract:
+		return "contract"
+	case xdr.ContractEventTypeSystem:
+		return "system"
+	default:
+		return "diagnostic"
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+func contractEvent(cid xdr.ContractId, typ xdr.ContractEventType, topic string, data uint32) xdr.ContractEvent {
+	sym := xdr.ScSymbol(topic)
+	u := xdr.Uint32(data)
+	return xdr.ContractEvent{
+		ContractId: &cid,
+		Type:       typ,
+		Body: xdr.ContractEventBody{
+			V: 0,
+			V0: &xdr.ContractEventV0{
+				Topics: []xdr.ScVal{{Type: xdr.ScValTypeScvSymbol, Sym: &sym}},
+				Data:   xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &u},
+			},
+		},
+	}
+}
+
+func encodeV3Meta(t *testing.T, sm xdr.SorobanTransactionMeta) string {
+	t.Helper()
+	results := []xdr.OperationResult{}
+	rm := xdr.TransactionResultMeta{
+		Result: xdr.TransactionResultPair{Result: xdr.TransactionResult{
+			Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &results},
+		}},
+		TxApplyProcessing: xdr.TransactionMeta{V: 3, V3: &xdr.TransactionMetaV3{
+			SorobanMeta: &sm,
+		}},
+	}
+	b64, err := xdr.MarshalBase64(rm)
+	require.NoError(t, err)
+	return b64
+}
+
+func TestMetaDiagnosticEvents_V3Diagnostic(t *testing.T) {
+	cid := xdr.ContractId{0xAA}
+	meta := encodeV3Meta(t, xdr.SorobanTransactionMeta{
+		ReturnValue: xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+		DiagnosticEvents: []xdr.DiagnosticEvent{
+			{InSuccessfulContractCall: true, Event: contractEvent(cid, xdr.ContractEventTypeDiagnostic, "fn_call", 1)},
+			{InSuccessfulContractCall: false, Event: contractEvent(cid, xdr.ContractEventTypeContract, "transfer", 50)},
+		},
+	})
+
+	events, err := MetaDiagnosticEvents(meta)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	wantID, err := strkey.Encode(strkey.VersionByteContract, cid[:])
+	require.NoError(t, err)
+
+	require.Equal(t, "diagnostic", events[0].EventType)
+	require.True(t, events[0].InSuccessfulContractCall)
+	require.NotNil(t, events[0].ContractID)
+	require.Equal(t, wantID, *events[0].ContractID)
+	require.Equal(t, []string{`{"symbol":"fn_call"}`}, events[0].Topics)
+
+	require.Equal(t, "contract", events[1].EventType)
+	require.False(t, events[1].InSuccessfulContractCall)
+	require.Equal(t, `{"u32":50}`, events[1].Data)
+}
+
+func TestMetaDiagnosticEvents_V3ContractEventsFallback(t *testing.T) {
+	cid := xdr.ContractId{0xBB}
+	meta := encodeV3Meta(t, xdr.SorobanTransactionMeta{
+		ReturnValue: xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+		Events:      []xdr.ContractEvent{contractEvent(cid, xdr.ContractEventTypeContract, "mint", 7)},
+	})
+
+	events, err := MetaDiagnosticEvents(meta)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "contract", events[0].EventType)
+	require.True(t, events[0].InSuccessfulContractCall)
+	require.Equal(t, []string{`{"symbol":"mint"}`}, events[0].Topics)
+}
+
+func TestMetaDiagnosticEvents_InvalidMeta(t *testing.T) {
+	_, err := MetaDiagnosticEvents("not-xdr")
+	require.Error(t, err)
+}
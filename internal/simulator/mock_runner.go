@@ -14,6 +14,10 @@ func (m *MockRunner) Run(req *SimulationRequest) (*SimulationResponse, error) {
 	return &SimulationResponse{Status: "success"}, nil
 }
 
+func (m *MockRunner) RunBatch(reqs []*SimulationRequest) ([]*SimulationResponse, error) {
+	return RunEach(m.Run, reqs), nil
+}
+
 func NewMockRunner(fn func(req *SimulationRequest) (*SimulationResponse, error)) *MockRunner {
 	return &MockRunner{RunFunc: fn}
 }
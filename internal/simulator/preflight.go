@@ -0,0 +1,93 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// MaxAuthTraceEventDepth bounds AuthTraceOptions.MaxEventDepth. Real call
+// trees are far shallower; larger values are almost always a typo.
+const MaxAuthTraceEventDepth = 256
+
+// Validate checks the request for mistakes the simulator would otherwise
+// report as an opaque failure: a missing or non-base64 envelope, ledger
+// entries without the ledger sequence they were read at, and an out of
+// range auth trace depth. The envelope may be empty for local WASM replay.
+// Errors wrap errors.ErrInvalidRequest.
+func (r *SimulationRequest) Validate() error {
+	if r.EnvelopeXdr == "" {
+		if r.WasmPath == nil {
+			return errors.WrapInvalidRequest(fmt.Errorf("envelope_xdr is empty"))
+		}
+	} else if _, err := base64.StdEncoding.DecodeString(r.EnvelopeXdr); err != nil {
+		return errors.WrapInvalidRequest(fmt.Errorf("envelope_xdr is not valid base64: %w", err))
+	}
+
+	if len(r.LedgerEntries) > 0 && r.LedgerSequence == 0 {
+		return errors.WrapInvalidRequest(fmt.Errorf("ledger_sequence must be set when ledger_entries are provided"))
+	}
+
+	if r.AuthTraceOpts != nil {
+		if d := r.AuthTraceOpts.MaxEventDepth; d < 0 || d > MaxAuthTraceEventDepth {
+			return errors.WrapInvalidRequest(fmt.Errorf("auth_trace_opts.max_event_depth %d out of range [0, %d]", d, MaxAuthTraceEventDepth))
+		}
+	}
+	return nil
+}
+
+// EntriesLedgerSequence returns the newest last-modified ledger among the
+// base64 LedgerEntry values of entries, the earliest ledger the set can
+// have been read at. Values that do not decode are ignored; zero means no
+// entry decoded.
+func EntriesLedgerSequence(entries map[string]string) uint32 {
+	var seq uint32
+	for _, b64 := range entries {
+		var entry xdr.LedgerEntry
+		if err := xdr.SafeUnmarshalBase64(b64, &entry); err != nil {
+			continue
+		}
+		if s := uint32(entry.LastModifiedLedgerSeq); s > seq {
+			seq = s
+		}
+	}
+	return seq
+}
+
+// PreflightXDR checks that envelopeXdr decodes to a TransactionEnvelope and,
+// when non-empty, that resultMetaXdr decodes to a TransactionResultMeta. It
+// runs before the simulator is spawned so corrupt input fails fast with a
+// clear message instead of deep inside the simulator.
+func PreflightXDR(envelopeXdr, resultMetaXdr string) error {
+	if envelopeXdr == "" {
+		return errors.WrapInvalidEnvelopeXDR(fmt.Errorf("envelope is empty"))
+	}
+	var env xdr.TransactionEnvelope
+	if err := decodeBase64XDR(envelopeXdr, &env); err != nil {
+		return errors.WrapInvalidEnvelopeXDR(err)
+	}
+
+	if resultMetaXdr != "" {
+		var meta xdr.TransactionResultMeta
+		if err := decodeBase64XDR(resultMetaXdr, &meta); err != nil {
+			return errors.WrapInvalidResultMetaXDR(err)
+		}
+	}
+	return nil
+}
+
+func decodeBase64XDR(b64 string, v interface{}) error {
+	raw, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return fmt.Errorf("not valid base64: %w", err)
+	}
+	if err := xdr.SafeUnmarshal(raw, v); err != nil {
+		return fmt.Errorf("cannot decode as %T: %w", v, err)
+	}
+	return nil
+}
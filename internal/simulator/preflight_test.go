@@ -0,0 +1,175 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validEnvelopeXDR(t *testing.T) string {
+	t.Helper()
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: xdr.MustMuxedAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"),
+			Fee:           100,
+			SeqNum:        1,
+			Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+			Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+			Operations: []xdr.Operation{{Body: xdr.OperationBody{
+				Type:           xdr.OperationTypeBumpSequence,
+				BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 2},
+			}}},
+		}},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	return b64
+}
+
+func TestPreflightXDR(t *testing.T) {
+	validEnv := validEnvelopeXDR(t)
+
+	// A well-formed ledger key is valid XDR, but not a TransactionEnvelope.
+	key := xdr.LedgerKey{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.LedgerKeyAccount{AccountId: xdr.MustAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")},
+	}
+	wrongType, err := xdr.MarshalBase64(key)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		envelope string
+		meta     string
+		wantErr  error
+	}{
+		{name: "empty envelope", envelope: "", wantErr: errors.ErrInvalidEnvelopeXDR},
+		{name: "non-base64 envelope", envelope: "not base64!", wantErr: errors.ErrInvalidEnvelopeXDR},
+		{name: "wrong type envelope", envelope: wrongType, wantErr: errors.ErrInvalidEnvelopeXDR},
+		{name: "valid envelope", envelope: validEnv},
+		{name: "non-base64 meta", envelope: validEnv, meta: "%%%", wantErr: errors.ErrInvalidResultMetaXDR},
+		{name: "wrong type meta", envelope: validEnv, meta: validEnv, wantErr: errors.ErrInvalidResultMetaXDR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := PreflightXDR(tt.envelope, tt.meta)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPreflightXDR_ErrorMessage(t *testing.T) {
+	err := PreflightXDR("", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid envelope XDR")
+}
+
+func TestSimulationRequest_Validate(t *testing.T) {
+	wasm := "contract.wasm"
+	entries := map[string]string{"k": "v"}
+
+	tests := []struct {
+		name    string
+		req     SimulationRequest
+		wantErr string
+	}{
+		{name: "valid", req: SimulationRequest{EnvelopeXdr: "AAAA"}},
+		{name: "empty envelope", req: SimulationRequest{}, wantErr: "envelope_xdr is empty"},
+		{name: "local wasm replay without envelope", req: SimulationRequest{WasmPath: &wasm}},
+		{name: "non-base64 envelope", req: SimulationRequest{EnvelopeXdr: "not base64!"}, wantErr: "not valid base64"},
+		{name: "entries without sequence", req: SimulationRequest{EnvelopeXdr: "AAAA", LedgerEntries: entries}, wantErr: "ledger_sequence"},
+		{name: "entries with sequence", req: SimulationRequest{EnvelopeXdr: "AAAA", LedgerEntries: entries, LedgerSequence: 7}},
+		{name: "negative depth", req: SimulationRequest{EnvelopeXdr: "AAAA", AuthTraceOpts: &AuthTraceOptions{MaxEventDepth: -1}}, wantErr: "max_event_depth"},
+		{name: "depth too large", req: SimulationRequest{EnvelopeXdr: "AAAA", AuthTraceOpts: &AuthTraceOptions{MaxEventDepth: MaxAuthTraceEventDepth + 1}}, wantErr: "max_event_depth"},
+		{name: "depth at bound", req: SimulationRequest{EnvelopeXdr: "AAAA", AuthTraceOpts: &AuthTraceOptions{MaxEventDepth: MaxAuthTraceEventDepth}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunner_Run_ValidatesBeforeSpawning(t *testing.T) {
+	runner := &Runner{BinaryPath: "/nonexistent/erst-sim"}
+
+	_, err := runner.Run(&SimulationRequest{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+}
+
+func TestEntriesLedgerSequence(t *testing.T) {
+	entry := func(seq uint32) string {
+		e := xdr.LedgerEntry{
+			LastModifiedLedgerSeq: xdr.Uint32(seq),
+			Data: xdr.LedgerEntryData{
+				Type:    xdr.LedgerEntryTypeAccount,
+				Account: &xdr.AccountEntry{AccountId: xdr.MustAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")},
+			},
+		}
+		b64, err := xdr.MarshalBase64(e)
+		require.NoError(t, err)
+		return b64
+	}
+
+	assert.Equal(t, uint32(0), EntriesLedgerSequence(nil))
+	assert.Equal(t, uint32(0), EntriesLedgerSequence(map[string]string{"k": "garbage"}))
+	assert.Equal(t, uint32(120), EntriesLedgerSequence(map[string]string{
+		"a": entry(100),
+		"b": entry(120),
+		"c": "garbage",
+	}))
+}
+
+func TestSimulationRequestBuilder_BuildPassesValidate(t *testing.T) {
+	entry := xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 321,
+		Data: xdr.LedgerEntryData{
+			Type:    xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{AccountId: xdr.MustAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")},
+		},
+	}
+	entryB64, err := xdr.MarshalBase64(entry)
+	require.NoError(t, err)
+
+	req, err := NewSimulationRequestBuilder().
+		WithEnvelopeXDR(validEnvelopeXDR(t)).
+		WithResultMetaXDR("AAAAAQ==").
+		WithLedgerEntry("key", entryB64).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(321), req.LedgerSequence)
+	assert.NoError(t, req.Validate())
+
+	// An explicit sequence wins, and covers entries that do not decode.
+	req, err = NewSimulationRequestBuilder().
+		WithEnvelopeXDR(validEnvelopeXDR(t)).
+		WithResultMetaXDR("AAAAAQ==").
+		WithLedgerEntry("key", "opaque").
+		WithLedgerSequence(500).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(500), req.LedgerSequence)
+	assert.NoError(t, req.Validate())
+}
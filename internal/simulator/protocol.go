@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"maps"
 	"sort"
+
+	"github.com/dotandev/hintents/internal/errors"
 )
 
 type Protocol struct {
@@ -61,18 +63,17 @@ func Get(version uint32) (*Protocol, error) {
 	if p, exists := protocols[version]; exists {
 		return p, nil
 	}
-	return nil, fmt.Errorf("unsupported protocol version: %d", version)
+	return nil, errors.WrapUnsupportedProtocol(version)
 }
 
-func GetOrDefault(version *uint32) *Protocol {
+// GetOrDefault returns the protocol for version, or the latest one when
+// version is nil or zero. A version outside the known range is an
+// ErrUnsupportedProtocol error rather than a silent fallback.
+func GetOrDefault(version *uint32) (*Protocol, error) {
 	if version == nil || *version == 0 {
-		return protocols[defaultVersion]
-	}
-	p, _ := Get(*version)
-	if p == nil {
-		return protocols[defaultVersion]
+		return protocols[defaultVersion], nil
 	}
-	return p
+	return Get(*version)
 }
 
 func Feature(version uint32, key string) (interface{}, error) {
@@ -97,7 +98,7 @@ func FeatureOrDefault(version uint32, key string, defVal interface{}) interface{
 
 func Validate(version uint32) error {
 	if _, ok := protocols[version]; !ok {
-		return fmt.Errorf("unsupported protocol version: %d", version)
+		return errors.WrapUnsupportedProtocol(version)
 	}
 	return nil
 }
@@ -0,0 +1,68 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"maps"
+)
+
+// ProtocolBudget is the outcome of simulating a request under one protocol
+// version. Error is set when the version is unknown or the simulator
+// rejected the run; BudgetUsage is then usually nil.
+type ProtocolBudget struct {
+	Version     uint32       `json:"version"`
+	Status      string       `json:"status"`
+	Error       string       `json:"error,omitempty"`
+	BudgetUsage *BudgetUsage `json:"budget_usage,omitempty"`
+}
+
+// CompareProtocols simulates req once under each of versions and returns
+// the budget of every run, in the order given. req is not modified.
+//
+// Versions GetOrDefault does not know are reported in their entry's Error
+// without running the simulator, and so are runs the simulator rejects, so
+// one bad version does not hide the others. Only a failure to run the
+// simulator at all is returned as an error.
+func CompareProtocols(runner RunnerInterface, req *SimulationRequest, versions []uint32) ([]ProtocolBudget, error) {
+	out := make([]ProtocolBudget, len(versions))
+	var batch []*SimulationRequest
+	var slots []int
+
+	for i, v := range versions {
+		out[i].Version = v
+		if _, err := GetOrDefault(&v); err != nil {
+			out[i].Status = StatusError
+			out[i].Error = err.Error()
+			continue
+		}
+
+		r := *req
+		r.ProtocolVersion = &v
+		// The runner writes the protocol's limits into CustomAuthCfg, so
+		// each run needs its own copy.
+		r.CustomAuthCfg = maps.Clone(req.CustomAuthCfg)
+		batch = append(batch, &r)
+		slots = append(slots, i)
+	}
+	if len(batch) == 0 {
+		return out, nil
+	}
+
+	responses, err := runner.RunBatch(batch)
+	if err != nil {
+		return nil, err
+	}
+	for j, resp := range responses {
+		i := slots[j]
+		if resp == nil {
+			out[i].Status = StatusError
+			out[i].Error = "simulator returned no response"
+			continue
+		}
+		out[i].Status = resp.Status
+		out[i].Error = resp.Error
+		out[i].BudgetUsage = resp.BudgetUsage
+	}
+	return out, nil
+}
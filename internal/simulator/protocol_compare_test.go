@@ -0,0 +1,49 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareProtocols(t *testing.T) {
+	runner := NewMockRunner(func(req *SimulationRequest) (*SimulationResponse, error) {
+		v := *req.ProtocolVersion
+		if v == 21 {
+			return nil, fmt.Errorf("protocol 21 rejected")
+		}
+		return &SimulationResponse{
+			Status:      StatusSuccess,
+			BudgetUsage: &BudgetUsage{CPUInstructions: uint64(v) * 1000, MemoryBytes: uint64(v) * 10, OperationsCount: 1},
+		}, nil
+	})
+
+	req := &SimulationRequest{EnvelopeXdr: "AAAA", CustomAuthCfg: map[string]interface{}{"k": "v"}}
+	got, err := CompareProtocols(runner, req, []uint32{20, 99, 21, 22})
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+
+	assert.Equal(t, uint32(20), got[0].Version)
+	assert.Equal(t, StatusSuccess, got[0].Status)
+	require.NotNil(t, got[0].BudgetUsage)
+	assert.Equal(t, uint64(20000), got[0].BudgetUsage.CPUInstructions)
+
+	assert.Equal(t, uint32(99), got[1].Version)
+	assert.Equal(t, StatusError, got[1].Status)
+	assert.Contains(t, got[1].Error, "unsupported protocol")
+
+	assert.Equal(t, StatusError, got[2].Status)
+	assert.Equal(t, "protocol 21 rejected", got[2].Error)
+	assert.Nil(t, got[2].BudgetUsage)
+
+	require.NotNil(t, got[3].BudgetUsage)
+	assert.Equal(t, uint64(220), got[3].BudgetUsage.MemoryBytes)
+
+	assert.Nil(t, req.ProtocolVersion, "request is not modified")
+	assert.Equal(t, map[string]interface{}{"k": "v"}, req.CustomAuthCfg)
+}
@@ -4,7 +4,10 @@
 package simulator
 
 import (
+	"errors"
 	"testing"
+
+	erstErrors "github.com/dotandev/hintents/internal/errors"
 )
 
 func TestLatestVersion(t *testing.T) {
@@ -40,18 +43,59 @@ func TestGet(t *testing.T) {
 }
 
 func TestGetOrDefault(t *testing.T) {
-	p := GetOrDefault(nil)
+	p, err := GetOrDefault(nil)
+	if err != nil {
+		t.Fatalf("GetOrDefault(nil) error = %v", err)
+	}
 	if p.Version != LatestVersion() {
 		t.Errorf("expected default version %d, got %d", LatestVersion(), p.Version)
 	}
 
 	v := uint32(20)
-	p = GetOrDefault(&v)
+	p, err = GetOrDefault(&v)
+	if err != nil {
+		t.Fatalf("GetOrDefault(20) error = %v", err)
+	}
 	if p.Version != 20 {
 		t.Errorf("expected version 20, got %d", p.Version)
 	}
 }
 
+func TestGetOrDefault_UnsupportedVersion(t *testing.T) {
+	for _, v := range []uint32{19, 99} {
+		v := v
+		p, err := GetOrDefault(&v)
+		if !errors.Is(err, erstErrors.ErrUnsupportedProtocol) {
+			t.Errorf("GetOrDefault(%d) error = %v, want ErrUnsupportedProtocol", v, err)
+		}
+		if p != nil {
+			t.Errorf("GetOrDefault(%d) returned protocol %d, want nil", v, p.Version)
+		}
+	}
+}
+
+func TestApplyProtocolConfig_UnsupportedVersion(t *testing.T) {
+	r := &Runner{}
+	req := &SimulationRequest{}
+
+	err := r.applyProtocolConfig(req, &Protocol{Version: 99})
+	if !errors.Is(err, erstErrors.ErrUnsupportedProtocol) {
+		t.Errorf("applyProtocolConfig error = %v, want ErrUnsupportedProtocol", err)
+	}
+	if req.CustomAuthCfg != nil {
+		t.Errorf("expected request to be left untouched, got %v", req.CustomAuthCfg)
+	}
+}
+
+func TestRunner_RejectsUnsupportedProtocol(t *testing.T) {
+	r := &Runner{}
+	v := uint32(99)
+	_, err := r.prepare(&SimulationRequest{EnvelopeXdr: "AAAA", ResultMetaXdr: "AAAA", ProtocolVersion: &v})
+	if !errors.Is(err, erstErrors.ErrUnsupportedProtocol) {
+		t.Errorf("prepare error = %v, want ErrUnsupportedProtocol", err)
+	}
+}
+
 func TestFeature(t *testing.T) {
 	tests := []struct {
 		version uint32
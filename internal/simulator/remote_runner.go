@@ -0,0 +1,91 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// RemoteRunner simulates transactions with a Soroban RPC server's
+// simulateTransaction method instead of the local erst-sim binary. Requests
+// go through the rpc.Client's HTTP client, so they are retried with the same
+// RetryConfig as every other RPC call.
+type RemoteRunner struct {
+	client *rpc.Client
+}
+
+var _ RunnerInterface = (*RemoteRunner)(nil)
+
+// NewRemoteRunner creates a RemoteRunner backed by client.
+func NewRemoteRunner(client *rpc.Client) *RemoteRunner {
+	return &RemoteRunner{client: client}
+}
+
+// Run simulates req.EnvelopeXdr against the server's current ledger state.
+// Ledger entries, timestamps and other local-only request fields are ignored.
+func (r *RemoteRunner) Run(req *SimulationRequest) (*SimulationResponse, error) {
+	if req.EnvelopeXdr == "" {
+		return nil, fmt.Errorf("remote simulation requires an envelope")
+	}
+
+	resp, err := r.client.SimulateTransaction(context.Background(), req.EnvelopeXdr)
+	if err != nil {
+		return nil, fmt.Errorf("remote simulation failed: %w", err)
+	}
+
+	result := &SimulationResponse{
+		Status:           StatusSuccess,
+		Events:           resp.Result.Events,
+		DiagnosticEvents: decodeRPCEvents(resp.Result.Events),
+	}
+	if resp.Result.Error != "" {
+		result.Status = StatusError
+		result.Error = resp.Result.Error
+	}
+
+	cpu := resp.Result.Cost.CpuInsns
+	if cpu == 0 {
+		cpu = resp.Result.Cost.CpuInsns_
+	}
+	mem := resp.Result.Cost.MemBytes
+	if mem == 0 {
+		mem = resp.Result.Cost.MemBytes_
+	}
+	if cpu > 0 || mem > 0 {
+		result.BudgetUsage = &BudgetUsage{
+			CPUInstructions: uint64(cpu),
+			MemoryBytes:     uint64(mem),
+		}
+	}
+	return result, nil
+}
+
+// decodeRPCEvents converts the base64 DiagnosticEvent XDR the RPC returns
+// into structured events. Events that fail to decode are left out; the raw
+// strings stay available in Events.
+func decodeRPCEvents(raw []string) []DiagnosticEvent {
+	var out []DiagnosticEvent
+	for _, b64 := range raw {
+		var de xdr.DiagnosticEvent
+		if err := xdr.SafeUnmarshalBase64(b64, &de); err != nil {
+			continue
+		}
+		ev, err := convertContractEvent(de.Event, de.InSuccessfulContractCall)
+		if err != nil {
+			continue
+		}
+		out = append(out, ev)
+	}
+	return out
+}
+
+// RunBatch simulates each request with its own RPC call; the server has no
+// batch method.
+func (r *RemoteRunner) RunBatch(reqs []*SimulationRequest) ([]*SimulationResponse, error) {
+	return RunEach(r.Run, reqs), nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteRunner_RetriesTransient503(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		attempt := len(bodies)
+		mu.Unlock()
+
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result": map[string]interface{}{
+				"minResourceFee": "100",
+				"cost":           map[string]int64{"cpuInsns": 1234, "memBytes": 56},
+			},
+		})
+	}))
+	defer server.Close()
+
+	cfg := rpc.DefaultRetryConfig()
+	cfg.InitialBackoff = 10 * time.Millisecond
+	cfg.MaxBackoff = 20 * time.Millisecond
+
+	client, err := rpc.NewClient(
+		rpc.WithNetwork(rpc.Testnet),
+		rpc.WithSorobanURL(server.URL),
+		rpc.WithHTTPClient(rpc.WithRetry(server.Client(), cfg)),
+	)
+	require.NoError(t, err)
+
+	resp, err := NewRemoteRunner(client).Run(&SimulationRequest{EnvelopeXdr: "AAAA"})
+	require.NoError(t, err)
+	require.Equal(t, StatusSuccess, resp.Status)
+	require.NotNil(t, resp.BudgetUsage)
+	require.Equal(t, uint64(1234), resp.BudgetUsage.CPUInstructions)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, bodies, 2)
+	// The retried request must carry the same JSON-RPC payload.
+	require.Equal(t, bodies[0], bodies[1])
+	require.Contains(t, bodies[1], "simulateTransaction")
+}
+
+func TestRemoteRunner_SimulationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  map[string]interface{}{"error": "HostError: Error(Contract, #1)"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	resp, err := NewRemoteRunner(client).Run(&SimulationRequest{EnvelopeXdr: "AAAA"})
+	require.NoError(t, err)
+	require.Equal(t, StatusError, resp.Status)
+	require.Contains(t, resp.Error, "Contract")
+}
+
+func TestDecodeRPCEvents(t *testing.T) {
+	de := xdr.DiagnosticEvent{
+		InSuccessfulContractCall: true,
+		Event:                    contractEvent(xdr.ContractId{0x01}, xdr.ContractEventTypeContract, "transfer", 9),
+	}
+	b64, err := xdr.MarshalBase64(de)
+	require.NoError(t, err)
+
+	events := decodeRPCEvents([]string{b64, "not-xdr"})
+	require.Len(t, events, 1)
+	require.Equal(t, "contract", events[0].EventType)
+	require.Equal(t, []string{`{"symbol":"transfer"}`}, events[0].Topics)
+	require.True(t, events[0].InSuccessfulContractCall)
+}
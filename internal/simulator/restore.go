@@ -0,0 +1,266 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/analytics"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// FootprintEntryState is the TTL information known for a footprint entry.
+type FootprintEntryState struct {
+	EntryXdr        string
+	LiveUntilLedger uint32
+}
+
+// ArchivedEntry is a persistent footprint entry whose TTL has expired. It
+// must be restored with a RestoreFootprint operation before the transaction
+// can access it.
+type ArchivedEntry struct {
+	Key             string `json:"key"`
+	LiveUntilLedger uint32 `json:"live_until_ledger"`
+	SizeBytes       int    `json:"size_bytes"`
+}
+
+// RestoreFeeModel holds the network fee parameters used to price a restore.
+type RestoreFeeModel struct {
+	FeePerReadEntry  int64
+	FeePerWriteEntry int64
+	FeePerRead1KB    int64
+	FeePerWrite1KB   int64
+	// RentFee1KB is the rent write fee per KiB, see
+	// analytics.RentFeeModel.
+	RentFee1KB                    int64
+	PersistentRentRateDenominator int64
+	// MinPersistentTTL is the TTL (in ledgers) a restored entry receives.
+	MinPersistentTTL uint32
+}
+
+// DefaultRestoreFeeModel approximates the public network settings from
+// analytics.DefaultFeeModel. Exact values should come from the network's
+// ConfigSetting entries, see NewRestoreFeeModel.
+var DefaultRestoreFeeModel = NewRestoreFeeModel(analytics.DefaultFeeModel, analytics.DefaultMinPersistentTTL)
+
+// NewRestoreFeeModel takes the rates a restore pays from the network's fee
+// model and the TTL a restored entry receives. The live state size is not
+// known here, so rent is priced at the higher bound of the rent write fee.
+func NewRestoreFeeModel(fees analytics.FeeModel, minPersistentTTL uint32) RestoreFeeModel {
+	return RestoreFeeModel{
+		RentFee1KB:                    max(fees.RentFee1KBLow, fees.RentFee1KBHigh),
+		FeePerReadEntry:               fees.FeePerDiskReadEntry,
+		FeePerWriteEntry:              fees.FeePerWriteEntry,
+		FeePerRead1KB:                 fees.FeePerDiskRead1KB,
+		FeePerWrite1KB:                fees.FeePerWrite1KB,
+		PersistentRentRateDenominator: fees.PersistentRentRateDenominator,
+		MinPersistentTTL:              minPersistentTTL,
+	}
+}
+
+// RestoreCost is the estimated resource usage and fee of restoring a set of
+// archived entries. Fees are in stroops.
+type RestoreCost struct {
+	Entries    int   `json:"entries"`
+	ReadBytes  int   `json:"read_bytes"`
+	WriteBytes int   `json:"write_bytes"`
+	EntryFee   int64 `json:"entry_fee"`
+	ReadFee    int64 `json:"read_fee"`
+	WriteFee   int64 `json:"write_fee"`
+	RentFee    int64 `json:"rent_fee"`
+	TotalFee   int64 `json:"total_fee"`
+}
+
+// FootprintKeys returns the read-only and read-write ledger keys declared in
+// the Soroban transaction data of a base64 TransactionEnvelope. Classic
+// transactions return no keys.
+func FootprintKeys(envelopeXdr string) ([]string, error) {
+	tx, err := sorobanTx(envelopeXdr)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Ext.V != 1 || tx.Ext.SorobanData == nil {
+		return nil, nil
+	}
+
+	fp := tx.Ext.SorobanData.Resources.Footprint
+	keys := make([]string, 0, len(fp.ReadOnly)+len(fp.ReadWrite))
+	for _, k := range append(append([]xdr.LedgerKey{}, fp.ReadOnly...), fp.ReadWrite...) {
+		b, err := k.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode footprint key: %w", err)
+		}
+		keys = append(keys, base64.StdEncoding.EncodeToString(b))
+	}
+	return keys, nil
+}
+
+// DetectArchivedEntries returns the footprint entries that are archived as of
+// currentLedger. Only persistent entries (contract code and persistent
+// contract data) can be archived; expired temporary entries are gone for good
+// and keys without a known state are skipped.
+func DetectArchivedEntries(keys []string, states map[string]FootprintEntryState, currentLedger uint32) []ArchivedEntry {
+	var archived []ArchivedEntry
+	for _, key := range keys {
+		state, ok := states[key]
+		if !ok || state.LiveUntilLedger == 0 || state.LiveUntilLedger >= currentLedger {
+			continue
+		}
+		if !isPersistentKey(key) {
+			continue
+		}
+
+		size := 0
+		if raw, err := base64.StdEncoding.DecodeString(state.EntryXdr); err == nil {
+			size = len(raw)
+		}
+		archived = append(archived, ArchivedEntry{
+			Key:             key,
+			LiveUntilLedger: state.LiveUntilLedger,
+			SizeBytes:       size,
+		})
+	}
+	return archived
+}
+
+// EstimateRestoreCost prices a RestoreFootprint operation for the given
+// entries. Each entry is read and rewritten, and pays rent for the minimum
+// persistent TTL, see analytics.CalculateRentFee.
+func EstimateRestoreCost(entries []ArchivedEntry, model RestoreFeeModel) RestoreCost {
+	rent := analytics.RentFeeModel{
+		RentFee1KB:          model.RentFee1KB,
+		RentRateDenominator: model.PersistentRentRateDenominator,
+	}
+	cost := RestoreCost{Entries: len(entries)}
+	for _, e := range entries {
+		cost.ReadBytes += e.SizeBytes
+		cost.WriteBytes += e.SizeBytes
+		cost.RentFee += analytics.CalculateRentFee(int64(e.SizeBytes), 0, model.MinPersistentTTL, rent)
+	}
+
+	n := int64(len(entries))
+	cost.EntryFee = n * (model.FeePerReadEntry + model.FeePerWriteEntry)
+	cost.ReadFee = ceilDiv(int64(cost.ReadBytes)*model.FeePerRead1KB, 1024)
+	cost.WriteFee = ceilDiv(int64(cost.WriteBytes)*model.FeePerWrite1KB, 1024)
+	cost.TotalFee = cost.EntryFee + cost.ReadFee + cost.WriteFee + cost.RentFee
+	return cost
+}
+
+// BuildRestoreRequest creates a simulation request for a RestoreFootprint
+// transaction that restores the archived entries ahead of the original
+// transaction. It reuses the source account and sequence number of the
+// original envelope and carries the archived entries as ledger state.
+func BuildRestoreRequest(original *SimulationRequest, entries []ArchivedEntry, model RestoreFeeModel) (*SimulationRequest, error) {
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no archived entries to restore")
+	}
+
+	tx, err := sorobanTx(original.EnvelopeXdr)
+	if err != nil {
+		return nil, err
+	}
+
+	readWrite := make([]xdr.LedgerKey, 0, len(entries))
+	for _, e := range entries {
+		var key xdr.LedgerKey
+		if err := xdr.SafeUnmarshalBase64(e.Key, &key); err != nil {
+			return nil, fmt.Errorf("failed to decode archived key: %w", err)
+		}
+		readWrite = append(readWrite, key)
+	}
+
+	cost := EstimateRestoreCost(entries, model)
+	restoreTx := xdr.Transaction{
+		SourceAccount: tx.SourceAccount,
+		Fee:           tx.Fee,
+		SeqNum:        tx.SeqNum,
+		Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+		Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+		Operations: []xdr.Operation{{
+			Body: xdr.OperationBody{
+				Type:               xdr.OperationTypeRestoreFootprint,
+				RestoreFootprintOp: &xdr.RestoreFootprintOp{Ext: xdr.ExtensionPoint{V: 0}},
+			},
+		}},
+		Ext: xdr.TransactionExt{
+			V: 1,
+			SorobanData: &xdr.SorobanTransactionData{
+				Resources: xdr.SorobanResources{
+					Footprint:     xdr.LedgerFootprint{ReadWrite: readWrite},
+					DiskReadBytes: xdr.Uint32(cost.ReadBytes),
+					WriteBytes:    xdr.Uint32(cost.WriteBytes),
+				},
+				ResourceFee: xdr.Int64(cost.TotalFee),
+			},
+		},
+	}
+
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1:   &xdr.TransactionV1Envelope{Tx: restoreTx},
+	}
+	envXdr, err := xdr.MarshalBase64(env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode restore envelope: %w", err)
+	}
+
+	ledgerEntries := make(map[string]string, len(entries))
+	for _, e := range entries {
+		if v, ok := original.LedgerEntries[e.Key]; ok {
+			ledgerEntries[e.Key] = v
+		}
+	}
+
+	return &SimulationRequest{
+		EnvelopeXdr:     envXdr,
+		ResultMetaXdr:   original.ResultMetaXdr,
+		LedgerEntries:   ledgerEntries,
+		Timestamp:       original.Timestamp,
+		LedgerSequence:  original.LedgerSequence,
+		ProtocolVersion: original.ProtocolVersion,
+	}, nil
+}
+
+func sorobanTx(envelopeXdr string) (xdr.Transaction, error) {
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXdr, &env); err != nil {
+		return xdr.Transaction{}, fmt.Errorf("failed to decode envelope: %w", err)
+	}
+
+	switch env.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		return env.MustV1().Tx, nil
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		inner := env.MustFeeBump().Tx.InnerTx
+		if inner.Type != xdr.EnvelopeTypeEnvelopeTypeTx {
+			return xdr.Transaction{}, fmt.Errorf("unsupported inner tx type: %s", inner.Type)
+		}
+		return inner.MustV1().Tx, nil
+	default:
+		return xdr.Transaction{}, fmt.Errorf("unsupported envelope type: %s", env.Type)
+	}
+}
+
+func isPersistentKey(keyB64 string) bool {
+	var key xdr.LedgerKey
+	if err := xdr.SafeUnmarshalBase64(keyB64, &key); err != nil {
+		return false
+	}
+	switch key.Type {
+	case xdr.LedgerEntryTypeContractCode:
+		return true
+	case xdr.LedgerEntryTypeContractData:
+		return key.ContractData != nil && key.ContractData.Durability == xdr.ContractDataDurabilityPersistent
+	default:
+		return false
+	}
+}
+
+func ceilDiv(a, b int64) int64 {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+	return (a + b - 1) / b
+}
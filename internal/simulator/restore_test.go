@@ -0,0 +1,189 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/analytics"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func contractDataKey(t *testing.T, sym string, durability xdr.ContractDataDurability) xdr.LedgerKey {
+	t.Helper()
+	cid := xdr.ContractId{0xAA}
+	s := xdr.ScSymbol(sym)
+	return xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &cid},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &s},
+			Durability: durability,
+		},
+	}
+}
+
+func encodeKey(t *testing.T, k xdr.LedgerKey) string {
+	t.Helper()
+	s, err := xdr.MarshalBase64(k)
+	require.NoError(t, err)
+	return s
+}
+
+func sorobanEnvelope(t *testing.T, readOnly, readWrite []xdr.LedgerKey) string {
+	t.Helper()
+	src, err := xdr.NewMuxedAccount(xdr.CryptoKeyTypeKeyTypeEd25519, xdr.Uint256{0x01})
+	require.NoError(t, err)
+
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx: xdr.Transaction{
+				SourceAccount: src,
+				Fee:           100,
+				SeqNum:        42,
+				Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+				Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+				Operations: []xdr.Operation{{
+					Body: xdr.OperationBody{
+						Type:                 xdr.OperationTypeExtendFootprintTtl,
+						ExtendFootprintTtlOp: &xdr.ExtendFootprintTtlOp{ExtendTo: 10},
+					},
+				}},
+				Ext: xdr.TransactionExt{
+					V: 1,
+					SorobanData: &xdr.SorobanTransactionData{
+						Resources: xdr.SorobanResources{
+							Footprint: xdr.LedgerFootprint{ReadOnly: readOnly, ReadWrite: readWrite},
+						},
+					},
+				},
+			},
+		},
+	}
+	s, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+	return s
+}
+
+func TestDetectArchivedEntries_IdentifiesAndCostsRestore(t *testing.T) {
+	archivedKey := contractDataKey(t, "balance", xdr.ContractDataDurabilityPersistent)
+	liveKey := contractDataKey(t, "admin", xdr.ContractDataDurabilityPersistent)
+	tempKey := contractDataKey(t, "nonce", xdr.ContractDataDurabilityTemporary)
+
+	envXdr := sorobanEnvelope(t, []xdr.LedgerKey{liveKey}, []xdr.LedgerKey{archivedKey, tempKey})
+
+	keys, err := FootprintKeys(envXdr)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+
+	entryXdr := base64.StdEncoding.EncodeToString(make([]byte, 2048))
+	states := map[string]FootprintEntryState{
+		encodeKey(t, archivedKey): {EntryXdr: entryXdr, LiveUntilLedger: 900},
+		encodeKey(t, liveKey):     {EntryXdr: entryXdr, LiveUntilLedger: 5000},
+		encodeKey(t, tempKey):     {EntryXdr: entryXdr, LiveUntilLedger: 500},
+	}
+
+	archived := DetectArchivedEntries(keys, states, 1000)
+	require.Len(t, archived, 1, "only the expired persistent entry needs a restore")
+	assert.Equal(t, encodeKey(t, archivedKey), archived[0].Key)
+	assert.Equal(t, uint32(900), archived[0].LiveUntilLedger)
+	assert.Equal(t, 2048, archived[0].SizeBytes)
+
+	model := RestoreFeeModel{
+		FeePerReadEntry:               100,
+		FeePerWriteEntry:              200,
+		FeePerRead1KB:                 10,
+		FeePerWrite1KB:                20,
+		RentFee1KB:                    30,
+		PersistentRentRateDenominator: 1000,
+		MinPersistentTTL:              5000,
+	}
+	cost := EstimateRestoreCost(archived, model)
+	assert.Equal(t, 1, cost.Entries)
+	assert.Equal(t, int64(300), cost.EntryFee)
+	assert.Equal(t, int64(20), cost.ReadFee)
+	assert.Equal(t, int64(40), cost.WriteFee)
+	// 2048 bytes * 30 * 5000 / (1024 * 1000) = 300
+	assert.Equal(t, int64(300), cost.RentFee)
+	assert.Equal(t, analytics.CalculateRentFee(2048, 0, 5000, analytics.RentFeeModel{RentFee1KB: 30, RentRateDenominator: 1000}), cost.RentFee)
+	assert.Equal(t, int64(660), cost.TotalFee)
+}
+
+func TestBuildRestoreRequest(t *testing.T) {
+	archivedKey := contractDataKey(t, "balance", xdr.ContractDataDurabilityPersistent)
+	keyB64 := encodeKey(t, archivedKey)
+	envXdr := sorobanEnvelope(t, nil, []xdr.LedgerKey{archivedKey})
+
+	original := &SimulationRequest{
+		EnvelopeXdr:   envXdr,
+		ResultMetaXdr: "meta",
+		LedgerEntries: map[string]string{keyB64: "entry", "other": "x"},
+		Timestamp:     7,
+	}
+	archived := []ArchivedEntry{{Key: keyB64, SizeBytes: 100}}
+
+	req, err := BuildRestoreRequest(original, archived, DefaultRestoreFeeModel)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{keyB64: "entry"}, req.LedgerEntries)
+	assert.Equal(t, int64(7), req.Timestamp)
+
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(req.EnvelopeXdr, &env))
+	tx := env.MustV1().Tx
+	require.Len(t, tx.Operations, 1)
+	assert.Equal(t, xdr.OperationTypeRestoreFootprint, tx.Operations[0].Body.Type)
+	assert.Equal(t, xdr.SequenceNumber(42), tx.SeqNum)
+	require.NotNil(t, tx.Ext.SorobanData)
+	assert.Len(t, tx.Ext.SorobanData.Resources.Footprint.ReadWrite, 1)
+	assert.Empty(t, tx.Ext.SorobanData.Resources.Footprint.ReadOnly)
+
+	_, err = BuildRestoreRequest(original, nil, DefaultRestoreFeeModel)
+	assert.Error(t, err)
+}
+
+func TestFootprintKeys_ClassicTransaction(t *testing.T) {
+	src, err := xdr.NewMuxedAccount(xdr.CryptoKeyTypeKeyTypeEd25519, xdr.Uint256{0x01})
+	require.NoError(t, err)
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{Tx: xdr.Transaction{
+			SourceAccount: src,
+			Cond:          xdr.Preconditions{Type: xdr.PreconditionTypePrecondNone},
+			Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+			Operations: []xdr.Operation{{Body: xdr.OperationBody{
+				Type:           xdr.OperationTypeBumpSequence,
+				BumpSequenceOp: &xdr.BumpSequenceOp{BumpTo: 1},
+			}}},
+		}},
+	}
+	envXdr, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	keys, err := FootprintKeys(envXdr)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestDefaultRestoreFeeModel_UsesAnalyticsDefaults(t *testing.T) {
+	fees := analytics.DefaultFeeModel
+	assert.Equal(t, RestoreFeeModel{
+		FeePerReadEntry:               fees.FeePerDiskReadEntry,
+		FeePerWriteEntry:              fees.FeePerWriteEntry,
+		FeePerRead1KB:                 fees.FeePerDiskRead1KB,
+		FeePerWrite1KB:                fees.FeePerWrite1KB,
+		RentFee1KB:                    fees.RentFee1KBHigh,
+		PersistentRentRateDenominator: fees.PersistentRentRateDenominator,
+		MinPersistentTTL:              analytics.DefaultMinPersistentTTL,
+	}, DefaultRestoreFeeModel)
+}
+
+func TestNewRestoreFeeModel_RentAtHigherBound(t *testing.T) {
+	model := NewRestoreFeeModel(analytics.FeeModel{FeePerWrite1KB: 11800, RentFee1KBLow: 500, RentFee1KBHigh: 4000}, 100)
+	assert.Equal(t, int64(4000), model.RentFee1KB)
+	assert.Equal(t, int64(11800), model.FeePerWrite1KB)
+}
@@ -5,19 +5,27 @@ package simulator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
 	"github.com/dotandev/hintents/internal/logger"
 )
 
+// DefaultTimeout bounds a simulation run when Runner.Timeout is zero.
+const DefaultTimeout = 30 * time.Second
+
 // Runner handles the execution of the Rust simulator binary
 type Runner struct {
 	BinaryPath string
 	Debug      bool
+	// Timeout bounds each run; the process is killed once it expires.
+	// Zero means DefaultTimeout and a negative value disables the limit.
+	Timeout time.Duration
 }
 
 // Compile-time check to ensure Runner implements RunnerInterface
@@ -30,7 +38,12 @@ var _ RunnerInterface = (*Runner)(nil)
 // 3. Local directory
 // 4. Dev target
 // 5. Global PATH
-func NewRunner(simPathOverride string, debug bool) (*Runner, error) {
+func NewRunner(simPathOverride string, debug bool, opts ...RunnerOption) (*Runner, error) {
+	var cfg runnerOptions
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	path, source, err := findSimBinary(simPathOverride)
 	if err != nil {
 		return nil, err
@@ -44,10 +57,37 @@ func NewRunner(simPathOverride string, debug bool) (*Runner, error) {
 		)
 	}
 
-	return &Runner{
+	r := &Runner{
 		BinaryPath: path,
 		Debug:      debug,
-	}, nil
+		Timeout:    DefaultTimeout,
+	}
+
+	if cfg.minVersion != "" {
+		if err := r.CheckCompatibility(cfg.minVersion); err != nil {
+			logger.Logger.Warn("Simulator binary may be incompatible with this CLI",
+				"path", path,
+				"error", err,
+			)
+		}
+	}
+
+	return r, nil
+}
+
+// RunnerOption configures NewRunner.
+type RunnerOption func(*runnerOptions)
+
+type runnerOptions struct {
+	minVersion string
+}
+
+// WithMinVersion makes NewRunner check the binary against minVersion and
+// log a warning when it is older. The runner is returned either way.
+func WithMinVersion(minVersion string) RunnerOption {
+	return func(o *runnerOptions) {
+		o.minVersion = minVersion
+	}
 }
 
 // -------------------- Binary Discovery --------------------
@@ -122,16 +162,18 @@ func abs(path string) string {
 
 // -------------------- Execution --------------------
 
+// Run simulates req with the runner's timeout. It is RunContext with a
+// background context.
 func (r *Runner) Run(req *SimulationRequest) (*SimulationResponse, error) {
-	proto := GetOrDefault(req.ProtocolVersion)
-
-	if req.ProtocolVersion != nil {
-		if err := Validate(*req.ProtocolVersion); err != nil {
-			return nil, err
-		}
-	}
+	return r.RunContext(context.Background(), req)
+}
 
-	if err := r.applyProtocolConfig(req, proto); err != nil {
+// RunContext simulates req, killing the simulator process when ctx is
+// cancelled or the runner's timeout expires. The returned error then wraps
+// context.Canceled or context.DeadlineExceeded.
+func (r *Runner) RunContext(ctx context.Context, req *SimulationRequest) (*SimulationResponse, error) {
+	proto, err := r.prepare(req)
+	if err != nil {
 		return nil, err
 	}
 
@@ -141,34 +183,172 @@ func (r *Runner) Run(req *SimulationRequest) (*SimulationResponse, error) {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	cmd := exec.Command(r.BinaryPath)
-	cmd.Stdin = bytes.NewReader(inputBytes)
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	logger.Logger.Debug("Simulation request prepared",
+		"request_bytes", len(inputBytes),
+		"ledger_entries", len(req.LedgerEntries),
+	)
+	logger.Logger.Debug("Simulation request payload",
+		"envelope_xdr", req.EnvelopeXdr,
+		"result_meta_xdr", req.ResultMetaXdr,
+	)
 
-	if err := cmd.Run(); err != nil {
-		logger.Logger.Error("Simulator execution failed", "error", err, "stderr", stderr.String())
-		return nil, fmt.Errorf("simulator execution failed: %w, stderr: %s", err, stderr.String())
+	output, err := r.exec(ctx, inputBytes, r.timeout())
+	if err != nil {
+		return nil, err
 	}
 
 	var resp SimulationResponse
-	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+	if err := json.Unmarshal(output, &resp); err != nil {
 		logger.Logger.Error("Failed to unmarshal response", "error", err)
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	resp.ProtocolVersion = &proto.Version
 
-	if resp.Status == "error" {
-		return nil, fmt.Errorf("simulation error: %s", resp.Error)
+	if err := checkStatus(&resp); err != nil {
+		return nil, err
 	}
 
 	return &resp, nil
 }
 
+// RunBatch simulates reqs in a single simulator invocation and returns one
+// response per request, in order. A request that fails does not abort the
+// batch: its slot carries a response with Error set. The timeout scales
+// with the number of requests.
+func (r *Runner) RunBatch(reqs []*SimulationRequest) ([]*SimulationResponse, error) {
+	return r.RunBatchContext(context.Background(), reqs)
+}
+
+// RunBatchContext is RunBatch bounded by ctx.
+func (r *Runner) RunBatchContext(ctx context.Context, reqs []*SimulationRequest) ([]*SimulationResponse, error) {
+	out := make([]*SimulationResponse, len(reqs))
+	protos := make([]*Protocol, len(reqs))
+	var batch []*SimulationRequest
+	var slots []int
+
+	for i, req := range reqs {
+		proto, err := r.prepare(req)
+		if err != nil {
+			out[i] = &SimulationResponse{Status: StatusError, Error: err.Error()}
+			continue
+		}
+		protos[i] = proto
+		batch = append(batch, req)
+		slots = append(slots, i)
+	}
+	if len(batch) == 0 {
+		return out, nil
+	}
+
+	inputBytes, err := json.Marshal(batch)
+	if err != nil {
+		logger.Logger.Error("Failed to marshal simulation batch", "error", err)
+		return nil, fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	logger.Logger.Debug("Simulation batch prepared",
+		"requests", len(batch),
+		"request_bytes", len(inputBytes),
+	)
+
+	timeout := r.timeout()
+	if timeout > 0 {
+		timeout *= time.Duration(len(batch))
+	}
+	output, err := r.exec(ctx, inputBytes, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*SimulationResponse
+	if err := json.Unmarshal(output, &responses); err != nil {
+		logger.Logger.Error("Failed to unmarshal batch response", "error", err)
+		return nil, fmt.Errorf("failed to unmarshal batch response: %w", err)
+	}
+	if len(responses) != len(batch) {
+		return nil, fmt.Errorf("simulator returned %d responses for %d requests", len(responses), len(batch))
+	}
+
+	for j, resp := range responses {
+		i := slots[j]
+		if resp == nil {
+			resp = &SimulationResponse{Status: StatusError, Error: "simulator returned no response"}
+		}
+		resp.ProtocolVersion = &protos[i].Version
+		if err := checkStatus(resp); err != nil && resp.Error == "" {
+			resp.Error = err.Error()
+		}
+		out[i] = resp
+	}
+	return out, nil
+}
+
+// prepare validates req and its protocol and applies the protocol's limits.
+func (r *Runner) prepare(req *SimulationRequest) (*Protocol, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	proto, err := GetOrDefault(req.ProtocolVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.applyProtocolConfig(req, proto); err != nil {
+		return nil, err
+	}
+	return proto, nil
+}
+
+// timeout resolves the runner's Timeout, applying DefaultTimeout when unset.
+func (r *Runner) timeout() time.Duration {
+	if r.Timeout == 0 {
+		return DefaultTimeout
+	}
+	return r.Timeout
+}
+
+// exec runs the simulator binary with input on stdin and returns its stdout.
+// A non-positive timeout leaves the run bounded by ctx alone.
+func (r *Runner) exec(ctx context.Context, input []byte, timeout time.Duration) ([]byte, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, r.BinaryPath)
+	cmd.Stdin = bytes.NewReader(input)
+	// Stop waiting on output pipes shortly after the kill, in case the
+	// simulator left a child process holding them open.
+	cmd.WaitDelay = time.Second
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			logger.Logger.Error("Simulator execution aborted", "error", ctxErr)
+			return nil, fmt.Errorf("simulator execution aborted: %w", ctxErr)
+		}
+		logger.Logger.Error("Simulator execution failed", "error", err, "stderr", stderr.String())
+		return nil, fmt.Errorf("simulator execution failed: %w, stderr: %s", err, stderr.String())
+	}
+
+	logger.Logger.Debug("Simulation response received", "response_bytes", stdout.Len())
+	return stdout.Bytes(), nil
+}
+
 func (r *Runner) applyProtocolConfig(req *SimulationRequest, proto *Protocol) error {
+	if proto == nil {
+		return fmt.Errorf("no protocol to apply")
+	}
+	if err := Validate(proto.Version); err != nil {
+		return err
+	}
+
 	if req.CustomAuthCfg == nil {
 		req.CustomAuthCfg = make(map[string]interface{})
 	}
@@ -311,7 +311,10 @@ func BenchmarkProtocolConfigApplication(b *testing.B) {
 	}
 
 	protocolVersion := uint32(20)
-	proto := GetOrDefault(&protocolVersion)
+	proto, err := GetOrDefault(&protocolVersion)
+	if err != nil {
+		b.Fatal(err)
+	}
 
 	b.ResetTimer()
 	b.ReportAllocs()
@@ -505,3 +508,33 @@ func BenchmarkCompleteSimulationWorkflow(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkRunBatch compares spawning the simulator once per request with
+// sending the whole batch to a single invocation.
+func BenchmarkRunBatch(b *testing.B) {
+	path, _ := batchSimulator(b)
+	runner := &Runner{BinaryPath: path}
+
+	reqs := make([]*SimulationRequest, 10)
+	for i := range reqs {
+		reqs[i] = &SimulationRequest{EnvelopeXdr: strings.Repeat("e", 64)}
+	}
+
+	b.Run("PerRequest", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, req := range reqs {
+				if _, err := runner.Run(req); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("Batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := runner.RunBatch(reqs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
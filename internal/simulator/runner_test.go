@@ -0,0 +1,184 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSimulator writes an executable script that drains stdin and prints
+// the given JSON response.
+func fakeSimulator(t *testing.T, response string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake simulator script requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "erst-sim")
+	script := "#!/bin/sh\ncat > /dev/null\nprintf '%s' '" + response + "'\n"
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	return path
+}
+
+func TestRunner_Run_LogsRequestAndResponseSizes(t *testing.T) {
+	response := `{"status":"success","events":["e1"]}`
+	runner := &Runner{BinaryPath: fakeSimulator(t, response)}
+
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf, false)
+	logger.SetLevel(slog.LevelInfo)
+	t.Cleanup(func() { logger.SetOutput(os.Stderr, false) })
+
+	newReq := func() *SimulationRequest {
+		return &SimulationRequest{
+			EnvelopeXdr:    "AAAA",
+			ResultMetaXdr:  "BBBB",
+			LedgerEntries:  map[string]string{"k1": "v1", "k2": "v2"},
+			LedgerSequence: 100,
+		}
+	}
+	_, err := runner.Run(newReq())
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "Simulation request prepared", "sizes are only logged at debug level")
+
+	buf.Reset()
+	logger.SetLevel(slog.LevelDebug)
+	resp, err := runner.Run(newReq())
+	require.NoError(t, err)
+	assert.Equal(t, "success", resp.Status)
+
+	out := buf.String()
+	assert.Contains(t, out, "Simulation request prepared")
+	assert.Contains(t, out, "ledger_entries=2")
+	assert.Regexp(t, `request_bytes=\d+`, out)
+	assert.Contains(t, out, "Simulation response received")
+	assert.Contains(t, out, "response_bytes="+strconv.Itoa(len(response)))
+}
+
+func TestRunner_Run_Statuses(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		wantErr    string
+		wantStatus string
+		wantWarn   bool
+	}{
+		{name: "success", response: `{"status":"success"}`, wantStatus: "success"},
+		{name: "error", response: `{"status":"error","error":"HostError: budget"}`, wantErr: "simulation error: HostError: budget"},
+		{name: "reverted", response: `{"status":"reverted","logs":["panic"]}`, wantStatus: "reverted"},
+		{name: "timeout", response: `{"status":"timeout","error":"exceeded 30s"}`, wantErr: "simulation timed out: exceeded 30s"},
+		{name: "unknown", response: `{"status":"paused"}`, wantStatus: "paused", wantWarn: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &Runner{BinaryPath: fakeSimulator(t, tt.response)}
+
+			buf := &bytes.Buffer{}
+			logger.SetOutput(buf, false)
+			t.Cleanup(func() { logger.SetOutput(os.Stderr, false) })
+
+			resp, err := runner.Run(&SimulationRequest{EnvelopeXdr: "AAAA"})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.Status)
+			assert.Equal(t, tt.wantWarn, bytes.Contains(buf.Bytes(), []byte("unrecognized status")))
+		})
+	}
+}
+
+func TestRunner_Run_TimeoutIsSentinel(t *testing.T) {
+	runner := &Runner{BinaryPath: fakeSimulator(t, `{"status":"timeout"}`)}
+	_, err := runner.Run(&SimulationRequest{EnvelopeXdr: "AAAA"})
+	assert.ErrorIs(t, err, errors.ErrSimulationTimeout)
+}
+
+func TestDescribeStatus(t *testing.T) {
+	assert.Equal(t, "success", DescribeStatus(StatusSuccess))
+	assert.Contains(t, DescribeStatus(StatusReverted), "rolled back")
+	assert.Contains(t, DescribeStatus(StatusTimeout), "did not complete")
+	assert.Equal(t, "paused (unrecognized status)", DescribeStatus("paused"))
+	assert.False(t, IsKnownStatus("paused"))
+	assert.True(t, IsKnownStatus(StatusError))
+}
+
+func TestParseOutcome(t *testing.T) {
+	tests := []struct {
+		status string
+		want   Outcome
+	}{
+		{StatusSuccess, OutcomeSuccess},
+		{StatusError, OutcomeError},
+		{StatusReverted, OutcomeReverted},
+		{StatusTimeout, OutcomeTimeout},
+		{"", OutcomeUnknown},
+		{"paused", OutcomeUnknown},
+		{"SUCCESS", OutcomeUnknown},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ParseOutcome(tt.status), "status %q", tt.status)
+		assert.Equal(t, tt.want, (&SimulationResponse{Status: tt.status}).Outcome(), "status %q", tt.status)
+	}
+
+	assert.Equal(t, OutcomeUnknown, (*SimulationResponse)(nil).Outcome())
+	assert.Equal(t, StatusReverted, OutcomeReverted.String())
+	assert.Equal(t, "unknown", OutcomeUnknown.String())
+}
+
+// hangingSimulator writes an executable script that never answers.
+func hangingSimulator(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake simulator script requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "erst-sim")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\nexec sleep 30\n"), 0755))
+	return path
+}
+
+func TestRunner_Run_KillsHungSimulatorAfterTimeout(t *testing.T) {
+	runner := &Runner{BinaryPath: hangingSimulator(t), Timeout: 100 * time.Millisecond}
+
+	start := time.Now()
+	_, err := runner.Run(&SimulationRequest{EnvelopeXdr: "AAAA"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestRunner_RunContext_Cancelled(t *testing.T) {
+	runner := &Runner{BinaryPath: hangingSimulator(t)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := runner.RunContext(ctx, &SimulationRequest{EnvelopeXdr: "AAAA"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRunner_RunContext_CompletesWithinTimeout(t *testing.T) {
+	runner := &Runner{BinaryPath: fakeSimulator(t, `{"status":"success"}`), Timeout: 5 * time.Second}
+
+	resp, err := runner.RunContext(context.Background(), &SimulationRequest{EnvelopeXdr: "AAAA"})
+	require.NoError(t, err)
+	assert.Equal(t, "success", resp.Status)
+}
@@ -47,26 +47,30 @@ type DiagnosticEvent struct {
 
 // BudgetUsage represents resource consumption during simulation
 type BudgetUsage struct {
-	CPUInstructions    uint64  `json:"cpu_instructions"`
-	MemoryBytes        uint64  `json:"memory_bytes"`
-	OperationsCount    int     `json:"operations_count"`
-	CPULimit           uint64  `json:"cpu_limit"`
-	MemoryLimit        uint64  `json:"memory_limit"`
-	CPUUsagePercent    float64 `json:"cpu_usage_percent"`
-	MemoryUsagePercent float64 `json:"memory_usage_percent"`
+	CPUInstructions    uint64       `json:"cpu_instructions"`
+	MemoryBytes        uint64       `json:"memory_bytes"`
+	OperationsCount    int          `json:"operations_count"`
+	CPULimit           uint64       `json:"cpu_limit"`
+	MemoryLimit        uint64       `json:"memory_limit"`
+	CPUUsagePercent    float64      `json:"cpu_usage_percent"`
+	MemoryUsagePercent float64      `json:"memory_usage_percent"`
+	Calls              []CallBudget `json:"calls,omitempty"` // Per-call attribution, when reported
 }
 
 type SimulationResponse struct {
-	Status            string               `json:"status"` // "success" or "error"
+	Status            string               `json:"status"` // see Status* constants
 	Error             string               `json:"error,omitempty"`
 	Events            []string             `json:"events,omitempty"`            // Raw event strings (backward compatibility)
 	DiagnosticEvents  []DiagnosticEvent    `json:"diagnostic_events,omitempty"` // Structured diagnostic events
 	Logs              []string             `json:"logs,omitempty"`              // Host debug logs
 	Flamegraph        string               `json:"flamegraph,omitempty"`        // SVG flamegraph
+	FoldedStacks      string               `json:"folded_stacks,omitempty"`     // Profile behind Flamegraph, in folded-stack format
 	AuthTrace         *authtrace.AuthTrace `json:"auth_trace,omitempty"`
 	BudgetUsage       *BudgetUsage         `json:"budget_usage,omitempty"` // Resource consumption metrics
 	CategorizedEvents []CategorizedEvent   `json:"categorized_events,omitempty"`
 	ProtocolVersion   *uint32              `json:"protocol_version,omitempty"` // Protocol version used
+	AccessedKeys      []string             `json:"accessed_keys,omitempty"`    // Base64 LedgerKeys read or written
+	Timings           *SimulationTimings   `json:"timings,omitempty"`          // Phase breakdown when profiling
 }
 
 type CategorizedEvent struct {
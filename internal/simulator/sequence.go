@@ -0,0 +1,65 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// EnvelopeSequence returns the source account sequence number of a base64
+// TransactionEnvelope. For fee-bump envelopes it is the inner transaction's.
+func EnvelopeSequence(envelopeXdr string) (int64, error) {
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXdr, &env); err != nil {
+		return 0, fmt.Errorf("failed to decode envelope: %w", err)
+	}
+	seq, err := sequenceNumber(&env)
+	if err != nil {
+		return 0, err
+	}
+	return int64(*seq), nil
+}
+
+// OverrideSequence returns envelopeXdr re-encoded with its sequence number
+// replaced by seq. Signatures are left untouched and no longer match the
+// transaction, which the simulator does not check.
+func OverrideSequence(envelopeXdr string, seq int64) (string, error) {
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXdr, &env); err != nil {
+		return "", fmt.Errorf("failed to decode envelope: %w", err)
+	}
+	num, err := sequenceNumber(&env)
+	if err != nil {
+		return "", err
+	}
+	*num = xdr.SequenceNumber(seq)
+
+	out, err := xdr.MarshalBase64(env)
+	if err != nil {
+		return "", fmt.Errorf("failed to re-encode envelope: %w", err)
+	}
+	got, err := EnvelopeSequence(out)
+	if err != nil {
+		return "", fmt.Errorf("re-encoded envelope is invalid: %w", err)
+	}
+	if got != seq {
+		return "", fmt.Errorf("re-encoded envelope has sequence %d, want %d", got, seq)
+	}
+	return out, nil
+}
+
+func sequenceNumber(env *xdr.TransactionEnvelope) (*xdr.SequenceNumber, error) {
+	switch env.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
+		return &env.V0.Tx.SeqNum, nil
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		return &env.V1.Tx.SeqNum, nil
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return &env.FeeBump.Tx.InnerTx.V1.Tx.SeqNum, nil
+	default:
+		return nil, fmt.Errorf("unsupported envelope type: %v", env.Type)
+	}
+}
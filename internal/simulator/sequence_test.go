@@ -0,0 +1,79 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverrideSequence_V1Envelope(t *testing.T) {
+	env := sorobanEnvelope(t, nil, nil)
+
+	seq, err := EnvelopeSequence(env)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), seq)
+
+	out, err := OverrideSequence(env, 43)
+	require.NoError(t, err)
+	require.NotEqual(t, env, out)
+
+	seq, err = EnvelopeSequence(out)
+	require.NoError(t, err)
+	require.Equal(t, int64(43), seq)
+}
+
+func TestOverrideSequence_FeeBumpEnvelope(t *testing.T) {
+	var inner xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(sorobanEnvelope(t, nil, nil), &inner))
+
+	feeSource, err := xdr.NewMuxedAccount(xdr.CryptoKeyTypeKeyTypeEd25519, xdr.Uint256{0x02})
+	require.NoError(t, err)
+	env := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{
+			Tx: xdr.FeeBumpTransaction{
+				FeeSource: feeSource,
+				Fee:       200,
+				InnerTx: xdr.FeeBumpTransactionInnerTx{
+					Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+					V1:   inner.V1,
+				},
+			},
+		},
+	}
+	b64, err := xdr.MarshalBase64(env)
+	require.NoError(t, err)
+
+	out, err := OverrideSequence(b64, 1000)
+	require.NoError(t, err)
+	seq, err := EnvelopeSequence(out)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), seq)
+}
+
+func TestOverrideSequence_InvalidEnvelope(t *testing.T) {
+	_, err := OverrideSequence("not-xdr", 1)
+	require.Error(t, err)
+}
+
+func TestOverrideSequence_SentToSimulator(t *testing.T) {
+	env, err := OverrideSequence(sorobanEnvelope(t, nil, nil), 7)
+	require.NoError(t, err)
+
+	var sent *SimulationRequest
+	runner := NewMockRunner(func(req *SimulationRequest) (*SimulationResponse, error) {
+		sent = req
+		return &SimulationResponse{Status: "success"}, nil
+	})
+	_, err = runner.Run(&SimulationRequest{EnvelopeXdr: env})
+	require.NoError(t, err)
+
+	require.NotNil(t, sent)
+	seq, err := EnvelopeSequence(sent.EnvelopeXdr)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), seq)
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+)
+
+// signatureLength is the number of hex characters kept from the topic hash.
+const signatureLength = 16
+
+// EventSignature returns a stable identifier for an event's ordered topics.
+// Events that share the same topics in the same order always produce the same
+// signature, which makes it possible to correlate an event type across many
+// transactions regardless of its data payload.
+func EventSignature(topics []string) string {
+	h := sha256.New()
+	var lenBuf [4]byte
+	for _, topic := range topics {
+		// Length-prefix each topic so ["ab","c"] and ["a","bc"] differ.
+		binary.BigEndian.PutUint32(lenBuf[:], uint32(len(topic)))
+		h.Write(lenBuf[:])
+		h.Write([]byte(topic))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:signatureLength]
+}
+
+// Signature returns the topic signature of the event.
+func (e DiagnosticEvent) Signature() string {
+	return EventSignature(e.Topics)
+}
+
+// Signature returns the topic signature of the event.
+func (e CategorizedEvent) Signature() string {
+	return EventSignature(e.Topics)
+}
+
+// EventSignatures returns the distinct topic signatures of events, in the
+// order they first occur.
+func EventSignatures(events []DiagnosticEvent) []string {
+	var sigs []string
+	seen := make(map[string]bool, len(events))
+	for _, e := range events {
+		sig := e.Signature()
+		if !seen[sig] {
+			seen[sig] = true
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs
+}
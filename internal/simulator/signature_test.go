@@ -0,0 +1,58 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventSignature_IdenticalTopics(t *testing.T) {
+	a := EventSignature([]string{"transfer", "GABC", "GDEF"})
+	b := EventSignature([]string{"transfer", "GABC", "GDEF"})
+
+	assert.Equal(t, a, b)
+	assert.Len(t, a, signatureLength)
+}
+
+func TestEventSignature_OrderMatters(t *testing.T) {
+	a := EventSignature([]string{"transfer", "GABC"})
+	b := EventSignature([]string{"GABC", "transfer"})
+
+	assert.NotEqual(t, a, b)
+}
+
+func TestEventSignature_TopicBoundaries(t *testing.T) {
+	a := EventSignature([]string{"ab", "c"})
+	b := EventSignature([]string{"a", "bc"})
+
+	assert.NotEqual(t, a, b)
+}
+
+func TestEventSignature_Empty(t *testing.T) {
+	assert.Equal(t, EventSignature(nil), EventSignature([]string{}))
+}
+
+func TestDiagnosticEvent_Signature(t *testing.T) {
+	diag := DiagnosticEvent{EventType: "contract", Topics: []string{"mint", "GABC"}, Data: "100"}
+	cat := CategorizedEvent{EventType: "contract", Topics: []string{"mint", "GABC"}, Data: "200"}
+
+	assert.Equal(t, EventSignature(diag.Topics), diag.Signature())
+	assert.Equal(t, diag.Signature(), cat.Signature(), "data payload must not affect the signature")
+}
+
+func TestEventSignatures_Distinct(t *testing.T) {
+	events := []DiagnosticEvent{
+		{Topics: []string{"transfer", "GABC"}, Data: "1"},
+		{Topics: []string{"mint", "GABC"}},
+		{Topics: []string{"transfer", "GABC"}, Data: "2"},
+	}
+
+	assert.Equal(t, []string{
+		EventSignature([]string{"transfer", "GABC"}),
+		EventSignature([]string{"mint", "GABC"}),
+	}, EventSignatures(events))
+	assert.Nil(t, EventSignatures(nil))
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+// Statuses reported by the simulator in SimulationResponse.Status.
+const (
+	StatusSuccess  = "success"
+	StatusError    = "error"
+	StatusReverted = "reverted"
+	StatusTimeout  = "timeout"
+)
+
+// Outcome is the typed form of SimulationResponse.Status. Branch on it
+// rather than on the status string; the JSON field stays a string.
+type Outcome int
+
+const (
+	// OutcomeUnknown covers an empty or unrecognized status.
+	OutcomeUnknown Outcome = iota
+	OutcomeSuccess
+	OutcomeError
+	OutcomeReverted
+	OutcomeTimeout
+)
+
+// ParseOutcome maps a status string to its Outcome.
+func ParseOutcome(status string) Outcome {
+	switch status {
+	case StatusSuccess:
+		return OutcomeSuccess
+	case StatusError:
+		return OutcomeError
+	case StatusReverted:
+		return OutcomeReverted
+	case StatusTimeout:
+		return OutcomeTimeout
+	default:
+		return OutcomeUnknown
+	}
+}
+
+// String returns the status string for o, or "unknown".
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeSuccess:
+		return StatusSuccess
+	case OutcomeError:
+		return StatusError
+	case OutcomeReverted:
+		return StatusReverted
+	case OutcomeTimeout:
+		return StatusTimeout
+	default:
+		return "unknown"
+	}
+}
+
+// Outcome parses the response's status. A nil response is unknown.
+func (r *SimulationResponse) Outcome() Outcome {
+	if r == nil {
+		return OutcomeUnknown
+	}
+	return ParseOutcome(r.Status)
+}
+
+// IsKnownStatus reports whether status is one the simulator is documented to
+// return.
+func IsKnownStatus(status string) bool {
+	return ParseOutcome(status) != OutcomeUnknown
+}
+
+// DescribeStatus renders a status for humans. Unrecognized statuses are
+// flagged rather than passed off as success.
+func DescribeStatus(status string) string {
+	switch status {
+	case StatusSuccess:
+		return "success"
+	case StatusError:
+		return "error"
+	case StatusReverted:
+		return "reverted (contract execution rolled back, no state changes applied)"
+	case StatusTimeout:
+		return "timeout (simulation did not complete)"
+	case "":
+		return "unknown (simulator did not report a status)"
+	default:
+		return fmt.Sprintf("%s (unrecognized status)", status)
+	}
+}
+
+// checkStatus maps the simulator status to an error for outcomes that leave
+// no usable result. A reverted run is returned as-is so its events and logs
+// can still be inspected; an unrecognized status is logged and passed through.
+func checkStatus(resp *SimulationResponse) error {
+	switch resp.Outcome() {
+	case OutcomeSuccess, OutcomeReverted:
+		return nil
+	case OutcomeError:
+		return fmt.Errorf("simulation error: %s", resp.Error)
+	case OutcomeTimeout:
+		return errors.WrapSimulationTimeout(resp.Error)
+	default:
+		logger.Logger.Warn("Simulator returned an unrecognized status", "status", resp.Status, "error", resp.Error)
+		return nil
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+)
+
+// SimulationTimings is the wall-clock breakdown of a simulation run, in
+// milliseconds. The simulator reports it when profiling is enabled.
+type SimulationTimings struct {
+	ParseMs    float64 `json:"parse_ms"`
+	HostInitMs float64 `json:"host_init_ms"`
+	InvokeMs   float64 `json:"invoke_ms"`
+	CommitMs   float64 `json:"commit_ms"`
+}
+
+// Total is the sum of all phases.
+func (t *SimulationTimings) Total() float64 {
+	return t.ParseMs + t.HostInitMs + t.InvokeMs + t.CommitMs
+}
+
+// FormatTimingsTable renders the timings as a table of phase, duration and
+// share of the total.
+func FormatTimingsTable(t *SimulationTimings) string {
+	total := t.Total()
+	phases := []struct {
+		name string
+		ms   float64
+	}{
+		{"parse", t.ParseMs},
+		{"host-init", t.HostInitMs},
+		{"invoke", t.InvokeMs},
+		{"commit", t.CommitMs},
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "PHASE\tMS\tSHARE")
+	for _, p := range phases {
+		share := 0.0
+		if total > 0 {
+			share = p.ms / total * 100
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%.2f\t%.1f%%\n", p.name, p.ms, share)
+	}
+	_, _ = fmt.Fprintf(w, "total\t%.2f\t100.0%%\n", total)
+	_ = w.Flush()
+	return buf.String()
+}
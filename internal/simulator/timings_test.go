@@ -0,0 +1,38 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulationResponse_Timings(t *testing.T) {
+	var resp SimulationResponse
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"status": "success",
+		"timings": {"parse_ms": 1.5, "host_init_ms": 2.5, "invoke_ms": 5, "commit_ms": 1}
+	}`), &resp))
+
+	require.NotNil(t, resp.Timings)
+	assert.Equal(t, 2.5, resp.Timings.HostInitMs)
+	assert.Equal(t, 10.0, resp.Timings.Total())
+
+	table := FormatTimingsTable(resp.Timings)
+	lines := strings.Split(strings.TrimRight(table, "\n"), "\n")
+	require.Len(t, lines, 6)
+	assert.Regexp(t, `^PHASE\s+MS\s+SHARE$`, lines[0])
+	assert.Regexp(t, `^invoke\s+5\.00\s+50\.0%$`, lines[3])
+	assert.Regexp(t, `^total\s+10\.00\s+100\.0%$`, lines[5])
+}
+
+func TestSimulationResponse_NoTimings(t *testing.T) {
+	var resp SimulationResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"status": "success"}`), &resp))
+	assert.Nil(t, resp.Timings)
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// versionTimeout bounds how long the binary may take to report its version.
+const versionTimeout = 5 * time.Second
+
+// MinSimulatorVersion is the oldest erst-sim release that supports the
+// request fields and protocol versions this CLI sends.
+const MinSimulatorVersion = "0.1.0"
+
+// Version runs the simulator binary with --version and returns the version
+// it reports, e.g. "0.1.0" for output "erst-sim 0.1.0".
+func (r *Runner) Version() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), versionTimeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.BinaryPath, "--version")
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run %s --version: %w", r.BinaryPath, err)
+	}
+	return parseVersion(stdout.String())
+}
+
+// parseVersion takes the last field of the first output line, so both
+// "erst-sim 0.1.0" and a bare "0.1.0" yield "0.1.0".
+func parseVersion(out string) (string, error) {
+	line, _, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("simulator reported no version")
+	}
+	return strings.TrimPrefix(fields[len(fields)-1], "v"), nil
+}
+
+// CheckCompatibility reports an error when the binary's version is older
+// than minVersion or cannot be determined.
+func (r *Runner) CheckCompatibility(minVersion string) error {
+	want, err := version.NewVersion(minVersion)
+	if err != nil {
+		return fmt.Errorf("invalid minimum simulator version %q: %w", minVersion, err)
+	}
+	reported, err := r.Version()
+	if err != nil {
+		return err
+	}
+	got, err := version.NewVersion(reported)
+	if err != nil {
+		return fmt.Errorf("simulator reported unparseable version %q: %w", reported, err)
+	}
+	if got.LessThan(want) {
+		return fmt.Errorf("simulator %s is older than the required %s; rebuild it with 'erst sim build'", reported, minVersion)
+	}
+	return nil
+}
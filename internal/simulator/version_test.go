@@ -0,0 +1,65 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerVersion(t *testing.T) {
+	r := &Runner{BinaryPath: fakeSimulator(t, "erst-sim 0.3.1")}
+	v, err := r.Version()
+	require.NoError(t, err)
+	require.Equal(t, "0.3.1", v)
+}
+
+func TestParseVersion(t *testing.T) {
+	for out, want := range map[string]string{
+		"erst-sim 0.1.0\n":       "0.1.0",
+		"0.2.0":                  "0.2.0",
+		"erst-sim v1.0.0\nextra": "1.0.0",
+	} {
+		got, err := parseVersion(out)
+		require.NoError(t, err)
+		require.Equal(t, want, got, out)
+	}
+
+	_, err := parseVersion("  \n")
+	require.Error(t, err)
+}
+
+func TestRunnerCheckCompatibility(t *testing.T) {
+	r := &Runner{BinaryPath: fakeSimulator(t, "erst-sim 0.3.1")}
+	require.NoError(t, r.CheckCompatibility("0.3.1"))
+	require.NoError(t, r.CheckCompatibility("0.2.0"))
+
+	err := r.CheckCompatibility("0.4.0")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "older than the required 0.4.0")
+
+	require.Error(t, r.CheckCompatibility("not-a-version"))
+}
+
+func TestNewRunner_WarnsOnOldSimulator(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf, false)
+	logger.SetLevel(slog.LevelWarn)
+	t.Cleanup(func() { logger.SetOutput(os.Stderr, false) })
+
+	r, err := NewRunner(fakeSimulator(t, "erst-sim 0.0.9"), false, WithMinVersion("0.1.0"))
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	require.Contains(t, buf.String(), "Simulator binary may be incompatible")
+
+	buf.Reset()
+	_, err = NewRunner(fakeSimulator(t, "erst-sim 0.1.0"), false, WithMinVersion("0.1.0"))
+	require.NoError(t, err)
+	require.NotContains(t, buf.String(), "incompatible")
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// LedgerEntryFetcher fetches ledger entries by base64 LedgerKey, returning
+// base64 LedgerEntryData keyed the same way. *rpc.Client implements it.
+type LedgerEntryFetcher interface {
+	GetLedgerEntries(ctx context.Context, keys []string) (map[string]string, error)
+}
+
+// CaptureContract fetches the state needed to simulate calls into a
+// contract: its instance entry (which holds instance storage), the Wasm code
+// it executes and any extra ledger keys given. Soroban RPC cannot enumerate a
+// contract's persistent or temporary entries, so those must be passed in
+// extraKeys.
+func CaptureContract(ctx context.Context, f LedgerEntryFetcher, contractID string, extraKeys []string) (*Snapshot, error) {
+	instanceKey, err := ContractInstanceKey(contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := append([]string{instanceKey}, extraKeys...)
+	entries, err := f.GetLedgerEntries(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch contract entries: %w", err)
+	}
+
+	instance, ok := entries[instanceKey]
+	if !ok {
+		return nil, fmt.Errorf("contract %s not found", contractID)
+	}
+
+	codeKey, err := contractCodeKey(instance)
+	if err != nil {
+		return nil, err
+	}
+	if codeKey != "" {
+		code, err := f.GetLedgerEntries(ctx, []string{codeKey})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch contract code: %w", err)
+		}
+		if _, ok := code[codeKey]; !ok {
+			return nil, fmt.Errorf("contract code for %s not found", contractID)
+		}
+		for k, v := range code {
+			entries[k] = v
+		}
+	}
+
+	return FromMap(entries), nil
+}
+
+// ContractInstanceKey returns the base64 LedgerKey of a contract's instance
+// entry.
+func ContractInstanceKey(contractID string) (string, error) {
+	raw, err := strkey.Decode(strkey.VersionByteContract, contractID)
+	if err != nil {
+		return "", fmt.Errorf("invalid contract ID %q: %w", contractID, err)
+	}
+	var id xdr.ContractId
+	copy(id[:], raw)
+
+	key := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &id},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+			Durability: xdr.ContractDataDurabilityPersistent,
+		},
+	}
+	return xdr.MarshalBase64(key)
+}
+
+// contractCodeKey reads the executable from a contract instance entry and
+// returns the LedgerKey of its Wasm code, or "" for Stellar Asset Contracts,
+// which have no code entry.
+func contractCodeKey(instanceB64 string) (string, error) {
+	var data xdr.LedgerEntryData
+	if err := xdr.SafeUnmarshalBase64(instanceB64, &data); err != nil {
+		return "", fmt.Errorf("failed to decode contract instance: %w", err)
+	}
+	cd, ok := data.GetContractData()
+	if !ok {
+		return "", fmt.Errorf("contract instance entry has type %s", data.Type)
+	}
+	inst, ok := cd.Val.GetInstance()
+	if !ok {
+		return "", fmt.Errorf("contract instance entry holds %s, not an instance", cd.Val.Type)
+	}
+	if inst.Executable.Type != xdr.ContractExecutableTypeContractExecutableWasm || inst.Executable.WasmHash == nil {
+		return "", nil
+	}
+
+	key := xdr.LedgerKey{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.LedgerKeyContractCode{Hash: *inst.Executable.WasmHash},
+	}
+	return xdr.MarshalBase64(key)
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockLedgerRPC serves getLedgerEntries from a fixed key -> LedgerEntryData map.
+func mockLedgerRPC(t *testing.T, state map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params [][]string `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		type entry struct {
+			Key string `json:"key"`
+			Xdr string `json:"xdr"`
+		}
+		entries := []entry{}
+		for _, key := range req.Params[0] {
+			if val, ok := state[key]; ok {
+				entries = append(entries, entry{Key: key, Xdr: val})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  map[string]interface{}{"entries": entries, "latestLedger": 100},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func mustB64(t *testing.T, v interface{}) string {
+	t.Helper()
+	s, err := xdr.MarshalBase64(v)
+	require.NoError(t, err)
+	return s
+}
+
+func TestCaptureContract_FetchesInstanceAndCode(t *testing.T) {
+	var id xdr.ContractId
+	id[0] = 0xAB
+	contractID, err := strkey.Encode(strkey.VersionByteContract, id[:])
+	require.NoError(t, err)
+
+	wasmHash := xdr.Hash{0x01, 0x02}
+	instanceKey, err := ContractInstanceKey(contractID)
+	require.NoError(t, err)
+	instanceData := xdr.LedgerEntryData{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.ContractDataEntry{
+			Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &id},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+			Durability: xdr.ContractDataDurabilityPersistent,
+			Val: xdr.ScVal{
+				Type: xdr.ScValTypeScvContractInstance,
+				Instance: &xdr.ScContractInstance{
+					Executable: xdr.ContractExecutable{
+						Type:     xdr.ContractExecutableTypeContractExecutableWasm,
+						WasmHash: &wasmHash,
+					},
+				},
+			},
+		},
+	}
+
+	codeKey := mustB64(t, xdr.LedgerKey{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.LedgerKeyContractCode{Hash: wasmHash},
+	})
+	codeData := xdr.LedgerEntryData{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.ContractCodeEntry{Hash: wasmHash, Code: []byte{0x00, 0x61, 0x73, 0x6d}},
+	}
+
+	sym := xdr.ScSymbol("Balance")
+	balanceKey := mustB64(t, xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &id},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+			Durability: xdr.ContractDataDurabilityPersistent,
+		},
+	})
+
+	state := map[string]string{
+		instanceKey: mustB64(t, instanceData),
+		codeKey:     mustB64(t, codeData),
+		balanceKey:  "BALANCE-ENTRY",
+	}
+	server := mockLedgerRPC(t, state)
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL(server.URL), rpc.WithCacheEnabled(false))
+	require.NoError(t, err)
+
+	snap, err := CaptureContract(context.Background(), client, contractID, []string{balanceKey})
+	require.NoError(t, err)
+
+	assert.Equal(t, state, snap.ToMap())
+}
+
+func TestCaptureContract_NotFound(t *testing.T) {
+	var id xdr.ContractId
+	contractID, err := strkey.Encode(strkey.VersionByteContract, id[:])
+	require.NoError(t, err)
+
+	server := mockLedgerRPC(t, map[string]string{})
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL(server.URL), rpc.WithCacheEnabled(false))
+	require.NoError(t, err)
+
+	_, err = CaptureContract(context.Background(), client, contractID, nil)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestContractInstanceKey_InvalidID(t *testing.T) {
+	_, err := ContractInstanceKey("GABC")
+	assert.Error(t, err)
+}
@@ -26,6 +26,7 @@ func TestRegression_{{.TestName}}(t *testing.T) {
 {{- end}}
 		},
 	}
+	req.LedgerSequence = simulator.EntriesLedgerSequence(req.LedgerEntries)
 
 	// Create simulator runner
 	runner, err := simulator.NewRunner("", false)
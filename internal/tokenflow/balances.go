@@ -0,0 +1,232 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenflow
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// BalanceChange is the net change of one account's balance in one token.
+// Delta is in the token's smallest units (XLM: stroops).
+type BalanceChange struct {
+	Account string
+	Token   Token
+	Delta   *big.Int
+}
+
+// BalanceChanges nets every movement in the report into one change per
+// (account, token). The MINT, BURN and CLAWBACK pseudo-accounts are not
+// listed, and accounts whose movements cancel out are omitted.
+func (r *Report) BalanceChanges() []BalanceChange {
+	type key struct {
+		account string
+		token   Token
+	}
+	deltas := map[key]*big.Int{}
+	add := func(account string, token Token, amount *big.Int) {
+		if isPseudoAccount(account) || amount == nil {
+			return
+		}
+		k := key{account: account, token: token}
+		if deltas[k] == nil {
+			deltas[k] = new(big.Int)
+		}
+		deltas[k].Add(deltas[k], amount)
+	}
+
+	for _, t := range r.Raw {
+		if t.Amount == nil {
+			continue
+		}
+		add(t.From, t.Token, new(big.Int).Neg(t.Amount))
+		add(t.To, t.Token, t.Amount)
+	}
+
+	out := make([]BalanceChange, 0, len(deltas))
+	for k, d := range deltas {
+		if d.Sign() == 0 {
+			continue
+		}
+		out = append(out, BalanceChange{Account: k.account, Token: k.token, Delta: d})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Account != out[j].Account {
+			return out[i].Account < out[j].Account
+		}
+		return out[i].Token.Display() < out[j].Token.Display()
+	})
+	return out
+}
+
+// NetDeltas returns BalanceChanges as account -> asset -> signed net amount
+// in the asset's smallest units. Assets are keyed by AssetKey, so native
+// payments and the native Stellar Asset Contract add up to one "XLM"
+// balance. Accounts and assets that net to zero are omitted. Amounts that
+// do not fit in an int64 are clamped to its range.
+func (r *Report) NetDeltas() map[string]map[string]int64 {
+	sums := map[string]map[string]*big.Int{}
+	for _, c := range r.BalanceChanges() {
+		key := AssetKey(c.Token)
+		if sums[c.Account] == nil {
+			sums[c.Account] = map[string]*big.Int{}
+		}
+		if sums[c.Account][key] == nil {
+			sums[c.Account][key] = new(big.Int)
+		}
+		sums[c.Account][key].Add(sums[c.Account][key], c.Delta)
+	}
+
+	out := map[string]map[string]int64{}
+	for account, assets := range sums {
+		for asset, d := range assets {
+			if d.Sign() == 0 {
+				continue
+			}
+			if out[account] == nil {
+				out[account] = map[string]int64{}
+			}
+			out[account][asset] = clampInt64(d)
+		}
+	}
+	return out
+}
+
+// NetDeltaLines renders NetDeltas one account per line, sorted by account
+// and asset, for example:
+//
+//	GABC... +1.5 XLM, -100 USDC
+func (r *Report) NetDeltaLines() []string {
+	deltas := r.NetDeltas()
+	accounts := make([]string, 0, len(deltas))
+	for account := range deltas {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	lines := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		assets := make([]string, 0, len(deltas[account]))
+		for asset := range deltas[account] {
+			assets = append(assets, asset)
+		}
+		sort.Strings(assets)
+
+		parts := make([]string, len(assets))
+		for i, asset := range assets {
+			parts[i] = r.formatNetDelta(asset, deltas[account][asset])
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", account, strings.Join(parts, ", ")))
+	}
+	return lines
+}
+
+func (r *Report) formatNetDelta(asset string, delta int64) string {
+	amount := big.NewInt(delta)
+	var s string
+	if asset == "XLM" {
+		s = formatStroopsAsXLM(amount)
+	} else {
+		s = amount.String()
+	}
+	if delta > 0 {
+		s = "+" + s
+	}
+	return s + " " + r.assetName(asset)
+}
+
+// assetName is the display name of an AssetKey: the issuer label of a
+// classic asset when the report has a label registry, otherwise the code
+// of a classic asset or a shortened contract ID.
+func (r *Report) assetName(key string) string {
+	switch {
+	case strings.Contains(key, ":"):
+		if r.Labels != nil {
+			return r.Labels.Label(key)
+		}
+		code, _, _ := strings.Cut(key, ":")
+		return code
+	case strings.HasPrefix(key, "C") && len(key) == 56:
+		return Token{ID: key, Symbol: "SAC"}.Display()
+	}
+	return key
+}
+
+// AssetKey names the asset a token moves: "XLM" for the native asset,
+// "CODE:ISSUER" for classic assets wrapped by a Stellar Asset Contract and
+// the contract ID for any other token.
+func AssetKey(t Token) string {
+	switch {
+	case t.Symbol == "XLM" && (t.ID == "" || t.Asset == "native"):
+		return "XLM"
+	case t.Asset != "":
+		return t.Asset
+	case t.ID != "":
+		return t.ID
+	}
+	return t.Symbol
+}
+
+func clampInt64(v *big.Int) int64 {
+	switch {
+	case v.IsInt64():
+		return v.Int64()
+	case v.Sign() > 0:
+		return math.MaxInt64
+	default:
+		return math.MinInt64
+	}
+}
+
+func isPseudoAccount(account string) bool {
+	switch account {
+	case "MINT", "BURN", "CLAWBACK":
+		return true
+	}
+	return false
+}
+
+// BalancesTable renders the balance changes as an aligned table.
+func (r *Report) BalancesTable() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ACCOUNT\tASSET\tDELTA")
+	for _, c := range r.BalanceChanges() {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", c.Account, r.tokenName(c.Token), signedAmount(c))
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+// WriteBalancesCSV writes the balance changes as CSV with an
+// account,asset,delta header. Deltas are in the token's smallest units so
+// the file can be summed without rounding.
+func (r *Report) WriteBalancesCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"account", "asset", "delta"}); err != nil {
+		return err
+	}
+	for _, c := range r.BalanceChanges() {
+		if err := cw.Write([]string{c.Account, r.tokenName(c.Token), c.Delta.String()}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func signedAmount(c BalanceChange) string {
+	s := formatAmount(Transfer{Token: c.Token, Amount: c.Delta})
+	if c.Delta.Sign() > 0 {
+		return "+" + s
+	}
+	return s
+}
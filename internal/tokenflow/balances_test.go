@@ -0,0 +1,111 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenflow
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math/big"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceChanges_NetsAcrossOperations(t *testing.T) {
+	cid := xdr.ContractId(bytes32(0xAA))
+	alice := scAddressAccount(bytes32(0x01))
+	bob := scAddressAccount(bytes32(0x02))
+	carol := scAddressAccount(bytes32(0x03))
+
+	transfer := func(from, to xdr.ScAddress, amount uint64) xdr.DiagnosticEvent {
+		return diagnosticEvent(cid, []xdr.ScVal{scSymbol("transfer"), scAddress(from), scAddress(to)}, scU128(amount), true)
+	}
+	events := []xdr.DiagnosticEvent{
+		transfer(alice, bob, 100),
+		transfer(bob, carol, 30),
+		transfer(carol, alice, 30),
+		diagnosticEvent(cid, []xdr.ScVal{scSymbol("mint"), scAddress(bob)}, scU64(5), true),
+	}
+
+	r, err := BuildReport("", encodeResultMetaWithDiagnosticEvents(t, events))
+	require.NoError(t, err)
+
+	got := map[string]*big.Int{}
+	for _, c := range r.BalanceChanges() {
+		got[c.Account] = c.Delta
+	}
+
+	// Carol received and sent 30, so she nets to zero and is omitted.
+	require.Len(t, got, 2)
+	require.Equal(t, big.NewInt(-70), got[addrString(alice)])
+	require.Equal(t, big.NewInt(75), got[addrString(bob)])
+	require.NotContains(t, got, "MINT")
+}
+
+func TestBalanceChanges_NativePaymentAndCSV(t *testing.T) {
+	src := bytes32(0x10)
+	dst := bytes32(0x20)
+
+	r, err := BuildReport(encodeEnvelopeWithNativePayment(src, dst, 12_345_678), "")
+	require.NoError(t, err)
+
+	changes := r.BalanceChanges()
+	require.Len(t, changes, 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteBalancesCSV(&buf))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"account", "asset", "delta"}, records[0])
+	require.Len(t, records, 3)
+
+	deltas := map[string]string{records[1][0]: records[1][2], records[2][0]: records[2][2]}
+	require.Equal(t, "-12345678", deltas[addrMuxed(src)])
+	require.Equal(t, "12345678", deltas[addrMuxed(dst)])
+
+	table := r.BalancesTable()
+	require.Contains(t, table, "ACCOUNT")
+	require.Contains(t, table, "+1.2345678")
+}
+
+func TestNetDeltas_SignsAndZeroNet(t *testing.T) {
+	sac := xdr.ContractId(bytes32(0xAA))
+	sacStr := addrString(xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &sac})
+	alice := scAddressAccount(bytes32(0x01))
+	bob := scAddressAccount(bytes32(0x02))
+	carol := scAddressAccount(bytes32(0x03))
+
+	events := []xdr.DiagnosticEvent{
+		diagnosticEvent(sac, []xdr.ScVal{scSymbol("mint"), scAddress(alice)}, scU64(100), true),
+		diagnosticEvent(sac, []xdr.ScVal{scSymbol("transfer"), scAddress(alice), scAddress(bob)}, scU128(40), true),
+		diagnosticEvent(sac, []xdr.ScVal{scSymbol("burn"), scAddress(bob)}, scU64(15), true),
+		diagnosticEvent(sac, []xdr.ScVal{scSymbol("transfer"), scAddress(carol), scAddress(bob)}, scU128(5), true),
+		diagnosticEvent(sac, []xdr.ScVal{scSymbol("transfer"), scAddress(bob), scAddress(carol)}, scU128(5), true),
+	}
+	r, err := BuildReport("", encodeResultMetaWithDiagnosticEvents(t, events))
+	require.NoError(t, err)
+
+	deltas := r.NetDeltas()
+	require.Equal(t, map[string]map[string]int64{
+		addrString(alice): {sacStr: 60},
+		addrString(bob):   {sacStr: 25},
+	}, deltas)
+
+	lines := r.NetDeltaLines()
+	require.Equal(t, []string{
+		addrString(alice) + " +60 SAC(" + sacStr[:12] + "…)",
+		addrString(bob) + " +25 SAC(" + sacStr[:12] + "…)",
+	}, lines)
+}
+
+func TestNetDeltas_NativePayment(t *testing.T) {
+	r, err := BuildReport(encodeEnvelopeWithNativePayment(bytes32(0x10), bytes32(0x20), 15_000_000), "")
+	require.NoError(t, err)
+
+	deltas := r.NetDeltas()
+	require.Equal(t, int64(-15_000_000), deltas[addrMuxed(bytes32(0x10))]["XLM"])
+	require.Equal(t, int64(15_000_000), deltas[addrMuxed(bytes32(0x20))]["XLM"])
+	require.Contains(t, r.NetDeltaLines(), addrMuxed(bytes32(0x10))+" -1.5 XLM")
+}
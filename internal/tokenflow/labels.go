@@ -0,0 +1,73 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// defaultAssetLabels maps well-known "CODE:ISSUER" assets to the home domain
+// of their issuer.
+var defaultAssetLabels = map[string]string{
+	"USDC:GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN": "circle.com",
+	"EURC:GDHU6WRG4IEQXM5NZ4BMPKOXHW76MZM4Y2IEMFDVXBSDP6SJY4ITNPP2": "circle.com",
+	"USDC:GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5": "circle.com testnet",
+	"AQUA:GBNZILSTVQZ4R7IKQDGHYGY2QXL5QOFJYQMXPKWRRM5PAV7Y4M67AQUA": "aqua.network",
+}
+
+// LabelRegistry maps classic assets to friendly issuer labels so reports can
+// show "USDC (circle.com)" instead of a raw issuer key.
+type LabelRegistry struct {
+	labels map[string]string
+}
+
+// NewLabelRegistry returns a registry seeded with the bundled well-known
+// issuers.
+func NewLabelRegistry() *LabelRegistry {
+	r := &LabelRegistry{labels: make(map[string]string, len(defaultAssetLabels))}
+	for asset, label := range defaultAssetLabels {
+		r.labels[asset] = label
+	}
+	return r
+}
+
+// Add registers a label for asset ("CODE:ISSUER"), replacing any existing one.
+func (r *LabelRegistry) Add(asset, label string) {
+	r.labels[asset] = label
+}
+
+// LoadFile merges labels from a JSON file of the form
+// {"CODE:ISSUER": "label"}. Entries in the file override bundled ones.
+func (r *LabelRegistry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read asset labels: %w", err)
+	}
+	var labels map[string]string
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return fmt.Errorf("parse asset labels %s: %w", path, err)
+	}
+	for asset, label := range labels {
+		if _, _, ok := strings.Cut(asset, ":"); !ok {
+			return fmt.Errorf("parse asset labels %s: %q is not CODE:ISSUER", path, asset)
+		}
+		r.Add(asset, label)
+	}
+	return nil
+}
+
+// Label returns the display name for a SEP-11 asset string: "CODE (label)"
+// for known issuers, the asset unchanged otherwise.
+func (r *LabelRegistry) Label(asset string) string {
+	if r != nil {
+		if label, ok := r.labels[asset]; ok {
+			code, _, _ := strings.Cut(asset, ":")
+			return code + " (" + label + ")"
+		}
+	}
+	return asset
+}
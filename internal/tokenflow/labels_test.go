@@ -0,0 +1,63 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	circleUSDC  = "USDC:GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN"
+	unknownUSDC = "USDC:GCKFBEIYV2U22IO2BJ4KVJOIP7XPWQGQFKKWXR6DOSJBV7STMAQSMTGG"
+)
+
+func TestLabelRegistry_Label(t *testing.T) {
+	reg := NewLabelRegistry()
+
+	require.Equal(t, "USDC (circle.com)", reg.Label(circleUSDC))
+	require.Equal(t, unknownUSDC, reg.Label(unknownUSDC))
+
+	var nilReg *LabelRegistry
+	require.Equal(t, circleUSDC, nilReg.Label(circleUSDC))
+}
+
+func TestLabelRegistry_LoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "labels.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"`+unknownUSDC+`": "example.org"}`), 0644))
+
+	reg := NewLabelRegistry()
+	require.NoError(t, reg.LoadFile(path))
+	require.Equal(t, "USDC (example.org)", reg.Label(unknownUSDC))
+	require.Equal(t, "USDC (circle.com)", reg.Label(circleUSDC))
+
+	bad := filepath.Join(t.TempDir(), "bad.json")
+	require.NoError(t, os.WriteFile(bad, []byte(`{"USDC": "x"}`), 0644))
+	require.Error(t, reg.LoadFile(bad))
+}
+
+func TestReport_SummaryLinesWithLabels(t *testing.T) {
+	cid := xdr.ContractId(bytes32(0xAA))
+	from := scAddressAccount(bytes32(0x01))
+	to := scAddressAccount(bytes32(0x02))
+
+	meta := encodeResultMetaWithDiagnosticEvents(t, []xdr.DiagnosticEvent{
+		diagnosticEvent(cid, []xdr.ScVal{scSymbol("transfer"), scAddress(from), scAddress(to), scString(circleUSDC)}, scI128(10), true),
+		diagnosticEvent(cid, []xdr.ScVal{scSymbol("transfer"), scAddress(from), scAddress(to), scString(unknownUSDC)}, scI128(20), true),
+	})
+
+	report, err := BuildReport("", meta)
+	require.NoError(t, err)
+	report.Labels = NewLabelRegistry()
+
+	summary := strings.Join(report.SummaryLines(), "\n")
+	require.Contains(t, summary, "10 USDC (circle.com)")
+	require.Contains(t, summary, "20 "+unknownUSDC)
+	require.Contains(t, report.MermaidFlowchart(), "USDC (circle.com)")
+}
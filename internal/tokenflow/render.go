@@ -4,7 +4,9 @@
 package tokenflow
 
 import (
+	"encoding/csv"
 	"fmt"
+	"io"
 	"math/big"
 	"regexp"
 	"strings"
@@ -13,10 +15,15 @@ import (
 // SummaryLines produces human-readable summaries like:
 //
 //	AccountA -> 50 XLM -> AccountB
+//	AccountA approves 100 SAC(C…) for AccountB (expires at ledger 5000)
 func (r *Report) SummaryLines() []string {
 	var lines []string
 	for _, t := range r.Agg {
-		lines = append(lines, fmt.Sprintf("%s -> %s %s -> %s", t.From, formatAmount(t), t.Token.Display(), t.To))
+		lines = append(lines, fmt.Sprintf("%s -> %s %s -> %s", t.From, formatAmount(t), r.tokenName(t.Token), t.To))
+	}
+	for _, a := range r.Approvals {
+		amount := formatAmount(Transfer{Token: a.Token, Amount: a.Amount})
+		lines = append(lines, fmt.Sprintf("%s approves %s %s for %s (expires at ledger %d)", a.From, amount, r.tokenName(a.Token), a.Spender, a.ExpirationLedger))
 	}
 	return lines
 }
@@ -42,13 +49,72 @@ func (r *Report) MermaidFlowchart() string {
 	for _, t := range r.Agg {
 		from := getNode(t.From)
 		to := getNode(t.To)
-		label := fmt.Sprintf("%s %s", formatAmount(t), t.Token.Display())
+		label := fmt.Sprintf("%s %s", formatAmount(t), r.tokenName(t.Token))
 		b.WriteString(fmt.Sprintf("  %s -->|\"%s\"| %s\n", from, escapeMermaidLabel(label), to))
 	}
 
 	return b.String()
 }
 
+// GraphvizDOT renders the same graph as MermaidFlowchart in Graphviz DOT
+// syntax: one node per account or contract and one edge per aggregated
+// movement, labelled with its amount and asset.
+func (r *Report) GraphvizDOT() string {
+	var b strings.Builder
+	b.WriteString("digraph tokenflow {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	nodeID := map[string]string{}
+	next := 0
+	getNode := func(label string) string {
+		if id, ok := nodeID[label]; ok {
+			return id
+		}
+		next++
+		id := fmt.Sprintf("n%d", next)
+		nodeID[label] = id
+		b.WriteString(fmt.Sprintf("  %s [label=\"%s\"];\n", id, escapeDOTLabel(label)))
+		return id
+	}
+
+	for _, t := range r.Agg {
+		from := getNode(t.From)
+		to := getNode(t.To)
+		label := fmt.Sprintf("%s %s", formatAmount(t), r.tokenName(t.Token))
+		b.WriteString(fmt.Sprintf("  %s -> %s [label=\"%s\"];\n", from, to, escapeDOTLabel(label)))
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// WriteCSV writes the aggregated movements as CSV with a
+// from,to,asset,amount,type header, one row per edge of MermaidFlowchart.
+// Amounts are formatted as in the chart so the two always agree.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"from", "to", "asset", "amount", "type"}); err != nil {
+		return err
+	}
+	for _, t := range r.Agg {
+		if err := cw.Write([]string{t.From, t.To, r.tokenName(t.Token), formatAmount(t), string(t.Kind)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// tokenName is Token.Display, except that classic assets are named by
+// code and issuer label when the report has a label registry.
+func (r *Report) tokenName(t Token) string {
+	if r.Labels == nil || t.Asset == "" || t.Asset == "native" {
+		return t.Display()
+	}
+	return r.Labels.Label(t.Asset)
+}
+
 func formatAmount(t Transfer) string {
 	if t.Amount == nil {
 		return "0"
@@ -86,6 +152,12 @@ func formatStroopsAsXLM(stroops *big.Int) string {
 	return fmt.Sprintf("%s.%s", intPart.String(), fracStr)
 }
 
+var dotUnsafe = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeDOTLabel(s string) string {
+	return dotUnsafe.Replace(s)
+}
+
 var mermaidUnsafe = regexp.MustCompile(`[]"]`)
 
 func escapeMermaidLabel(s string) string {
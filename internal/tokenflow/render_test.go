@@ -0,0 +1,70 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenflow
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestReport_GraphvizDOT_Golden(t *testing.T) {
+	cid := xdr.ContractId(bytes32(0xAA))
+	from := scAddressAccount(bytes32(0x01))
+	to := scAddressAccount(bytes32(0x02))
+
+	rmB64 := encodeResultMetaWithDiagnosticEvents(t, []xdr.DiagnosticEvent{
+		diagnosticEvent(cid, []xdr.ScVal{scSymbol("transfer"), scAddress(from), scAddress(to)}, scU128(50), true),
+		diagnosticEvent(cid, []xdr.ScVal{scSymbol("mint"), scAddress(to)}, scU64(7), true),
+	})
+	envB64 := encodeEnvelopeWithNativePayment(bytes32(0x01), bytes32(0x02), 12_345_678)
+
+	report, err := BuildReport(envB64, rmB64)
+	require.NoError(t, err)
+
+	got := report.GraphvizDOT()
+	golden := filepath.Join("testdata", "flow.dot")
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(golden, []byte(got), 0644))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	require.Equal(t, string(want), got)
+}
+
+func TestEscapeDOTLabel(t *testing.T) {
+	require.Equal(t, `a \"b\" \\ c\n`, escapeDOTLabel("a \"b\" \\ c\n"))
+}
+
+func TestReport_WriteCSV(t *testing.T) {
+	cid := xdr.ContractId(bytes32(0xAA))
+	to := scAddressAccount(bytes32(0x02))
+	rmB64 := encodeResultMetaWithDiagnosticEvents(t, []xdr.DiagnosticEvent{
+		diagnosticEvent(cid, []xdr.ScVal{scSymbol("mint"), scAddress(to)}, scU64(3), true),
+		diagnosticEvent(cid, []xdr.ScVal{scSymbol("mint"), scAddress(to)}, scU64(4), true),
+	})
+	envB64 := encodeEnvelopeWithNativePayment(bytes32(0x01), bytes32(0x02), 12_345_678)
+
+	report, err := BuildReport(envB64, rmB64)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteCSV(&buf))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+
+	require.Equal(t, [][]string{
+		{"from", "to", "asset", "amount", "type"},
+		{"MINT", addrString(to), report.Agg[0].Token.Display(), "7", "mint"},
+		{addrMuxed(bytes32(0x01)), addrMuxed(bytes32(0x02)), "XLM", "1.2345678", "transfer"},
+	}, records)
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenflow
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// SEP41EventType is the name of a standard token interface event.
+type SEP41EventType string
+
+const (
+	SEP41Transfer SEP41EventType = "transfer"
+	SEP41Mint     SEP41EventType = "mint"
+	SEP41Burn     SEP41EventType = "burn"
+	SEP41Clawback SEP41EventType = "clawback"
+	SEP41Approve  SEP41EventType = "approve"
+)
+
+// SEP41Event is a decoded SEP-41 token event.
+//
+// Field usage depends on Type:
+//   - transfer: From, To, Amount
+//   - mint:     Admin (when present), To, Amount
+//   - burn:     From, Amount
+//   - clawback: Admin (when present), From, Amount
+//   - approve:  From, Spender, Amount, ExpirationLedger
+type SEP41Event struct {
+	Type             SEP41EventType
+	Contract         string
+	From             string
+	To               string
+	Admin            string
+	Spender          string
+	Amount           *big.Int
+	ExpirationLedger uint32
+	// Asset is the SEP-11 asset string ("native" or "CODE:ISSUER") that
+	// Stellar Asset Contracts append as a trailing topic. Empty for custom tokens.
+	Asset string
+}
+
+// Approval is an allowance granted through a SEP-41 approve event.
+type Approval struct {
+	From             string
+	Spender          string
+	Token            Token
+	Amount           *big.Int
+	ExpirationLedger uint32
+}
+
+// ClassifySEP41 decodes a contract event following the SEP-41 token
+// interface. It returns false if the event is not a well-formed SEP-41 event.
+//
+// Both the original SEP-41 layout (mint and clawback carry an admin topic)
+// and the CAP-67 layout (admin topic dropped) are accepted.
+func ClassifySEP41(ce xdr.ContractEvent) (SEP41Event, bool) {
+	if ce.ContractId == nil {
+		return SEP41Event{}, false
+	}
+	contract, err := strkey.Encode(strkey.VersionByteContract, ce.ContractId[:])
+	if err != nil {
+		return SEP41Event{}, false
+	}
+
+	body, ok := ce.Body.GetV0()
+	if !ok || len(body.Topics) == 0 {
+		return SEP41Event{}, false
+	}
+	name, ok := scValSymbol(body.Topics[0])
+	if !ok {
+		return SEP41Event{}, false
+	}
+
+	// Split leading address topics from an optional trailing asset string.
+	topics := body.Topics[1:]
+	var addrs []string
+	for _, t := range topics {
+		a, ok := scValAddressString(t)
+		if !ok {
+			break
+		}
+		addrs = append(addrs, a)
+	}
+	asset := ""
+	if rest := topics[len(addrs):]; len(rest) > 0 {
+		s, ok := scValString(rest[0])
+		if !ok || len(rest) > 1 {
+			return SEP41Event{}, false
+		}
+		asset = s
+	}
+
+	ev := SEP41Event{Type: SEP41EventType(name), Contract: contract, Asset: asset}
+
+	switch ev.Type {
+	case SEP41Transfer:
+		// topics: [transfer, from, to], data: amount
+		if len(addrs) != 2 {
+			return SEP41Event{}, false
+		}
+		ev.From, ev.To = addrs[0], addrs[1]
+	case SEP41Mint:
+		// topics: [mint, admin, to] or [mint, to], data: amount
+		switch len(addrs) {
+		case 1:
+			ev.To = addrs[0]
+		case 2:
+			ev.Admin, ev.To = addrs[0], addrs[1]
+		default:
+			return SEP41Event{}, false
+		}
+	case SEP41Burn:
+		// topics: [burn, from], data: amount
+		if len(addrs) != 1 {
+			return SEP41Event{}, false
+		}
+		ev.From = addrs[0]
+	case SEP41Clawback:
+		// topics: [clawback, admin, from] or [clawback, from], data: amount
+		switch len(addrs) {
+		case 1:
+			ev.From = addrs[0]
+		case 2:
+			ev.Admin, ev.From = addrs[0], addrs[1]
+		default:
+			return SEP41Event{}, false
+		}
+	case SEP41Approve:
+		// topics: [approve, from, spender], data: [amount, expiration_ledger]
+		if len(addrs) != 2 {
+			return SEP41Event{}, false
+		}
+		ev.From, ev.Spender = addrs[0], addrs[1]
+		amt, exp, ok := approveData(body.Data)
+		if !ok {
+			return SEP41Event{}, false
+		}
+		ev.Amount, ev.ExpirationLedger = amt, exp
+		return ev, true
+	default:
+		return SEP41Event{}, false
+	}
+
+	amt, ok := eventAmount(body.Data)
+	if !ok || amt.Sign() < 0 {
+		return SEP41Event{}, false
+	}
+	ev.Amount = amt
+	return ev, true
+}
+
+// Token returns the token that emitted the event.
+func (e SEP41Event) Token() Token {
+	t := Token{Symbol: "SAC", ID: e.Contract, Asset: e.Asset}
+	switch {
+	case e.Asset == "native":
+		t.Symbol = "XLM"
+	case e.Asset != "":
+		code, _, _ := strings.Cut(e.Asset, ":")
+		t.Symbol = code
+	}
+	return t
+}
+
+// Transfer converts a value-moving event into a Transfer. Approve events do
+// not move funds and return false.
+func (e SEP41Event) Transfer() (Transfer, bool) {
+	tr := Transfer{Token: e.Token(), Amount: e.Amount}
+	switch e.Type {
+	case SEP41Transfer:
+		tr.From, tr.To, tr.Kind = e.From, e.To, KindTransfer
+	case SEP41Mint:
+		tr.From, tr.To, tr.Kind = "MINT", e.To, KindMint
+	case SEP41Burn:
+		tr.From, tr.To, tr.Kind = e.From, "BURN", KindBurn
+	case SEP41Clawback:
+		tr.From, tr.To, tr.Kind = e.From, "CLAWBACK", KindClawback
+	default:
+		return Transfer{}, false
+	}
+	return tr, true
+}
+
+// eventAmount reads the amount from event data. Besides a bare integer it
+// accepts the CAP-67 map form {amount, to_muxed_id}.
+func eventAmount(v xdr.ScVal) (*big.Int, bool) {
+	if v.Type == xdr.ScValTypeScvMap && v.Map != nil && *v.Map != nil {
+		for _, entry := range **v.Map {
+			if k, ok := scValSymbol(entry.Key); ok && k == "amount" {
+				return scValAmount(entry.Val)
+			}
+		}
+		return nil, false
+	}
+	return scValAmount(v)
+}
+
+func approveData(v xdr.ScVal) (*big.Int, uint32, bool) {
+	if v.Type != xdr.ScValTypeScvVec || v.Vec == nil || *v.Vec == nil {
+		return nil, 0, false
+	}
+	vec := **v.Vec
+	if len(vec) != 2 {
+		return nil, 0, false
+	}
+	amt, ok := scValAmount(vec[0])
+	if !ok || amt.Sign() < 0 {
+		return nil, 0, false
+	}
+	if vec[1].Type != xdr.ScValTypeScvU32 || vec[1].U32 == nil {
+		return nil, 0, false
+	}
+	return amt, uint32(*vec[1].U32), true
+}
+
+func scValString(v xdr.ScVal) (string, bool) {
+	if v.Type != xdr.ScValTypeScvString || v.Str == nil {
+		return "", false
+	}
+	return string(*v.Str), true
+}
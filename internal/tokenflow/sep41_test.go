@@ -0,0 +1,154 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenflow
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifySEP41_Transfer(t *testing.T) {
+	cid := xdr.ContractId(bytes32(0xAA))
+	contractStr, err := strkey.Encode(strkey.VersionByteContract, cid[:])
+	require.NoError(t, err)
+
+	from := scAddressAccount(bytes32(0x01))
+	to := scAddressAccount(bytes32(0x02))
+
+	de := diagnosticEvent(cid, []xdr.ScVal{
+		scSymbol("transfer"),
+		scAddress(from),
+		scAddress(to),
+		scString("USDC:GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN"),
+	}, scI128(1_000), true)
+
+	ev, ok := ClassifySEP41(de.Event)
+	require.True(t, ok)
+	require.Equal(t, SEP41Transfer, ev.Type)
+	require.Equal(t, contractStr, ev.Contract)
+	require.Equal(t, addrString(from), ev.From)
+	require.Equal(t, addrString(to), ev.To)
+	require.Equal(t, big.NewInt(1_000), ev.Amount)
+
+	tok := ev.Token()
+	require.Equal(t, "USDC", tok.Symbol)
+	require.Equal(t, contractStr, tok.ID)
+	require.Equal(t, "USDC:GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN", tok.Asset)
+}
+
+func TestClassifySEP41_Approve(t *testing.T) {
+	cid := xdr.ContractId(bytes32(0xAA))
+	from := scAddressAccount(bytes32(0x01))
+	spender := scAddressAccount(bytes32(0x02))
+
+	de := diagnosticEvent(cid, []xdr.ScVal{
+		scSymbol("approve"),
+		scAddress(from),
+		scAddress(spender),
+	}, scVec(scI128(500), scU32(123_456)), true)
+
+	ev, ok := ClassifySEP41(de.Event)
+	require.True(t, ok)
+	require.Equal(t, SEP41Approve, ev.Type)
+	require.Equal(t, addrString(from), ev.From)
+	require.Equal(t, addrString(spender), ev.Spender)
+	require.Equal(t, big.NewInt(500), ev.Amount)
+	require.Equal(t, uint32(123_456), ev.ExpirationLedger)
+
+	_, moves := ev.Transfer()
+	require.False(t, moves)
+}
+
+func TestClassifySEP41_MintWithAdmin(t *testing.T) {
+	cid := xdr.ContractId(bytes32(0xAA))
+	admin := scAddressAccount(bytes32(0x09))
+	to := scAddressAccount(bytes32(0x03))
+
+	de := diagnosticEvent(cid, []xdr.ScVal{
+		scSymbol("mint"),
+		scAddress(admin),
+		scAddress(to),
+	}, scI128(42), true)
+
+	ev, ok := ClassifySEP41(de.Event)
+	require.True(t, ok)
+	require.Equal(t, addrString(admin), ev.Admin)
+	require.Equal(t, addrString(to), ev.To)
+}
+
+func TestClassifySEP41_Rejects(t *testing.T) {
+	cid := xdr.ContractId(bytes32(0xAA))
+	a := scAddressAccount(bytes32(0x01))
+
+	tests := []struct {
+		name   string
+		topics []xdr.ScVal
+		data   xdr.ScVal
+	}{
+		{"unknown event", []xdr.ScVal{scSymbol("swap"), scAddress(a)}, scI128(1)},
+		{"transfer missing recipient", []xdr.ScVal{scSymbol("transfer"), scAddress(a)}, scI128(1)},
+		{"approve without expiration", []xdr.ScVal{scSymbol("approve"), scAddress(a), scAddress(a)}, scI128(1)},
+		{"non-numeric amount", []xdr.ScVal{scSymbol("burn"), scAddress(a)}, scSymbol("oops")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			de := diagnosticEvent(cid, tt.topics, tt.data, true)
+			_, ok := ClassifySEP41(de.Event)
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestBuildReport_SEP41BurnAndApprove(t *testing.T) {
+	cid := xdr.ContractId(bytes32(0xAA))
+	owner := scAddressAccount(bytes32(0x01))
+	spender := scAddressAccount(bytes32(0x02))
+
+	burn := diagnosticEvent(cid, []xdr.ScVal{scSymbol("burn"), scAddress(owner)}, scI128(10), true)
+	approve := diagnosticEvent(cid, []xdr.ScVal{
+		scSymbol("approve"),
+		scAddress(owner),
+		scAddress(spender),
+	}, scVec(scI128(300), scU32(99)), true)
+
+	r, err := BuildReport("", encodeResultMetaWithDiagnosticEvents(t, []xdr.DiagnosticEvent{burn, approve}))
+	require.NoError(t, err)
+
+	require.Len(t, r.Agg, 1)
+	require.Equal(t, KindBurn, r.Agg[0].Kind)
+	require.Equal(t, addrString(owner), r.Agg[0].From)
+	require.Equal(t, "BURN", r.Agg[0].To)
+
+	require.Len(t, r.Approvals, 1)
+	require.Equal(t, addrString(spender), r.Approvals[0].Spender)
+	require.Equal(t, big.NewInt(300), r.Approvals[0].Amount)
+	require.Equal(t, uint32(99), r.Approvals[0].ExpirationLedger)
+	require.Contains(t, r.SummaryLines()[1], "expires at ledger 99")
+}
+
+func scString(s string) xdr.ScVal {
+	str := xdr.ScString(s)
+	return xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &str}
+}
+
+func scI128(v int64) xdr.ScVal {
+	parts := xdr.Int128Parts{Hi: xdr.Int64(0), Lo: xdr.Uint64(v)}
+	return xdr.ScVal{Type: xdr.ScValTypeScvI128, I128: &parts}
+}
+
+func scU32(v uint32) xdr.ScVal {
+	u := xdr.Uint32(v)
+	return xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &u}
+}
+
+func scVec(vals ...xdr.ScVal) xdr.ScVal {
+	vec := xdr.ScVec(vals)
+	pv := &vec
+	return xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &pv}
+}
@@ -10,7 +10,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/stellar/go-stellar-sdk/strkey"
 	"github.com/stellar/go-stellar-sdk/xdr"
 )
 
@@ -20,14 +19,20 @@ type Kind string
 const (
 	KindTransfer Kind = "transfer"
 	KindMint     Kind = "mint"
+	KindBurn     Kind = "burn"
+	KindClawback Kind = "clawback"
 )
 
 // Token identifies an asset.
 // - XLM: Symbol="XLM", ID=""
 // - SAC: Symbol="SAC" (best-effort), ID="C...." (contract id)
+//
+// When a Stellar Asset Contract reports its SEP-11 asset, Symbol is the asset
+// code and Asset holds the full "CODE:ISSUER" (or "native") string.
 type Token struct {
 	Symbol string
 	ID     string
+	Asset  string
 }
 
 func (t Token) Display() string {
@@ -61,15 +66,65 @@ type Transfer struct {
 
 // Report is the aggregated “money flow” view.
 type Report struct {
-	Raw []Transfer
-	Agg []Transfer
+	Raw       []Transfer
+	Agg       []Transfer
+	Approvals []Approval
+
+	// Labels, when set, names classic assets by issuer in rendered output.
+	Labels *LabelRegistry
+}
+
+// ReportOption configures BuildReport.
+type ReportOption func(*reportOptions)
+
+type reportOptions struct {
+	assets []string
+}
+
+// WithAssetFilter keeps only the movements and approvals of the listed
+// assets. An asset is named by its symbol ("XLM", "USDC"), its SEP-11
+// string ("native", "USDC:G..."), or its contract ID ("C..."); symbols are
+// matched case-insensitively. Without assets every movement is kept.
+func WithAssetFilter(assets ...string) ReportOption {
+	return func(o *reportOptions) {
+		o.assets = append(o.assets, assets...)
+	}
+}
+
+// matches reports whether t passes the asset filter.
+func (o *reportOptions) matches(t Token) bool {
+	if len(o.assets) == 0 {
+		return true
+	}
+	for _, a := range o.assets {
+		a = strings.TrimSpace(a)
+		switch {
+		case a == "":
+			continue
+		case strings.EqualFold(a, t.Symbol):
+			return true
+		case t.ID != "" && a == t.ID:
+			return true
+		case t.Asset != "" && a == t.Asset:
+			return true
+		case strings.EqualFold(a, "native") && t.Symbol == "XLM" && t.ID == "":
+			return true
+		}
+	}
+	return false
 }
 
 // BuildReport extracts transfers/mints from:
 // - native XLM payments in EnvelopeXdr
-// - Soroban SAC transfer/mint events from ResultMetaXdr diagnostic events
-func BuildReport(envelopeXdrB64, resultMetaXdrB64 string) (*Report, error) {
+// - Soroban SEP-41 token events from ResultMetaXdr diagnostic events
+func BuildReport(envelopeXdrB64, resultMetaXdrB64 string, opts ...ReportOption) (*Report, error) {
+	var o reportOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var raw []Transfer
+	var approvals []Approval
 
 	if envelopeXdrB64 != "" {
 		xlm, err := extractNativeXLMPayments(envelopeXdrB64)
@@ -80,19 +135,50 @@ func BuildReport(envelopeXdrB64, resultMetaXdrB64 string) (*Report, error) {
 	}
 
 	if resultMetaXdrB64 != "" {
-		sac, err := extractSACTransfersAndMints(resultMetaXdrB64)
+		sac, approved, err := extractTokenEvents(resultMetaXdrB64)
 		if err != nil {
 			return nil, err
 		}
 		raw = append(raw, sac...)
+		approvals = approved
 	}
 
+	raw = filterTransfers(raw, &o)
+	approvals = filterApprovals(approvals, &o)
+
 	return &Report{
-		Raw: raw,
-		Agg: aggregate(raw),
+		Raw:       raw,
+		Agg:       aggregate(raw),
+		Approvals: approvals,
 	}, nil
 }
 
+func filterTransfers(in []Transfer, o *reportOptions) []Transfer {
+	if len(o.assets) == 0 {
+		return in
+	}
+	var out []Transfer
+	for _, t := range in {
+		if o.matches(t.Token) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func filterApprovals(in []Approval, o *reportOptions) []Approval {
+	if len(o.assets) == 0 {
+		return in
+	}
+	var out []Approval
+	for _, a := range in {
+		if o.matches(a.Token) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
 func extractNativeXLMPayments(envelopeXdrB64 string) ([]Transfer, error) {
 	envBytes, err := base64.StdEncoding.DecodeString(envelopeXdrB64)
 	if err != nil {
@@ -162,19 +248,20 @@ func extractNativeXLMPayments(envelopeXdrB64 string) ([]Transfer, error) {
 	return transfers, nil
 }
 
-func extractSACTransfersAndMints(resultMetaXdrB64 string) ([]Transfer, error) {
+func extractTokenEvents(resultMetaXdrB64 string) ([]Transfer, []Approval, error) {
 	metaBytes, err := base64.StdEncoding.DecodeString(resultMetaXdrB64)
 	if err != nil {
-		return nil, fmt.Errorf("decode result_meta xdr base64: %w", err)
+		return nil, nil, fmt.Errorf("decode result_meta xdr base64: %w", err)
 	}
 
 	var rm xdr.TransactionResultMeta
 	if err := xdr.SafeUnmarshal(metaBytes, &rm); err != nil {
-		return nil, fmt.Errorf("unmarshal TransactionResultMeta: %w", err)
+		return nil, nil, fmt.Errorf("unmarshal TransactionResultMeta: %w", err)
 	}
 
 	diag := extractDiagnosticEvents(rm.TxApplyProcessing)
 	var out []Transfer
+	var approvals []Approval
 
 	for _, de := range diag {
 		// Avoid counting reverted calls.
@@ -182,75 +269,28 @@ func extractSACTransfersAndMints(resultMetaXdrB64 string) ([]Transfer, error) {
 			continue
 		}
 
-		ce := de.Event
-		if ce.ContractId == nil {
-			continue
-		}
-
-		contractStr, err := strkey.Encode(strkey.VersionByteContract, ce.ContractId[:])
-		if err != nil {
-			continue
-		}
-
-		body, ok := ce.Body.GetV0()
-		if !ok || len(body.Topics) == 0 {
+		ev, ok := ClassifySEP41(de.Event)
+		if !ok {
 			continue
 		}
 
-		op, ok := scValSymbol(body.Topics[0])
-		if !ok {
+		if ev.Type == SEP41Approve {
+			approvals = append(approvals, Approval{
+				From:             ev.From,
+				Spender:          ev.Spender,
+				Token:            ev.Token(),
+				Amount:           ev.Amount,
+				ExpirationLedger: ev.ExpirationLedger,
+			})
 			continue
 		}
 
-		switch op {
-		case "transfer":
-			// Expected topics: ["transfer", from, to], data: amount
-			if len(body.Topics) < 3 {
-				continue
-			}
-			from, ok := scValAddressString(body.Topics[1])
-			if !ok {
-				continue
-			}
-			to, ok := scValAddressString(body.Topics[2])
-			if !ok {
-				continue
-			}
-			amt, ok := scValAmount(body.Data)
-			if !ok || amt.Sign() < 0 {
-				continue
-			}
-			out = append(out, Transfer{
-				From:   from,
-				To:     to,
-				Token:  Token{Symbol: "SAC", ID: contractStr},
-				Amount: amt,
-				Kind:   KindTransfer,
-			})
-		case "mint":
-			// Expected topics: ["mint", to], data: amount
-			if len(body.Topics) < 2 {
-				continue
-			}
-			to, ok := scValAddressString(body.Topics[1])
-			if !ok {
-				continue
-			}
-			amt, ok := scValAmount(body.Data)
-			if !ok || amt.Sign() < 0 {
-				continue
-			}
-			out = append(out, Transfer{
-				From:   "MINT",
-				To:     to,
-				Token:  Token{Symbol: "SAC", ID: contractStr},
-				Amount: amt,
-				Kind:   KindMint,
-			})
+		if tr, ok := ev.Transfer(); ok {
+			out = append(out, tr)
 		}
 	}
 
-	return out, nil
+	return out, approvals, nil
 }
 
 func extractDiagnosticEvents(tm xdr.TransactionMeta) []xdr.DiagnosticEvent {
@@ -333,16 +373,17 @@ func muxedAccountToAddress(a xdr.MuxedAccount) (string, error) {
 
 func aggregate(in []Transfer) []Transfer {
 	type key struct {
-		from string
-		to   string
-		kind Kind
-		sym  string
-		id   string
+		from  string
+		to    string
+		kind  Kind
+		sym   string
+		id    string
+		asset string
 	}
 
 	m := map[key]*big.Int{}
 	for _, t := range in {
-		k := key{from: t.From, to: t.To, kind: t.Kind, sym: t.Token.Symbol, id: t.Token.ID}
+		k := key{from: t.From, to: t.To, kind: t.Kind, sym: t.Token.Symbol, id: t.Token.ID, asset: t.Token.Asset}
 		if m[k] == nil {
 			m[k] = new(big.Int)
 		}
@@ -355,7 +396,7 @@ func aggregate(in []Transfer) []Transfer {
 			From:   k.from,
 			To:     k.to,
 			Kind:   k.kind,
-			Token:  Token{Symbol: k.sym, ID: k.id},
+			Token:  Token{Symbol: k.sym, ID: k.id, Asset: k.asset},
 			Amount: new(big.Int).Set(v),
 		})
 	}
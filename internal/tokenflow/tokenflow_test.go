@@ -82,6 +82,51 @@ func TestBuildReport_NativeXLMPayment_FromEnvelope(t *testing.T) {
 	require.Equal(t, big.NewInt(12_345_678), tr.Amount)
 }
 
+func TestBuildReport_WithAssetFilter(t *testing.T) {
+	sac := xdr.ContractId(bytes32(0xAA))
+	sacStr, err := strkey.Encode(strkey.VersionByteContract, sac[:])
+	require.NoError(t, err)
+	from := scAddressAccount(bytes32(0x01))
+	to := scAddressAccount(bytes32(0x02))
+
+	rmB64 := encodeResultMetaWithDiagnosticEvents(t, []xdr.DiagnosticEvent{
+		diagnosticEvent(sac, []xdr.ScVal{scSymbol("transfer"), scAddress(from), scAddress(to)}, scU128(50), true),
+	})
+	envB64 := encodeEnvelopeWithNativePayment(bytes32(0x10), bytes32(0x20), 100)
+
+	all, err := BuildReport(envB64, rmB64)
+	require.NoError(t, err)
+	require.Len(t, all.Agg, 2)
+
+	empty, err := BuildReport(envB64, rmB64, WithAssetFilter())
+	require.NoError(t, err)
+	require.Len(t, empty.Agg, 2)
+
+	tests := []struct {
+		name   string
+		assets []string
+		want   string
+	}{
+		{"symbol", []string{"xlm"}, "XLM"},
+		{"native", []string{"native"}, "XLM"},
+		{"contract id", []string{sacStr}, "SAC"},
+		{"unknown asset", []string{"USDC"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := BuildReport(envB64, rmB64, WithAssetFilter(tt.assets...))
+			require.NoError(t, err)
+			if tt.want == "" {
+				require.Empty(t, r.Agg)
+				require.Empty(t, r.Raw)
+				return
+			}
+			require.Len(t, r.Agg, 1)
+			require.Equal(t, tt.want, r.Agg[0].Token.Symbol)
+		})
+	}
+}
+
 func encodeResultMetaWithDiagnosticEvents(t *testing.T, events []xdr.DiagnosticEvent) string {
 	t.Helper()
 
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,12 +25,17 @@ const (
 	CheckInterval = 24 * time.Hour
 	// RequestTimeout is the maximum time to wait for GitHub API
 	RequestTimeout = 5 * time.Second
+	// CheckTimeout bounds the whole check, so a slow network never keeps
+	// the background goroutine alive long after the command finished.
+	CheckTimeout = 2 * time.Second
 )
 
 // Checker handles update checking logic
 type Checker struct {
 	currentVersion string
 	cacheDir       string
+	apiURL         string
+	timeout        time.Duration
 }
 
 // GitHubRelease represents the GitHub API response for a release
@@ -49,10 +55,24 @@ func NewChecker(currentVersion string) *Checker {
 	return &Checker{
 		currentVersion: currentVersion,
 		cacheDir:       cacheDir,
+		apiURL:         GitHubAPIURL,
+		timeout:        CheckTimeout,
 	}
 }
 
-// CheckForUpdates runs the update check in a goroutine (non-blocking)
+// Start runs CheckForUpdates in a new goroutine and returns immediately. The
+// returned channel is closed once the check has finished.
+func (c *Checker) Start() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.CheckForUpdates()
+	}()
+	return done
+}
+
+// CheckForUpdates performs the update check. It blocks for at most the
+// checker's timeout; use Start to run it in the background.
 func (c *Checker) CheckForUpdates() {
 	// Check if update checking is disabled
 	if c.isUpdateCheckDisabled() {
@@ -66,7 +86,7 @@ func (c *Checker) CheckForUpdates() {
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
 	// Fetch latest version from GitHub
@@ -114,7 +134,7 @@ func (c *Checker) shouldCheck() (bool, error) {
 
 // fetchLatestVersion calls GitHub API to get the latest release
 func (c *Checker) fetchLatestVersion(ctx context.Context) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", GitHubAPIURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.apiURL, nil)
 	if err != nil {
 		return "", err
 	}
@@ -213,6 +233,11 @@ func (c *Checker) isUpdateCheckDisabled() bool {
 		return true
 	}
 
+	// Automation (CI=true, as set by most CI providers) never checks
+	if runningInCI() {
+		return true
+	}
+
 	// Check config file
 	configPath := getConfigPath()
 	if configPath != "" {
@@ -224,6 +249,13 @@ func (c *Checker) isUpdateCheckDisabled() bool {
 	return false
 }
 
+// runningInCI reports whether the CI environment variable is set to a true
+// value.
+func runningInCI() bool {
+	ci, err := strconv.ParseBool(os.Getenv("CI"))
+	return err == nil && ci
+}
+
 // getConfigPath returns the path to the config file
 func getConfigPath() string {
 	// Try to use OS-specific config directory
@@ -48,6 +48,7 @@ func TestConfigPrecedenceIntegration(t *testing.T) {
 	require.NoError(t, os.Setenv("HOME", tmpDir))
 	require.NoError(t, os.Setenv("XDG_CONFIG_HOME", tmpDir))
 	require.NoError(t, os.Setenv("AppData", tmpDir))
+	t.Setenv("CI", "")
 
 	configPath := getConfigPath()
 	require.NotEmpty(t, configPath)
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -200,6 +201,21 @@ func TestOptOut(t *testing.T) {
 
 	t.Run("default behavior is enabled", func(t *testing.T) {
 		os.Unsetenv("ERST_NO_UPDATE_CHECK")
+		t.Setenv("CI", "")
+		assert.False(t, checker.isUpdateCheckDisabled())
+	})
+
+	t.Run("CI=true disables checker", func(t *testing.T) {
+		os.Unsetenv("ERST_NO_UPDATE_CHECK")
+		t.Setenv("CI", "true")
+
+		assert.True(t, checker.isUpdateCheckDisabled())
+	})
+
+	t.Run("CI=false keeps checker enabled", func(t *testing.T) {
+		os.Unsetenv("ERST_NO_UPDATE_CHECK")
+		t.Setenv("CI", "false")
+
 		assert.False(t, checker.isUpdateCheckDisabled())
 	})
 
@@ -352,3 +368,52 @@ func TestDisplayNotification(t *testing.T) {
 	assert.Contains(t, output, "available")
 	assert.Contains(t, output, "go install")
 }
+
+func TestCheckForUpdates_SkippedInCI(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		_ = json.NewEncoder(w).Encode(GitHubRelease{TagName: "v9.9.9"})
+	}))
+	defer server.Close()
+
+	os.Unsetenv("ERST_NO_UPDATE_CHECK")
+	t.Setenv("CI", "true")
+
+	checker := NewChecker("v1.0.0")
+	checker.apiURL = server.URL
+	checker.cacheDir = t.TempDir()
+	checker.CheckForUpdates()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&hits), "no request should be made in CI")
+}
+
+func TestStart_DoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	os.Unsetenv("ERST_NO_UPDATE_CHECK")
+	t.Setenv("CI", "")
+
+	checker := NewChecker("v1.0.0")
+	checker.apiURL = server.URL
+	checker.cacheDir = t.TempDir()
+	checker.timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	done := checker.Start()
+	assert.Less(t, time.Since(start), 20*time.Millisecond, "Start must return before the check finishes")
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("check did not give up after its timeout")
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package visualizer
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FoldedStacks is a profile in the folded-stack format used by flamegraph
+// tools: each key is a call stack with frames joined by ";", root first,
+// and each value is the weight sampled for that stack.
+type FoldedStacks map[string]int64
+
+// ParseFoldedStacks parses folded-stack text, one "frame;frame;... count"
+// line per stack. Blank lines are skipped and repeated stacks are summed.
+func ParseFoldedStacks(text string) (FoldedStacks, error) {
+	stacks := make(FoldedStacks)
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	line := 0
+	for scanner.Scan() {
+		line++
+		l := strings.TrimSpace(scanner.Text())
+		if l == "" {
+			continue
+		}
+		sep := strings.LastIndexByte(l, ' ')
+		if sep <= 0 {
+			return nil, fmt.Errorf("line %d: expected \"stack count\", got %q", line, l)
+		}
+		count, err := strconv.ParseInt(l[sep+1:], 10, 64)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("line %d: invalid count %q", line, l[sep+1:])
+		}
+		stacks[strings.TrimSpace(l[:sep])] += count
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read folded stacks: %w", err)
+	}
+	return stacks, nil
+}
+
+// MergeFoldedStacks parses each folded-stack input and sums them into one
+// profile, so identical stacks from different runs add up.
+func MergeFoldedStacks(inputs ...string) (FoldedStacks, error) {
+	merged := make(FoldedStacks)
+	for i, in := range inputs {
+		stacks, err := ParseFoldedStacks(in)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i+1, err)
+		}
+		for stack, count := range stacks {
+			merged[stack] += count
+		}
+	}
+	return merged, nil
+}
+
+// String renders the profile as folded-stack text, sorted by stack.
+func (f FoldedStacks) String() string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s %d\n", k, f[k])
+	}
+	return b.String()
+}
+
+// Total returns the summed weight of every stack.
+func (f FoldedStacks) Total() int64 {
+	var total int64
+	for _, c := range f {
+		total += c
+	}
+	return total
+}
+
+// Flamegraph layout, in pixels.
+const (
+	flameWidth       = 1200
+	flameFrameHeight = 16
+	flameMargin      = 10
+	flameTitleHeight = 24
+	// flameCharWidth approximates the width of one character of label text.
+	flameCharWidth = 7
+)
+
+type flameNode struct {
+	name     string
+	value    int64
+	children map[string]*flameNode
+}
+
+func (n *flameNode) child(name string) *flameNode {
+	if n.children == nil {
+		n.children = make(map[string]*flameNode)
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &flameNode{name: name}
+		n.children[name] = c
+	}
+	return c
+}
+
+func (n *flameNode) sortedChildren() []*flameNode {
+	out := make([]*flameNode, 0, len(n.children))
+	for _, c := range n.children {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out
+}
+
+func (n *flameNode) depth() int {
+	d := 0
+	for _, c := range n.children {
+		if cd := c.depth(); cd > d {
+			d = cd
+		}
+	}
+	return d + 1
+}
+
+// RenderFlamegraphSVG draws the profile as a standalone SVG flamegraph with
+// the root at the bottom. Siblings are ordered by name, so the same profile
+// always renders the same image.
+func RenderFlamegraphSVG(stacks FoldedStacks, title string) string {
+	root := &flameNode{name: "all"}
+	for stack, count := range stacks {
+		if count == 0 {
+			continue
+		}
+		root.value += count
+		n := root
+		for _, frame := range strings.Split(stack, ";") {
+			n = n.child(frame)
+			n.value += count
+		}
+	}
+
+	depth := root.depth()
+	height := flameTitleHeight + depth*flameFrameHeight + 2*flameMargin
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<?xml version="1.0" standalone="no"?>`+"\n")
+	fmt.Fprintf(&b, `<svg version="1.1" width="%d" height="%d" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg">`+"\n",
+		flameWidth, height, flameWidth, height)
+	fmt.Fprintf(&b, `<rect x="0" y="0" width="%d" height="%d" fill="#f8f8f8"/>`+"\n", flameWidth, height)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" font-family="Verdana" font-size="15">%s</text>`+"\n",
+		flameWidth/2, flameMargin+14, html.EscapeString(title))
+
+	if root.value > 0 {
+		scale := float64(flameWidth-2*flameMargin) / float64(root.value)
+		baseY := height - flameMargin - flameFrameHeight
+		writeFlameNode(&b, root, root.value, flameMargin, baseY, scale)
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+func writeFlameNode(b *strings.Builder, n *flameNode, total int64, x float64, y int, scale float64) {
+	width := float64(n.value) * scale
+	label := fmt.Sprintf("%s (%d, %.2f%%)", n.name, n.value, 100*float64(n.value)/float64(total))
+
+	fmt.Fprintf(b, `<g><title>%s</title>`, html.EscapeString(label))
+	fmt.Fprintf(b, `<rect x="%.1f" y="%d" width="%.1f" height="%d" fill="%s" rx="2" ry="2"/>`,
+		x, y, width, flameFrameHeight-1, flameColor(n.name))
+	if fit := int(width-4) / flameCharWidth; fit >= 3 {
+		text := n.name
+		if len(text) > fit {
+			text = text[:fit-2] + ".."
+		}
+		fmt.Fprintf(b, `<text x="%.1f" y="%d" font-family="Verdana" font-size="12">%s</text>`,
+			x+3, y+flameFrameHeight-4, html.EscapeString(text))
+	}
+	b.WriteString("</g>\n")
+
+	for _, c := range n.sortedChildren() {
+		writeFlameNode(b, c, total, x, y-flameFrameHeight, scale)
+		x += float64(c.value) * scale
+	}
+}
+
+// flameColor picks a warm color derived from the frame name, so a frame
+// keeps its color across renders.
+func flameColor(name string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	v := h.Sum32()
+	r := 205 + v%50
+	g := (v >> 8) % 230
+	bl := (v >> 16) % 55
+	return fmt.Sprintf("rgb(%d,%d,%d)", r, g, bl)
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package visualizer
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func assertWellFormedXML(t *testing.T, doc string) {
+	t.Helper()
+	dec := xml.NewDecoder(strings.NewReader(doc))
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("output is not well-formed XML: %v", err)
+		}
+	}
+}
+
+func TestMergeFoldedStacks(t *testing.T) {
+	first := "Total;CPU 1000\nTotal;Memory 200\n"
+	second := "Total;CPU 500\nTotal;Storage 30\n\n"
+
+	merged, err := MergeFoldedStacks(first, second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := FoldedStacks{
+		"Total;CPU":     1500,
+		"Total;Memory":  200,
+		"Total;Storage": 30,
+	}
+	if len(merged) != len(want) {
+		t.Fatalf("merged = %v, want %v", merged, want)
+	}
+	for stack, count := range want {
+		if merged[stack] != count {
+			t.Errorf("%s = %d, want %d", stack, merged[stack], count)
+		}
+	}
+	if merged.Total() != 1730 {
+		t.Errorf("Total() = %d, want 1730", merged.Total())
+	}
+
+	wantText := "Total;CPU 1500\nTotal;Memory 200\nTotal;Storage 30\n"
+	if merged.String() != wantText {
+		t.Errorf("String() = %q, want %q", merged.String(), wantText)
+	}
+}
+
+func TestParseFoldedStacks_FramesWithSpaces(t *testing.T) {
+	stacks, err := ParseFoldedStacks("invoke contract;call transfer 42\ninvoke contract;call transfer 8\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stacks["invoke contract;call transfer"] != 50 {
+		t.Errorf("stacks = %v, want the repeated stack summed to 50", stacks)
+	}
+}
+
+func TestParseFoldedStacks_Invalid(t *testing.T) {
+	for _, in := range []string{"no-count", "a;b x", "a;b -1", " 5"} {
+		if _, err := ParseFoldedStacks(in); err == nil {
+			t.Errorf("ParseFoldedStacks(%q) succeeded, want an error", in)
+		}
+	}
+}
+
+func TestMergeFoldedStacks_ReportsBadInput(t *testing.T) {
+	_, err := MergeFoldedStacks("a 1\n", "broken\n")
+	if err == nil || !strings.Contains(err.Error(), "input 2") {
+		t.Errorf("expected an error naming input 2, got %v", err)
+	}
+}
+
+func TestRenderFlamegraphSVG(t *testing.T) {
+	stacks, err := MergeFoldedStacks("Total;CPU 1500\nTotal;Memory 500\n", "Total;CPU <fast> 10\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svg := RenderFlamegraphSVG(stacks, "Merged & compared")
+	assertWellFormedXML(t, svg)
+	for _, want := range []string{
+		"Merged &amp; compared",
+		"<title>all (2010, 100.00%)</title>",
+		"<title>Total (2010, 100.00%)</title>",
+		"<title>CPU (1500, 74.63%)</title>",
+		"<title>Memory (500, 24.88%)</title>",
+		"CPU &lt;fast&gt;",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG does not contain %q", want)
+		}
+	}
+
+	if again := RenderFlamegraphSVG(stacks, "Merged & compared"); again != svg {
+		t.Error("rendering the same profile twice gave different output")
+	}
+}
+
+func TestRenderFlamegraphSVG_Empty(t *testing.T) {
+	svg := RenderFlamegraphSVG(FoldedStacks{}, "empty")
+	assertWellFormedXML(t, svg)
+	if strings.Contains(svg, "<g>") {
+		t.Error("expected no frames for an empty profile")
+	}
+}
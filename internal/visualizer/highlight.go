@@ -0,0 +1,59 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package visualizer
+
+import "regexp"
+
+// Highlighter colors the parts of structured output a reader scans for:
+// labels, addresses and numbers. When disabled it returns text unchanged,
+// so callers can apply it unconditionally.
+type Highlighter struct {
+	Enabled bool
+}
+
+// NewHighlighter returns a Highlighter that is enabled when ColorEnabled
+// reports that stdout accepts ANSI colors.
+func NewHighlighter() Highlighter {
+	return Highlighter{Enabled: ColorEnabled()}
+}
+
+// Label highlights a field label.
+func (h Highlighter) Label(text string) string {
+	return h.wrap(text, sgrBold)
+}
+
+// Address highlights an account, contract or muxed address.
+func (h Highlighter) Address(text string) string {
+	return h.wrap(text, sgrCyan)
+}
+
+// Number highlights a numeric value.
+func (h Highlighter) Number(text string) string {
+	return h.wrap(text, sgrYellow)
+}
+
+// valueToken matches strkey addresses and standalone decimal numbers.
+// Addresses come first so their digits are not matched as numbers.
+var valueToken = regexp.MustCompile(`\b(?:[GC][A-Z2-7]{55}|M[A-Z2-7]{68})\b|-?\b\d+(?:\.\d+)?\b`)
+
+// Values highlights every address and number found in free text, such as
+// a rendered field value or a token flow summary line.
+func (h Highlighter) Values(text string) string {
+	if !h.Enabled {
+		return text
+	}
+	return valueToken.ReplaceAllStringFunc(text, func(tok string) string {
+		if tok[0] >= 'A' && tok[0] <= 'Z' {
+			return h.Address(tok)
+		}
+		return h.Number(tok)
+	})
+}
+
+func (h Highlighter) wrap(text, code string) string {
+	if !h.Enabled || text == "" {
+		return text
+	}
+	return code + text + sgrReset
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package visualizer
+
+import "testing"
+
+const highlightAccount = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+
+func TestHighlighterDisabledReturnsPlainText(t *testing.T) {
+	h := Highlighter{}
+	text := highlightAccount + " -> 50 XLM"
+	if got := h.Values(text); got != text {
+		t.Errorf("Values() = %q, want plain text", got)
+	}
+	if got := h.Label("Balance:"); got != "Balance:" {
+		t.Errorf("Label() = %q, want plain text", got)
+	}
+}
+
+func TestHighlighterValues(t *testing.T) {
+	h := Highlighter{Enabled: true}
+
+	got := h.Values(highlightAccount + " -> -1.5 XLM -> abcd0042 0xff12")
+	want := sgrCyan + highlightAccount + sgrReset + " -> " + sgrYellow + "-1.5" + sgrReset + " XLM -> abcd0042 0xff12"
+	if got != want {
+		t.Errorf("Values() =\n%q\nwant\n%q", got, want)
+	}
+
+	if got := h.Label("Balance:"); got != sgrBold+"Balance:"+sgrReset {
+		t.Errorf("Label() = %q", got)
+	}
+}
@@ -69,7 +69,7 @@ func FormatSlackMessage(report ReportData) SlackMessage {
 	}
 
 	statusIcon := "[FAILED]"
-	if report.Status == "success" {
+	if simulator.ParseOutcome(report.Status) == simulator.OutcomeSuccess {
 		statusIcon = "[SUCCESS]"
 	}
 
@@ -178,7 +178,7 @@ func FormatDiscordMessage(report ReportData) DiscordMessage {
 	colorInt := hexToDecimal(color)
 
 	statusTitle := "[FAILED] Simulation Failed"
-	if report.Status == "success" {
+	if simulator.ParseOutcome(report.Status) == simulator.OutcomeSuccess {
 		statusTitle = "[SUCCESS] Simulation Succeeded"
 	}
 
@@ -264,9 +264,9 @@ func FormatDiscordMessage(report ReportData) DiscordMessage {
 
 func colorForStatus(status string) string {
 	switch status {
-	case "success":
+	case simulator.StatusSuccess:
 		return "36a64f" // Green
-	case "error":
+	case simulator.StatusError:
 		return "e74c3c" // Red
 	case "warning":
 		return "f39c12" // Orange
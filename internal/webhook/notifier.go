@@ -71,7 +71,7 @@ func (sn *SimulatorNotifier) NotifyResponse(
 	}
 
 	// Skip if error-only mode and status is success
-	if sn.errorOnly && resp.Status == "success" {
+	if sn.errorOnly && resp.Outcome() == simulator.OutcomeSuccess {
 		return
 	}
 
@@ -42,8 +42,8 @@ fn init_logger() {
     }
 }
 
-fn send_error(msg: String) {
-    let res = SimulationResponse {
+fn error_response(msg: String) -> SimulationResponse {
+    SimulationResponse {
         status: "error".to_string(),
         error: Some(msg),
         events: vec![],
@@ -51,11 +51,15 @@ fn send_error(msg: String) {
         categorized_events: vec![],
         logs: vec![],
         flamegraph: None,
+        folded_stacks: None,
         optimization_report: None,
         budget_usage: None,
         source_location: None,
-    };
-    println!("{}", serde_json::to_string(&res).unwrap());
+    }
+}
+
+fn send_error(msg: String) {
+    println!("{}", serde_json::to_string(&error_response(msg)).unwrap());
     std::process::exit(1);
 }
 
@@ -133,6 +137,14 @@ fn categorize_events(events: &soroban_env_host::events::Events) -> Vec<Categoriz
 }
 
 fn main() {
+    if std::env::args()
+        .skip(1)
+        .any(|a| a == "--version" || a == "-V")
+    {
+        println!("erst-sim {}", env!("CARGO_PKG_VERSION"));
+        return;
+    }
+
     // 1. Initialize the logger immediately
     init_logger();
 
@@ -150,6 +162,7 @@ fn main() {
             categorized_events: vec![],
             logs: vec![],
             flamegraph: None,
+            folded_stacks: None,
             optimization_report: None,
             budget_usage: None,
             source_location: None,
@@ -159,6 +172,26 @@ fn main() {
         return;
     }
 
+    if buffer.trim_start().starts_with('[') {
+        // Batch mode: an array of requests yields an array of responses in
+        // the same order. A request that fails only fails its own slot.
+        let requests: Vec<serde_json::Value> = match serde_json::from_str(&buffer) {
+            Ok(reqs) => reqs,
+            Err(e) => return send_error(format!("Invalid JSON: {}", e)),
+        };
+        let responses: Vec<SimulationResponse> = requests
+            .into_iter()
+            .map(
+                |value| match serde_json::from_value::<SimulationRequest>(value) {
+                    Ok(request) => simulate(request).unwrap_or_else(error_response),
+                    Err(e) => error_response(format!("Invalid JSON: {}", e)),
+                },
+            )
+            .collect();
+        println!("{}", serde_json::to_string(&responses).unwrap());
+        return;
+    }
+
     // Parse Request
     let request: SimulationRequest = match serde_json::from_str(&buffer) {
         Ok(req) => req,
@@ -171,6 +204,7 @@ fn main() {
                 categorized_events: vec![],
                 logs: vec![],
                 flamegraph: None,
+                folded_stacks: None,
                 optimization_report: None,
                 budget_usage: None,
                 source_location: None,
@@ -180,6 +214,16 @@ fn main() {
         }
     };
 
+    match simulate(request) {
+        Ok(response) => println!("{}", serde_json::to_string(&response).unwrap()),
+        Err(msg) => send_error(msg),
+    }
+}
+
+/// Runs a single simulation request. Errors in the request itself (bad XDR
+/// and the like) are returned as Err; execution failures are reported in the
+/// response.
+fn simulate(request: SimulationRequest) -> Result<SimulationResponse, String> {
     // Decode Envelope XDR
     let envelope = match base64::engine::general_purpose::STANDARD.decode(&request.envelope_xdr) {
         Ok(bytes) => match soroban_env_host::xdr::TransactionEnvelope::from_xdr(
@@ -188,11 +232,11 @@ fn main() {
         ) {
             Ok(env) => env,
             Err(e) => {
-                return send_error(format!("Failed to parse Envelope XDR: {}", e));
+                return Err(format!("Failed to parse Envelope XDR: {}", e));
             }
         },
         Err(e) => {
-            return send_error(format!("Failed to decode Envelope Base64: {}", e));
+            return Err(format!("Failed to decode Envelope Base64: {}", e));
         }
     };
 
@@ -208,7 +252,7 @@ fn main() {
             ) {
                 Ok(meta) => Some(meta),
                 Err(e) => {
-                    return send_error(format!("Failed to parse ResultMeta XDR: {}", e));
+                    return Err(format!("Failed to parse ResultMeta XDR: {}", e));
                 }
             },
             Err(e) => {
@@ -255,9 +299,9 @@ fn main() {
                     soroban_env_host::xdr::Limits::none(),
                 ) {
                     Ok(k) => k,
-                    Err(e) => return send_error(format!("Failed to parse LedgerKey XDR: {}", e)),
+                    Err(e) => return Err(format!("Failed to parse LedgerKey XDR: {}", e)),
                 },
-                Err(e) => return send_error(format!("Failed to decode LedgerKey Base64: {}", e)),
+                Err(e) => return Err(format!("Failed to decode LedgerKey Base64: {}", e)),
             };
 
             let _entry = match base64::engine::general_purpose::STANDARD.decode(entry_xdr) {
@@ -266,9 +310,9 @@ fn main() {
                     soroban_env_host::xdr::Limits::none(),
                 ) {
                     Ok(e) => e,
-                    Err(e) => return send_error(format!("Failed to parse LedgerEntry XDR: {}", e)),
+                    Err(e) => return Err(format!("Failed to parse LedgerEntry XDR: {}", e)),
                 },
-                Err(e) => return send_error(format!("Failed to decode LedgerEntry Base64: {}", e)),
+                Err(e) => return Err(format!("Failed to decode LedgerEntry Base64: {}", e)),
             };
             loaded_entries_count += 1;
         }
@@ -319,9 +363,11 @@ fn main() {
     };
 
     let mut flamegraph_svg = None;
+    let mut folded_stacks = None;
     if request.profile.unwrap_or(false) {
         // Simple simulated flamegraph for demonstration
         let folded_data = format!("Total;CPU {}\nTotal;Memory {}\n", cpu_insns, mem_bytes);
+        folded_stacks = Some(folded_data.clone());
         let mut result = Vec::new();
         let mut options = inferno::flamegraph::Options::default();
         options.title = "Soroban Resource Consumption".to_string();
@@ -416,12 +462,13 @@ fn main() {
                 categorized_events,
                 logs: final_logs,
                 flamegraph: flamegraph_svg,
+                folded_stacks,
                 optimization_report,
                 budget_usage: Some(budget_usage),
                 source_location: None,
             };
 
-            println!("{}", serde_json::to_string(&response).unwrap());
+            Ok(response)
         }
         Ok(Err(host_error)) => {
             // Host error during execution (e.g., contract trap, validation failure)
@@ -442,11 +489,12 @@ fn main() {
                 categorized_events: vec![],
                 logs: vec![],
                 flamegraph: None,
+                folded_stacks: None,
                 optimization_report: None,
                 budget_usage: None,
                 source_location: None,
             };
-            println!("{}", serde_json::to_string(&response).unwrap());
+            Ok(response)
         }
         Err(panic_info) => {
             let panic_msg = if let Some(s) = panic_info.downcast_ref::<&str>() {
@@ -465,11 +513,12 @@ fn main() {
                 categorized_events: vec![],
                 logs: vec![format!("PANIC: {}", panic_msg)],
                 flamegraph: None,
+                folded_stacks: None,
                 optimization_report: None,
                 budget_usage: None,
                 source_location: None,
             };
-            println!("{}", serde_json::to_string(&response).unwrap());
+            Ok(response)
         }
     }
 }